@@ -21,13 +21,28 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// A booking holds reserved seats, general-admission tickets from a pool
+// (pool_id with quantity) or both. With best_available the seats are picked
+// by the service instead of being listed in seat_ids.
 type CreateBookingRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	EventId       int64                  `protobuf:"varint,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
 	SeatIds       []int64                `protobuf:"varint,3,rep,packed,name=seat_ids,json=seatIds,proto3" json:"seat_ids,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	PoolId        int64                  `protobuf:"varint,4,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	BestAvailable *BestAvailable         `protobuf:"bytes,6,opt,name=best_available,json=bestAvailable,proto3" json:"best_available,omitempty"`
+	// a retry with the same key and request gets the original response
+	// instead of a second booking; the same key with another request is
+	// refused with ALREADY_EXISTS
+	IdempotencyKey string `protobuf:"bytes,7,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// a code that cannot be used fails the booking with FAILED_PRECONDITION
+	// and an ErrorInfo detail with reason PROMO_CODE_REJECTED
+	PromoCode string `protobuf:"bytes,8,opt,name=promo_code,json=promoCode,proto3" json:"promo_code,omitempty"`
+	// further codes stacked on promo_code; every code must be stackable then
+	AdditionalPromoCodes []string `protobuf:"bytes,9,rep,name=additional_promo_codes,json=additionalPromoCodes,proto3" json:"additional_promo_codes,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *CreateBookingRequest) Reset() {
@@ -81,16 +96,128 @@ func (x *CreateBookingRequest) GetSeatIds() []int64 {
 	return nil
 }
 
+func (x *CreateBookingRequest) GetPoolId() int64 {
+	if x != nil {
+		return x.PoolId
+	}
+	return 0
+}
+
+func (x *CreateBookingRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CreateBookingRequest) GetBestAvailable() *BestAvailable {
+	if x != nil {
+		return x.BestAvailable
+	}
+	return nil
+}
+
+func (x *CreateBookingRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateBookingRequest) GetPromoCode() string {
+	if x != nil {
+		return x.PromoCode
+	}
+	return ""
+}
+
+func (x *CreateBookingRequest) GetAdditionalPromoCodes() []string {
+	if x != nil {
+		return x.AdditionalPromoCodes
+	}
+	return nil
+}
+
+// BestAvailable asks for quantity seats next to each other in one row,
+// from the best scored sector that has them.
+type BestAvailable struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Quantity int32                  `protobuf:"varint,1,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// empty for any sector
+	Sector string `protobuf:"bytes,2,opt,name=sector,proto3" json:"sector,omitempty"`
+	// the most a single seat may cost, in minor units; 0 for no cap
+	MaxPrice      int64 `protobuf:"varint,3,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BestAvailable) Reset() {
+	*x = BestAvailable{}
+	mi := &file_booking_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BestAvailable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BestAvailable) ProtoMessage() {}
+
+func (x *BestAvailable) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BestAvailable.ProtoReflect.Descriptor instead.
+func (*BestAvailable) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BestAvailable) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *BestAvailable) GetSector() string {
+	if x != nil {
+		return x.Sector
+	}
+	return ""
+}
+
+func (x *BestAvailable) GetMaxPrice() int64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+// The total is computed from the seat prices at booking time, in minor units.
+// seat_ids and seat_labels are set when the seats were picked by best_available.
 type CreateBookingResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	BookingId     int64                  `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	TotalAmount   int64                  `protobuf:"varint,2,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	SeatIds       []int64                `protobuf:"varint,4,rep,packed,name=seat_ids,json=seatIds,proto3" json:"seat_ids,omitempty"`
+	SeatLabels    []string               `protobuf:"bytes,5,rep,name=seat_labels,json=seatLabels,proto3" json:"seat_labels,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateBookingResponse) Reset() {
 	*x = CreateBookingResponse{}
-	mi := &file_booking_proto_msgTypes[1]
+	mi := &file_booking_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -102,7 +229,7 @@ func (x *CreateBookingResponse) String() string {
 func (*CreateBookingResponse) ProtoMessage() {}
 
 func (x *CreateBookingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_booking_proto_msgTypes[1]
+	mi := &file_booking_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -115,7 +242,7 @@ func (x *CreateBookingResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateBookingResponse.ProtoReflect.Descriptor instead.
 func (*CreateBookingResponse) Descriptor() ([]byte, []int) {
-	return file_booking_proto_rawDescGZIP(), []int{1}
+	return file_booking_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreateBookingResponse) GetBookingId() int64 {
@@ -125,6 +252,34 @@ func (x *CreateBookingResponse) GetBookingId() int64 {
 	return 0
 }
 
+func (x *CreateBookingResponse) GetTotalAmount() int64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *CreateBookingResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateBookingResponse) GetSeatIds() []int64 {
+	if x != nil {
+		return x.SeatIds
+	}
+	return nil
+}
+
+func (x *CreateBookingResponse) GetSeatLabels() []string {
+	if x != nil {
+		return x.SeatLabels
+	}
+	return nil
+}
+
 type HandlePaymentWebhookRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	BookingId     int64                  `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
@@ -135,7 +290,7 @@ type HandlePaymentWebhookRequest struct {
 
 func (x *HandlePaymentWebhookRequest) Reset() {
 	*x = HandlePaymentWebhookRequest{}
-	mi := &file_booking_proto_msgTypes[2]
+	mi := &file_booking_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -147,7 +302,7 @@ func (x *HandlePaymentWebhookRequest) String() string {
 func (*HandlePaymentWebhookRequest) ProtoMessage() {}
 
 func (x *HandlePaymentWebhookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_booking_proto_msgTypes[2]
+	mi := &file_booking_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -160,7 +315,7 @@ func (x *HandlePaymentWebhookRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HandlePaymentWebhookRequest.ProtoReflect.Descriptor instead.
 func (*HandlePaymentWebhookRequest) Descriptor() ([]byte, []int) {
-	return file_booking_proto_rawDescGZIP(), []int{2}
+	return file_booking_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *HandlePaymentWebhookRequest) GetBookingId() int64 {
@@ -185,7 +340,7 @@ type HandlePaymentWebhookResponse struct {
 
 func (x *HandlePaymentWebhookResponse) Reset() {
 	*x = HandlePaymentWebhookResponse{}
-	mi := &file_booking_proto_msgTypes[3]
+	mi := &file_booking_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -197,7 +352,7 @@ func (x *HandlePaymentWebhookResponse) String() string {
 func (*HandlePaymentWebhookResponse) ProtoMessage() {}
 
 func (x *HandlePaymentWebhookResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_booking_proto_msgTypes[3]
+	mi := &file_booking_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -210,29 +365,3743 @@ func (x *HandlePaymentWebhookResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HandlePaymentWebhookResponse.ProtoReflect.Descriptor instead.
 func (*HandlePaymentWebhookResponse) Descriptor() ([]byte, []int) {
-	return file_booking_proto_rawDescGZIP(), []int{3}
+	return file_booking_proto_rawDescGZIP(), []int{4}
 }
 
-var File_booking_proto protoreflect.FileDescriptor
+type GetEventCancellationJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_booking_proto_rawDesc = "" +
-	"\n" +
-	"\rbooking.proto\x12\abooking\"e\n" +
-	"\x14CreateBookingRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x19\n" +
-	"\bevent_id\x18\x02 \x01(\x03R\aeventId\x12\x19\n" +
-	"\bseat_ids\x18\x03 \x03(\x03R\aseatIds\"6\n" +
-	"\x15CreateBookingResponse\x12\x1d\n" +
-	"\n" +
-	"booking_id\x18\x01 \x01(\x03R\tbookingId\"T\n" +
-	"\x1bHandlePaymentWebhookRequest\x12\x1d\n" +
-	"\n" +
-	"booking_id\x18\x01 \x01(\x03R\tbookingId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"\x1e\n" +
-	"\x1cHandlePaymentWebhookResponse2\xc5\x01\n" +
-	"\x0eBookingService\x12N\n" +
-	"\rCreateBooking\x12\x1d.booking.CreateBookingRequest\x1a\x1e.booking.CreateBookingResponse\x12c\n" +
-	"\x14HandlePaymentWebhook\x12$.booking.HandlePaymentWebhookRequest\x1a%.booking.HandlePaymentWebhookResponseB\x15Z\x13./booking;bookingv1b\x06proto3"
+func (x *GetEventCancellationJobRequest) Reset() {
+	*x = GetEventCancellationJobRequest{}
+	mi := &file_booking_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventCancellationJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventCancellationJobRequest) ProtoMessage() {}
+
+func (x *GetEventCancellationJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventCancellationJobRequest.ProtoReflect.Descriptor instead.
+func (*GetEventCancellationJobRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetEventCancellationJobRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+type FailedCancellationItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookingId     int64                  `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	Attempts      int32                  `protobuf:"varint,2,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FailedCancellationItem) Reset() {
+	*x = FailedCancellationItem{}
+	mi := &file_booking_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FailedCancellationItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailedCancellationItem) ProtoMessage() {}
+
+func (x *FailedCancellationItem) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailedCancellationItem.ProtoReflect.Descriptor instead.
+func (*FailedCancellationItem) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FailedCancellationItem) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+func (x *FailedCancellationItem) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *FailedCancellationItem) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Progress of the bulk job that refunds and releases the bookings of a
+// cancelled event. status is RUNNING, COMPLETED or COMPLETED_WITH_ERRORS.
+type EventCancellationJob struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	JobId         int64                     `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	EventId       int64                     `protobuf:"varint,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Status        string                    `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Total         int32                     `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Processed     int32                     `protobuf:"varint,5,opt,name=processed,proto3" json:"processed,omitempty"`
+	Failed        int32                     `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
+	FailedItems   []*FailedCancellationItem `protobuf:"bytes,7,rep,name=failed_items,json=failedItems,proto3" json:"failed_items,omitempty"`
+	CreatedAt     string                    `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	FinishedAt    string                    `protobuf:"bytes,9,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventCancellationJob) Reset() {
+	*x = EventCancellationJob{}
+	mi := &file_booking_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventCancellationJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventCancellationJob) ProtoMessage() {}
+
+func (x *EventCancellationJob) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventCancellationJob.ProtoReflect.Descriptor instead.
+func (*EventCancellationJob) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EventCancellationJob) GetJobId() int64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *EventCancellationJob) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *EventCancellationJob) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *EventCancellationJob) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *EventCancellationJob) GetProcessed() int32 {
+	if x != nil {
+		return x.Processed
+	}
+	return 0
+}
+
+func (x *EventCancellationJob) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *EventCancellationJob) GetFailedItems() []*FailedCancellationItem {
+	if x != nil {
+		return x.FailedItems
+	}
+	return nil
+}
+
+func (x *EventCancellationJob) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *EventCancellationJob) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+type GetUserCalendarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserCalendarRequest) Reset() {
+	*x = GetUserCalendarRequest{}
+	mi := &file_booking_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserCalendarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserCalendarRequest) ProtoMessage() {}
+
+func (x *GetUserCalendarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserCalendarRequest.ProtoReflect.Descriptor instead.
+func (*GetUserCalendarRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetUserCalendarRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type UserCalendar struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// RFC 5545 iCalendar object with the user's confirmed bookings
+	Content       string `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserCalendar) Reset() {
+	*x = UserCalendar{}
+	mi := &file_booking_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserCalendar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserCalendar) ProtoMessage() {}
+
+func (x *UserCalendar) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserCalendar.ProtoReflect.Descriptor instead.
+func (*UserCalendar) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UserCalendar) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// The booking must belong to user_id, otherwise it is reported as not found.
+type GetBookingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookingId     int64                  `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookingRequest) Reset() {
+	*x = GetBookingRequest{}
+	mi := &file_booking_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookingRequest) ProtoMessage() {}
+
+func (x *GetBookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookingRequest.ProtoReflect.Descriptor instead.
+func (*GetBookingRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetBookingRequest) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+func (x *GetBookingRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type BookedSeat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SeatId        int64                  `protobuf:"varint,1,opt,name=seat_id,json=seatId,proto3" json:"seat_id,omitempty"`
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Sector        string                 `protobuf:"bytes,3,opt,name=sector,proto3" json:"sector,omitempty"`
+	Row           int32                  `protobuf:"varint,4,opt,name=row,proto3" json:"row,omitempty"`
+	PriceAmount   int64                  `protobuf:"varint,5,opt,name=price_amount,json=priceAmount,proto3" json:"price_amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookedSeat) Reset() {
+	*x = BookedSeat{}
+	mi := &file_booking_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookedSeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookedSeat) ProtoMessage() {}
+
+func (x *BookedSeat) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookedSeat.ProtoReflect.Descriptor instead.
+func (*BookedSeat) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BookedSeat) GetSeatId() int64 {
+	if x != nil {
+		return x.SeatId
+	}
+	return 0
+}
+
+func (x *BookedSeat) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *BookedSeat) GetSector() string {
+	if x != nil {
+		return x.Sector
+	}
+	return ""
+}
+
+func (x *BookedSeat) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *BookedSeat) GetPriceAmount() int64 {
+	if x != nil {
+		return x.PriceAmount
+	}
+	return 0
+}
+
+func (x *BookedSeat) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type BookedPoolItem struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	PoolId   int64                  `protobuf:"varint,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Quantity int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// per ticket
+	PriceAmount   int64  `protobuf:"varint,4,opt,name=price_amount,json=priceAmount,proto3" json:"price_amount,omitempty"`
+	Currency      string `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookedPoolItem) Reset() {
+	*x = BookedPoolItem{}
+	mi := &file_booking_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookedPoolItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookedPoolItem) ProtoMessage() {}
+
+func (x *BookedPoolItem) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookedPoolItem.ProtoReflect.Descriptor instead.
+func (*BookedPoolItem) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BookedPoolItem) GetPoolId() int64 {
+	if x != nil {
+		return x.PoolId
+	}
+	return 0
+}
+
+func (x *BookedPoolItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BookedPoolItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *BookedPoolItem) GetPriceAmount() int64 {
+	if x != nil {
+		return x.PriceAmount
+	}
+	return 0
+}
+
+func (x *BookedPoolItem) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// Timestamps are RFC 3339; event_starts_at is empty when the event has no
+// start date.
+type Booking struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookingId     int64                  `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	EventId       int64                  `protobuf:"varint,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventTitle    string                 `protobuf:"bytes,4,opt,name=event_title,json=eventTitle,proto3" json:"event_title,omitempty"`
+	EventStartsAt string                 `protobuf:"bytes,5,opt,name=event_starts_at,json=eventStartsAt,proto3" json:"event_starts_at,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Seats         []*BookedSeat          `protobuf:"bytes,7,rep,name=seats,proto3" json:"seats,omitempty"`
+	PoolItems     []*BookedPoolItem      `protobuf:"bytes,8,rep,name=pool_items,json=poolItems,proto3" json:"pool_items,omitempty"`
+	TotalAmount   int64                  `protobuf:"varint,9,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,10,opt,name=currency,proto3" json:"currency,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// what was paid back when the customer cancelled a confirmed booking
+	RefundAmount *int64 `protobuf:"varint,13,opt,name=refund_amount,json=refundAmount,proto3,oneof" json:"refund_amount,omitempty"`
+	// RFC 3339, when an unpaid booking releases its tickets; empty once it is
+	// neither PENDING nor AWAITING_PAYMENT
+	ExpiresAt string `protobuf:"bytes,14,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// what the promo codes took off; total_amount is after it
+	DiscountAmount int64 `protobuf:"varint,15,opt,name=discount_amount,json=discountAmount,proto3" json:"discount_amount,omitempty"`
+	// the booking the seats were transferred out of, 0 for bought ones
+	TransferredFromId int64 `protobuf:"varint,16,opt,name=transferred_from_id,json=transferredFromId,proto3" json:"transferred_from_id,omitempty"`
+	// tickets of lower versions are void
+	TicketVersion int32 `protobuf:"varint,17,opt,name=ticket_version,json=ticketVersion,proto3" json:"ticket_version,omitempty"`
+	// the order the booking was bought with, 0 for one bought alone
+	OrderId       int64 `protobuf:"varint,18,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Booking) Reset() {
+	*x = Booking{}
+	mi := &file_booking_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Booking) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Booking) ProtoMessage() {}
+
+func (x *Booking) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Booking.ProtoReflect.Descriptor instead.
+func (*Booking) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Booking) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+func (x *Booking) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Booking) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *Booking) GetEventTitle() string {
+	if x != nil {
+		return x.EventTitle
+	}
+	return ""
+}
+
+func (x *Booking) GetEventStartsAt() string {
+	if x != nil {
+		return x.EventStartsAt
+	}
+	return ""
+}
+
+func (x *Booking) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Booking) GetSeats() []*BookedSeat {
+	if x != nil {
+		return x.Seats
+	}
+	return nil
+}
+
+func (x *Booking) GetPoolItems() []*BookedPoolItem {
+	if x != nil {
+		return x.PoolItems
+	}
+	return nil
+}
+
+func (x *Booking) GetTotalAmount() int64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *Booking) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Booking) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Booking) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Booking) GetRefundAmount() int64 {
+	if x != nil && x.RefundAmount != nil {
+		return *x.RefundAmount
+	}
+	return 0
+}
+
+func (x *Booking) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *Booking) GetDiscountAmount() int64 {
+	if x != nil {
+		return x.DiscountAmount
+	}
+	return 0
+}
+
+func (x *Booking) GetTransferredFromId() int64 {
+	if x != nil {
+		return x.TransferredFromId
+	}
+	return 0
+}
+
+func (x *Booking) GetTicketVersion() int32 {
+	if x != nil {
+		return x.TicketVersion
+	}
+	return 0
+}
+
+func (x *Booking) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+// Bookings are listed newest first, a page at a time; status optionally
+// keeps only bookings in that status.
+type ListUserBookingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserBookingsRequest) Reset() {
+	*x = ListUserBookingsRequest{}
+	mi := &file_booking_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserBookingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserBookingsRequest) ProtoMessage() {}
+
+func (x *ListUserBookingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserBookingsRequest.ProtoReflect.Descriptor instead.
+func (*ListUserBookingsRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListUserBookingsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListUserBookingsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListUserBookingsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListUserBookingsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListUserBookingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bookings      []*Booking             `protobuf:"bytes,1,rep,name=bookings,proto3" json:"bookings,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserBookingsResponse) Reset() {
+	*x = ListUserBookingsResponse{}
+	mi := &file_booking_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserBookingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserBookingsResponse) ProtoMessage() {}
+
+func (x *ListUserBookingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserBookingsResponse.ProtoReflect.Descriptor instead.
+func (*ListUserBookingsResponse) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListUserBookingsResponse) GetBookings() []*Booking {
+	if x != nil {
+		return x.Bookings
+	}
+	return nil
+}
+
+func (x *ListUserBookingsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// The booking must belong to user_id. Unpaid bookings are simply cancelled,
+// paid ones are refunded according to the refund policy of the event.
+type CancelBookingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookingId     int64                  `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelBookingRequest) Reset() {
+	*x = CancelBookingRequest{}
+	mi := &file_booking_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBookingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBookingRequest) ProtoMessage() {}
+
+func (x *CancelBookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBookingRequest.ProtoReflect.Descriptor instead.
+func (*CancelBookingRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CancelBookingRequest) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+func (x *CancelBookingRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type CancelBookingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookingId     int64                  `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	RefundAmount  int64                  `protobuf:"varint,3,opt,name=refund_amount,json=refundAmount,proto3" json:"refund_amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelBookingResponse) Reset() {
+	*x = CancelBookingResponse{}
+	mi := &file_booking_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBookingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBookingResponse) ProtoMessage() {}
+
+func (x *CancelBookingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBookingResponse.ProtoReflect.Descriptor instead.
+func (*CancelBookingResponse) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CancelBookingResponse) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+func (x *CancelBookingResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CancelBookingResponse) GetRefundAmount() int64 {
+	if x != nil {
+		return x.RefundAmount
+	}
+	return 0
+}
+
+func (x *CancelBookingResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// The booking must belong to user_id and still be held; each extension adds
+// the event's hold time to the deadline, a limited number of times.
+type ExtendHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookingId     int64                  `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExtendHoldRequest) Reset() {
+	*x = ExtendHoldRequest{}
+	mi := &file_booking_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtendHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendHoldRequest) ProtoMessage() {}
+
+func (x *ExtendHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendHoldRequest.ProtoReflect.Descriptor instead.
+func (*ExtendHoldRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ExtendHoldRequest) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+func (x *ExtendHoldRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ExtendHoldResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	BookingId int64                  `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	// RFC 3339
+	ExpiresAt      string `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	ExtensionsLeft int32  `protobuf:"varint,3,opt,name=extensions_left,json=extensionsLeft,proto3" json:"extensions_left,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExtendHoldResponse) Reset() {
+	*x = ExtendHoldResponse{}
+	mi := &file_booking_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtendHoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendHoldResponse) ProtoMessage() {}
+
+func (x *ExtendHoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendHoldResponse.ProtoReflect.Descriptor instead.
+func (*ExtendHoldResponse) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ExtendHoldResponse) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+func (x *ExtendHoldResponse) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *ExtendHoldResponse) GetExtensionsLeft() int32 {
+	if x != nil {
+		return x.ExtensionsLeft
+	}
+	return 0
+}
+
+// A waiting room lets admit_per_minute queued customers through to booking
+// the event each minute, each for admission_minutes; setting admit_per_minute
+// to 0 removes it and opens the event to everyone.
+type WaitingRoom struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	EventId          int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	AdmitPerMinute   int32                  `protobuf:"varint,2,opt,name=admit_per_minute,json=admitPerMinute,proto3" json:"admit_per_minute,omitempty"`
+	AdmissionMinutes int32                  `protobuf:"varint,3,opt,name=admission_minutes,json=admissionMinutes,proto3" json:"admission_minutes,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *WaitingRoom) Reset() {
+	*x = WaitingRoom{}
+	mi := &file_booking_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitingRoom) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitingRoom) ProtoMessage() {}
+
+func (x *WaitingRoom) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitingRoom.ProtoReflect.Descriptor instead.
+func (*WaitingRoom) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WaitingRoom) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *WaitingRoom) GetAdmitPerMinute() int32 {
+	if x != nil {
+		return x.AdmitPerMinute
+	}
+	return 0
+}
+
+func (x *WaitingRoom) GetAdmissionMinutes() int32 {
+	if x != nil {
+		return x.AdmissionMinutes
+	}
+	return 0
+}
+
+type GetWaitingRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWaitingRoomRequest) Reset() {
+	*x = GetWaitingRoomRequest{}
+	mi := &file_booking_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWaitingRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWaitingRoomRequest) ProtoMessage() {}
+
+func (x *GetWaitingRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWaitingRoomRequest.ProtoReflect.Descriptor instead.
+func (*GetWaitingRoomRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetWaitingRoomRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+type WaitingRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitingRoomRequest) Reset() {
+	*x = WaitingRoomRequest{}
+	mi := &file_booking_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitingRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitingRoomRequest) ProtoMessage() {}
+
+func (x *WaitingRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitingRoomRequest.ProtoReflect.Descriptor instead.
+func (*WaitingRoomRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WaitingRoomRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *WaitingRoomRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type WaitingRoomStatus struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	EventId int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// 0 once admitted
+	Position             int64 `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"`
+	EstimatedWaitSeconds int64 `protobuf:"varint,3,opt,name=estimated_wait_seconds,json=estimatedWaitSeconds,proto3" json:"estimated_wait_seconds,omitempty"`
+	// set once admitted, to be sent with bookings of the event
+	AdmissionToken string `protobuf:"bytes,4,opt,name=admission_token,json=admissionToken,proto3" json:"admission_token,omitempty"`
+	// RFC 3339
+	TokenExpiresAt string `protobuf:"bytes,5,opt,name=token_expires_at,json=tokenExpiresAt,proto3" json:"token_expires_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WaitingRoomStatus) Reset() {
+	*x = WaitingRoomStatus{}
+	mi := &file_booking_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitingRoomStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitingRoomStatus) ProtoMessage() {}
+
+func (x *WaitingRoomStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitingRoomStatus.ProtoReflect.Descriptor instead.
+func (*WaitingRoomStatus) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *WaitingRoomStatus) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *WaitingRoomStatus) GetPosition() int64 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *WaitingRoomStatus) GetEstimatedWaitSeconds() int64 {
+	if x != nil {
+		return x.EstimatedWaitSeconds
+	}
+	return 0
+}
+
+func (x *WaitingRoomStatus) GetAdmissionToken() string {
+	if x != nil {
+		return x.AdmissionToken
+	}
+	return ""
+}
+
+func (x *WaitingRoomStatus) GetTokenExpiresAt() string {
+	if x != nil {
+		return x.TokenExpiresAt
+	}
+	return ""
+}
+
+// Customers join the waitlist of a sold out event for quantity seats, or
+// tickets of pool_id when it is set. Released tickets are offered to them in
+// turn; the claim token of an offer is only sent in its notification.
+type JoinWaitlistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	PoolId        int64                  `protobuf:"varint,4,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinWaitlistRequest) Reset() {
+	*x = JoinWaitlistRequest{}
+	mi := &file_booking_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinWaitlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinWaitlistRequest) ProtoMessage() {}
+
+func (x *JoinWaitlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinWaitlistRequest.ProtoReflect.Descriptor instead.
+func (*JoinWaitlistRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *JoinWaitlistRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *JoinWaitlistRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *JoinWaitlistRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *JoinWaitlistRequest) GetPoolId() int64 {
+	if x != nil {
+		return x.PoolId
+	}
+	return 0
+}
+
+type WaitlistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitlistRequest) Reset() {
+	*x = WaitlistRequest{}
+	mi := &file_booking_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitlistRequest) ProtoMessage() {}
+
+func (x *WaitlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitlistRequest.ProtoReflect.Descriptor instead.
+func (*WaitlistRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *WaitlistRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *WaitlistRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// status is WAITING, OFFERED, CLAIMED, EXPIRED or LEFT; position is set only
+// while WAITING, the offer fields only while OFFERED.
+type WaitlistEntry struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	EventId        int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Status         string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Quantity       int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	PoolId         int64                  `protobuf:"varint,4,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	Position       int64                  `protobuf:"varint,5,opt,name=position,proto3" json:"position,omitempty"`
+	OfferedSeatIds []int64                `protobuf:"varint,6,rep,packed,name=offered_seat_ids,json=offeredSeatIds,proto3" json:"offered_seat_ids,omitempty"`
+	// RFC 3339
+	OfferExpiresAt string `protobuf:"bytes,7,opt,name=offer_expires_at,json=offerExpiresAt,proto3" json:"offer_expires_at,omitempty"`
+	BookingId      int64  `protobuf:"varint,8,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WaitlistEntry) Reset() {
+	*x = WaitlistEntry{}
+	mi := &file_booking_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitlistEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitlistEntry) ProtoMessage() {}
+
+func (x *WaitlistEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitlistEntry.ProtoReflect.Descriptor instead.
+func (*WaitlistEntry) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *WaitlistEntry) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *WaitlistEntry) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WaitlistEntry) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *WaitlistEntry) GetPoolId() int64 {
+	if x != nil {
+		return x.PoolId
+	}
+	return 0
+}
+
+func (x *WaitlistEntry) GetPosition() int64 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *WaitlistEntry) GetOfferedSeatIds() []int64 {
+	if x != nil {
+		return x.OfferedSeatIds
+	}
+	return nil
+}
+
+func (x *WaitlistEntry) GetOfferExpiresAt() string {
+	if x != nil {
+		return x.OfferExpiresAt
+	}
+	return ""
+}
+
+func (x *WaitlistEntry) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+type LeaveWaitlistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveWaitlistResponse) Reset() {
+	*x = LeaveWaitlistResponse{}
+	mi := &file_booking_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveWaitlistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveWaitlistResponse) ProtoMessage() {}
+
+func (x *LeaveWaitlistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveWaitlistResponse.ProtoReflect.Descriptor instead.
+func (*LeaveWaitlistResponse) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{27}
+}
+
+type ClaimWaitlistOfferRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	UserId     int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ClaimToken string                 `protobuf:"bytes,2,opt,name=claim_token,json=claimToken,proto3" json:"claim_token,omitempty"`
+	// the event of the offer; the token is only good for it
+	EventId       int64 `protobuf:"varint,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimWaitlistOfferRequest) Reset() {
+	*x = ClaimWaitlistOfferRequest{}
+	mi := &file_booking_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimWaitlistOfferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimWaitlistOfferRequest) ProtoMessage() {}
+
+func (x *ClaimWaitlistOfferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimWaitlistOfferRequest.ProtoReflect.Descriptor instead.
+func (*ClaimWaitlistOfferRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ClaimWaitlistOfferRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ClaimWaitlistOfferRequest) GetClaimToken() string {
+	if x != nil {
+		return x.ClaimToken
+	}
+	return ""
+}
+
+func (x *ClaimWaitlistOfferRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+// A promo code takes percent off the tickets, or amount in minor units of
+// currency off the booking. Zero caps mean no cap; event_id 0 and no
+// price_tier_ids make it apply to any ticket. Valid times are RFC 3339 and
+// optional.
+type PromoCode struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Code                  string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	DiscountType          string                 `protobuf:"bytes,2,opt,name=discount_type,json=discountType,proto3" json:"discount_type,omitempty"`
+	Percent               int32                  `protobuf:"varint,3,opt,name=percent,proto3" json:"percent,omitempty"`
+	Amount                int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency              string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	ValidFrom             string                 `protobuf:"bytes,6,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	ValidUntil            string                 `protobuf:"bytes,7,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
+	MaxRedemptions        int32                  `protobuf:"varint,8,opt,name=max_redemptions,json=maxRedemptions,proto3" json:"max_redemptions,omitempty"`
+	MaxRedemptionsPerUser int32                  `protobuf:"varint,9,opt,name=max_redemptions_per_user,json=maxRedemptionsPerUser,proto3" json:"max_redemptions_per_user,omitempty"`
+	EventId               int64                  `protobuf:"varint,10,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	PriceTierIds          []int64                `protobuf:"varint,11,rep,packed,name=price_tier_ids,json=priceTierIds,proto3" json:"price_tier_ids,omitempty"`
+	Stackable             bool                   `protobuf:"varint,12,opt,name=stackable,proto3" json:"stackable,omitempty"`
+	Active                bool                   `protobuf:"varint,13,opt,name=active,proto3" json:"active,omitempty"`
+	// read only
+	Redemptions   int32 `protobuf:"varint,14,opt,name=redemptions,proto3" json:"redemptions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoCode) Reset() {
+	*x = PromoCode{}
+	mi := &file_booking_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoCode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoCode) ProtoMessage() {}
+
+func (x *PromoCode) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoCode.ProtoReflect.Descriptor instead.
+func (*PromoCode) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PromoCode) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *PromoCode) GetDiscountType() string {
+	if x != nil {
+		return x.DiscountType
+	}
+	return ""
+}
+
+func (x *PromoCode) GetPercent() int32 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *PromoCode) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PromoCode) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PromoCode) GetValidFrom() string {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return ""
+}
+
+func (x *PromoCode) GetValidUntil() string {
+	if x != nil {
+		return x.ValidUntil
+	}
+	return ""
+}
+
+func (x *PromoCode) GetMaxRedemptions() int32 {
+	if x != nil {
+		return x.MaxRedemptions
+	}
+	return 0
+}
+
+func (x *PromoCode) GetMaxRedemptionsPerUser() int32 {
+	if x != nil {
+		return x.MaxRedemptionsPerUser
+	}
+	return 0
+}
+
+func (x *PromoCode) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *PromoCode) GetPriceTierIds() []int64 {
+	if x != nil {
+		return x.PriceTierIds
+	}
+	return nil
+}
+
+func (x *PromoCode) GetStackable() bool {
+	if x != nil {
+		return x.Stackable
+	}
+	return false
+}
+
+func (x *PromoCode) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *PromoCode) GetRedemptions() int32 {
+	if x != nil {
+		return x.Redemptions
+	}
+	return 0
+}
+
+type GetPromoCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPromoCodeRequest) Reset() {
+	*x = GetPromoCodeRequest{}
+	mi := &file_booking_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPromoCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPromoCodeRequest) ProtoMessage() {}
+
+func (x *GetPromoCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPromoCodeRequest.ProtoReflect.Descriptor instead.
+func (*GetPromoCodeRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetPromoCodeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// A confirmed booking, or seat_ids of it, offered to whoever signs in with
+// recipient_email. The accept token is only sent to the recipient.
+type StartTransferRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	BookingId      int64                  `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	UserId         int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RecipientEmail string                 `protobuf:"bytes,3,opt,name=recipient_email,json=recipientEmail,proto3" json:"recipient_email,omitempty"`
+	// empty for all tickets of the booking
+	SeatIds       []int64 `protobuf:"varint,4,rep,packed,name=seat_ids,json=seatIds,proto3" json:"seat_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartTransferRequest) Reset() {
+	*x = StartTransferRequest{}
+	mi := &file_booking_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartTransferRequest) ProtoMessage() {}
+
+func (x *StartTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartTransferRequest.ProtoReflect.Descriptor instead.
+func (*StartTransferRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *StartTransferRequest) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+func (x *StartTransferRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *StartTransferRequest) GetRecipientEmail() string {
+	if x != nil {
+		return x.RecipientEmail
+	}
+	return ""
+}
+
+func (x *StartTransferRequest) GetSeatIds() []int64 {
+	if x != nil {
+		return x.SeatIds
+	}
+	return nil
+}
+
+// status is PENDING, ACCEPTED, CANCELLED or EXPIRED.
+type Transfer struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TransferId     int64                  `protobuf:"varint,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	BookingId      int64                  `protobuf:"varint,2,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	RecipientEmail string                 `protobuf:"bytes,3,opt,name=recipient_email,json=recipientEmail,proto3" json:"recipient_email,omitempty"`
+	SeatIds        []int64                `protobuf:"varint,4,rep,packed,name=seat_ids,json=seatIds,proto3" json:"seat_ids,omitempty"`
+	Status         string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	// RFC 3339
+	ExpiresAt string `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// the booking holding the tickets once accepted
+	RecipientBookingId int64  `protobuf:"varint,7,opt,name=recipient_booking_id,json=recipientBookingId,proto3" json:"recipient_booking_id,omitempty"`
+	CreatedAt          string `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Transfer) Reset() {
+	*x = Transfer{}
+	mi := &file_booking_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transfer) ProtoMessage() {}
+
+func (x *Transfer) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transfer.ProtoReflect.Descriptor instead.
+func (*Transfer) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Transfer) GetTransferId() int64 {
+	if x != nil {
+		return x.TransferId
+	}
+	return 0
+}
+
+func (x *Transfer) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+func (x *Transfer) GetRecipientEmail() string {
+	if x != nil {
+		return x.RecipientEmail
+	}
+	return ""
+}
+
+func (x *Transfer) GetSeatIds() []int64 {
+	if x != nil {
+		return x.SeatIds
+	}
+	return nil
+}
+
+func (x *Transfer) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Transfer) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *Transfer) GetRecipientBookingId() int64 {
+	if x != nil {
+		return x.RecipientBookingId
+	}
+	return 0
+}
+
+func (x *Transfer) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type CancelTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransferId    int64                  `protobuf:"varint,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTransferRequest) Reset() {
+	*x = CancelTransferRequest{}
+	mi := &file_booking_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTransferRequest) ProtoMessage() {}
+
+func (x *CancelTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTransferRequest.ProtoReflect.Descriptor instead.
+func (*CancelTransferRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CancelTransferRequest) GetTransferId() int64 {
+	if x != nil {
+		return x.TransferId
+	}
+	return 0
+}
+
+func (x *CancelTransferRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type AcceptTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AcceptToken   string                 `protobuf:"bytes,2,opt,name=accept_token,json=acceptToken,proto3" json:"accept_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptTransferRequest) Reset() {
+	*x = AcceptTransferRequest{}
+	mi := &file_booking_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptTransferRequest) ProtoMessage() {}
+
+func (x *AcceptTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptTransferRequest.ProtoReflect.Descriptor instead.
+func (*AcceptTransferRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AcceptTransferRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AcceptTransferRequest) GetAcceptToken() string {
+	if x != nil {
+		return x.AcceptToken
+	}
+	return ""
+}
+
+type GetOwnershipHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookingId     int64                  `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOwnershipHistoryRequest) Reset() {
+	*x = GetOwnershipHistoryRequest{}
+	mi := &file_booking_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOwnershipHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOwnershipHistoryRequest) ProtoMessage() {}
+
+func (x *GetOwnershipHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOwnershipHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetOwnershipHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetOwnershipHistoryRequest) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+func (x *GetOwnershipHistoryRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// from_user_id is 0 for the purchase; from_booking_id is set when seat_ids
+// were split off another booking.
+type OwnershipChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookingId     int64                  `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	FromBookingId int64                  `protobuf:"varint,2,opt,name=from_booking_id,json=fromBookingId,proto3" json:"from_booking_id,omitempty"`
+	SeatIds       []int64                `protobuf:"varint,3,rep,packed,name=seat_ids,json=seatIds,proto3" json:"seat_ids,omitempty"`
+	FromUserId    int64                  `protobuf:"varint,4,opt,name=from_user_id,json=fromUserId,proto3" json:"from_user_id,omitempty"`
+	ToUserId      int64                  `protobuf:"varint,5,opt,name=to_user_id,json=toUserId,proto3" json:"to_user_id,omitempty"`
+	TransferId    int64                  `protobuf:"varint,6,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	// RFC 3339
+	ChangedAt     string `protobuf:"bytes,7,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OwnershipChange) Reset() {
+	*x = OwnershipChange{}
+	mi := &file_booking_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OwnershipChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OwnershipChange) ProtoMessage() {}
+
+func (x *OwnershipChange) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OwnershipChange.ProtoReflect.Descriptor instead.
+func (*OwnershipChange) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *OwnershipChange) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+func (x *OwnershipChange) GetFromBookingId() int64 {
+	if x != nil {
+		return x.FromBookingId
+	}
+	return 0
+}
+
+func (x *OwnershipChange) GetSeatIds() []int64 {
+	if x != nil {
+		return x.SeatIds
+	}
+	return nil
+}
+
+func (x *OwnershipChange) GetFromUserId() int64 {
+	if x != nil {
+		return x.FromUserId
+	}
+	return 0
+}
+
+func (x *OwnershipChange) GetToUserId() int64 {
+	if x != nil {
+		return x.ToUserId
+	}
+	return 0
+}
+
+func (x *OwnershipChange) GetTransferId() int64 {
+	if x != nil {
+		return x.TransferId
+	}
+	return 0
+}
+
+func (x *OwnershipChange) GetChangedAt() string {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return ""
+}
+
+type OwnershipHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*OwnershipChange     `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OwnershipHistory) Reset() {
+	*x = OwnershipHistory{}
+	mi := &file_booking_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OwnershipHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OwnershipHistory) ProtoMessage() {}
+
+func (x *OwnershipHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OwnershipHistory.ProtoReflect.Descriptor instead.
+func (*OwnershipHistory) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *OwnershipHistory) GetChanges() []*OwnershipChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// seat_ids of a confirmed booking given back for a refund; at least one
+// ticket has to stay.
+type CancelSeatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookingId     int64                  `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SeatIds       []int64                `protobuf:"varint,3,rep,packed,name=seat_ids,json=seatIds,proto3" json:"seat_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelSeatsRequest) Reset() {
+	*x = CancelSeatsRequest{}
+	mi := &file_booking_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelSeatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelSeatsRequest) ProtoMessage() {}
+
+func (x *CancelSeatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelSeatsRequest.ProtoReflect.Descriptor instead.
+func (*CancelSeatsRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CancelSeatsRequest) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+func (x *CancelSeatsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CancelSeatsRequest) GetSeatIds() []int64 {
+	if x != nil {
+		return x.SeatIds
+	}
+	return nil
+}
+
+// release_seat_ids of a confirmed booking swapped for as many available
+// seat_ids of its event.
+type ExchangeSeatsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	BookingId      int64                  `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	UserId         int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ReleaseSeatIds []int64                `protobuf:"varint,3,rep,packed,name=release_seat_ids,json=releaseSeatIds,proto3" json:"release_seat_ids,omitempty"`
+	SeatIds        []int64                `protobuf:"varint,4,rep,packed,name=seat_ids,json=seatIds,proto3" json:"seat_ids,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExchangeSeatsRequest) Reset() {
+	*x = ExchangeSeatsRequest{}
+	mi := &file_booking_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangeSeatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeSeatsRequest) ProtoMessage() {}
+
+func (x *ExchangeSeatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeSeatsRequest.ProtoReflect.Descriptor instead.
+func (*ExchangeSeatsRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ExchangeSeatsRequest) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+func (x *ExchangeSeatsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ExchangeSeatsRequest) GetReleaseSeatIds() []int64 {
+	if x != nil {
+		return x.ReleaseSeatIds
+	}
+	return nil
+}
+
+func (x *ExchangeSeatsRequest) GetSeatIds() []int64 {
+	if x != nil {
+		return x.SeatIds
+	}
+	return nil
+}
+
+type ListSeatChangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookingId     int64                  `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSeatChangesRequest) Reset() {
+	*x = ListSeatChangesRequest{}
+	mi := &file_booking_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSeatChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSeatChangesRequest) ProtoMessage() {}
+
+func (x *ListSeatChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSeatChangesRequest.ProtoReflect.Descriptor instead.
+func (*ListSeatChangesRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListSeatChangesRequest) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+func (x *ListSeatChangesRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// change_type is CANCELLATION or EXCHANGE; status is COMPLETED, or PENDING
+// while amount_due is being paid, then CANCELLED or EXPIRED when it was not.
+type SeatChange struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ChangeId        int64                  `protobuf:"varint,1,opt,name=change_id,json=changeId,proto3" json:"change_id,omitempty"`
+	BookingId       int64                  `protobuf:"varint,2,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	ChangeType      string                 `protobuf:"bytes,3,opt,name=change_type,json=changeType,proto3" json:"change_type,omitempty"`
+	Status          string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	ReleasedSeatIds []int64                `protobuf:"varint,5,rep,packed,name=released_seat_ids,json=releasedSeatIds,proto3" json:"released_seat_ids,omitempty"`
+	AddedSeatIds    []int64                `protobuf:"varint,6,rep,packed,name=added_seat_ids,json=addedSeatIds,proto3" json:"added_seat_ids,omitempty"`
+	// in minor units of currency
+	RefundAmount int64  `protobuf:"varint,7,opt,name=refund_amount,json=refundAmount,proto3" json:"refund_amount,omitempty"`
+	AmountDue    int64  `protobuf:"varint,8,opt,name=amount_due,json=amountDue,proto3" json:"amount_due,omitempty"`
+	Currency     string `protobuf:"bytes,9,opt,name=currency,proto3" json:"currency,omitempty"`
+	// RFC 3339, set while PENDING
+	ExpiresAt string `protobuf:"bytes,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt string `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// PENDING while the refund is on its way to the customer, SENT once it
+	// has been paid out; empty when nothing is refunded
+	RefundStatus  string `protobuf:"bytes,12,opt,name=refund_status,json=refundStatus,proto3" json:"refund_status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeatChange) Reset() {
+	*x = SeatChange{}
+	mi := &file_booking_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeatChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatChange) ProtoMessage() {}
+
+func (x *SeatChange) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatChange.ProtoReflect.Descriptor instead.
+func (*SeatChange) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SeatChange) GetChangeId() int64 {
+	if x != nil {
+		return x.ChangeId
+	}
+	return 0
+}
+
+func (x *SeatChange) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+func (x *SeatChange) GetChangeType() string {
+	if x != nil {
+		return x.ChangeType
+	}
+	return ""
+}
+
+func (x *SeatChange) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SeatChange) GetReleasedSeatIds() []int64 {
+	if x != nil {
+		return x.ReleasedSeatIds
+	}
+	return nil
+}
+
+func (x *SeatChange) GetAddedSeatIds() []int64 {
+	if x != nil {
+		return x.AddedSeatIds
+	}
+	return nil
+}
+
+func (x *SeatChange) GetRefundAmount() int64 {
+	if x != nil {
+		return x.RefundAmount
+	}
+	return 0
+}
+
+func (x *SeatChange) GetAmountDue() int64 {
+	if x != nil {
+		return x.AmountDue
+	}
+	return 0
+}
+
+func (x *SeatChange) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *SeatChange) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *SeatChange) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *SeatChange) GetRefundStatus() string {
+	if x != nil {
+		return x.RefundStatus
+	}
+	return ""
+}
+
+type SeatChanges struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*SeatChange          `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeatChanges) Reset() {
+	*x = SeatChanges{}
+	mi := &file_booking_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeatChanges) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatChanges) ProtoMessage() {}
+
+func (x *SeatChanges) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatChanges.ProtoReflect.Descriptor instead.
+func (*SeatChanges) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SeatChanges) GetChanges() []*SeatChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type GetBookingStatusHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookingId     int64                  `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookingStatusHistoryRequest) Reset() {
+	*x = GetBookingStatusHistoryRequest{}
+	mi := &file_booking_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookingStatusHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookingStatusHistoryRequest) ProtoMessage() {}
+
+func (x *GetBookingStatusHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookingStatusHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetBookingStatusHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetBookingStatusHistoryRequest) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+func (x *GetBookingStatusHistoryRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// from_status is empty for the status the booking was created in; actor is
+// user:<id>, system or payment; correlation_id is the id of the request that
+// caused the change, empty for background jobs.
+type BookingStatusChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromStatus    string                 `protobuf:"bytes,1,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"`
+	ToStatus      string                 `protobuf:"bytes,2,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	CorrelationId string                 `protobuf:"bytes,5,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	// RFC 3339
+	ChangedAt     string `protobuf:"bytes,6,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookingStatusChange) Reset() {
+	*x = BookingStatusChange{}
+	mi := &file_booking_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookingStatusChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingStatusChange) ProtoMessage() {}
+
+func (x *BookingStatusChange) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingStatusChange.ProtoReflect.Descriptor instead.
+func (*BookingStatusChange) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *BookingStatusChange) GetFromStatus() string {
+	if x != nil {
+		return x.FromStatus
+	}
+	return ""
+}
+
+func (x *BookingStatusChange) GetToStatus() string {
+	if x != nil {
+		return x.ToStatus
+	}
+	return ""
+}
+
+func (x *BookingStatusChange) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *BookingStatusChange) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BookingStatusChange) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *BookingStatusChange) GetChangedAt() string {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return ""
+}
+
+type BookingStatusHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*BookingStatusChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookingStatusHistory) Reset() {
+	*x = BookingStatusHistory{}
+	mi := &file_booking_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookingStatusHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingStatusHistory) ProtoMessage() {}
+
+func (x *BookingStatusHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingStatusHistory.ProtoReflect.Descriptor instead.
+func (*BookingStatusHistory) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *BookingStatusHistory) GetChanges() []*BookingStatusChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// staff_id is the organizer or admin letting the holder in. ticket_version is
+// the version of the ticket scanned; one reissued since is turned away.
+type CheckInBookingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	BookingId     int64                  `protobuf:"varint,2,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	StaffId       int64                  `protobuf:"varint,3,opt,name=staff_id,json=staffId,proto3" json:"staff_id,omitempty"`
+	TicketVersion int32                  `protobuf:"varint,4,opt,name=ticket_version,json=ticketVersion,proto3" json:"ticket_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckInBookingRequest) Reset() {
+	*x = CheckInBookingRequest{}
+	mi := &file_booking_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckInBookingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckInBookingRequest) ProtoMessage() {}
+
+func (x *CheckInBookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckInBookingRequest.ProtoReflect.Descriptor instead.
+func (*CheckInBookingRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CheckInBookingRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *CheckInBookingRequest) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+func (x *CheckInBookingRequest) GetStaffId() int64 {
+	if x != nil {
+		return x.StaffId
+	}
+	return 0
+}
+
+func (x *CheckInBookingRequest) GetTicketVersion() int32 {
+	if x != nil {
+		return x.TicketVersion
+	}
+	return 0
+}
+
+type CheckInBookingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookingId     int64                  `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckInBookingResponse) Reset() {
+	*x = CheckInBookingResponse{}
+	mi := &file_booking_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckInBookingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckInBookingResponse) ProtoMessage() {}
+
+func (x *CheckInBookingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckInBookingResponse.ProtoReflect.Descriptor instead.
+func (*CheckInBookingResponse) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CheckInBookingResponse) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+func (x *CheckInBookingResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// OrderItem is what an order books of one event: seats or a quantity from
+// a ticket pool, with the promo codes for that event.
+type OrderItem struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	EventId  int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	SeatIds  []int64                `protobuf:"varint,2,rep,packed,name=seat_ids,json=seatIds,proto3" json:"seat_ids,omitempty"`
+	PoolId   int64                  `protobuf:"varint,3,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	Quantity int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// refused like promo_code of CreateBookingRequest
+	PromoCodes    []string `protobuf:"bytes,5,rep,name=promo_codes,json=promoCodes,proto3" json:"promo_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderItem) Reset() {
+	*x = OrderItem{}
+	mi := &file_booking_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItem) ProtoMessage() {}
+
+func (x *OrderItem) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
+func (*OrderItem) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *OrderItem) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *OrderItem) GetSeatIds() []int64 {
+	if x != nil {
+		return x.SeatIds
+	}
+	return nil
+}
+
+func (x *OrderItem) GetPoolId() int64 {
+	if x != nil {
+		return x.PoolId
+	}
+	return 0
+}
+
+func (x *OrderItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *OrderItem) GetPromoCodes() []string {
+	if x != nil {
+		return x.PromoCodes
+	}
+	return nil
+}
+
+// An order books up to 10 different events at once, all or nothing.
+type CreateOrderRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items  []*OrderItem           `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// as in CreateBookingRequest: a retry with the same key and request gets
+	// the original order back instead of a second one
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateOrderRequest) Reset() {
+	*x = CreateOrderRequest{}
+	mi := &file_booking_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderRequest) ProtoMessage() {}
+
+func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *CreateOrderRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type CreateOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TotalAmount   int64                  `protobuf:"varint,2,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrderResponse) Reset() {
+	*x = CreateOrderResponse{}
+	mi := &file_booking_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderResponse) ProtoMessage() {}
+
+func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrderResponse) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *CreateOrderResponse) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *CreateOrderResponse) GetTotalAmount() int64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *CreateOrderResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_booking_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetOrderRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *GetOrderRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// The order is paid in one payment made against payment_booking_id.
+// Timestamps are RFC 3339; expires_at is empty once the order is not
+// PENDING.
+type Order struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OrderId          int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status           string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	TotalAmount      int64                  `protobuf:"varint,3,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	Currency         string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	PaymentBookingId int64                  `protobuf:"varint,5,opt,name=payment_booking_id,json=paymentBookingId,proto3" json:"payment_booking_id,omitempty"`
+	ExpiresAt        string                 `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Bookings         []*Booking             `protobuf:"bytes,7,rep,name=bookings,proto3" json:"bookings,omitempty"`
+	CreatedAt        string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_booking_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *Order) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *Order) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Order) GetTotalAmount() int64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *Order) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Order) GetPaymentBookingId() int64 {
+	if x != nil {
+		return x.PaymentBookingId
+	}
+	return 0
+}
+
+func (x *Order) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *Order) GetBookings() []*Booking {
+	if x != nil {
+		return x.Bookings
+	}
+	return nil
+}
+
+func (x *Order) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_booking_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_booking_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *CancelOrderRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *CancelOrderRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+var File_booking_proto protoreflect.FileDescriptor
+
+const file_booking_proto_rawDesc = "" +
+	"\n" +
+	"\rbooking.proto\x12\abooking\"\xd7\x02\n" +
+	"\x14CreateBookingRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\x03R\aeventId\x12\x19\n" +
+	"\bseat_ids\x18\x03 \x03(\x03R\aseatIds\x12\x17\n" +
+	"\apool_id\x18\x04 \x01(\x03R\x06poolId\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12=\n" +
+	"\x0ebest_available\x18\x06 \x01(\v2\x16.booking.BestAvailableR\rbestAvailable\x12'\n" +
+	"\x0fidempotency_key\x18\a \x01(\tR\x0eidempotencyKey\x12\x1d\n" +
+	"\n" +
+	"promo_code\x18\b \x01(\tR\tpromoCode\x124\n" +
+	"\x16additional_promo_codes\x18\t \x03(\tR\x14additionalPromoCodes\"`\n" +
+	"\rBestAvailable\x12\x1a\n" +
+	"\bquantity\x18\x01 \x01(\x05R\bquantity\x12\x16\n" +
+	"\x06sector\x18\x02 \x01(\tR\x06sector\x12\x1b\n" +
+	"\tmax_price\x18\x03 \x01(\x03R\bmaxPrice\"\xb1\x01\n" +
+	"\x15CreateBookingResponse\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\x03R\tbookingId\x12!\n" +
+	"\ftotal_amount\x18\x02 \x01(\x03R\vtotalAmount\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x19\n" +
+	"\bseat_ids\x18\x04 \x03(\x03R\aseatIds\x12\x1f\n" +
+	"\vseat_labels\x18\x05 \x03(\tR\n" +
+	"seatLabels\"T\n" +
+	"\x1bHandlePaymentWebhookRequest\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\x03R\tbookingId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"\x1e\n" +
+	"\x1cHandlePaymentWebhookResponse\";\n" +
+	"\x1eGetEventCancellationJobRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\"i\n" +
+	"\x16FailedCancellationItem\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\x03R\tbookingId\x12\x1a\n" +
+	"\battempts\x18\x02 \x01(\x05R\battempts\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\xb0\x02\n" +
+	"\x14EventCancellationJob\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\x03R\x05jobId\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\x03R\aeventId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\x12\x1c\n" +
+	"\tprocessed\x18\x05 \x01(\x05R\tprocessed\x12\x16\n" +
+	"\x06failed\x18\x06 \x01(\x05R\x06failed\x12B\n" +
+	"\ffailed_items\x18\a \x03(\v2\x1f.booking.FailedCancellationItemR\vfailedItems\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x1f\n" +
+	"\vfinished_at\x18\t \x01(\tR\n" +
+	"finishedAt\"1\n" +
+	"\x16GetUserCalendarRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"(\n" +
+	"\fUserCalendar\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\"K\n" +
+	"\x11GetBookingRequest\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\x03R\tbookingId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"\xa4\x01\n" +
+	"\n" +
+	"BookedSeat\x12\x17\n" +
+	"\aseat_id\x18\x01 \x01(\x03R\x06seatId\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x16\n" +
+	"\x06sector\x18\x03 \x01(\tR\x06sector\x12\x10\n" +
+	"\x03row\x18\x04 \x01(\x05R\x03row\x12!\n" +
+	"\fprice_amount\x18\x05 \x01(\x03R\vpriceAmount\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\"\x98\x01\n" +
+	"\x0eBookedPoolItem\x12\x17\n" +
+	"\apool_id\x18\x01 \x01(\x03R\x06poolId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12!\n" +
+	"\fprice_amount\x18\x04 \x01(\x03R\vpriceAmount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\"\x93\x05\n" +
+	"\aBooking\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\x03R\tbookingId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\x03R\aeventId\x12\x1f\n" +
+	"\vevent_title\x18\x04 \x01(\tR\n" +
+	"eventTitle\x12&\n" +
+	"\x0fevent_starts_at\x18\x05 \x01(\tR\reventStartsAt\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12)\n" +
+	"\x05seats\x18\a \x03(\v2\x13.booking.BookedSeatR\x05seats\x126\n" +
+	"\n" +
+	"pool_items\x18\b \x03(\v2\x17.booking.BookedPoolItemR\tpoolItems\x12!\n" +
+	"\ftotal_amount\x18\t \x01(\x03R\vtotalAmount\x12\x1a\n" +
+	"\bcurrency\x18\n" +
+	" \x01(\tR\bcurrency\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\tR\tupdatedAt\x12(\n" +
+	"\rrefund_amount\x18\r \x01(\x03H\x00R\frefundAmount\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x0e \x01(\tR\texpiresAt\x12'\n" +
+	"\x0fdiscount_amount\x18\x0f \x01(\x03R\x0ediscountAmount\x12.\n" +
+	"\x13transferred_from_id\x18\x10 \x01(\x03R\x11transferredFromId\x12%\n" +
+	"\x0eticket_version\x18\x11 \x01(\x05R\rticketVersion\x12\x19\n" +
+	"\border_id\x18\x12 \x01(\x03R\aorderIdB\x10\n" +
+	"\x0e_refund_amount\"\x86\x01\n" +
+	"\x17ListUserBookingsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\"p\n" +
+	"\x18ListUserBookingsResponse\x12,\n" +
+	"\bbookings\x18\x01 \x03(\v2\x10.booking.BookingR\bbookings\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"N\n" +
+	"\x14CancelBookingRequest\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\x03R\tbookingId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"\x8f\x01\n" +
+	"\x15CancelBookingResponse\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\x03R\tbookingId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12#\n" +
+	"\rrefund_amount\x18\x03 \x01(\x03R\frefundAmount\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\"K\n" +
+	"\x11ExtendHoldRequest\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\x03R\tbookingId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"{\n" +
+	"\x12ExtendHoldResponse\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\x03R\tbookingId\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\tR\texpiresAt\x12'\n" +
+	"\x0fextensions_left\x18\x03 \x01(\x05R\x0eextensionsLeft\"\x7f\n" +
+	"\vWaitingRoom\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12(\n" +
+	"\x10admit_per_minute\x18\x02 \x01(\x05R\x0eadmitPerMinute\x12+\n" +
+	"\x11admission_minutes\x18\x03 \x01(\x05R\x10admissionMinutes\"2\n" +
+	"\x15GetWaitingRoomRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\"H\n" +
+	"\x12WaitingRoomRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"\xd3\x01\n" +
+	"\x11WaitingRoomStatus\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x1a\n" +
+	"\bposition\x18\x02 \x01(\x03R\bposition\x124\n" +
+	"\x16estimated_wait_seconds\x18\x03 \x01(\x03R\x14estimatedWaitSeconds\x12'\n" +
+	"\x0fadmission_token\x18\x04 \x01(\tR\x0eadmissionToken\x12(\n" +
+	"\x10token_expires_at\x18\x05 \x01(\tR\x0etokenExpiresAt\"~\n" +
+	"\x13JoinWaitlistRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x17\n" +
+	"\apool_id\x18\x04 \x01(\x03R\x06poolId\"E\n" +
+	"\x0fWaitlistRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"\x86\x02\n" +
+	"\rWaitlistEntry\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x17\n" +
+	"\apool_id\x18\x04 \x01(\x03R\x06poolId\x12\x1a\n" +
+	"\bposition\x18\x05 \x01(\x03R\bposition\x12(\n" +
+	"\x10offered_seat_ids\x18\x06 \x03(\x03R\x0eofferedSeatIds\x12(\n" +
+	"\x10offer_expires_at\x18\a \x01(\tR\x0eofferExpiresAt\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\b \x01(\x03R\tbookingId\"\x17\n" +
+	"\x15LeaveWaitlistResponse\"p\n" +
+	"\x19ClaimWaitlistOfferRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1f\n" +
+	"\vclaim_token\x18\x02 \x01(\tR\n" +
+	"claimToken\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\x03R\aeventId\"\xcd\x03\n" +
+	"\tPromoCode\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12#\n" +
+	"\rdiscount_type\x18\x02 \x01(\tR\fdiscountType\x12\x18\n" +
+	"\apercent\x18\x03 \x01(\x05R\apercent\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x1d\n" +
+	"\n" +
+	"valid_from\x18\x06 \x01(\tR\tvalidFrom\x12\x1f\n" +
+	"\vvalid_until\x18\a \x01(\tR\n" +
+	"validUntil\x12'\n" +
+	"\x0fmax_redemptions\x18\b \x01(\x05R\x0emaxRedemptions\x127\n" +
+	"\x18max_redemptions_per_user\x18\t \x01(\x05R\x15maxRedemptionsPerUser\x12\x19\n" +
+	"\bevent_id\x18\n" +
+	" \x01(\x03R\aeventId\x12$\n" +
+	"\x0eprice_tier_ids\x18\v \x03(\x03R\fpriceTierIds\x12\x1c\n" +
+	"\tstackable\x18\f \x01(\bR\tstackable\x12\x16\n" +
+	"\x06active\x18\r \x01(\bR\x06active\x12 \n" +
+	"\vredemptions\x18\x0e \x01(\x05R\vredemptions\")\n" +
+	"\x13GetPromoCodeRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"\x92\x01\n" +
+	"\x14StartTransferRequest\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\x03R\tbookingId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12'\n" +
+	"\x0frecipient_email\x18\x03 \x01(\tR\x0erecipientEmail\x12\x19\n" +
+	"\bseat_ids\x18\x04 \x03(\x03R\aseatIds\"\x96\x02\n" +
+	"\bTransfer\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\x03R\n" +
+	"transferId\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x02 \x01(\x03R\tbookingId\x12'\n" +
+	"\x0frecipient_email\x18\x03 \x01(\tR\x0erecipientEmail\x12\x19\n" +
+	"\bseat_ids\x18\x04 \x03(\x03R\aseatIds\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\tR\texpiresAt\x120\n" +
+	"\x14recipient_booking_id\x18\a \x01(\x03R\x12recipientBookingId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\"Q\n" +
+	"\x15CancelTransferRequest\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\x03R\n" +
+	"transferId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"S\n" +
+	"\x15AcceptTransferRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12!\n" +
+	"\faccept_token\x18\x02 \x01(\tR\vacceptToken\"T\n" +
+	"\x1aGetOwnershipHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\x03R\tbookingId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"\xf3\x01\n" +
+	"\x0fOwnershipChange\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\x03R\tbookingId\x12&\n" +
+	"\x0ffrom_booking_id\x18\x02 \x01(\x03R\rfromBookingId\x12\x19\n" +
+	"\bseat_ids\x18\x03 \x03(\x03R\aseatIds\x12 \n" +
+	"\ffrom_user_id\x18\x04 \x01(\x03R\n" +
+	"fromUserId\x12\x1c\n" +
+	"\n" +
+	"to_user_id\x18\x05 \x01(\x03R\btoUserId\x12\x1f\n" +
+	"\vtransfer_id\x18\x06 \x01(\x03R\n" +
+	"transferId\x12\x1d\n" +
+	"\n" +
+	"changed_at\x18\a \x01(\tR\tchangedAt\"F\n" +
+	"\x10OwnershipHistory\x122\n" +
+	"\achanges\x18\x01 \x03(\v2\x18.booking.OwnershipChangeR\achanges\"g\n" +
+	"\x12CancelSeatsRequest\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\x03R\tbookingId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x19\n" +
+	"\bseat_ids\x18\x03 \x03(\x03R\aseatIds\"\x93\x01\n" +
+	"\x14ExchangeSeatsRequest\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\x03R\tbookingId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12(\n" +
+	"\x10release_seat_ids\x18\x03 \x03(\x03R\x0ereleaseSeatIds\x12\x19\n" +
+	"\bseat_ids\x18\x04 \x03(\x03R\aseatIds\"P\n" +
+	"\x16ListSeatChangesRequest\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\x03R\tbookingId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"\x96\x03\n" +
+	"\n" +
+	"SeatChange\x12\x1b\n" +
+	"\tchange_id\x18\x01 \x01(\x03R\bchangeId\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x02 \x01(\x03R\tbookingId\x12\x1f\n" +
+	"\vchange_type\x18\x03 \x01(\tR\n" +
+	"changeType\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12*\n" +
+	"\x11released_seat_ids\x18\x05 \x03(\x03R\x0freleasedSeatIds\x12$\n" +
+	"\x0eadded_seat_ids\x18\x06 \x03(\x03R\faddedSeatIds\x12#\n" +
+	"\rrefund_amount\x18\a \x01(\x03R\frefundAmount\x12\x1d\n" +
+	"\n" +
+	"amount_due\x18\b \x01(\x03R\tamountDue\x12\x1a\n" +
+	"\bcurrency\x18\t \x01(\tR\bcurrency\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\n" +
+	" \x01(\tR\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\tR\tcreatedAt\x12#\n" +
+	"\rrefund_status\x18\f \x01(\tR\frefundStatus\"<\n" +
+	"\vSeatChanges\x12-\n" +
+	"\achanges\x18\x01 \x03(\v2\x13.booking.SeatChangeR\achanges\"X\n" +
+	"\x1eGetBookingStatusHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\x03R\tbookingId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"\xc7\x01\n" +
+	"\x13BookingStatusChange\x12\x1f\n" +
+	"\vfrom_status\x18\x01 \x01(\tR\n" +
+	"fromStatus\x12\x1b\n" +
+	"\tto_status\x18\x02 \x01(\tR\btoStatus\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12%\n" +
+	"\x0ecorrelation_id\x18\x05 \x01(\tR\rcorrelationId\x12\x1d\n" +
+	"\n" +
+	"changed_at\x18\x06 \x01(\tR\tchangedAt\"N\n" +
+	"\x14BookingStatusHistory\x126\n" +
+	"\achanges\x18\x01 \x03(\v2\x1c.booking.BookingStatusChangeR\achanges\"\x93\x01\n" +
+	"\x15CheckInBookingRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x02 \x01(\x03R\tbookingId\x12\x19\n" +
+	"\bstaff_id\x18\x03 \x01(\x03R\astaffId\x12%\n" +
+	"\x0eticket_version\x18\x04 \x01(\x05R\rticketVersion\"O\n" +
+	"\x16CheckInBookingResponse\x12\x1d\n" +
+	"\n" +
+	"booking_id\x18\x01 \x01(\x03R\tbookingId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"\x97\x01\n" +
+	"\tOrderItem\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x19\n" +
+	"\bseat_ids\x18\x02 \x03(\x03R\aseatIds\x12\x17\n" +
+	"\apool_id\x18\x03 \x01(\x03R\x06poolId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x1f\n" +
+	"\vpromo_codes\x18\x05 \x03(\tR\n" +
+	"promoCodes\"\x80\x01\n" +
+	"\x12CreateOrderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12(\n" +
+	"\x05items\x18\x02 \x03(\v2\x12.booking.OrderItemR\x05items\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\"o\n" +
+	"\x13CreateOrderResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12!\n" +
+	"\ftotal_amount\x18\x02 \x01(\x03R\vtotalAmount\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"E\n" +
+	"\x0fGetOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"\x93\x02\n" +
+	"\x05Order\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12!\n" +
+	"\ftotal_amount\x18\x03 \x01(\x03R\vtotalAmount\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12,\n" +
+	"\x12payment_booking_id\x18\x05 \x01(\x03R\x10paymentBookingId\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\tR\texpiresAt\x12,\n" +
+	"\bbookings\x18\a \x03(\v2\x10.booking.BookingR\bbookings\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\"H\n" +
+	"\x12CancelOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId2\xd3\x11\n" +
+	"\x0eBookingService\x12N\n" +
+	"\rCreateBooking\x12\x1d.booking.CreateBookingRequest\x1a\x1e.booking.CreateBookingResponse\x12c\n" +
+	"\x14HandlePaymentWebhook\x12$.booking.HandlePaymentWebhookRequest\x1a%.booking.HandlePaymentWebhookResponse\x12a\n" +
+	"\x17GetEventCancellationJob\x12'.booking.GetEventCancellationJobRequest\x1a\x1d.booking.EventCancellationJob\x12I\n" +
+	"\x0fGetUserCalendar\x12\x1f.booking.GetUserCalendarRequest\x1a\x15.booking.UserCalendar\x12:\n" +
+	"\n" +
+	"GetBooking\x12\x1a.booking.GetBookingRequest\x1a\x10.booking.Booking\x12W\n" +
+	"\x10ListUserBookings\x12 .booking.ListUserBookingsRequest\x1a!.booking.ListUserBookingsResponse\x12N\n" +
+	"\rCancelBooking\x12\x1d.booking.CancelBookingRequest\x1a\x1e.booking.CancelBookingResponse\x12E\n" +
+	"\n" +
+	"ExtendHold\x12\x1a.booking.ExtendHoldRequest\x1a\x1b.booking.ExtendHoldResponse\x12<\n" +
+	"\x0eSetWaitingRoom\x12\x14.booking.WaitingRoom\x1a\x14.booking.WaitingRoom\x12F\n" +
+	"\x0eGetWaitingRoom\x12\x1e.booking.GetWaitingRoomRequest\x1a\x14.booking.WaitingRoom\x12J\n" +
+	"\x0fJoinWaitingRoom\x12\x1b.booking.WaitingRoomRequest\x1a\x1a.booking.WaitingRoomStatus\x12O\n" +
+	"\x14GetWaitingRoomStatus\x12\x1b.booking.WaitingRoomRequest\x1a\x1a.booking.WaitingRoomStatus\x12D\n" +
+	"\fJoinWaitlist\x12\x1c.booking.JoinWaitlistRequest\x1a\x16.booking.WaitlistEntry\x12D\n" +
+	"\x10GetWaitlistEntry\x12\x18.booking.WaitlistRequest\x1a\x16.booking.WaitlistEntry\x12I\n" +
+	"\rLeaveWaitlist\x12\x18.booking.WaitlistRequest\x1a\x1e.booking.LeaveWaitlistResponse\x12X\n" +
+	"\x12ClaimWaitlistOffer\x12\".booking.ClaimWaitlistOfferRequest\x1a\x1e.booking.CreateBookingResponse\x126\n" +
+	"\fSetPromoCode\x12\x12.booking.PromoCode\x1a\x12.booking.PromoCode\x12@\n" +
+	"\fGetPromoCode\x12\x1c.booking.GetPromoCodeRequest\x1a\x12.booking.PromoCode\x12A\n" +
+	"\rStartTransfer\x12\x1d.booking.StartTransferRequest\x1a\x11.booking.Transfer\x12C\n" +
+	"\x0eCancelTransfer\x12\x1e.booking.CancelTransferRequest\x1a\x11.booking.Transfer\x12C\n" +
+	"\x0eAcceptTransfer\x12\x1e.booking.AcceptTransferRequest\x1a\x11.booking.Transfer\x12U\n" +
+	"\x13GetOwnershipHistory\x12#.booking.GetOwnershipHistoryRequest\x1a\x19.booking.OwnershipHistory\x12?\n" +
+	"\vCancelSeats\x12\x1b.booking.CancelSeatsRequest\x1a\x13.booking.SeatChange\x12C\n" +
+	"\rExchangeSeats\x12\x1d.booking.ExchangeSeatsRequest\x1a\x13.booking.SeatChange\x12H\n" +
+	"\x0fListSeatChanges\x12\x1f.booking.ListSeatChangesRequest\x1a\x14.booking.SeatChanges\x12a\n" +
+	"\x17GetBookingStatusHistory\x12'.booking.GetBookingStatusHistoryRequest\x1a\x1d.booking.BookingStatusHistory\x12Q\n" +
+	"\x0eCheckInBooking\x12\x1e.booking.CheckInBookingRequest\x1a\x1f.booking.CheckInBookingResponse\x12H\n" +
+	"\vCreateOrder\x12\x1b.booking.CreateOrderRequest\x1a\x1c.booking.CreateOrderResponse\x124\n" +
+	"\bGetOrder\x12\x18.booking.GetOrderRequest\x1a\x0e.booking.Order\x12:\n" +
+	"\vCancelOrder\x12\x1b.booking.CancelOrderRequest\x1a\x0e.booking.OrderB\x15Z\x13./booking;bookingv1b\x06proto3"
 
 var (
 	file_booking_proto_rawDescOnce sync.Once
@@ -246,23 +4115,139 @@ func file_booking_proto_rawDescGZIP() []byte {
 	return file_booking_proto_rawDescData
 }
 
-var file_booking_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_booking_proto_msgTypes = make([]protoimpl.MessageInfo, 54)
 var file_booking_proto_goTypes = []any{
-	(*CreateBookingRequest)(nil),         // 0: booking.CreateBookingRequest
-	(*CreateBookingResponse)(nil),        // 1: booking.CreateBookingResponse
-	(*HandlePaymentWebhookRequest)(nil),  // 2: booking.HandlePaymentWebhookRequest
-	(*HandlePaymentWebhookResponse)(nil), // 3: booking.HandlePaymentWebhookResponse
+	(*CreateBookingRequest)(nil),           // 0: booking.CreateBookingRequest
+	(*BestAvailable)(nil),                  // 1: booking.BestAvailable
+	(*CreateBookingResponse)(nil),          // 2: booking.CreateBookingResponse
+	(*HandlePaymentWebhookRequest)(nil),    // 3: booking.HandlePaymentWebhookRequest
+	(*HandlePaymentWebhookResponse)(nil),   // 4: booking.HandlePaymentWebhookResponse
+	(*GetEventCancellationJobRequest)(nil), // 5: booking.GetEventCancellationJobRequest
+	(*FailedCancellationItem)(nil),         // 6: booking.FailedCancellationItem
+	(*EventCancellationJob)(nil),           // 7: booking.EventCancellationJob
+	(*GetUserCalendarRequest)(nil),         // 8: booking.GetUserCalendarRequest
+	(*UserCalendar)(nil),                   // 9: booking.UserCalendar
+	(*GetBookingRequest)(nil),              // 10: booking.GetBookingRequest
+	(*BookedSeat)(nil),                     // 11: booking.BookedSeat
+	(*BookedPoolItem)(nil),                 // 12: booking.BookedPoolItem
+	(*Booking)(nil),                        // 13: booking.Booking
+	(*ListUserBookingsRequest)(nil),        // 14: booking.ListUserBookingsRequest
+	(*ListUserBookingsResponse)(nil),       // 15: booking.ListUserBookingsResponse
+	(*CancelBookingRequest)(nil),           // 16: booking.CancelBookingRequest
+	(*CancelBookingResponse)(nil),          // 17: booking.CancelBookingResponse
+	(*ExtendHoldRequest)(nil),              // 18: booking.ExtendHoldRequest
+	(*ExtendHoldResponse)(nil),             // 19: booking.ExtendHoldResponse
+	(*WaitingRoom)(nil),                    // 20: booking.WaitingRoom
+	(*GetWaitingRoomRequest)(nil),          // 21: booking.GetWaitingRoomRequest
+	(*WaitingRoomRequest)(nil),             // 22: booking.WaitingRoomRequest
+	(*WaitingRoomStatus)(nil),              // 23: booking.WaitingRoomStatus
+	(*JoinWaitlistRequest)(nil),            // 24: booking.JoinWaitlistRequest
+	(*WaitlistRequest)(nil),                // 25: booking.WaitlistRequest
+	(*WaitlistEntry)(nil),                  // 26: booking.WaitlistEntry
+	(*LeaveWaitlistResponse)(nil),          // 27: booking.LeaveWaitlistResponse
+	(*ClaimWaitlistOfferRequest)(nil),      // 28: booking.ClaimWaitlistOfferRequest
+	(*PromoCode)(nil),                      // 29: booking.PromoCode
+	(*GetPromoCodeRequest)(nil),            // 30: booking.GetPromoCodeRequest
+	(*StartTransferRequest)(nil),           // 31: booking.StartTransferRequest
+	(*Transfer)(nil),                       // 32: booking.Transfer
+	(*CancelTransferRequest)(nil),          // 33: booking.CancelTransferRequest
+	(*AcceptTransferRequest)(nil),          // 34: booking.AcceptTransferRequest
+	(*GetOwnershipHistoryRequest)(nil),     // 35: booking.GetOwnershipHistoryRequest
+	(*OwnershipChange)(nil),                // 36: booking.OwnershipChange
+	(*OwnershipHistory)(nil),               // 37: booking.OwnershipHistory
+	(*CancelSeatsRequest)(nil),             // 38: booking.CancelSeatsRequest
+	(*ExchangeSeatsRequest)(nil),           // 39: booking.ExchangeSeatsRequest
+	(*ListSeatChangesRequest)(nil),         // 40: booking.ListSeatChangesRequest
+	(*SeatChange)(nil),                     // 41: booking.SeatChange
+	(*SeatChanges)(nil),                    // 42: booking.SeatChanges
+	(*GetBookingStatusHistoryRequest)(nil), // 43: booking.GetBookingStatusHistoryRequest
+	(*BookingStatusChange)(nil),            // 44: booking.BookingStatusChange
+	(*BookingStatusHistory)(nil),           // 45: booking.BookingStatusHistory
+	(*CheckInBookingRequest)(nil),          // 46: booking.CheckInBookingRequest
+	(*CheckInBookingResponse)(nil),         // 47: booking.CheckInBookingResponse
+	(*OrderItem)(nil),                      // 48: booking.OrderItem
+	(*CreateOrderRequest)(nil),             // 49: booking.CreateOrderRequest
+	(*CreateOrderResponse)(nil),            // 50: booking.CreateOrderResponse
+	(*GetOrderRequest)(nil),                // 51: booking.GetOrderRequest
+	(*Order)(nil),                          // 52: booking.Order
+	(*CancelOrderRequest)(nil),             // 53: booking.CancelOrderRequest
 }
 var file_booking_proto_depIdxs = []int32{
-	0, // 0: booking.BookingService.CreateBooking:input_type -> booking.CreateBookingRequest
-	2, // 1: booking.BookingService.HandlePaymentWebhook:input_type -> booking.HandlePaymentWebhookRequest
-	1, // 2: booking.BookingService.CreateBooking:output_type -> booking.CreateBookingResponse
-	3, // 3: booking.BookingService.HandlePaymentWebhook:output_type -> booking.HandlePaymentWebhookResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1,  // 0: booking.CreateBookingRequest.best_available:type_name -> booking.BestAvailable
+	6,  // 1: booking.EventCancellationJob.failed_items:type_name -> booking.FailedCancellationItem
+	11, // 2: booking.Booking.seats:type_name -> booking.BookedSeat
+	12, // 3: booking.Booking.pool_items:type_name -> booking.BookedPoolItem
+	13, // 4: booking.ListUserBookingsResponse.bookings:type_name -> booking.Booking
+	36, // 5: booking.OwnershipHistory.changes:type_name -> booking.OwnershipChange
+	41, // 6: booking.SeatChanges.changes:type_name -> booking.SeatChange
+	44, // 7: booking.BookingStatusHistory.changes:type_name -> booking.BookingStatusChange
+	48, // 8: booking.CreateOrderRequest.items:type_name -> booking.OrderItem
+	13, // 9: booking.Order.bookings:type_name -> booking.Booking
+	0,  // 10: booking.BookingService.CreateBooking:input_type -> booking.CreateBookingRequest
+	3,  // 11: booking.BookingService.HandlePaymentWebhook:input_type -> booking.HandlePaymentWebhookRequest
+	5,  // 12: booking.BookingService.GetEventCancellationJob:input_type -> booking.GetEventCancellationJobRequest
+	8,  // 13: booking.BookingService.GetUserCalendar:input_type -> booking.GetUserCalendarRequest
+	10, // 14: booking.BookingService.GetBooking:input_type -> booking.GetBookingRequest
+	14, // 15: booking.BookingService.ListUserBookings:input_type -> booking.ListUserBookingsRequest
+	16, // 16: booking.BookingService.CancelBooking:input_type -> booking.CancelBookingRequest
+	18, // 17: booking.BookingService.ExtendHold:input_type -> booking.ExtendHoldRequest
+	20, // 18: booking.BookingService.SetWaitingRoom:input_type -> booking.WaitingRoom
+	21, // 19: booking.BookingService.GetWaitingRoom:input_type -> booking.GetWaitingRoomRequest
+	22, // 20: booking.BookingService.JoinWaitingRoom:input_type -> booking.WaitingRoomRequest
+	22, // 21: booking.BookingService.GetWaitingRoomStatus:input_type -> booking.WaitingRoomRequest
+	24, // 22: booking.BookingService.JoinWaitlist:input_type -> booking.JoinWaitlistRequest
+	25, // 23: booking.BookingService.GetWaitlistEntry:input_type -> booking.WaitlistRequest
+	25, // 24: booking.BookingService.LeaveWaitlist:input_type -> booking.WaitlistRequest
+	28, // 25: booking.BookingService.ClaimWaitlistOffer:input_type -> booking.ClaimWaitlistOfferRequest
+	29, // 26: booking.BookingService.SetPromoCode:input_type -> booking.PromoCode
+	30, // 27: booking.BookingService.GetPromoCode:input_type -> booking.GetPromoCodeRequest
+	31, // 28: booking.BookingService.StartTransfer:input_type -> booking.StartTransferRequest
+	33, // 29: booking.BookingService.CancelTransfer:input_type -> booking.CancelTransferRequest
+	34, // 30: booking.BookingService.AcceptTransfer:input_type -> booking.AcceptTransferRequest
+	35, // 31: booking.BookingService.GetOwnershipHistory:input_type -> booking.GetOwnershipHistoryRequest
+	38, // 32: booking.BookingService.CancelSeats:input_type -> booking.CancelSeatsRequest
+	39, // 33: booking.BookingService.ExchangeSeats:input_type -> booking.ExchangeSeatsRequest
+	40, // 34: booking.BookingService.ListSeatChanges:input_type -> booking.ListSeatChangesRequest
+	43, // 35: booking.BookingService.GetBookingStatusHistory:input_type -> booking.GetBookingStatusHistoryRequest
+	46, // 36: booking.BookingService.CheckInBooking:input_type -> booking.CheckInBookingRequest
+	49, // 37: booking.BookingService.CreateOrder:input_type -> booking.CreateOrderRequest
+	51, // 38: booking.BookingService.GetOrder:input_type -> booking.GetOrderRequest
+	53, // 39: booking.BookingService.CancelOrder:input_type -> booking.CancelOrderRequest
+	2,  // 40: booking.BookingService.CreateBooking:output_type -> booking.CreateBookingResponse
+	4,  // 41: booking.BookingService.HandlePaymentWebhook:output_type -> booking.HandlePaymentWebhookResponse
+	7,  // 42: booking.BookingService.GetEventCancellationJob:output_type -> booking.EventCancellationJob
+	9,  // 43: booking.BookingService.GetUserCalendar:output_type -> booking.UserCalendar
+	13, // 44: booking.BookingService.GetBooking:output_type -> booking.Booking
+	15, // 45: booking.BookingService.ListUserBookings:output_type -> booking.ListUserBookingsResponse
+	17, // 46: booking.BookingService.CancelBooking:output_type -> booking.CancelBookingResponse
+	19, // 47: booking.BookingService.ExtendHold:output_type -> booking.ExtendHoldResponse
+	20, // 48: booking.BookingService.SetWaitingRoom:output_type -> booking.WaitingRoom
+	20, // 49: booking.BookingService.GetWaitingRoom:output_type -> booking.WaitingRoom
+	23, // 50: booking.BookingService.JoinWaitingRoom:output_type -> booking.WaitingRoomStatus
+	23, // 51: booking.BookingService.GetWaitingRoomStatus:output_type -> booking.WaitingRoomStatus
+	26, // 52: booking.BookingService.JoinWaitlist:output_type -> booking.WaitlistEntry
+	26, // 53: booking.BookingService.GetWaitlistEntry:output_type -> booking.WaitlistEntry
+	27, // 54: booking.BookingService.LeaveWaitlist:output_type -> booking.LeaveWaitlistResponse
+	2,  // 55: booking.BookingService.ClaimWaitlistOffer:output_type -> booking.CreateBookingResponse
+	29, // 56: booking.BookingService.SetPromoCode:output_type -> booking.PromoCode
+	29, // 57: booking.BookingService.GetPromoCode:output_type -> booking.PromoCode
+	32, // 58: booking.BookingService.StartTransfer:output_type -> booking.Transfer
+	32, // 59: booking.BookingService.CancelTransfer:output_type -> booking.Transfer
+	32, // 60: booking.BookingService.AcceptTransfer:output_type -> booking.Transfer
+	37, // 61: booking.BookingService.GetOwnershipHistory:output_type -> booking.OwnershipHistory
+	41, // 62: booking.BookingService.CancelSeats:output_type -> booking.SeatChange
+	41, // 63: booking.BookingService.ExchangeSeats:output_type -> booking.SeatChange
+	42, // 64: booking.BookingService.ListSeatChanges:output_type -> booking.SeatChanges
+	45, // 65: booking.BookingService.GetBookingStatusHistory:output_type -> booking.BookingStatusHistory
+	47, // 66: booking.BookingService.CheckInBooking:output_type -> booking.CheckInBookingResponse
+	50, // 67: booking.BookingService.CreateOrder:output_type -> booking.CreateOrderResponse
+	52, // 68: booking.BookingService.GetOrder:output_type -> booking.Order
+	52, // 69: booking.BookingService.CancelOrder:output_type -> booking.Order
+	40, // [40:70] is the sub-list for method output_type
+	10, // [10:40] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_booking_proto_init() }
@@ -270,13 +4255,14 @@ func file_booking_proto_init() {
 	if File_booking_proto != nil {
 		return
 	}
+	file_booking_proto_msgTypes[13].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_booking_proto_rawDesc), len(file_booking_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   54,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,36 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	BookingService_CreateBooking_FullMethodName        = "/booking.BookingService/CreateBooking"
-	BookingService_HandlePaymentWebhook_FullMethodName = "/booking.BookingService/HandlePaymentWebhook"
+	BookingService_CreateBooking_FullMethodName           = "/booking.BookingService/CreateBooking"
+	BookingService_HandlePaymentWebhook_FullMethodName    = "/booking.BookingService/HandlePaymentWebhook"
+	BookingService_GetEventCancellationJob_FullMethodName = "/booking.BookingService/GetEventCancellationJob"
+	BookingService_GetUserCalendar_FullMethodName         = "/booking.BookingService/GetUserCalendar"
+	BookingService_GetBooking_FullMethodName              = "/booking.BookingService/GetBooking"
+	BookingService_ListUserBookings_FullMethodName        = "/booking.BookingService/ListUserBookings"
+	BookingService_CancelBooking_FullMethodName           = "/booking.BookingService/CancelBooking"
+	BookingService_ExtendHold_FullMethodName              = "/booking.BookingService/ExtendHold"
+	BookingService_SetWaitingRoom_FullMethodName          = "/booking.BookingService/SetWaitingRoom"
+	BookingService_GetWaitingRoom_FullMethodName          = "/booking.BookingService/GetWaitingRoom"
+	BookingService_JoinWaitingRoom_FullMethodName         = "/booking.BookingService/JoinWaitingRoom"
+	BookingService_GetWaitingRoomStatus_FullMethodName    = "/booking.BookingService/GetWaitingRoomStatus"
+	BookingService_JoinWaitlist_FullMethodName            = "/booking.BookingService/JoinWaitlist"
+	BookingService_GetWaitlistEntry_FullMethodName        = "/booking.BookingService/GetWaitlistEntry"
+	BookingService_LeaveWaitlist_FullMethodName           = "/booking.BookingService/LeaveWaitlist"
+	BookingService_ClaimWaitlistOffer_FullMethodName      = "/booking.BookingService/ClaimWaitlistOffer"
+	BookingService_SetPromoCode_FullMethodName            = "/booking.BookingService/SetPromoCode"
+	BookingService_GetPromoCode_FullMethodName            = "/booking.BookingService/GetPromoCode"
+	BookingService_StartTransfer_FullMethodName           = "/booking.BookingService/StartTransfer"
+	BookingService_CancelTransfer_FullMethodName          = "/booking.BookingService/CancelTransfer"
+	BookingService_AcceptTransfer_FullMethodName          = "/booking.BookingService/AcceptTransfer"
+	BookingService_GetOwnershipHistory_FullMethodName     = "/booking.BookingService/GetOwnershipHistory"
+	BookingService_CancelSeats_FullMethodName             = "/booking.BookingService/CancelSeats"
+	BookingService_ExchangeSeats_FullMethodName           = "/booking.BookingService/ExchangeSeats"
+	BookingService_ListSeatChanges_FullMethodName         = "/booking.BookingService/ListSeatChanges"
+	BookingService_GetBookingStatusHistory_FullMethodName = "/booking.BookingService/GetBookingStatusHistory"
+	BookingService_CheckInBooking_FullMethodName          = "/booking.BookingService/CheckInBooking"
+	BookingService_CreateOrder_FullMethodName             = "/booking.BookingService/CreateOrder"
+	BookingService_GetOrder_FullMethodName                = "/booking.BookingService/GetOrder"
+	BookingService_CancelOrder_FullMethodName             = "/booking.BookingService/CancelOrder"
 )
 
 // BookingServiceClient is the client API for BookingService service.
@@ -29,6 +57,34 @@ const (
 type BookingServiceClient interface {
 	CreateBooking(ctx context.Context, in *CreateBookingRequest, opts ...grpc.CallOption) (*CreateBookingResponse, error)
 	HandlePaymentWebhook(ctx context.Context, in *HandlePaymentWebhookRequest, opts ...grpc.CallOption) (*HandlePaymentWebhookResponse, error)
+	GetEventCancellationJob(ctx context.Context, in *GetEventCancellationJobRequest, opts ...grpc.CallOption) (*EventCancellationJob, error)
+	GetUserCalendar(ctx context.Context, in *GetUserCalendarRequest, opts ...grpc.CallOption) (*UserCalendar, error)
+	GetBooking(ctx context.Context, in *GetBookingRequest, opts ...grpc.CallOption) (*Booking, error)
+	ListUserBookings(ctx context.Context, in *ListUserBookingsRequest, opts ...grpc.CallOption) (*ListUserBookingsResponse, error)
+	CancelBooking(ctx context.Context, in *CancelBookingRequest, opts ...grpc.CallOption) (*CancelBookingResponse, error)
+	ExtendHold(ctx context.Context, in *ExtendHoldRequest, opts ...grpc.CallOption) (*ExtendHoldResponse, error)
+	SetWaitingRoom(ctx context.Context, in *WaitingRoom, opts ...grpc.CallOption) (*WaitingRoom, error)
+	GetWaitingRoom(ctx context.Context, in *GetWaitingRoomRequest, opts ...grpc.CallOption) (*WaitingRoom, error)
+	JoinWaitingRoom(ctx context.Context, in *WaitingRoomRequest, opts ...grpc.CallOption) (*WaitingRoomStatus, error)
+	GetWaitingRoomStatus(ctx context.Context, in *WaitingRoomRequest, opts ...grpc.CallOption) (*WaitingRoomStatus, error)
+	JoinWaitlist(ctx context.Context, in *JoinWaitlistRequest, opts ...grpc.CallOption) (*WaitlistEntry, error)
+	GetWaitlistEntry(ctx context.Context, in *WaitlistRequest, opts ...grpc.CallOption) (*WaitlistEntry, error)
+	LeaveWaitlist(ctx context.Context, in *WaitlistRequest, opts ...grpc.CallOption) (*LeaveWaitlistResponse, error)
+	ClaimWaitlistOffer(ctx context.Context, in *ClaimWaitlistOfferRequest, opts ...grpc.CallOption) (*CreateBookingResponse, error)
+	SetPromoCode(ctx context.Context, in *PromoCode, opts ...grpc.CallOption) (*PromoCode, error)
+	GetPromoCode(ctx context.Context, in *GetPromoCodeRequest, opts ...grpc.CallOption) (*PromoCode, error)
+	StartTransfer(ctx context.Context, in *StartTransferRequest, opts ...grpc.CallOption) (*Transfer, error)
+	CancelTransfer(ctx context.Context, in *CancelTransferRequest, opts ...grpc.CallOption) (*Transfer, error)
+	AcceptTransfer(ctx context.Context, in *AcceptTransferRequest, opts ...grpc.CallOption) (*Transfer, error)
+	GetOwnershipHistory(ctx context.Context, in *GetOwnershipHistoryRequest, opts ...grpc.CallOption) (*OwnershipHistory, error)
+	CancelSeats(ctx context.Context, in *CancelSeatsRequest, opts ...grpc.CallOption) (*SeatChange, error)
+	ExchangeSeats(ctx context.Context, in *ExchangeSeatsRequest, opts ...grpc.CallOption) (*SeatChange, error)
+	ListSeatChanges(ctx context.Context, in *ListSeatChangesRequest, opts ...grpc.CallOption) (*SeatChanges, error)
+	GetBookingStatusHistory(ctx context.Context, in *GetBookingStatusHistoryRequest, opts ...grpc.CallOption) (*BookingStatusHistory, error)
+	CheckInBooking(ctx context.Context, in *CheckInBookingRequest, opts ...grpc.CallOption) (*CheckInBookingResponse, error)
+	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*CreateOrderResponse, error)
+	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*Order, error)
 }
 
 type bookingServiceClient struct {
@@ -59,12 +115,320 @@ func (c *bookingServiceClient) HandlePaymentWebhook(ctx context.Context, in *Han
 	return out, nil
 }
 
+func (c *bookingServiceClient) GetEventCancellationJob(ctx context.Context, in *GetEventCancellationJobRequest, opts ...grpc.CallOption) (*EventCancellationJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EventCancellationJob)
+	err := c.cc.Invoke(ctx, BookingService_GetEventCancellationJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) GetUserCalendar(ctx context.Context, in *GetUserCalendarRequest, opts ...grpc.CallOption) (*UserCalendar, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserCalendar)
+	err := c.cc.Invoke(ctx, BookingService_GetUserCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) GetBooking(ctx context.Context, in *GetBookingRequest, opts ...grpc.CallOption) (*Booking, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Booking)
+	err := c.cc.Invoke(ctx, BookingService_GetBooking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) ListUserBookings(ctx context.Context, in *ListUserBookingsRequest, opts ...grpc.CallOption) (*ListUserBookingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUserBookingsResponse)
+	err := c.cc.Invoke(ctx, BookingService_ListUserBookings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) CancelBooking(ctx context.Context, in *CancelBookingRequest, opts ...grpc.CallOption) (*CancelBookingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelBookingResponse)
+	err := c.cc.Invoke(ctx, BookingService_CancelBooking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) ExtendHold(ctx context.Context, in *ExtendHoldRequest, opts ...grpc.CallOption) (*ExtendHoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExtendHoldResponse)
+	err := c.cc.Invoke(ctx, BookingService_ExtendHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) SetWaitingRoom(ctx context.Context, in *WaitingRoom, opts ...grpc.CallOption) (*WaitingRoom, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WaitingRoom)
+	err := c.cc.Invoke(ctx, BookingService_SetWaitingRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) GetWaitingRoom(ctx context.Context, in *GetWaitingRoomRequest, opts ...grpc.CallOption) (*WaitingRoom, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WaitingRoom)
+	err := c.cc.Invoke(ctx, BookingService_GetWaitingRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) JoinWaitingRoom(ctx context.Context, in *WaitingRoomRequest, opts ...grpc.CallOption) (*WaitingRoomStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WaitingRoomStatus)
+	err := c.cc.Invoke(ctx, BookingService_JoinWaitingRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) GetWaitingRoomStatus(ctx context.Context, in *WaitingRoomRequest, opts ...grpc.CallOption) (*WaitingRoomStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WaitingRoomStatus)
+	err := c.cc.Invoke(ctx, BookingService_GetWaitingRoomStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) JoinWaitlist(ctx context.Context, in *JoinWaitlistRequest, opts ...grpc.CallOption) (*WaitlistEntry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WaitlistEntry)
+	err := c.cc.Invoke(ctx, BookingService_JoinWaitlist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) GetWaitlistEntry(ctx context.Context, in *WaitlistRequest, opts ...grpc.CallOption) (*WaitlistEntry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WaitlistEntry)
+	err := c.cc.Invoke(ctx, BookingService_GetWaitlistEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) LeaveWaitlist(ctx context.Context, in *WaitlistRequest, opts ...grpc.CallOption) (*LeaveWaitlistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaveWaitlistResponse)
+	err := c.cc.Invoke(ctx, BookingService_LeaveWaitlist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) ClaimWaitlistOffer(ctx context.Context, in *ClaimWaitlistOfferRequest, opts ...grpc.CallOption) (*CreateBookingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateBookingResponse)
+	err := c.cc.Invoke(ctx, BookingService_ClaimWaitlistOffer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) SetPromoCode(ctx context.Context, in *PromoCode, opts ...grpc.CallOption) (*PromoCode, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromoCode)
+	err := c.cc.Invoke(ctx, BookingService_SetPromoCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) GetPromoCode(ctx context.Context, in *GetPromoCodeRequest, opts ...grpc.CallOption) (*PromoCode, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromoCode)
+	err := c.cc.Invoke(ctx, BookingService_GetPromoCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) StartTransfer(ctx context.Context, in *StartTransferRequest, opts ...grpc.CallOption) (*Transfer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Transfer)
+	err := c.cc.Invoke(ctx, BookingService_StartTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) CancelTransfer(ctx context.Context, in *CancelTransferRequest, opts ...grpc.CallOption) (*Transfer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Transfer)
+	err := c.cc.Invoke(ctx, BookingService_CancelTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) AcceptTransfer(ctx context.Context, in *AcceptTransferRequest, opts ...grpc.CallOption) (*Transfer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Transfer)
+	err := c.cc.Invoke(ctx, BookingService_AcceptTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) GetOwnershipHistory(ctx context.Context, in *GetOwnershipHistoryRequest, opts ...grpc.CallOption) (*OwnershipHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OwnershipHistory)
+	err := c.cc.Invoke(ctx, BookingService_GetOwnershipHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) CancelSeats(ctx context.Context, in *CancelSeatsRequest, opts ...grpc.CallOption) (*SeatChange, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeatChange)
+	err := c.cc.Invoke(ctx, BookingService_CancelSeats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) ExchangeSeats(ctx context.Context, in *ExchangeSeatsRequest, opts ...grpc.CallOption) (*SeatChange, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeatChange)
+	err := c.cc.Invoke(ctx, BookingService_ExchangeSeats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) ListSeatChanges(ctx context.Context, in *ListSeatChangesRequest, opts ...grpc.CallOption) (*SeatChanges, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeatChanges)
+	err := c.cc.Invoke(ctx, BookingService_ListSeatChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) GetBookingStatusHistory(ctx context.Context, in *GetBookingStatusHistoryRequest, opts ...grpc.CallOption) (*BookingStatusHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BookingStatusHistory)
+	err := c.cc.Invoke(ctx, BookingService_GetBookingStatusHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) CheckInBooking(ctx context.Context, in *CheckInBookingRequest, opts ...grpc.CallOption) (*CheckInBookingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckInBookingResponse)
+	err := c.cc.Invoke(ctx, BookingService_CheckInBooking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*CreateOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateOrderResponse)
+	err := c.cc.Invoke(ctx, BookingService_CreateOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, BookingService_GetOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, BookingService_CancelOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BookingServiceServer is the server API for BookingService service.
 // All implementations must embed UnimplementedBookingServiceServer
 // for forward compatibility.
 type BookingServiceServer interface {
 	CreateBooking(context.Context, *CreateBookingRequest) (*CreateBookingResponse, error)
 	HandlePaymentWebhook(context.Context, *HandlePaymentWebhookRequest) (*HandlePaymentWebhookResponse, error)
+	GetEventCancellationJob(context.Context, *GetEventCancellationJobRequest) (*EventCancellationJob, error)
+	GetUserCalendar(context.Context, *GetUserCalendarRequest) (*UserCalendar, error)
+	GetBooking(context.Context, *GetBookingRequest) (*Booking, error)
+	ListUserBookings(context.Context, *ListUserBookingsRequest) (*ListUserBookingsResponse, error)
+	CancelBooking(context.Context, *CancelBookingRequest) (*CancelBookingResponse, error)
+	ExtendHold(context.Context, *ExtendHoldRequest) (*ExtendHoldResponse, error)
+	SetWaitingRoom(context.Context, *WaitingRoom) (*WaitingRoom, error)
+	GetWaitingRoom(context.Context, *GetWaitingRoomRequest) (*WaitingRoom, error)
+	JoinWaitingRoom(context.Context, *WaitingRoomRequest) (*WaitingRoomStatus, error)
+	GetWaitingRoomStatus(context.Context, *WaitingRoomRequest) (*WaitingRoomStatus, error)
+	JoinWaitlist(context.Context, *JoinWaitlistRequest) (*WaitlistEntry, error)
+	GetWaitlistEntry(context.Context, *WaitlistRequest) (*WaitlistEntry, error)
+	LeaveWaitlist(context.Context, *WaitlistRequest) (*LeaveWaitlistResponse, error)
+	ClaimWaitlistOffer(context.Context, *ClaimWaitlistOfferRequest) (*CreateBookingResponse, error)
+	SetPromoCode(context.Context, *PromoCode) (*PromoCode, error)
+	GetPromoCode(context.Context, *GetPromoCodeRequest) (*PromoCode, error)
+	StartTransfer(context.Context, *StartTransferRequest) (*Transfer, error)
+	CancelTransfer(context.Context, *CancelTransferRequest) (*Transfer, error)
+	AcceptTransfer(context.Context, *AcceptTransferRequest) (*Transfer, error)
+	GetOwnershipHistory(context.Context, *GetOwnershipHistoryRequest) (*OwnershipHistory, error)
+	CancelSeats(context.Context, *CancelSeatsRequest) (*SeatChange, error)
+	ExchangeSeats(context.Context, *ExchangeSeatsRequest) (*SeatChange, error)
+	ListSeatChanges(context.Context, *ListSeatChangesRequest) (*SeatChanges, error)
+	GetBookingStatusHistory(context.Context, *GetBookingStatusHistoryRequest) (*BookingStatusHistory, error)
+	CheckInBooking(context.Context, *CheckInBookingRequest) (*CheckInBookingResponse, error)
+	CreateOrder(context.Context, *CreateOrderRequest) (*CreateOrderResponse, error)
+	GetOrder(context.Context, *GetOrderRequest) (*Order, error)
+	CancelOrder(context.Context, *CancelOrderRequest) (*Order, error)
 	mustEmbedUnimplementedBookingServiceServer()
 }
 
@@ -81,6 +445,90 @@ func (UnimplementedBookingServiceServer) CreateBooking(context.Context, *CreateB
 func (UnimplementedBookingServiceServer) HandlePaymentWebhook(context.Context, *HandlePaymentWebhookRequest) (*HandlePaymentWebhookResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HandlePaymentWebhook not implemented")
 }
+func (UnimplementedBookingServiceServer) GetEventCancellationJob(context.Context, *GetEventCancellationJobRequest) (*EventCancellationJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEventCancellationJob not implemented")
+}
+func (UnimplementedBookingServiceServer) GetUserCalendar(context.Context, *GetUserCalendarRequest) (*UserCalendar, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserCalendar not implemented")
+}
+func (UnimplementedBookingServiceServer) GetBooking(context.Context, *GetBookingRequest) (*Booking, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBooking not implemented")
+}
+func (UnimplementedBookingServiceServer) ListUserBookings(context.Context, *ListUserBookingsRequest) (*ListUserBookingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserBookings not implemented")
+}
+func (UnimplementedBookingServiceServer) CancelBooking(context.Context, *CancelBookingRequest) (*CancelBookingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelBooking not implemented")
+}
+func (UnimplementedBookingServiceServer) ExtendHold(context.Context, *ExtendHoldRequest) (*ExtendHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtendHold not implemented")
+}
+func (UnimplementedBookingServiceServer) SetWaitingRoom(context.Context, *WaitingRoom) (*WaitingRoom, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWaitingRoom not implemented")
+}
+func (UnimplementedBookingServiceServer) GetWaitingRoom(context.Context, *GetWaitingRoomRequest) (*WaitingRoom, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWaitingRoom not implemented")
+}
+func (UnimplementedBookingServiceServer) JoinWaitingRoom(context.Context, *WaitingRoomRequest) (*WaitingRoomStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinWaitingRoom not implemented")
+}
+func (UnimplementedBookingServiceServer) GetWaitingRoomStatus(context.Context, *WaitingRoomRequest) (*WaitingRoomStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWaitingRoomStatus not implemented")
+}
+func (UnimplementedBookingServiceServer) JoinWaitlist(context.Context, *JoinWaitlistRequest) (*WaitlistEntry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinWaitlist not implemented")
+}
+func (UnimplementedBookingServiceServer) GetWaitlistEntry(context.Context, *WaitlistRequest) (*WaitlistEntry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWaitlistEntry not implemented")
+}
+func (UnimplementedBookingServiceServer) LeaveWaitlist(context.Context, *WaitlistRequest) (*LeaveWaitlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveWaitlist not implemented")
+}
+func (UnimplementedBookingServiceServer) ClaimWaitlistOffer(context.Context, *ClaimWaitlistOfferRequest) (*CreateBookingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClaimWaitlistOffer not implemented")
+}
+func (UnimplementedBookingServiceServer) SetPromoCode(context.Context, *PromoCode) (*PromoCode, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPromoCode not implemented")
+}
+func (UnimplementedBookingServiceServer) GetPromoCode(context.Context, *GetPromoCodeRequest) (*PromoCode, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPromoCode not implemented")
+}
+func (UnimplementedBookingServiceServer) StartTransfer(context.Context, *StartTransferRequest) (*Transfer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartTransfer not implemented")
+}
+func (UnimplementedBookingServiceServer) CancelTransfer(context.Context, *CancelTransferRequest) (*Transfer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTransfer not implemented")
+}
+func (UnimplementedBookingServiceServer) AcceptTransfer(context.Context, *AcceptTransferRequest) (*Transfer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptTransfer not implemented")
+}
+func (UnimplementedBookingServiceServer) GetOwnershipHistory(context.Context, *GetOwnershipHistoryRequest) (*OwnershipHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOwnershipHistory not implemented")
+}
+func (UnimplementedBookingServiceServer) CancelSeats(context.Context, *CancelSeatsRequest) (*SeatChange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSeats not implemented")
+}
+func (UnimplementedBookingServiceServer) ExchangeSeats(context.Context, *ExchangeSeatsRequest) (*SeatChange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExchangeSeats not implemented")
+}
+func (UnimplementedBookingServiceServer) ListSeatChanges(context.Context, *ListSeatChangesRequest) (*SeatChanges, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSeatChanges not implemented")
+}
+func (UnimplementedBookingServiceServer) GetBookingStatusHistory(context.Context, *GetBookingStatusHistoryRequest) (*BookingStatusHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBookingStatusHistory not implemented")
+}
+func (UnimplementedBookingServiceServer) CheckInBooking(context.Context, *CheckInBookingRequest) (*CheckInBookingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckInBooking not implemented")
+}
+func (UnimplementedBookingServiceServer) CreateOrder(context.Context, *CreateOrderRequest) (*CreateOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrder not implemented")
+}
+func (UnimplementedBookingServiceServer) GetOrder(context.Context, *GetOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (UnimplementedBookingServiceServer) CancelOrder(context.Context, *CancelOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
 func (UnimplementedBookingServiceServer) mustEmbedUnimplementedBookingServiceServer() {}
 func (UnimplementedBookingServiceServer) testEmbeddedByValue()                        {}
 
@@ -138,20 +586,636 @@ func _BookingService_HandlePaymentWebhook_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
-// BookingService_ServiceDesc is the grpc.ServiceDesc for BookingService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var BookingService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "booking.BookingService",
-	HandlerType: (*BookingServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateBooking",
-			Handler:    _BookingService_CreateBooking_Handler,
-		},
-		{
-			MethodName: "HandlePaymentWebhook",
-			Handler:    _BookingService_HandlePaymentWebhook_Handler,
+func _BookingService_GetEventCancellationJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventCancellationJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).GetEventCancellationJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_GetEventCancellationJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).GetEventCancellationJob(ctx, req.(*GetEventCancellationJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_GetUserCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserCalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).GetUserCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_GetUserCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).GetUserCalendar(ctx, req.(*GetUserCalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_GetBooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBookingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).GetBooking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_GetBooking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).GetBooking(ctx, req.(*GetBookingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_ListUserBookings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserBookingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).ListUserBookings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_ListUserBookings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).ListUserBookings(ctx, req.(*ListUserBookingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_CancelBooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBookingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).CancelBooking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_CancelBooking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).CancelBooking(ctx, req.(*CancelBookingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_ExtendHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtendHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).ExtendHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_ExtendHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).ExtendHold(ctx, req.(*ExtendHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_SetWaitingRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WaitingRoom)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).SetWaitingRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_SetWaitingRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).SetWaitingRoom(ctx, req.(*WaitingRoom))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_GetWaitingRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWaitingRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).GetWaitingRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_GetWaitingRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).GetWaitingRoom(ctx, req.(*GetWaitingRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_JoinWaitingRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WaitingRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).JoinWaitingRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_JoinWaitingRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).JoinWaitingRoom(ctx, req.(*WaitingRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_GetWaitingRoomStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WaitingRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).GetWaitingRoomStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_GetWaitingRoomStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).GetWaitingRoomStatus(ctx, req.(*WaitingRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_JoinWaitlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinWaitlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).JoinWaitlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_JoinWaitlist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).JoinWaitlist(ctx, req.(*JoinWaitlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_GetWaitlistEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WaitlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).GetWaitlistEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_GetWaitlistEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).GetWaitlistEntry(ctx, req.(*WaitlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_LeaveWaitlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WaitlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).LeaveWaitlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_LeaveWaitlist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).LeaveWaitlist(ctx, req.(*WaitlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_ClaimWaitlistOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimWaitlistOfferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).ClaimWaitlistOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_ClaimWaitlistOffer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).ClaimWaitlistOffer(ctx, req.(*ClaimWaitlistOfferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_SetPromoCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoCode)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).SetPromoCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_SetPromoCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).SetPromoCode(ctx, req.(*PromoCode))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_GetPromoCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPromoCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).GetPromoCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_GetPromoCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).GetPromoCode(ctx, req.(*GetPromoCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_StartTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).StartTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_StartTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).StartTransfer(ctx, req.(*StartTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_CancelTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).CancelTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_CancelTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).CancelTransfer(ctx, req.(*CancelTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_AcceptTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).AcceptTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_AcceptTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).AcceptTransfer(ctx, req.(*AcceptTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_GetOwnershipHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOwnershipHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).GetOwnershipHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_GetOwnershipHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).GetOwnershipHistory(ctx, req.(*GetOwnershipHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_CancelSeats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelSeatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).CancelSeats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_CancelSeats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).CancelSeats(ctx, req.(*CancelSeatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_ExchangeSeats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExchangeSeatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).ExchangeSeats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_ExchangeSeats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).ExchangeSeats(ctx, req.(*ExchangeSeatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_ListSeatChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSeatChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).ListSeatChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_ListSeatChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).ListSeatChanges(ctx, req.(*ListSeatChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_GetBookingStatusHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBookingStatusHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).GetBookingStatusHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_GetBookingStatusHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).GetBookingStatusHistory(ctx, req.(*GetBookingStatusHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_CheckInBooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckInBookingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).CheckInBooking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_CheckInBooking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).CheckInBooking(ctx, req.(*CheckInBookingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_CreateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).CreateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_CreateOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).CreateOrder(ctx, req.(*CreateOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_GetOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).GetOrder(ctx, req.(*GetOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_CancelOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BookingService_ServiceDesc is the grpc.ServiceDesc for BookingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BookingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "booking.BookingService",
+	HandlerType: (*BookingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateBooking",
+			Handler:    _BookingService_CreateBooking_Handler,
+		},
+		{
+			MethodName: "HandlePaymentWebhook",
+			Handler:    _BookingService_HandlePaymentWebhook_Handler,
+		},
+		{
+			MethodName: "GetEventCancellationJob",
+			Handler:    _BookingService_GetEventCancellationJob_Handler,
+		},
+		{
+			MethodName: "GetUserCalendar",
+			Handler:    _BookingService_GetUserCalendar_Handler,
+		},
+		{
+			MethodName: "GetBooking",
+			Handler:    _BookingService_GetBooking_Handler,
+		},
+		{
+			MethodName: "ListUserBookings",
+			Handler:    _BookingService_ListUserBookings_Handler,
+		},
+		{
+			MethodName: "CancelBooking",
+			Handler:    _BookingService_CancelBooking_Handler,
+		},
+		{
+			MethodName: "ExtendHold",
+			Handler:    _BookingService_ExtendHold_Handler,
+		},
+		{
+			MethodName: "SetWaitingRoom",
+			Handler:    _BookingService_SetWaitingRoom_Handler,
+		},
+		{
+			MethodName: "GetWaitingRoom",
+			Handler:    _BookingService_GetWaitingRoom_Handler,
+		},
+		{
+			MethodName: "JoinWaitingRoom",
+			Handler:    _BookingService_JoinWaitingRoom_Handler,
+		},
+		{
+			MethodName: "GetWaitingRoomStatus",
+			Handler:    _BookingService_GetWaitingRoomStatus_Handler,
+		},
+		{
+			MethodName: "JoinWaitlist",
+			Handler:    _BookingService_JoinWaitlist_Handler,
+		},
+		{
+			MethodName: "GetWaitlistEntry",
+			Handler:    _BookingService_GetWaitlistEntry_Handler,
+		},
+		{
+			MethodName: "LeaveWaitlist",
+			Handler:    _BookingService_LeaveWaitlist_Handler,
+		},
+		{
+			MethodName: "ClaimWaitlistOffer",
+			Handler:    _BookingService_ClaimWaitlistOffer_Handler,
+		},
+		{
+			MethodName: "SetPromoCode",
+			Handler:    _BookingService_SetPromoCode_Handler,
+		},
+		{
+			MethodName: "GetPromoCode",
+			Handler:    _BookingService_GetPromoCode_Handler,
+		},
+		{
+			MethodName: "StartTransfer",
+			Handler:    _BookingService_StartTransfer_Handler,
+		},
+		{
+			MethodName: "CancelTransfer",
+			Handler:    _BookingService_CancelTransfer_Handler,
+		},
+		{
+			MethodName: "AcceptTransfer",
+			Handler:    _BookingService_AcceptTransfer_Handler,
+		},
+		{
+			MethodName: "GetOwnershipHistory",
+			Handler:    _BookingService_GetOwnershipHistory_Handler,
+		},
+		{
+			MethodName: "CancelSeats",
+			Handler:    _BookingService_CancelSeats_Handler,
+		},
+		{
+			MethodName: "ExchangeSeats",
+			Handler:    _BookingService_ExchangeSeats_Handler,
+		},
+		{
+			MethodName: "ListSeatChanges",
+			Handler:    _BookingService_ListSeatChanges_Handler,
+		},
+		{
+			MethodName: "GetBookingStatusHistory",
+			Handler:    _BookingService_GetBookingStatusHistory_Handler,
+		},
+		{
+			MethodName: "CheckInBooking",
+			Handler:    _BookingService_CheckInBooking_Handler,
+		},
+		{
+			MethodName: "CreateOrder",
+			Handler:    _BookingService_CreateOrder_Handler,
+		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _BookingService_GetOrder_Handler,
+		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _BookingService_CancelOrder_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -22,12 +22,35 @@ const (
 )
 
 type Event struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// DRAFT, PUBLISHED, ON_SALE, SOLD_OUT or CANCELLED
+	Status string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	// RFC 3339, empty when not scheduled
+	OnSaleAt    string `protobuf:"bytes,5,opt,name=on_sale_at,json=onSaleAt,proto3" json:"on_sale_at,omitempty"`
+	OffSaleAt   string `protobuf:"bytes,6,opt,name=off_sale_at,json=offSaleAt,proto3" json:"off_sale_at,omitempty"`
+	OrganizerId int64  `protobuf:"varint,7,opt,name=organizer_id,json=organizerId,proto3" json:"organizer_id,omitempty"`
+	// slugs of the categories the event is filed under
+	Categories []string      `protobuf:"bytes,8,rep,name=categories,proto3" json:"categories,omitempty"`
+	Tags       []string      `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	Poster     *EventImage   `protobuf:"bytes,10,opt,name=poster,proto3" json:"poster,omitempty"`
+	Gallery    []*EventImage `protobuf:"bytes,11,rep,name=gallery,proto3" json:"gallery,omitempty"`
+	// RFC 3339, empty when the date is not announced yet
+	StartsAt string `protobuf:"bytes,12,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	// key of the event in the organizer's own system, set by imports
+	ExternalRef string `protobuf:"bytes,13,opt,name=external_ref,json=externalRef,proto3" json:"external_ref,omitempty"`
+	// last change of the event or its categories, tags and images
+	UpdatedAt string `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// locale of title and description
+	Locale string `protobuf:"bytes,15,opt,name=locale,proto3" json:"locale,omitempty"`
+	// minutes a booking holds its tickets before it expires unpaid, 0 for the default
+	HoldMinutes int32 `protobuf:"varint,16,opt,name=hold_minutes,json=holdMinutes,proto3" json:"hold_minutes,omitempty"`
+	// how many times a buyer may extend the hold, unset for the default
+	MaxHoldExtensions *int32 `protobuf:"varint,17,opt,name=max_hold_extensions,json=maxHoldExtensions,proto3,oneof" json:"max_hold_extensions,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *Event) Reset() {
@@ -81,17 +104,262 @@ func (x *Event) GetDescription() string {
 	return ""
 }
 
+func (x *Event) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Event) GetOnSaleAt() string {
+	if x != nil {
+		return x.OnSaleAt
+	}
+	return ""
+}
+
+func (x *Event) GetOffSaleAt() string {
+	if x != nil {
+		return x.OffSaleAt
+	}
+	return ""
+}
+
+func (x *Event) GetOrganizerId() int64 {
+	if x != nil {
+		return x.OrganizerId
+	}
+	return 0
+}
+
+func (x *Event) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *Event) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Event) GetPoster() *EventImage {
+	if x != nil {
+		return x.Poster
+	}
+	return nil
+}
+
+func (x *Event) GetGallery() []*EventImage {
+	if x != nil {
+		return x.Gallery
+	}
+	return nil
+}
+
+func (x *Event) GetStartsAt() string {
+	if x != nil {
+		return x.StartsAt
+	}
+	return ""
+}
+
+func (x *Event) GetExternalRef() string {
+	if x != nil {
+		return x.ExternalRef
+	}
+	return ""
+}
+
+func (x *Event) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Event) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *Event) GetHoldMinutes() int32 {
+	if x != nil {
+		return x.HoldMinutes
+	}
+	return 0
+}
+
+func (x *Event) GetMaxHoldExtensions() int32 {
+	if x != nil && x.MaxHoldExtensions != nil {
+		return *x.MaxHoldExtensions
+	}
+	return 0
+}
+
+type ImageRendition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// thumbnail, medium or large
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Url           string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Width         int32  `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageRendition) Reset() {
+	*x = ImageRendition{}
+	mi := &file_event_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageRendition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageRendition) ProtoMessage() {}
+
+func (x *ImageRendition) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageRendition.ProtoReflect.Descriptor instead.
+func (*ImageRendition) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ImageRendition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ImageRendition) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ImageRendition) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ImageRendition) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type EventImage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// POSTER or GALLERY
+	Kind          string            `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Position      int32             `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	Renditions    []*ImageRendition `protobuf:"bytes,4,rep,name=renditions,proto3" json:"renditions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventImage) Reset() {
+	*x = EventImage{}
+	mi := &file_event_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventImage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventImage) ProtoMessage() {}
+
+func (x *EventImage) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventImage.ProtoReflect.Descriptor instead.
+func (*EventImage) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EventImage) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *EventImage) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *EventImage) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *EventImage) GetRenditions() []*ImageRendition {
+	if x != nil {
+		return x.Renditions
+	}
+	return nil
+}
+
+// page_token switches the listing to keyset pagination; page_number is kept
+// for backwards compatibility and is ignored when a token is present.
+// category is a slug and also matches the events of its subcategories.
 type ListEventsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PageNumber    int32                  `protobuf:"varint,1,opt,name=page_number,json=pageNumber,proto3" json:"page_number,omitempty"`
-	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	PageNumber int32                  `protobuf:"varint,1,opt,name=page_number,json=pageNumber,proto3" json:"page_number,omitempty"`
+	PageSize   int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken  string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Category   string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Tag        string                 `protobuf:"bytes,5,opt,name=tag,proto3" json:"tag,omitempty"`
+	// preferred locales, best first, e.g. "de-AT,de,en"; the default locale
+	// is used for events without a matching translation
+	Locale        string `protobuf:"bytes,6,opt,name=locale,proto3" json:"locale,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListEventsRequest) Reset() {
 	*x = ListEventsRequest{}
-	mi := &file_event_proto_msgTypes[1]
+	mi := &file_event_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -103,7 +371,7 @@ func (x *ListEventsRequest) String() string {
 func (*ListEventsRequest) ProtoMessage() {}
 
 func (x *ListEventsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_event_proto_msgTypes[1]
+	mi := &file_event_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -116,7 +384,7 @@ func (x *ListEventsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListEventsRequest.ProtoReflect.Descriptor instead.
 func (*ListEventsRequest) Descriptor() ([]byte, []int) {
-	return file_event_proto_rawDescGZIP(), []int{1}
+	return file_event_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ListEventsRequest) GetPageNumber() int32 {
@@ -133,17 +401,50 @@ func (x *ListEventsRequest) GetPageSize() int32 {
 	return 0
 }
 
+func (x *ListEventsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+// total_count is exact in page-number mode; with page_token it is the
+// planner's estimate, which can be off while table statistics are stale.
 type ListEventsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Events        []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
-	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	TotalCount    *int64                 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3,oneof" json:"total_count,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// latest change of any event matching the filter, for HTTP Last-Modified
+	LastModified  string `protobuf:"bytes,4,opt,name=last_modified,json=lastModified,proto3" json:"last_modified,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListEventsResponse) Reset() {
 	*x = ListEventsResponse{}
-	mi := &file_event_proto_msgTypes[2]
+	mi := &file_event_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -155,7 +456,7 @@ func (x *ListEventsResponse) String() string {
 func (*ListEventsResponse) ProtoMessage() {}
 
 func (x *ListEventsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_event_proto_msgTypes[2]
+	mi := &file_event_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -168,7 +469,7 @@ func (x *ListEventsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListEventsResponse.ProtoReflect.Descriptor instead.
 func (*ListEventsResponse) Descriptor() ([]byte, []int) {
-	return file_event_proto_rawDescGZIP(), []int{2}
+	return file_event_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ListEventsResponse) GetEvents() []*Event {
@@ -179,22 +480,38 @@ func (x *ListEventsResponse) GetEvents() []*Event {
 }
 
 func (x *ListEventsResponse) GetTotalCount() int64 {
-	if x != nil {
-		return x.TotalCount
+	if x != nil && x.TotalCount != nil {
+		return *x.TotalCount
 	}
 	return 0
 }
 
+func (x *ListEventsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListEventsResponse) GetLastModified() string {
+	if x != nil {
+		return x.LastModified
+	}
+	return ""
+}
+
 type GetEventRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	EventId int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// same as in ListEventsRequest
+	Locale        string `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetEventRequest) Reset() {
 	*x = GetEventRequest{}
-	mi := &file_event_proto_msgTypes[3]
+	mi := &file_event_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -206,7 +523,7 @@ func (x *GetEventRequest) String() string {
 func (*GetEventRequest) ProtoMessage() {}
 
 func (x *GetEventRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_event_proto_msgTypes[3]
+	mi := &file_event_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -219,7 +536,7 @@ func (x *GetEventRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetEventRequest.ProtoReflect.Descriptor instead.
 func (*GetEventRequest) Descriptor() ([]byte, []int) {
-	return file_event_proto_rawDescGZIP(), []int{3}
+	return file_event_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetEventRequest) GetEventId() int64 {
@@ -229,29 +546,2919 @@ func (x *GetEventRequest) GetEventId() int64 {
 	return 0
 }
 
-var File_event_proto protoreflect.FileDescriptor
+func (x *GetEventRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
 
-const file_event_proto_rawDesc = "" +
-	"\n" +
-	"\vevent.proto\x12\x05event\"O\n" +
-	"\x05Event\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\"Q\n" +
-	"\x11ListEventsRequest\x12\x1f\n" +
-	"\vpage_number\x18\x01 \x01(\x05R\n" +
-	"pageNumber\x12\x1b\n" +
-	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"[\n" +
-	"\x12ListEventsResponse\x12$\n" +
-	"\x06events\x18\x01 \x03(\v2\f.event.EventR\x06events\x12\x1f\n" +
-	"\vtotal_count\x18\x02 \x01(\x03R\n" +
-	"totalCount\",\n" +
-	"\x0fGetEventRequest\x12\x19\n" +
-	"\bevent_id\x18\x01 \x01(\x03R\aeventId2\x83\x01\n" +
+type UpdateEventStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateEventStatusRequest) Reset() {
+	*x = UpdateEventStatusRequest{}
+	mi := &file_event_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEventStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEventStatusRequest) ProtoMessage() {}
+
+func (x *UpdateEventStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEventStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEventStatusRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateEventStatusRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *UpdateEventStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// Empty timestamps clear the corresponding bound of the sales window, and a
+// zero hold time or an unset number of extensions restores the default.
+type ScheduleSalesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	OnSaleAt      string                 `protobuf:"bytes,2,opt,name=on_sale_at,json=onSaleAt,proto3" json:"on_sale_at,omitempty"`
+	OffSaleAt     string                 `protobuf:"bytes,3,opt,name=off_sale_at,json=offSaleAt,proto3" json:"off_sale_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleSalesRequest) Reset() {
+	*x = ScheduleSalesRequest{}
+	mi := &file_event_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleSalesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleSalesRequest) ProtoMessage() {}
+
+func (x *ScheduleSalesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleSalesRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleSalesRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ScheduleSalesRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *ScheduleSalesRequest) GetOnSaleAt() string {
+	if x != nil {
+		return x.OnSaleAt
+	}
+	return ""
+}
+
+func (x *ScheduleSalesRequest) GetOffSaleAt() string {
+	if x != nil {
+		return x.OffSaleAt
+	}
+	return ""
+}
+
+// How the bookings of an event hold their tickets; zero hold minutes and an
+// unset extension limit fall back to booking-service's defaults.
+type SetHoldPolicyRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	EventId int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// minutes a booking holds its tickets before it expires unpaid, up to a day
+	HoldMinutes int32 `protobuf:"varint,2,opt,name=hold_minutes,json=holdMinutes,proto3" json:"hold_minutes,omitempty"`
+	// how many times a buyer may extend the hold, up to 10
+	MaxHoldExtensions *int32 `protobuf:"varint,3,opt,name=max_hold_extensions,json=maxHoldExtensions,proto3,oneof" json:"max_hold_extensions,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SetHoldPolicyRequest) Reset() {
+	*x = SetHoldPolicyRequest{}
+	mi := &file_event_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetHoldPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetHoldPolicyRequest) ProtoMessage() {}
+
+func (x *SetHoldPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetHoldPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetHoldPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SetHoldPolicyRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *SetHoldPolicyRequest) GetHoldMinutes() int32 {
+	if x != nil {
+		return x.HoldMinutes
+	}
+	return 0
+}
+
+func (x *SetHoldPolicyRequest) GetMaxHoldExtensions() int32 {
+	if x != nil && x.MaxHoldExtensions != nil {
+		return *x.MaxHoldExtensions
+	}
+	return 0
+}
+
+// Cancelling an event makes booking-service refund and release every booking
+// of it; the reason is passed on to the ticket holders.
+type CancelEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelEventRequest) Reset() {
+	*x = CancelEventRequest{}
+	mi := &file_event_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelEventRequest) ProtoMessage() {}
+
+func (x *CancelEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelEventRequest.ProtoReflect.Descriptor instead.
+func (*CancelEventRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CancelEventRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *CancelEventRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// amount is in minor units of currency (ISO 4217), e.g. 150000 RUB is 1500.00.
+type PriceTier struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	EventId       int64                  `protobuf:"varint,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceTier) Reset() {
+	*x = PriceTier{}
+	mi := &file_event_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceTier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceTier) ProtoMessage() {}
+
+func (x *PriceTier) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceTier.ProtoReflect.Descriptor instead.
+func (*PriceTier) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PriceTier) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PriceTier) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *PriceTier) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PriceTier) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PriceTier) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// Creates the tier or updates the one with the same name, then assigns it to
+// every seat of the listed sectors and to the listed seats. All tiers of an
+// event must share one currency.
+type SetPriceTierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Sectors       []string               `protobuf:"bytes,5,rep,name=sectors,proto3" json:"sectors,omitempty"`
+	SeatIds       []int64                `protobuf:"varint,6,rep,packed,name=seat_ids,json=seatIds,proto3" json:"seat_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPriceTierRequest) Reset() {
+	*x = SetPriceTierRequest{}
+	mi := &file_event_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPriceTierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPriceTierRequest) ProtoMessage() {}
+
+func (x *SetPriceTierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPriceTierRequest.ProtoReflect.Descriptor instead.
+func (*SetPriceTierRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetPriceTierRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *SetPriceTierRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetPriceTierRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *SetPriceTierRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *SetPriceTierRequest) GetSectors() []string {
+	if x != nil {
+		return x.Sectors
+	}
+	return nil
+}
+
+func (x *SetPriceTierRequest) GetSeatIds() []int64 {
+	if x != nil {
+		return x.SeatIds
+	}
+	return nil
+}
+
+type ListPriceTiersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPriceTiersRequest) Reset() {
+	*x = ListPriceTiersRequest{}
+	mi := &file_event_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPriceTiersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPriceTiersRequest) ProtoMessage() {}
+
+func (x *ListPriceTiersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPriceTiersRequest.ProtoReflect.Descriptor instead.
+func (*ListPriceTiersRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListPriceTiersRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+type ListPriceTiersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tiers         []*PriceTier           `protobuf:"bytes,1,rep,name=tiers,proto3" json:"tiers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPriceTiersResponse) Reset() {
+	*x = ListPriceTiersResponse{}
+	mi := &file_event_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPriceTiersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPriceTiersResponse) ProtoMessage() {}
+
+func (x *ListPriceTiersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPriceTiersResponse.ProtoReflect.Descriptor instead.
+func (*ListPriceTiersResponse) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListPriceTiersResponse) GetTiers() []*PriceTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+// What a customer gets back when cancelling a confirmed booking: everything
+// at least full_refund_hours before the start, partial_refund_percent of it at
+// least partial_refund_hours before, nothing later or when non_refundable.
+// Events without a policy refund in full until they start.
+type RefundPolicy struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	EventId              int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	NonRefundable        bool                   `protobuf:"varint,2,opt,name=non_refundable,json=nonRefundable,proto3" json:"non_refundable,omitempty"`
+	FullRefundHours      int32                  `protobuf:"varint,3,opt,name=full_refund_hours,json=fullRefundHours,proto3" json:"full_refund_hours,omitempty"`
+	PartialRefundHours   int32                  `protobuf:"varint,4,opt,name=partial_refund_hours,json=partialRefundHours,proto3" json:"partial_refund_hours,omitempty"`
+	PartialRefundPercent int32                  `protobuf:"varint,5,opt,name=partial_refund_percent,json=partialRefundPercent,proto3" json:"partial_refund_percent,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RefundPolicy) Reset() {
+	*x = RefundPolicy{}
+	mi := &file_event_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundPolicy) ProtoMessage() {}
+
+func (x *RefundPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundPolicy.ProtoReflect.Descriptor instead.
+func (*RefundPolicy) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RefundPolicy) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *RefundPolicy) GetNonRefundable() bool {
+	if x != nil {
+		return x.NonRefundable
+	}
+	return false
+}
+
+func (x *RefundPolicy) GetFullRefundHours() int32 {
+	if x != nil {
+		return x.FullRefundHours
+	}
+	return 0
+}
+
+func (x *RefundPolicy) GetPartialRefundHours() int32 {
+	if x != nil {
+		return x.PartialRefundHours
+	}
+	return 0
+}
+
+func (x *RefundPolicy) GetPartialRefundPercent() int32 {
+	if x != nil {
+		return x.PartialRefundPercent
+	}
+	return 0
+}
+
+type GetRefundPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRefundPolicyRequest) Reset() {
+	*x = GetRefundPolicyRequest{}
+	mi := &file_event_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRefundPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRefundPolicyRequest) ProtoMessage() {}
+
+func (x *GetRefundPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRefundPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetRefundPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetRefundPolicyRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+// What one customer may buy of the event; 0 means no limit. Tickets count
+// seats and general-admission tickets of paid and unexpired unpaid bookings.
+type PurchaseLimits struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	EventId              int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	MaxTicketsPerUser    int32                  `protobuf:"varint,2,opt,name=max_tickets_per_user,json=maxTicketsPerUser,proto3" json:"max_tickets_per_user,omitempty"`
+	MaxPendingBookings   int32                  `protobuf:"varint,3,opt,name=max_pending_bookings,json=maxPendingBookings,proto3" json:"max_pending_bookings,omitempty"`
+	MaxTicketsPerBooking int32                  `protobuf:"varint,4,opt,name=max_tickets_per_booking,json=maxTicketsPerBooking,proto3" json:"max_tickets_per_booking,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *PurchaseLimits) Reset() {
+	*x = PurchaseLimits{}
+	mi := &file_event_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurchaseLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurchaseLimits) ProtoMessage() {}
+
+func (x *PurchaseLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurchaseLimits.ProtoReflect.Descriptor instead.
+func (*PurchaseLimits) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PurchaseLimits) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *PurchaseLimits) GetMaxTicketsPerUser() int32 {
+	if x != nil {
+		return x.MaxTicketsPerUser
+	}
+	return 0
+}
+
+func (x *PurchaseLimits) GetMaxPendingBookings() int32 {
+	if x != nil {
+		return x.MaxPendingBookings
+	}
+	return 0
+}
+
+func (x *PurchaseLimits) GetMaxTicketsPerBooking() int32 {
+	if x != nil {
+		return x.MaxTicketsPerBooking
+	}
+	return 0
+}
+
+type GetPurchaseLimitsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPurchaseLimitsRequest) Reset() {
+	*x = GetPurchaseLimitsRequest{}
+	mi := &file_event_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPurchaseLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPurchaseLimitsRequest) ProtoMessage() {}
+
+func (x *GetPurchaseLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPurchaseLimitsRequest.ProtoReflect.Descriptor instead.
+func (*GetPurchaseLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetPurchaseLimitsRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+// Whether paid tickets of the event may be handed over to someone else, until
+// cutoff_hours before the start and at most max_transfers times each; 0 means
+// no limit. Events without a policy allow transfers until they start.
+type TransferPolicy struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	EventId          int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	TransfersAllowed bool                   `protobuf:"varint,2,opt,name=transfers_allowed,json=transfersAllowed,proto3" json:"transfers_allowed,omitempty"`
+	CutoffHours      int32                  `protobuf:"varint,3,opt,name=cutoff_hours,json=cutoffHours,proto3" json:"cutoff_hours,omitempty"`
+	MaxTransfers     int32                  `protobuf:"varint,4,opt,name=max_transfers,json=maxTransfers,proto3" json:"max_transfers,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TransferPolicy) Reset() {
+	*x = TransferPolicy{}
+	mi := &file_event_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferPolicy) ProtoMessage() {}
+
+func (x *TransferPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferPolicy.ProtoReflect.Descriptor instead.
+func (*TransferPolicy) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TransferPolicy) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *TransferPolicy) GetTransfersAllowed() bool {
+	if x != nil {
+		return x.TransfersAllowed
+	}
+	return false
+}
+
+func (x *TransferPolicy) GetCutoffHours() int32 {
+	if x != nil {
+		return x.CutoffHours
+	}
+	return 0
+}
+
+func (x *TransferPolicy) GetMaxTransfers() int32 {
+	if x != nil {
+		return x.MaxTransfers
+	}
+	return 0
+}
+
+type GetTransferPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferPolicyRequest) Reset() {
+	*x = GetTransferPolicyRequest{}
+	mi := &file_event_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferPolicyRequest) ProtoMessage() {}
+
+func (x *GetTransferPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetTransferPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetTransferPolicyRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+// General-admission inventory: up to capacity unnumbered tickets. held counts
+// tickets of unpaid bookings, available is capacity - sold - held.
+type InventoryPool struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	EventId       int64                  `protobuf:"varint,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Capacity      int32                  `protobuf:"varint,4,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Sold          int32                  `protobuf:"varint,5,opt,name=sold,proto3" json:"sold,omitempty"`
+	Held          int32                  `protobuf:"varint,6,opt,name=held,proto3" json:"held,omitempty"`
+	Available     int32                  `protobuf:"varint,7,opt,name=available,proto3" json:"available,omitempty"`
+	PriceTierId   int64                  `protobuf:"varint,8,opt,name=price_tier_id,json=priceTierId,proto3" json:"price_tier_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InventoryPool) Reset() {
+	*x = InventoryPool{}
+	mi := &file_event_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryPool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryPool) ProtoMessage() {}
+
+func (x *InventoryPool) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryPool.ProtoReflect.Descriptor instead.
+func (*InventoryPool) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *InventoryPool) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *InventoryPool) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *InventoryPool) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InventoryPool) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *InventoryPool) GetSold() int32 {
+	if x != nil {
+		return x.Sold
+	}
+	return 0
+}
+
+func (x *InventoryPool) GetHeld() int32 {
+	if x != nil {
+		return x.Held
+	}
+	return 0
+}
+
+func (x *InventoryPool) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *InventoryPool) GetPriceTierId() int64 {
+	if x != nil {
+		return x.PriceTierId
+	}
+	return 0
+}
+
+// Creates the pool or updates the one with the same name. Capacity can not be
+// lowered below the tickets already sold or held.
+type SetInventoryPoolRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Capacity      int32                  `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	PriceTierId   int64                  `protobuf:"varint,4,opt,name=price_tier_id,json=priceTierId,proto3" json:"price_tier_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetInventoryPoolRequest) Reset() {
+	*x = SetInventoryPoolRequest{}
+	mi := &file_event_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetInventoryPoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetInventoryPoolRequest) ProtoMessage() {}
+
+func (x *SetInventoryPoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetInventoryPoolRequest.ProtoReflect.Descriptor instead.
+func (*SetInventoryPoolRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SetInventoryPoolRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *SetInventoryPoolRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetInventoryPoolRequest) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *SetInventoryPoolRequest) GetPriceTierId() int64 {
+	if x != nil {
+		return x.PriceTierId
+	}
+	return 0
+}
+
+type ListInventoryPoolsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInventoryPoolsRequest) Reset() {
+	*x = ListInventoryPoolsRequest{}
+	mi := &file_event_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInventoryPoolsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInventoryPoolsRequest) ProtoMessage() {}
+
+func (x *ListInventoryPoolsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInventoryPoolsRequest.ProtoReflect.Descriptor instead.
+func (*ListInventoryPoolsRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListInventoryPoolsRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+type ListInventoryPoolsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pools         []*InventoryPool       `protobuf:"bytes,1,rep,name=pools,proto3" json:"pools,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInventoryPoolsResponse) Reset() {
+	*x = ListInventoryPoolsResponse{}
+	mi := &file_event_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInventoryPoolsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInventoryPoolsResponse) ProtoMessage() {}
+
+func (x *ListInventoryPoolsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInventoryPoolsResponse.ProtoReflect.Descriptor instead.
+func (*ListInventoryPoolsResponse) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListInventoryPoolsResponse) GetPools() []*InventoryPool {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
+}
+
+// How good the seats of a sector are, from 0 to 100; best-available
+// selection prefers higher scores. Sectors without a score count as 50.
+type SectorScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Sector        string                 `protobuf:"bytes,2,opt,name=sector,proto3" json:"sector,omitempty"`
+	Score         int32                  `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SectorScore) Reset() {
+	*x = SectorScore{}
+	mi := &file_event_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SectorScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SectorScore) ProtoMessage() {}
+
+func (x *SectorScore) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SectorScore.ProtoReflect.Descriptor instead.
+func (*SectorScore) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SectorScore) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *SectorScore) GetSector() string {
+	if x != nil {
+		return x.Sector
+	}
+	return ""
+}
+
+func (x *SectorScore) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type SetSectorScoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Sector        string                 `protobuf:"bytes,2,opt,name=sector,proto3" json:"sector,omitempty"`
+	Score         int32                  `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSectorScoreRequest) Reset() {
+	*x = SetSectorScoreRequest{}
+	mi := &file_event_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSectorScoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSectorScoreRequest) ProtoMessage() {}
+
+func (x *SetSectorScoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSectorScoreRequest.ProtoReflect.Descriptor instead.
+func (*SetSectorScoreRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SetSectorScoreRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *SetSectorScoreRequest) GetSector() string {
+	if x != nil {
+		return x.Sector
+	}
+	return ""
+}
+
+func (x *SetSectorScoreRequest) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type ListSectorScoresRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSectorScoresRequest) Reset() {
+	*x = ListSectorScoresRequest{}
+	mi := &file_event_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSectorScoresRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSectorScoresRequest) ProtoMessage() {}
+
+func (x *ListSectorScoresRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSectorScoresRequest.ProtoReflect.Descriptor instead.
+func (*ListSectorScoresRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListSectorScoresRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+type ListSectorScoresResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scores        []*SectorScore         `protobuf:"bytes,1,rep,name=scores,proto3" json:"scores,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSectorScoresResponse) Reset() {
+	*x = ListSectorScoresResponse{}
+	mi := &file_event_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSectorScoresResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSectorScoresResponse) ProtoMessage() {}
+
+func (x *ListSectorScoresResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSectorScoresResponse.ProtoReflect.Descriptor instead.
+func (*ListSectorScoresResponse) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListSectorScoresResponse) GetScores() []*SectorScore {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+type Category struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Slug  string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// empty for top-level categories
+	ParentSlug    string      `protobuf:"bytes,3,opt,name=parent_slug,json=parentSlug,proto3" json:"parent_slug,omitempty"`
+	Position      int32       `protobuf:"varint,4,opt,name=position,proto3" json:"position,omitempty"`
+	Children      []*Category `protobuf:"bytes,5,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_event_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Category) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetParentSlug() string {
+	if x != nil {
+		return x.ParentSlug
+	}
+	return ""
+}
+
+func (x *Category) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *Category) GetChildren() []*Category {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type UpsertCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ParentSlug    string                 `protobuf:"bytes,3,opt,name=parent_slug,json=parentSlug,proto3" json:"parent_slug,omitempty"`
+	Position      int32                  `protobuf:"varint,4,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertCategoryRequest) Reset() {
+	*x = UpsertCategoryRequest{}
+	mi := &file_event_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertCategoryRequest) ProtoMessage() {}
+
+func (x *UpsertCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertCategoryRequest.ProtoReflect.Descriptor instead.
+func (*UpsertCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UpsertCategoryRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *UpsertCategoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpsertCategoryRequest) GetParentSlug() string {
+	if x != nil {
+		return x.ParentSlug
+	}
+	return ""
+}
+
+func (x *UpsertCategoryRequest) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type ListCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesRequest) Reset() {
+	*x = ListCategoriesRequest{}
+	mi := &file_event_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesRequest) ProtoMessage() {}
+
+func (x *ListCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{30}
+}
+
+// Top-level categories with their subcategories nested, ordered by position.
+type ListCategoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesResponse) Reset() {
+	*x = ListCategoriesResponse{}
+	mi := &file_event_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesResponse) ProtoMessage() {}
+
+func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListCategoriesResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+// Replaces the categories of the event.
+type SetEventCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Categories    []string               `protobuf:"bytes,2,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetEventCategoriesRequest) Reset() {
+	*x = SetEventCategoriesRequest{}
+	mi := &file_event_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetEventCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetEventCategoriesRequest) ProtoMessage() {}
+
+func (x *SetEventCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetEventCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*SetEventCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SetEventCategoriesRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *SetEventCategoriesRequest) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+// Replaces the tags of the event; tags are free-form and stored lowercased.
+type SetEventTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetEventTagsRequest) Reset() {
+	*x = SetEventTagsRequest{}
+	mi := &file_event_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetEventTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetEventTagsRequest) ProtoMessage() {}
+
+func (x *SetEventTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetEventTagsRequest.ProtoReflect.Descriptor instead.
+func (*SetEventTagsRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SetEventTagsRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *SetEventTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// An editorial list of events such as "This weekend", events keep the given order.
+type Collection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Events        []*Event               `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Collection) Reset() {
+	*x = Collection{}
+	mi := &file_event_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Collection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Collection) ProtoMessage() {}
+
+func (x *Collection) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Collection.ProtoReflect.Descriptor instead.
+func (*Collection) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Collection) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Collection) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Collection) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Collection) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type UpsertCollectionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	EventIds      []int64                `protobuf:"varint,4,rep,packed,name=event_ids,json=eventIds,proto3" json:"event_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertCollectionRequest) Reset() {
+	*x = UpsertCollectionRequest{}
+	mi := &file_event_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertCollectionRequest) ProtoMessage() {}
+
+func (x *UpsertCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertCollectionRequest.ProtoReflect.Descriptor instead.
+func (*UpsertCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UpsertCollectionRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *UpsertCollectionRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpsertCollectionRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpsertCollectionRequest) GetEventIds() []int64 {
+	if x != nil {
+		return x.EventIds
+	}
+	return nil
+}
+
+type GetCollectionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCollectionRequest) Reset() {
+	*x = GetCollectionRequest{}
+	mi := &file_event_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCollectionRequest) ProtoMessage() {}
+
+func (x *GetCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCollectionRequest.ProtoReflect.Descriptor instead.
+func (*GetCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetCollectionRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+type UploadEventImageRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	EventId int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// POSTER replaces the current poster, GALLERY appends to the gallery
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// JPEG, PNG or GIF
+	Content       []byte `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadEventImageRequest) Reset() {
+	*x = UploadEventImageRequest{}
+	mi := &file_event_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadEventImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadEventImageRequest) ProtoMessage() {}
+
+func (x *UploadEventImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadEventImageRequest.ProtoReflect.Descriptor instead.
+func (*UploadEventImageRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UploadEventImageRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *UploadEventImageRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *UploadEventImageRequest) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type DeleteEventImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	ImageId       int64                  `protobuf:"varint,2,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEventImageRequest) Reset() {
+	*x = DeleteEventImageRequest{}
+	mi := &file_event_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEventImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEventImageRequest) ProtoMessage() {}
+
+func (x *DeleteEventImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEventImageRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEventImageRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DeleteEventImageRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *DeleteEventImageRequest) GetImageId() int64 {
+	if x != nil {
+		return x.ImageId
+	}
+	return 0
+}
+
+type ImportEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// csv or json
+	Format  string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	Content []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	// validate and report what would change without saving anything
+	DryRun bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// owner of newly created events; 0 when an admin imports
+	OrganizerId   int64 `protobuf:"varint,4,opt,name=organizer_id,json=organizerId,proto3" json:"organizer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportEventsRequest) Reset() {
+	*x = ImportEventsRequest{}
+	mi := &file_event_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportEventsRequest) ProtoMessage() {}
+
+func (x *ImportEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportEventsRequest.ProtoReflect.Descriptor instead.
+func (*ImportEventsRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ImportEventsRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ImportEventsRequest) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *ImportEventsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportEventsRequest) GetOrganizerId() int64 {
+	if x != nil {
+		return x.OrganizerId
+	}
+	return 0
+}
+
+type ImportError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 1-based line of the file the error refers to
+	Line          int32  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportError) Reset() {
+	*x = ImportError{}
+	mi := &file_event_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportError) ProtoMessage() {}
+
+func (x *ImportError) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportError.ProtoReflect.Descriptor instead.
+func (*ImportError) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ImportError) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ImportEventsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// false for dry runs and when the file has errors
+	Applied       bool           `protobuf:"varint,1,opt,name=applied,proto3" json:"applied,omitempty"`
+	EventsCreated int32          `protobuf:"varint,2,opt,name=events_created,json=eventsCreated,proto3" json:"events_created,omitempty"`
+	EventsUpdated int32          `protobuf:"varint,3,opt,name=events_updated,json=eventsUpdated,proto3" json:"events_updated,omitempty"`
+	SeatsCreated  int32          `protobuf:"varint,4,opt,name=seats_created,json=seatsCreated,proto3" json:"seats_created,omitempty"`
+	SeatsUpdated  int32          `protobuf:"varint,5,opt,name=seats_updated,json=seatsUpdated,proto3" json:"seats_updated,omitempty"`
+	Errors        []*ImportError `protobuf:"bytes,6,rep,name=errors,proto3" json:"errors,omitempty"`
+	// booked or held seats keep their row, sector and price tier; each one
+	// is also listed in warnings
+	SeatsSkipped int32 `protobuf:"varint,7,opt,name=seats_skipped,json=seatsSkipped,proto3" json:"seats_skipped,omitempty"`
+	// lines applied only in part; unlike errors they do not stop the import
+	Warnings      []*ImportError `protobuf:"bytes,8,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportEventsResponse) Reset() {
+	*x = ImportEventsResponse{}
+	mi := &file_event_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportEventsResponse) ProtoMessage() {}
+
+func (x *ImportEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportEventsResponse.ProtoReflect.Descriptor instead.
+func (*ImportEventsResponse) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ImportEventsResponse) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+func (x *ImportEventsResponse) GetEventsCreated() int32 {
+	if x != nil {
+		return x.EventsCreated
+	}
+	return 0
+}
+
+func (x *ImportEventsResponse) GetEventsUpdated() int32 {
+	if x != nil {
+		return x.EventsUpdated
+	}
+	return 0
+}
+
+func (x *ImportEventsResponse) GetSeatsCreated() int32 {
+	if x != nil {
+		return x.SeatsCreated
+	}
+	return 0
+}
+
+func (x *ImportEventsResponse) GetSeatsUpdated() int32 {
+	if x != nil {
+		return x.SeatsUpdated
+	}
+	return 0
+}
+
+func (x *ImportEventsResponse) GetErrors() []*ImportError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ImportEventsResponse) GetSeatsSkipped() int32 {
+	if x != nil {
+		return x.SeatsSkipped
+	}
+	return 0
+}
+
+func (x *ImportEventsResponse) GetWarnings() []*ImportError {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+// upcoming published events with a start date; filters are optional
+type GetEventsCalendarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrganizerId   int64                  `protobuf:"varint,1,opt,name=organizer_id,json=organizerId,proto3" json:"organizer_id,omitempty"`
+	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventsCalendarRequest) Reset() {
+	*x = GetEventsCalendarRequest{}
+	mi := &file_event_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventsCalendarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventsCalendarRequest) ProtoMessage() {}
+
+func (x *GetEventsCalendarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventsCalendarRequest.ProtoReflect.Descriptor instead.
+func (*GetEventsCalendarRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetEventsCalendarRequest) GetOrganizerId() int64 {
+	if x != nil {
+		return x.OrganizerId
+	}
+	return 0
+}
+
+func (x *GetEventsCalendarRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type CalendarFeed struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// RFC 5545 iCalendar object
+	Content       string `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalendarFeed) Reset() {
+	*x = CalendarFeed{}
+	mi := &file_event_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalendarFeed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalendarFeed) ProtoMessage() {}
+
+func (x *CalendarFeed) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalendarFeed.ProtoReflect.Descriptor instead.
+func (*CalendarFeed) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CalendarFeed) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type SetEventTranslationRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	EventId int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Locale  string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	Title   string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	// falls back to the default description when empty
+	Description   string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetEventTranslationRequest) Reset() {
+	*x = SetEventTranslationRequest{}
+	mi := &file_event_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetEventTranslationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetEventTranslationRequest) ProtoMessage() {}
+
+func (x *SetEventTranslationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetEventTranslationRequest.ProtoReflect.Descriptor instead.
+func (*SetEventTranslationRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SetEventTranslationRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *SetEventTranslationRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *SetEventTranslationRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SetEventTranslationRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type DeleteEventTranslationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEventTranslationRequest) Reset() {
+	*x = DeleteEventTranslationRequest{}
+	mi := &file_event_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEventTranslationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEventTranslationRequest) ProtoMessage() {}
+
+func (x *DeleteEventTranslationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEventTranslationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEventTranslationRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *DeleteEventTranslationRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *DeleteEventTranslationRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type GetTranslationStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 0 reports on the events of all organizers
+	OrganizerId int64 `protobuf:"varint,1,opt,name=organizer_id,json=organizerId,proto3" json:"organizer_id,omitempty"`
+	// locales to report on; by default every locale any of the events is translated to
+	Locales       []string `protobuf:"bytes,2,rep,name=locales,proto3" json:"locales,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTranslationStatusRequest) Reset() {
+	*x = GetTranslationStatusRequest{}
+	mi := &file_event_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTranslationStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTranslationStatusRequest) ProtoMessage() {}
+
+func (x *GetTranslationStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTranslationStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetTranslationStatusRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetTranslationStatusRequest) GetOrganizerId() int64 {
+	if x != nil {
+		return x.OrganizerId
+	}
+	return 0
+}
+
+func (x *GetTranslationStatusRequest) GetLocales() []string {
+	if x != nil {
+		return x.Locales
+	}
+	return nil
+}
+
+// status is COMPLETE, PARTIAL (the default text has a description the
+// translation lacks) or MISSING
+type LocaleTranslationStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Locale        string                 `protobuf:"bytes,1,opt,name=locale,proto3" json:"locale,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LocaleTranslationStatus) Reset() {
+	*x = LocaleTranslationStatus{}
+	mi := &file_event_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LocaleTranslationStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocaleTranslationStatus) ProtoMessage() {}
+
+func (x *LocaleTranslationStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocaleTranslationStatus.ProtoReflect.Descriptor instead.
+func (*LocaleTranslationStatus) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *LocaleTranslationStatus) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *LocaleTranslationStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type EventTranslationStatus struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	EventId int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// in the default locale
+	Title         string                     `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Locales       []*LocaleTranslationStatus `protobuf:"bytes,3,rep,name=locales,proto3" json:"locales,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventTranslationStatus) Reset() {
+	*x = EventTranslationStatus{}
+	mi := &file_event_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventTranslationStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventTranslationStatus) ProtoMessage() {}
+
+func (x *EventTranslationStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventTranslationStatus.ProtoReflect.Descriptor instead.
+func (*EventTranslationStatus) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *EventTranslationStatus) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *EventTranslationStatus) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *EventTranslationStatus) GetLocales() []*LocaleTranslationStatus {
+	if x != nil {
+		return x.Locales
+	}
+	return nil
+}
+
+type TranslationStatusReport struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	DefaultLocale string                    `protobuf:"bytes,1,opt,name=default_locale,json=defaultLocale,proto3" json:"default_locale,omitempty"`
+	Locales       []string                  `protobuf:"bytes,2,rep,name=locales,proto3" json:"locales,omitempty"`
+	Events        []*EventTranslationStatus `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	Complete      int32                     `protobuf:"varint,4,opt,name=complete,proto3" json:"complete,omitempty"`
+	Partial       int32                     `protobuf:"varint,5,opt,name=partial,proto3" json:"partial,omitempty"`
+	Missing       int32                     `protobuf:"varint,6,opt,name=missing,proto3" json:"missing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TranslationStatusReport) Reset() {
+	*x = TranslationStatusReport{}
+	mi := &file_event_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranslationStatusReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranslationStatusReport) ProtoMessage() {}
+
+func (x *TranslationStatusReport) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranslationStatusReport.ProtoReflect.Descriptor instead.
+func (*TranslationStatusReport) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *TranslationStatusReport) GetDefaultLocale() string {
+	if x != nil {
+		return x.DefaultLocale
+	}
+	return ""
+}
+
+func (x *TranslationStatusReport) GetLocales() []string {
+	if x != nil {
+		return x.Locales
+	}
+	return nil
+}
+
+func (x *TranslationStatusReport) GetEvents() []*EventTranslationStatus {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *TranslationStatusReport) GetComplete() int32 {
+	if x != nil {
+		return x.Complete
+	}
+	return 0
+}
+
+func (x *TranslationStatusReport) GetPartial() int32 {
+	if x != nil {
+		return x.Partial
+	}
+	return 0
+}
+
+func (x *TranslationStatusReport) GetMissing() int32 {
+	if x != nil {
+		return x.Missing
+	}
+	return 0
+}
+
+var File_event_proto protoreflect.FileDescriptor
+
+const file_event_proto_rawDesc = "" +
+	"\n" +
+	"\vevent.proto\x12\x05event\"\xbb\x04\n" +
+	"\x05Event\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1c\n" +
+	"\n" +
+	"on_sale_at\x18\x05 \x01(\tR\bonSaleAt\x12\x1e\n" +
+	"\voff_sale_at\x18\x06 \x01(\tR\toffSaleAt\x12!\n" +
+	"\forganizer_id\x18\a \x01(\x03R\vorganizerId\x12\x1e\n" +
+	"\n" +
+	"categories\x18\b \x03(\tR\n" +
+	"categories\x12\x12\n" +
+	"\x04tags\x18\t \x03(\tR\x04tags\x12)\n" +
+	"\x06poster\x18\n" +
+	" \x01(\v2\x11.event.EventImageR\x06poster\x12+\n" +
+	"\agallery\x18\v \x03(\v2\x11.event.EventImageR\agallery\x12\x1b\n" +
+	"\tstarts_at\x18\f \x01(\tR\bstartsAt\x12!\n" +
+	"\fexternal_ref\x18\r \x01(\tR\vexternalRef\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\tR\tupdatedAt\x12\x16\n" +
+	"\x06locale\x18\x0f \x01(\tR\x06locale\x12!\n" +
+	"\fhold_minutes\x18\x10 \x01(\x05R\vholdMinutes\x123\n" +
+	"\x13max_hold_extensions\x18\x11 \x01(\x05H\x00R\x11maxHoldExtensions\x88\x01\x01B\x16\n" +
+	"\x14_max_hold_extensions\"d\n" +
+	"\x0eImageRendition\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x14\n" +
+	"\x05width\x18\x03 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x04 \x01(\x05R\x06height\"\x83\x01\n" +
+	"\n" +
+	"EventImage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1a\n" +
+	"\bposition\x18\x03 \x01(\x05R\bposition\x125\n" +
+	"\n" +
+	"renditions\x18\x04 \x03(\v2\x15.event.ImageRenditionR\n" +
+	"renditions\"\xb6\x01\n" +
+	"\x11ListEventsRequest\x12\x1f\n" +
+	"\vpage_number\x18\x01 \x01(\x05R\n" +
+	"pageNumber\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x10\n" +
+	"\x03tag\x18\x05 \x01(\tR\x03tag\x12\x16\n" +
+	"\x06locale\x18\x06 \x01(\tR\x06locale\"\xbd\x01\n" +
+	"\x12ListEventsResponse\x12$\n" +
+	"\x06events\x18\x01 \x03(\v2\f.event.EventR\x06events\x12$\n" +
+	"\vtotal_count\x18\x02 \x01(\x03H\x00R\n" +
+	"totalCount\x88\x01\x01\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\x12#\n" +
+	"\rlast_modified\x18\x04 \x01(\tR\flastModifiedB\x0e\n" +
+	"\f_total_count\"D\n" +
+	"\x0fGetEventRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\"M\n" +
+	"\x18UpdateEventStatusRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"o\n" +
+	"\x14ScheduleSalesRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x1c\n" +
+	"\n" +
+	"on_sale_at\x18\x02 \x01(\tR\bonSaleAt\x12\x1e\n" +
+	"\voff_sale_at\x18\x03 \x01(\tR\toffSaleAt\"\xa1\x01\n" +
+	"\x14SetHoldPolicyRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12!\n" +
+	"\fhold_minutes\x18\x02 \x01(\x05R\vholdMinutes\x123\n" +
+	"\x13max_hold_extensions\x18\x03 \x01(\x05H\x00R\x11maxHoldExtensions\x88\x01\x01B\x16\n" +
+	"\x14_max_hold_extensions\"G\n" +
+	"\x12CancelEventRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"~\n" +
+	"\tPriceTier\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\x03R\aeventId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\"\xad\x01\n" +
+	"\x13SetPriceTierRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x18\n" +
+	"\asectors\x18\x05 \x03(\tR\asectors\x12\x19\n" +
+	"\bseat_ids\x18\x06 \x03(\x03R\aseatIds\"2\n" +
+	"\x15ListPriceTiersRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\"@\n" +
+	"\x16ListPriceTiersResponse\x12&\n" +
+	"\x05tiers\x18\x01 \x03(\v2\x10.event.PriceTierR\x05tiers\"\xe4\x01\n" +
+	"\fRefundPolicy\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12%\n" +
+	"\x0enon_refundable\x18\x02 \x01(\bR\rnonRefundable\x12*\n" +
+	"\x11full_refund_hours\x18\x03 \x01(\x05R\x0ffullRefundHours\x120\n" +
+	"\x14partial_refund_hours\x18\x04 \x01(\x05R\x12partialRefundHours\x124\n" +
+	"\x16partial_refund_percent\x18\x05 \x01(\x05R\x14partialRefundPercent\"3\n" +
+	"\x16GetRefundPolicyRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\"\xc5\x01\n" +
+	"\x0ePurchaseLimits\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12/\n" +
+	"\x14max_tickets_per_user\x18\x02 \x01(\x05R\x11maxTicketsPerUser\x120\n" +
+	"\x14max_pending_bookings\x18\x03 \x01(\x05R\x12maxPendingBookings\x125\n" +
+	"\x17max_tickets_per_booking\x18\x04 \x01(\x05R\x14maxTicketsPerBooking\"5\n" +
+	"\x18GetPurchaseLimitsRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\"\xa0\x01\n" +
+	"\x0eTransferPolicy\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12+\n" +
+	"\x11transfers_allowed\x18\x02 \x01(\bR\x10transfersAllowed\x12!\n" +
+	"\fcutoff_hours\x18\x03 \x01(\x05R\vcutoffHours\x12#\n" +
+	"\rmax_transfers\x18\x04 \x01(\x05R\fmaxTransfers\"5\n" +
+	"\x18GetTransferPolicyRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\"\xd4\x01\n" +
+	"\rInventoryPool\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\x03R\aeventId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcapacity\x18\x04 \x01(\x05R\bcapacity\x12\x12\n" +
+	"\x04sold\x18\x05 \x01(\x05R\x04sold\x12\x12\n" +
+	"\x04held\x18\x06 \x01(\x05R\x04held\x12\x1c\n" +
+	"\tavailable\x18\a \x01(\x05R\tavailable\x12\"\n" +
+	"\rprice_tier_id\x18\b \x01(\x03R\vpriceTierId\"\x88\x01\n" +
+	"\x17SetInventoryPoolRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcapacity\x18\x03 \x01(\x05R\bcapacity\x12\"\n" +
+	"\rprice_tier_id\x18\x04 \x01(\x03R\vpriceTierId\"6\n" +
+	"\x19ListInventoryPoolsRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\"H\n" +
+	"\x1aListInventoryPoolsResponse\x12*\n" +
+	"\x05pools\x18\x01 \x03(\v2\x14.event.InventoryPoolR\x05pools\"V\n" +
+	"\vSectorScore\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x16\n" +
+	"\x06sector\x18\x02 \x01(\tR\x06sector\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x05R\x05score\"`\n" +
+	"\x15SetSectorScoreRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x16\n" +
+	"\x06sector\x18\x02 \x01(\tR\x06sector\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x05R\x05score\"4\n" +
+	"\x17ListSectorScoresRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\"F\n" +
+	"\x18ListSectorScoresResponse\x12*\n" +
+	"\x06scores\x18\x01 \x03(\v2\x12.event.SectorScoreR\x06scores\"\x9c\x01\n" +
+	"\bCategory\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\vparent_slug\x18\x03 \x01(\tR\n" +
+	"parentSlug\x12\x1a\n" +
+	"\bposition\x18\x04 \x01(\x05R\bposition\x12+\n" +
+	"\bchildren\x18\x05 \x03(\v2\x0f.event.CategoryR\bchildren\"|\n" +
+	"\x15UpsertCategoryRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\vparent_slug\x18\x03 \x01(\tR\n" +
+	"parentSlug\x12\x1a\n" +
+	"\bposition\x18\x04 \x01(\x05R\bposition\"\x17\n" +
+	"\x15ListCategoriesRequest\"I\n" +
+	"\x16ListCategoriesResponse\x12/\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x0f.event.CategoryR\n" +
+	"categories\"V\n" +
+	"\x19SetEventCategoriesRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x02 \x03(\tR\n" +
+	"categories\"D\n" +
+	"\x13SetEventTagsRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"~\n" +
+	"\n" +
+	"Collection\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12$\n" +
+	"\x06events\x18\x04 \x03(\v2\f.event.EventR\x06events\"\x82\x01\n" +
+	"\x17UpsertCollectionRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1b\n" +
+	"\tevent_ids\x18\x04 \x03(\x03R\beventIds\"*\n" +
+	"\x14GetCollectionRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\"b\n" +
+	"\x17UploadEventImageRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\"O\n" +
+	"\x17DeleteEventImageRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x19\n" +
+	"\bimage_id\x18\x02 \x01(\x03R\aimageId\"\x83\x01\n" +
+	"\x13ImportEventsRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\x12!\n" +
+	"\forganizer_id\x18\x04 \x01(\x03R\vorganizerId\";\n" +
+	"\vImportError\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xc9\x02\n" +
+	"\x14ImportEventsResponse\x12\x18\n" +
+	"\aapplied\x18\x01 \x01(\bR\aapplied\x12%\n" +
+	"\x0eevents_created\x18\x02 \x01(\x05R\reventsCreated\x12%\n" +
+	"\x0eevents_updated\x18\x03 \x01(\x05R\reventsUpdated\x12#\n" +
+	"\rseats_created\x18\x04 \x01(\x05R\fseatsCreated\x12#\n" +
+	"\rseats_updated\x18\x05 \x01(\x05R\fseatsUpdated\x12*\n" +
+	"\x06errors\x18\x06 \x03(\v2\x12.event.ImportErrorR\x06errors\x12#\n" +
+	"\rseats_skipped\x18\a \x01(\x05R\fseatsSkipped\x12.\n" +
+	"\bwarnings\x18\b \x03(\v2\x12.event.ImportErrorR\bwarnings\"Y\n" +
+	"\x18GetEventsCalendarRequest\x12!\n" +
+	"\forganizer_id\x18\x01 \x01(\x03R\vorganizerId\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\"(\n" +
+	"\fCalendarFeed\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\"\x87\x01\n" +
+	"\x1aSetEventTranslationRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"R\n" +
+	"\x1dDeleteEventTranslationRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\"Z\n" +
+	"\x1bGetTranslationStatusRequest\x12!\n" +
+	"\forganizer_id\x18\x01 \x01(\x03R\vorganizerId\x12\x18\n" +
+	"\alocales\x18\x02 \x03(\tR\alocales\"I\n" +
+	"\x17LocaleTranslationStatus\x12\x16\n" +
+	"\x06locale\x18\x01 \x01(\tR\x06locale\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"\x83\x01\n" +
+	"\x16EventTranslationStatus\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x128\n" +
+	"\alocales\x18\x03 \x03(\v2\x1e.event.LocaleTranslationStatusR\alocales\"\xe1\x01\n" +
+	"\x17TranslationStatusReport\x12%\n" +
+	"\x0edefault_locale\x18\x01 \x01(\tR\rdefaultLocale\x12\x18\n" +
+	"\alocales\x18\x02 \x03(\tR\alocales\x125\n" +
+	"\x06events\x18\x03 \x03(\v2\x1d.event.EventTranslationStatusR\x06events\x12\x1a\n" +
+	"\bcomplete\x18\x04 \x01(\x05R\bcomplete\x12\x18\n" +
+	"\apartial\x18\x05 \x01(\x05R\apartial\x12\x18\n" +
+	"\amissing\x18\x06 \x01(\x05R\amissing2\x8d\x11\n" +
 	"\fEventService\x12A\n" +
 	"\n" +
 	"ListEvents\x12\x18.event.ListEventsRequest\x1a\x19.event.ListEventsResponse\x120\n" +
-	"\bGetEvent\x12\x16.event.GetEventRequest\x1a\f.event.EventB\x11Z\x0f./event;eventv1b\x06proto3"
+	"\bGetEvent\x12\x16.event.GetEventRequest\x1a\f.event.Event\x12B\n" +
+	"\x11UpdateEventStatus\x12\x1f.event.UpdateEventStatusRequest\x1a\f.event.Event\x12:\n" +
+	"\rScheduleSales\x12\x1b.event.ScheduleSalesRequest\x1a\f.event.Event\x12:\n" +
+	"\rSetHoldPolicy\x12\x1b.event.SetHoldPolicyRequest\x1a\f.event.Event\x126\n" +
+	"\vCancelEvent\x12\x19.event.CancelEventRequest\x1a\f.event.Event\x12<\n" +
+	"\fSetPriceTier\x12\x1a.event.SetPriceTierRequest\x1a\x10.event.PriceTier\x12M\n" +
+	"\x0eListPriceTiers\x12\x1c.event.ListPriceTiersRequest\x1a\x1d.event.ListPriceTiersResponse\x12;\n" +
+	"\x0fSetRefundPolicy\x12\x13.event.RefundPolicy\x1a\x13.event.RefundPolicy\x12E\n" +
+	"\x0fGetRefundPolicy\x12\x1d.event.GetRefundPolicyRequest\x1a\x13.event.RefundPolicy\x12A\n" +
+	"\x11SetPurchaseLimits\x12\x15.event.PurchaseLimits\x1a\x15.event.PurchaseLimits\x12K\n" +
+	"\x11GetPurchaseLimits\x12\x1f.event.GetPurchaseLimitsRequest\x1a\x15.event.PurchaseLimits\x12A\n" +
+	"\x11SetTransferPolicy\x12\x15.event.TransferPolicy\x1a\x15.event.TransferPolicy\x12K\n" +
+	"\x11GetTransferPolicy\x12\x1f.event.GetTransferPolicyRequest\x1a\x15.event.TransferPolicy\x12H\n" +
+	"\x10SetInventoryPool\x12\x1e.event.SetInventoryPoolRequest\x1a\x14.event.InventoryPool\x12Y\n" +
+	"\x12ListInventoryPools\x12 .event.ListInventoryPoolsRequest\x1a!.event.ListInventoryPoolsResponse\x12B\n" +
+	"\x0eSetSectorScore\x12\x1c.event.SetSectorScoreRequest\x1a\x12.event.SectorScore\x12S\n" +
+	"\x10ListSectorScores\x12\x1e.event.ListSectorScoresRequest\x1a\x1f.event.ListSectorScoresResponse\x12?\n" +
+	"\x0eUpsertCategory\x12\x1c.event.UpsertCategoryRequest\x1a\x0f.event.Category\x12M\n" +
+	"\x0eListCategories\x12\x1c.event.ListCategoriesRequest\x1a\x1d.event.ListCategoriesResponse\x12D\n" +
+	"\x12SetEventCategories\x12 .event.SetEventCategoriesRequest\x1a\f.event.Event\x128\n" +
+	"\fSetEventTags\x12\x1a.event.SetEventTagsRequest\x1a\f.event.Event\x12E\n" +
+	"\x10UpsertCollection\x12\x1e.event.UpsertCollectionRequest\x1a\x11.event.Collection\x12?\n" +
+	"\rGetCollection\x12\x1b.event.GetCollectionRequest\x1a\x11.event.Collection\x12E\n" +
+	"\x10UploadEventImage\x12\x1e.event.UploadEventImageRequest\x1a\x11.event.EventImage\x12@\n" +
+	"\x10DeleteEventImage\x12\x1e.event.DeleteEventImageRequest\x1a\f.event.Event\x12G\n" +
+	"\fImportEvents\x12\x1a.event.ImportEventsRequest\x1a\x1b.event.ImportEventsResponse\x12I\n" +
+	"\x11GetEventsCalendar\x12\x1f.event.GetEventsCalendarRequest\x1a\x13.event.CalendarFeed\x12F\n" +
+	"\x13SetEventTranslation\x12!.event.SetEventTranslationRequest\x1a\f.event.Event\x12L\n" +
+	"\x16DeleteEventTranslation\x12$.event.DeleteEventTranslationRequest\x1a\f.event.Event\x12Z\n" +
+	"\x14GetTranslationStatus\x12\".event.GetTranslationStatusRequest\x1a\x1e.event.TranslationStatusReportB\x11Z\x0f./event;eventv1b\x06proto3"
 
 var (
 	file_event_proto_rawDescOnce sync.Once
@@ -265,24 +3472,141 @@ func file_event_proto_rawDescGZIP() []byte {
 	return file_event_proto_rawDescData
 }
 
-var file_event_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_event_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
 var file_event_proto_goTypes = []any{
-	(*Event)(nil),              // 0: event.Event
-	(*ListEventsRequest)(nil),  // 1: event.ListEventsRequest
-	(*ListEventsResponse)(nil), // 2: event.ListEventsResponse
-	(*GetEventRequest)(nil),    // 3: event.GetEventRequest
+	(*Event)(nil),                         // 0: event.Event
+	(*ImageRendition)(nil),                // 1: event.ImageRendition
+	(*EventImage)(nil),                    // 2: event.EventImage
+	(*ListEventsRequest)(nil),             // 3: event.ListEventsRequest
+	(*ListEventsResponse)(nil),            // 4: event.ListEventsResponse
+	(*GetEventRequest)(nil),               // 5: event.GetEventRequest
+	(*UpdateEventStatusRequest)(nil),      // 6: event.UpdateEventStatusRequest
+	(*ScheduleSalesRequest)(nil),          // 7: event.ScheduleSalesRequest
+	(*SetHoldPolicyRequest)(nil),          // 8: event.SetHoldPolicyRequest
+	(*CancelEventRequest)(nil),            // 9: event.CancelEventRequest
+	(*PriceTier)(nil),                     // 10: event.PriceTier
+	(*SetPriceTierRequest)(nil),           // 11: event.SetPriceTierRequest
+	(*ListPriceTiersRequest)(nil),         // 12: event.ListPriceTiersRequest
+	(*ListPriceTiersResponse)(nil),        // 13: event.ListPriceTiersResponse
+	(*RefundPolicy)(nil),                  // 14: event.RefundPolicy
+	(*GetRefundPolicyRequest)(nil),        // 15: event.GetRefundPolicyRequest
+	(*PurchaseLimits)(nil),                // 16: event.PurchaseLimits
+	(*GetPurchaseLimitsRequest)(nil),      // 17: event.GetPurchaseLimitsRequest
+	(*TransferPolicy)(nil),                // 18: event.TransferPolicy
+	(*GetTransferPolicyRequest)(nil),      // 19: event.GetTransferPolicyRequest
+	(*InventoryPool)(nil),                 // 20: event.InventoryPool
+	(*SetInventoryPoolRequest)(nil),       // 21: event.SetInventoryPoolRequest
+	(*ListInventoryPoolsRequest)(nil),     // 22: event.ListInventoryPoolsRequest
+	(*ListInventoryPoolsResponse)(nil),    // 23: event.ListInventoryPoolsResponse
+	(*SectorScore)(nil),                   // 24: event.SectorScore
+	(*SetSectorScoreRequest)(nil),         // 25: event.SetSectorScoreRequest
+	(*ListSectorScoresRequest)(nil),       // 26: event.ListSectorScoresRequest
+	(*ListSectorScoresResponse)(nil),      // 27: event.ListSectorScoresResponse
+	(*Category)(nil),                      // 28: event.Category
+	(*UpsertCategoryRequest)(nil),         // 29: event.UpsertCategoryRequest
+	(*ListCategoriesRequest)(nil),         // 30: event.ListCategoriesRequest
+	(*ListCategoriesResponse)(nil),        // 31: event.ListCategoriesResponse
+	(*SetEventCategoriesRequest)(nil),     // 32: event.SetEventCategoriesRequest
+	(*SetEventTagsRequest)(nil),           // 33: event.SetEventTagsRequest
+	(*Collection)(nil),                    // 34: event.Collection
+	(*UpsertCollectionRequest)(nil),       // 35: event.UpsertCollectionRequest
+	(*GetCollectionRequest)(nil),          // 36: event.GetCollectionRequest
+	(*UploadEventImageRequest)(nil),       // 37: event.UploadEventImageRequest
+	(*DeleteEventImageRequest)(nil),       // 38: event.DeleteEventImageRequest
+	(*ImportEventsRequest)(nil),           // 39: event.ImportEventsRequest
+	(*ImportError)(nil),                   // 40: event.ImportError
+	(*ImportEventsResponse)(nil),          // 41: event.ImportEventsResponse
+	(*GetEventsCalendarRequest)(nil),      // 42: event.GetEventsCalendarRequest
+	(*CalendarFeed)(nil),                  // 43: event.CalendarFeed
+	(*SetEventTranslationRequest)(nil),    // 44: event.SetEventTranslationRequest
+	(*DeleteEventTranslationRequest)(nil), // 45: event.DeleteEventTranslationRequest
+	(*GetTranslationStatusRequest)(nil),   // 46: event.GetTranslationStatusRequest
+	(*LocaleTranslationStatus)(nil),       // 47: event.LocaleTranslationStatus
+	(*EventTranslationStatus)(nil),        // 48: event.EventTranslationStatus
+	(*TranslationStatusReport)(nil),       // 49: event.TranslationStatusReport
 }
 var file_event_proto_depIdxs = []int32{
-	0, // 0: event.ListEventsResponse.events:type_name -> event.Event
-	1, // 1: event.EventService.ListEvents:input_type -> event.ListEventsRequest
-	3, // 2: event.EventService.GetEvent:input_type -> event.GetEventRequest
-	2, // 3: event.EventService.ListEvents:output_type -> event.ListEventsResponse
-	0, // 4: event.EventService.GetEvent:output_type -> event.Event
-	3, // [3:5] is the sub-list for method output_type
-	1, // [1:3] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	2,  // 0: event.Event.poster:type_name -> event.EventImage
+	2,  // 1: event.Event.gallery:type_name -> event.EventImage
+	1,  // 2: event.EventImage.renditions:type_name -> event.ImageRendition
+	0,  // 3: event.ListEventsResponse.events:type_name -> event.Event
+	10, // 4: event.ListPriceTiersResponse.tiers:type_name -> event.PriceTier
+	20, // 5: event.ListInventoryPoolsResponse.pools:type_name -> event.InventoryPool
+	24, // 6: event.ListSectorScoresResponse.scores:type_name -> event.SectorScore
+	28, // 7: event.Category.children:type_name -> event.Category
+	28, // 8: event.ListCategoriesResponse.categories:type_name -> event.Category
+	0,  // 9: event.Collection.events:type_name -> event.Event
+	40, // 10: event.ImportEventsResponse.errors:type_name -> event.ImportError
+	40, // 11: event.ImportEventsResponse.warnings:type_name -> event.ImportError
+	47, // 12: event.EventTranslationStatus.locales:type_name -> event.LocaleTranslationStatus
+	48, // 13: event.TranslationStatusReport.events:type_name -> event.EventTranslationStatus
+	3,  // 14: event.EventService.ListEvents:input_type -> event.ListEventsRequest
+	5,  // 15: event.EventService.GetEvent:input_type -> event.GetEventRequest
+	6,  // 16: event.EventService.UpdateEventStatus:input_type -> event.UpdateEventStatusRequest
+	7,  // 17: event.EventService.ScheduleSales:input_type -> event.ScheduleSalesRequest
+	8,  // 18: event.EventService.SetHoldPolicy:input_type -> event.SetHoldPolicyRequest
+	9,  // 19: event.EventService.CancelEvent:input_type -> event.CancelEventRequest
+	11, // 20: event.EventService.SetPriceTier:input_type -> event.SetPriceTierRequest
+	12, // 21: event.EventService.ListPriceTiers:input_type -> event.ListPriceTiersRequest
+	14, // 22: event.EventService.SetRefundPolicy:input_type -> event.RefundPolicy
+	15, // 23: event.EventService.GetRefundPolicy:input_type -> event.GetRefundPolicyRequest
+	16, // 24: event.EventService.SetPurchaseLimits:input_type -> event.PurchaseLimits
+	17, // 25: event.EventService.GetPurchaseLimits:input_type -> event.GetPurchaseLimitsRequest
+	18, // 26: event.EventService.SetTransferPolicy:input_type -> event.TransferPolicy
+	19, // 27: event.EventService.GetTransferPolicy:input_type -> event.GetTransferPolicyRequest
+	21, // 28: event.EventService.SetInventoryPool:input_type -> event.SetInventoryPoolRequest
+	22, // 29: event.EventService.ListInventoryPools:input_type -> event.ListInventoryPoolsRequest
+	25, // 30: event.EventService.SetSectorScore:input_type -> event.SetSectorScoreRequest
+	26, // 31: event.EventService.ListSectorScores:input_type -> event.ListSectorScoresRequest
+	29, // 32: event.EventService.UpsertCategory:input_type -> event.UpsertCategoryRequest
+	30, // 33: event.EventService.ListCategories:input_type -> event.ListCategoriesRequest
+	32, // 34: event.EventService.SetEventCategories:input_type -> event.SetEventCategoriesRequest
+	33, // 35: event.EventService.SetEventTags:input_type -> event.SetEventTagsRequest
+	35, // 36: event.EventService.UpsertCollection:input_type -> event.UpsertCollectionRequest
+	36, // 37: event.EventService.GetCollection:input_type -> event.GetCollectionRequest
+	37, // 38: event.EventService.UploadEventImage:input_type -> event.UploadEventImageRequest
+	38, // 39: event.EventService.DeleteEventImage:input_type -> event.DeleteEventImageRequest
+	39, // 40: event.EventService.ImportEvents:input_type -> event.ImportEventsRequest
+	42, // 41: event.EventService.GetEventsCalendar:input_type -> event.GetEventsCalendarRequest
+	44, // 42: event.EventService.SetEventTranslation:input_type -> event.SetEventTranslationRequest
+	45, // 43: event.EventService.DeleteEventTranslation:input_type -> event.DeleteEventTranslationRequest
+	46, // 44: event.EventService.GetTranslationStatus:input_type -> event.GetTranslationStatusRequest
+	4,  // 45: event.EventService.ListEvents:output_type -> event.ListEventsResponse
+	0,  // 46: event.EventService.GetEvent:output_type -> event.Event
+	0,  // 47: event.EventService.UpdateEventStatus:output_type -> event.Event
+	0,  // 48: event.EventService.ScheduleSales:output_type -> event.Event
+	0,  // 49: event.EventService.SetHoldPolicy:output_type -> event.Event
+	0,  // 50: event.EventService.CancelEvent:output_type -> event.Event
+	10, // 51: event.EventService.SetPriceTier:output_type -> event.PriceTier
+	13, // 52: event.EventService.ListPriceTiers:output_type -> event.ListPriceTiersResponse
+	14, // 53: event.EventService.SetRefundPolicy:output_type -> event.RefundPolicy
+	14, // 54: event.EventService.GetRefundPolicy:output_type -> event.RefundPolicy
+	16, // 55: event.EventService.SetPurchaseLimits:output_type -> event.PurchaseLimits
+	16, // 56: event.EventService.GetPurchaseLimits:output_type -> event.PurchaseLimits
+	18, // 57: event.EventService.SetTransferPolicy:output_type -> event.TransferPolicy
+	18, // 58: event.EventService.GetTransferPolicy:output_type -> event.TransferPolicy
+	20, // 59: event.EventService.SetInventoryPool:output_type -> event.InventoryPool
+	23, // 60: event.EventService.ListInventoryPools:output_type -> event.ListInventoryPoolsResponse
+	24, // 61: event.EventService.SetSectorScore:output_type -> event.SectorScore
+	27, // 62: event.EventService.ListSectorScores:output_type -> event.ListSectorScoresResponse
+	28, // 63: event.EventService.UpsertCategory:output_type -> event.Category
+	31, // 64: event.EventService.ListCategories:output_type -> event.ListCategoriesResponse
+	0,  // 65: event.EventService.SetEventCategories:output_type -> event.Event
+	0,  // 66: event.EventService.SetEventTags:output_type -> event.Event
+	34, // 67: event.EventService.UpsertCollection:output_type -> event.Collection
+	34, // 68: event.EventService.GetCollection:output_type -> event.Collection
+	2,  // 69: event.EventService.UploadEventImage:output_type -> event.EventImage
+	0,  // 70: event.EventService.DeleteEventImage:output_type -> event.Event
+	41, // 71: event.EventService.ImportEvents:output_type -> event.ImportEventsResponse
+	43, // 72: event.EventService.GetEventsCalendar:output_type -> event.CalendarFeed
+	0,  // 73: event.EventService.SetEventTranslation:output_type -> event.Event
+	0,  // 74: event.EventService.DeleteEventTranslation:output_type -> event.Event
+	49, // 75: event.EventService.GetTranslationStatus:output_type -> event.TranslationStatusReport
+	45, // [45:76] is the sub-list for method output_type
+	14, // [14:45] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_event_proto_init() }
@@ -290,13 +3614,16 @@ func file_event_proto_init() {
 	if File_event_proto != nil {
 		return
 	}
+	file_event_proto_msgTypes[0].OneofWrappers = []any{}
+	file_event_proto_msgTypes[4].OneofWrappers = []any{}
+	file_event_proto_msgTypes[8].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_event_proto_rawDesc), len(file_event_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   50,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
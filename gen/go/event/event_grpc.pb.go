@@ -19,8 +19,37 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	EventService_ListEvents_FullMethodName = "/event.EventService/ListEvents"
-	EventService_GetEvent_FullMethodName   = "/event.EventService/GetEvent"
+	EventService_ListEvents_FullMethodName             = "/event.EventService/ListEvents"
+	EventService_GetEvent_FullMethodName               = "/event.EventService/GetEvent"
+	EventService_UpdateEventStatus_FullMethodName      = "/event.EventService/UpdateEventStatus"
+	EventService_ScheduleSales_FullMethodName          = "/event.EventService/ScheduleSales"
+	EventService_SetHoldPolicy_FullMethodName          = "/event.EventService/SetHoldPolicy"
+	EventService_CancelEvent_FullMethodName            = "/event.EventService/CancelEvent"
+	EventService_SetPriceTier_FullMethodName           = "/event.EventService/SetPriceTier"
+	EventService_ListPriceTiers_FullMethodName         = "/event.EventService/ListPriceTiers"
+	EventService_SetRefundPolicy_FullMethodName        = "/event.EventService/SetRefundPolicy"
+	EventService_GetRefundPolicy_FullMethodName        = "/event.EventService/GetRefundPolicy"
+	EventService_SetPurchaseLimits_FullMethodName      = "/event.EventService/SetPurchaseLimits"
+	EventService_GetPurchaseLimits_FullMethodName      = "/event.EventService/GetPurchaseLimits"
+	EventService_SetTransferPolicy_FullMethodName      = "/event.EventService/SetTransferPolicy"
+	EventService_GetTransferPolicy_FullMethodName      = "/event.EventService/GetTransferPolicy"
+	EventService_SetInventoryPool_FullMethodName       = "/event.EventService/SetInventoryPool"
+	EventService_ListInventoryPools_FullMethodName     = "/event.EventService/ListInventoryPools"
+	EventService_SetSectorScore_FullMethodName         = "/event.EventService/SetSectorScore"
+	EventService_ListSectorScores_FullMethodName       = "/event.EventService/ListSectorScores"
+	EventService_UpsertCategory_FullMethodName         = "/event.EventService/UpsertCategory"
+	EventService_ListCategories_FullMethodName         = "/event.EventService/ListCategories"
+	EventService_SetEventCategories_FullMethodName     = "/event.EventService/SetEventCategories"
+	EventService_SetEventTags_FullMethodName           = "/event.EventService/SetEventTags"
+	EventService_UpsertCollection_FullMethodName       = "/event.EventService/UpsertCollection"
+	EventService_GetCollection_FullMethodName          = "/event.EventService/GetCollection"
+	EventService_UploadEventImage_FullMethodName       = "/event.EventService/UploadEventImage"
+	EventService_DeleteEventImage_FullMethodName       = "/event.EventService/DeleteEventImage"
+	EventService_ImportEvents_FullMethodName           = "/event.EventService/ImportEvents"
+	EventService_GetEventsCalendar_FullMethodName      = "/event.EventService/GetEventsCalendar"
+	EventService_SetEventTranslation_FullMethodName    = "/event.EventService/SetEventTranslation"
+	EventService_DeleteEventTranslation_FullMethodName = "/event.EventService/DeleteEventTranslation"
+	EventService_GetTranslationStatus_FullMethodName   = "/event.EventService/GetTranslationStatus"
 )
 
 // EventServiceClient is the client API for EventService service.
@@ -29,6 +58,35 @@ const (
 type EventServiceClient interface {
 	ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error)
 	GetEvent(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*Event, error)
+	UpdateEventStatus(ctx context.Context, in *UpdateEventStatusRequest, opts ...grpc.CallOption) (*Event, error)
+	ScheduleSales(ctx context.Context, in *ScheduleSalesRequest, opts ...grpc.CallOption) (*Event, error)
+	SetHoldPolicy(ctx context.Context, in *SetHoldPolicyRequest, opts ...grpc.CallOption) (*Event, error)
+	CancelEvent(ctx context.Context, in *CancelEventRequest, opts ...grpc.CallOption) (*Event, error)
+	SetPriceTier(ctx context.Context, in *SetPriceTierRequest, opts ...grpc.CallOption) (*PriceTier, error)
+	ListPriceTiers(ctx context.Context, in *ListPriceTiersRequest, opts ...grpc.CallOption) (*ListPriceTiersResponse, error)
+	SetRefundPolicy(ctx context.Context, in *RefundPolicy, opts ...grpc.CallOption) (*RefundPolicy, error)
+	GetRefundPolicy(ctx context.Context, in *GetRefundPolicyRequest, opts ...grpc.CallOption) (*RefundPolicy, error)
+	SetPurchaseLimits(ctx context.Context, in *PurchaseLimits, opts ...grpc.CallOption) (*PurchaseLimits, error)
+	GetPurchaseLimits(ctx context.Context, in *GetPurchaseLimitsRequest, opts ...grpc.CallOption) (*PurchaseLimits, error)
+	SetTransferPolicy(ctx context.Context, in *TransferPolicy, opts ...grpc.CallOption) (*TransferPolicy, error)
+	GetTransferPolicy(ctx context.Context, in *GetTransferPolicyRequest, opts ...grpc.CallOption) (*TransferPolicy, error)
+	SetInventoryPool(ctx context.Context, in *SetInventoryPoolRequest, opts ...grpc.CallOption) (*InventoryPool, error)
+	ListInventoryPools(ctx context.Context, in *ListInventoryPoolsRequest, opts ...grpc.CallOption) (*ListInventoryPoolsResponse, error)
+	SetSectorScore(ctx context.Context, in *SetSectorScoreRequest, opts ...grpc.CallOption) (*SectorScore, error)
+	ListSectorScores(ctx context.Context, in *ListSectorScoresRequest, opts ...grpc.CallOption) (*ListSectorScoresResponse, error)
+	UpsertCategory(ctx context.Context, in *UpsertCategoryRequest, opts ...grpc.CallOption) (*Category, error)
+	ListCategories(ctx context.Context, in *ListCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error)
+	SetEventCategories(ctx context.Context, in *SetEventCategoriesRequest, opts ...grpc.CallOption) (*Event, error)
+	SetEventTags(ctx context.Context, in *SetEventTagsRequest, opts ...grpc.CallOption) (*Event, error)
+	UpsertCollection(ctx context.Context, in *UpsertCollectionRequest, opts ...grpc.CallOption) (*Collection, error)
+	GetCollection(ctx context.Context, in *GetCollectionRequest, opts ...grpc.CallOption) (*Collection, error)
+	UploadEventImage(ctx context.Context, in *UploadEventImageRequest, opts ...grpc.CallOption) (*EventImage, error)
+	DeleteEventImage(ctx context.Context, in *DeleteEventImageRequest, opts ...grpc.CallOption) (*Event, error)
+	ImportEvents(ctx context.Context, in *ImportEventsRequest, opts ...grpc.CallOption) (*ImportEventsResponse, error)
+	GetEventsCalendar(ctx context.Context, in *GetEventsCalendarRequest, opts ...grpc.CallOption) (*CalendarFeed, error)
+	SetEventTranslation(ctx context.Context, in *SetEventTranslationRequest, opts ...grpc.CallOption) (*Event, error)
+	DeleteEventTranslation(ctx context.Context, in *DeleteEventTranslationRequest, opts ...grpc.CallOption) (*Event, error)
+	GetTranslationStatus(ctx context.Context, in *GetTranslationStatusRequest, opts ...grpc.CallOption) (*TranslationStatusReport, error)
 }
 
 type eventServiceClient struct {
@@ -59,12 +117,331 @@ func (c *eventServiceClient) GetEvent(ctx context.Context, in *GetEventRequest,
 	return out, nil
 }
 
+func (c *eventServiceClient) UpdateEventStatus(ctx context.Context, in *UpdateEventStatusRequest, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, EventService_UpdateEventStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) ScheduleSales(ctx context.Context, in *ScheduleSalesRequest, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, EventService_ScheduleSales_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) SetHoldPolicy(ctx context.Context, in *SetHoldPolicyRequest, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, EventService_SetHoldPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) CancelEvent(ctx context.Context, in *CancelEventRequest, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, EventService_CancelEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) SetPriceTier(ctx context.Context, in *SetPriceTierRequest, opts ...grpc.CallOption) (*PriceTier, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PriceTier)
+	err := c.cc.Invoke(ctx, EventService_SetPriceTier_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) ListPriceTiers(ctx context.Context, in *ListPriceTiersRequest, opts ...grpc.CallOption) (*ListPriceTiersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPriceTiersResponse)
+	err := c.cc.Invoke(ctx, EventService_ListPriceTiers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) SetRefundPolicy(ctx context.Context, in *RefundPolicy, opts ...grpc.CallOption) (*RefundPolicy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundPolicy)
+	err := c.cc.Invoke(ctx, EventService_SetRefundPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) GetRefundPolicy(ctx context.Context, in *GetRefundPolicyRequest, opts ...grpc.CallOption) (*RefundPolicy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundPolicy)
+	err := c.cc.Invoke(ctx, EventService_GetRefundPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) SetPurchaseLimits(ctx context.Context, in *PurchaseLimits, opts ...grpc.CallOption) (*PurchaseLimits, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurchaseLimits)
+	err := c.cc.Invoke(ctx, EventService_SetPurchaseLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) GetPurchaseLimits(ctx context.Context, in *GetPurchaseLimitsRequest, opts ...grpc.CallOption) (*PurchaseLimits, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurchaseLimits)
+	err := c.cc.Invoke(ctx, EventService_GetPurchaseLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) SetTransferPolicy(ctx context.Context, in *TransferPolicy, opts ...grpc.CallOption) (*TransferPolicy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferPolicy)
+	err := c.cc.Invoke(ctx, EventService_SetTransferPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) GetTransferPolicy(ctx context.Context, in *GetTransferPolicyRequest, opts ...grpc.CallOption) (*TransferPolicy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferPolicy)
+	err := c.cc.Invoke(ctx, EventService_GetTransferPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) SetInventoryPool(ctx context.Context, in *SetInventoryPoolRequest, opts ...grpc.CallOption) (*InventoryPool, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InventoryPool)
+	err := c.cc.Invoke(ctx, EventService_SetInventoryPool_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) ListInventoryPools(ctx context.Context, in *ListInventoryPoolsRequest, opts ...grpc.CallOption) (*ListInventoryPoolsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInventoryPoolsResponse)
+	err := c.cc.Invoke(ctx, EventService_ListInventoryPools_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) SetSectorScore(ctx context.Context, in *SetSectorScoreRequest, opts ...grpc.CallOption) (*SectorScore, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SectorScore)
+	err := c.cc.Invoke(ctx, EventService_SetSectorScore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) ListSectorScores(ctx context.Context, in *ListSectorScoresRequest, opts ...grpc.CallOption) (*ListSectorScoresResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSectorScoresResponse)
+	err := c.cc.Invoke(ctx, EventService_ListSectorScores_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) UpsertCategory(ctx context.Context, in *UpsertCategoryRequest, opts ...grpc.CallOption) (*Category, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Category)
+	err := c.cc.Invoke(ctx, EventService_UpsertCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) ListCategories(ctx context.Context, in *ListCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCategoriesResponse)
+	err := c.cc.Invoke(ctx, EventService_ListCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) SetEventCategories(ctx context.Context, in *SetEventCategoriesRequest, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, EventService_SetEventCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) SetEventTags(ctx context.Context, in *SetEventTagsRequest, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, EventService_SetEventTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) UpsertCollection(ctx context.Context, in *UpsertCollectionRequest, opts ...grpc.CallOption) (*Collection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Collection)
+	err := c.cc.Invoke(ctx, EventService_UpsertCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) GetCollection(ctx context.Context, in *GetCollectionRequest, opts ...grpc.CallOption) (*Collection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Collection)
+	err := c.cc.Invoke(ctx, EventService_GetCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) UploadEventImage(ctx context.Context, in *UploadEventImageRequest, opts ...grpc.CallOption) (*EventImage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EventImage)
+	err := c.cc.Invoke(ctx, EventService_UploadEventImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) DeleteEventImage(ctx context.Context, in *DeleteEventImageRequest, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, EventService_DeleteEventImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) ImportEvents(ctx context.Context, in *ImportEventsRequest, opts ...grpc.CallOption) (*ImportEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportEventsResponse)
+	err := c.cc.Invoke(ctx, EventService_ImportEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) GetEventsCalendar(ctx context.Context, in *GetEventsCalendarRequest, opts ...grpc.CallOption) (*CalendarFeed, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CalendarFeed)
+	err := c.cc.Invoke(ctx, EventService_GetEventsCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) SetEventTranslation(ctx context.Context, in *SetEventTranslationRequest, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, EventService_SetEventTranslation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) DeleteEventTranslation(ctx context.Context, in *DeleteEventTranslationRequest, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, EventService_DeleteEventTranslation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) GetTranslationStatus(ctx context.Context, in *GetTranslationStatusRequest, opts ...grpc.CallOption) (*TranslationStatusReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TranslationStatusReport)
+	err := c.cc.Invoke(ctx, EventService_GetTranslationStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EventServiceServer is the server API for EventService service.
 // All implementations must embed UnimplementedEventServiceServer
 // for forward compatibility.
 type EventServiceServer interface {
 	ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error)
 	GetEvent(context.Context, *GetEventRequest) (*Event, error)
+	UpdateEventStatus(context.Context, *UpdateEventStatusRequest) (*Event, error)
+	ScheduleSales(context.Context, *ScheduleSalesRequest) (*Event, error)
+	SetHoldPolicy(context.Context, *SetHoldPolicyRequest) (*Event, error)
+	CancelEvent(context.Context, *CancelEventRequest) (*Event, error)
+	SetPriceTier(context.Context, *SetPriceTierRequest) (*PriceTier, error)
+	ListPriceTiers(context.Context, *ListPriceTiersRequest) (*ListPriceTiersResponse, error)
+	SetRefundPolicy(context.Context, *RefundPolicy) (*RefundPolicy, error)
+	GetRefundPolicy(context.Context, *GetRefundPolicyRequest) (*RefundPolicy, error)
+	SetPurchaseLimits(context.Context, *PurchaseLimits) (*PurchaseLimits, error)
+	GetPurchaseLimits(context.Context, *GetPurchaseLimitsRequest) (*PurchaseLimits, error)
+	SetTransferPolicy(context.Context, *TransferPolicy) (*TransferPolicy, error)
+	GetTransferPolicy(context.Context, *GetTransferPolicyRequest) (*TransferPolicy, error)
+	SetInventoryPool(context.Context, *SetInventoryPoolRequest) (*InventoryPool, error)
+	ListInventoryPools(context.Context, *ListInventoryPoolsRequest) (*ListInventoryPoolsResponse, error)
+	SetSectorScore(context.Context, *SetSectorScoreRequest) (*SectorScore, error)
+	ListSectorScores(context.Context, *ListSectorScoresRequest) (*ListSectorScoresResponse, error)
+	UpsertCategory(context.Context, *UpsertCategoryRequest) (*Category, error)
+	ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error)
+	SetEventCategories(context.Context, *SetEventCategoriesRequest) (*Event, error)
+	SetEventTags(context.Context, *SetEventTagsRequest) (*Event, error)
+	UpsertCollection(context.Context, *UpsertCollectionRequest) (*Collection, error)
+	GetCollection(context.Context, *GetCollectionRequest) (*Collection, error)
+	UploadEventImage(context.Context, *UploadEventImageRequest) (*EventImage, error)
+	DeleteEventImage(context.Context, *DeleteEventImageRequest) (*Event, error)
+	ImportEvents(context.Context, *ImportEventsRequest) (*ImportEventsResponse, error)
+	GetEventsCalendar(context.Context, *GetEventsCalendarRequest) (*CalendarFeed, error)
+	SetEventTranslation(context.Context, *SetEventTranslationRequest) (*Event, error)
+	DeleteEventTranslation(context.Context, *DeleteEventTranslationRequest) (*Event, error)
+	GetTranslationStatus(context.Context, *GetTranslationStatusRequest) (*TranslationStatusReport, error)
 	mustEmbedUnimplementedEventServiceServer()
 }
 
@@ -81,6 +458,93 @@ func (UnimplementedEventServiceServer) ListEvents(context.Context, *ListEventsRe
 func (UnimplementedEventServiceServer) GetEvent(context.Context, *GetEventRequest) (*Event, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetEvent not implemented")
 }
+func (UnimplementedEventServiceServer) UpdateEventStatus(context.Context, *UpdateEventStatusRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEventStatus not implemented")
+}
+func (UnimplementedEventServiceServer) ScheduleSales(context.Context, *ScheduleSalesRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleSales not implemented")
+}
+func (UnimplementedEventServiceServer) SetHoldPolicy(context.Context, *SetHoldPolicyRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetHoldPolicy not implemented")
+}
+func (UnimplementedEventServiceServer) CancelEvent(context.Context, *CancelEventRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelEvent not implemented")
+}
+func (UnimplementedEventServiceServer) SetPriceTier(context.Context, *SetPriceTierRequest) (*PriceTier, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPriceTier not implemented")
+}
+func (UnimplementedEventServiceServer) ListPriceTiers(context.Context, *ListPriceTiersRequest) (*ListPriceTiersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPriceTiers not implemented")
+}
+func (UnimplementedEventServiceServer) SetRefundPolicy(context.Context, *RefundPolicy) (*RefundPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRefundPolicy not implemented")
+}
+func (UnimplementedEventServiceServer) GetRefundPolicy(context.Context, *GetRefundPolicyRequest) (*RefundPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRefundPolicy not implemented")
+}
+func (UnimplementedEventServiceServer) SetPurchaseLimits(context.Context, *PurchaseLimits) (*PurchaseLimits, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPurchaseLimits not implemented")
+}
+func (UnimplementedEventServiceServer) GetPurchaseLimits(context.Context, *GetPurchaseLimitsRequest) (*PurchaseLimits, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPurchaseLimits not implemented")
+}
+func (UnimplementedEventServiceServer) SetTransferPolicy(context.Context, *TransferPolicy) (*TransferPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTransferPolicy not implemented")
+}
+func (UnimplementedEventServiceServer) GetTransferPolicy(context.Context, *GetTransferPolicyRequest) (*TransferPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransferPolicy not implemented")
+}
+func (UnimplementedEventServiceServer) SetInventoryPool(context.Context, *SetInventoryPoolRequest) (*InventoryPool, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetInventoryPool not implemented")
+}
+func (UnimplementedEventServiceServer) ListInventoryPools(context.Context, *ListInventoryPoolsRequest) (*ListInventoryPoolsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInventoryPools not implemented")
+}
+func (UnimplementedEventServiceServer) SetSectorScore(context.Context, *SetSectorScoreRequest) (*SectorScore, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSectorScore not implemented")
+}
+func (UnimplementedEventServiceServer) ListSectorScores(context.Context, *ListSectorScoresRequest) (*ListSectorScoresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSectorScores not implemented")
+}
+func (UnimplementedEventServiceServer) UpsertCategory(context.Context, *UpsertCategoryRequest) (*Category, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertCategory not implemented")
+}
+func (UnimplementedEventServiceServer) ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCategories not implemented")
+}
+func (UnimplementedEventServiceServer) SetEventCategories(context.Context, *SetEventCategoriesRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetEventCategories not implemented")
+}
+func (UnimplementedEventServiceServer) SetEventTags(context.Context, *SetEventTagsRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetEventTags not implemented")
+}
+func (UnimplementedEventServiceServer) UpsertCollection(context.Context, *UpsertCollectionRequest) (*Collection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertCollection not implemented")
+}
+func (UnimplementedEventServiceServer) GetCollection(context.Context, *GetCollectionRequest) (*Collection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCollection not implemented")
+}
+func (UnimplementedEventServiceServer) UploadEventImage(context.Context, *UploadEventImageRequest) (*EventImage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadEventImage not implemented")
+}
+func (UnimplementedEventServiceServer) DeleteEventImage(context.Context, *DeleteEventImageRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEventImage not implemented")
+}
+func (UnimplementedEventServiceServer) ImportEvents(context.Context, *ImportEventsRequest) (*ImportEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportEvents not implemented")
+}
+func (UnimplementedEventServiceServer) GetEventsCalendar(context.Context, *GetEventsCalendarRequest) (*CalendarFeed, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEventsCalendar not implemented")
+}
+func (UnimplementedEventServiceServer) SetEventTranslation(context.Context, *SetEventTranslationRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetEventTranslation not implemented")
+}
+func (UnimplementedEventServiceServer) DeleteEventTranslation(context.Context, *DeleteEventTranslationRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEventTranslation not implemented")
+}
+func (UnimplementedEventServiceServer) GetTranslationStatus(context.Context, *GetTranslationStatusRequest) (*TranslationStatusReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTranslationStatus not implemented")
+}
 func (UnimplementedEventServiceServer) mustEmbedUnimplementedEventServiceServer() {}
 func (UnimplementedEventServiceServer) testEmbeddedByValue()                      {}
 
@@ -138,20 +602,658 @@ func _EventService_GetEvent_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
-// EventService_ServiceDesc is the grpc.ServiceDesc for EventService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var EventService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "event.EventService",
-	HandlerType: (*EventServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "ListEvents",
-			Handler:    _EventService_ListEvents_Handler,
-		},
-		{
-			MethodName: "GetEvent",
-			Handler:    _EventService_GetEvent_Handler,
+func _EventService_UpdateEventStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEventStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).UpdateEventStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_UpdateEventStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).UpdateEventStatus(ctx, req.(*UpdateEventStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_ScheduleSales_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleSalesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).ScheduleSales(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_ScheduleSales_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).ScheduleSales(ctx, req.(*ScheduleSalesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_SetHoldPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetHoldPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).SetHoldPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_SetHoldPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).SetHoldPolicy(ctx, req.(*SetHoldPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_CancelEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).CancelEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_CancelEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).CancelEvent(ctx, req.(*CancelEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_SetPriceTier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPriceTierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).SetPriceTier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_SetPriceTier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).SetPriceTier(ctx, req.(*SetPriceTierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_ListPriceTiers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPriceTiersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).ListPriceTiers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_ListPriceTiers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).ListPriceTiers(ctx, req.(*ListPriceTiersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_SetRefundPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundPolicy)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).SetRefundPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_SetRefundPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).SetRefundPolicy(ctx, req.(*RefundPolicy))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_GetRefundPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRefundPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).GetRefundPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_GetRefundPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).GetRefundPolicy(ctx, req.(*GetRefundPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_SetPurchaseLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurchaseLimits)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).SetPurchaseLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_SetPurchaseLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).SetPurchaseLimits(ctx, req.(*PurchaseLimits))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_GetPurchaseLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPurchaseLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).GetPurchaseLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_GetPurchaseLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).GetPurchaseLimits(ctx, req.(*GetPurchaseLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_SetTransferPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferPolicy)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).SetTransferPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_SetTransferPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).SetTransferPolicy(ctx, req.(*TransferPolicy))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_GetTransferPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransferPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).GetTransferPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_GetTransferPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).GetTransferPolicy(ctx, req.(*GetTransferPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_SetInventoryPool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetInventoryPoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).SetInventoryPool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_SetInventoryPool_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).SetInventoryPool(ctx, req.(*SetInventoryPoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_ListInventoryPools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInventoryPoolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).ListInventoryPools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_ListInventoryPools_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).ListInventoryPools(ctx, req.(*ListInventoryPoolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_SetSectorScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSectorScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).SetSectorScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_SetSectorScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).SetSectorScore(ctx, req.(*SetSectorScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_ListSectorScores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSectorScoresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).ListSectorScores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_ListSectorScores_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).ListSectorScores(ctx, req.(*ListSectorScoresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_UpsertCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).UpsertCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_UpsertCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).UpsertCategory(ctx, req.(*UpsertCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_ListCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).ListCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_ListCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).ListCategories(ctx, req.(*ListCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_SetEventCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetEventCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).SetEventCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_SetEventCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).SetEventCategories(ctx, req.(*SetEventCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_SetEventTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetEventTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).SetEventTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_SetEventTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).SetEventTags(ctx, req.(*SetEventTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_UpsertCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).UpsertCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_UpsertCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).UpsertCollection(ctx, req.(*UpsertCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_GetCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).GetCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_GetCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).GetCollection(ctx, req.(*GetCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_UploadEventImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadEventImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).UploadEventImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_UploadEventImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).UploadEventImage(ctx, req.(*UploadEventImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_DeleteEventImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEventImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).DeleteEventImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_DeleteEventImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).DeleteEventImage(ctx, req.(*DeleteEventImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_ImportEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).ImportEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_ImportEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).ImportEvents(ctx, req.(*ImportEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_GetEventsCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventsCalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).GetEventsCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_GetEventsCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).GetEventsCalendar(ctx, req.(*GetEventsCalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_SetEventTranslation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetEventTranslationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).SetEventTranslation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_SetEventTranslation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).SetEventTranslation(ctx, req.(*SetEventTranslationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_DeleteEventTranslation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEventTranslationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).DeleteEventTranslation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_DeleteEventTranslation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).DeleteEventTranslation(ctx, req.(*DeleteEventTranslationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_GetTranslationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTranslationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).GetTranslationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_GetTranslationStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).GetTranslationStatus(ctx, req.(*GetTranslationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EventService_ServiceDesc is the grpc.ServiceDesc for EventService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EventService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "event.EventService",
+	HandlerType: (*EventServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListEvents",
+			Handler:    _EventService_ListEvents_Handler,
+		},
+		{
+			MethodName: "GetEvent",
+			Handler:    _EventService_GetEvent_Handler,
+		},
+		{
+			MethodName: "UpdateEventStatus",
+			Handler:    _EventService_UpdateEventStatus_Handler,
+		},
+		{
+			MethodName: "ScheduleSales",
+			Handler:    _EventService_ScheduleSales_Handler,
+		},
+		{
+			MethodName: "SetHoldPolicy",
+			Handler:    _EventService_SetHoldPolicy_Handler,
+		},
+		{
+			MethodName: "CancelEvent",
+			Handler:    _EventService_CancelEvent_Handler,
+		},
+		{
+			MethodName: "SetPriceTier",
+			Handler:    _EventService_SetPriceTier_Handler,
+		},
+		{
+			MethodName: "ListPriceTiers",
+			Handler:    _EventService_ListPriceTiers_Handler,
+		},
+		{
+			MethodName: "SetRefundPolicy",
+			Handler:    _EventService_SetRefundPolicy_Handler,
+		},
+		{
+			MethodName: "GetRefundPolicy",
+			Handler:    _EventService_GetRefundPolicy_Handler,
+		},
+		{
+			MethodName: "SetPurchaseLimits",
+			Handler:    _EventService_SetPurchaseLimits_Handler,
+		},
+		{
+			MethodName: "GetPurchaseLimits",
+			Handler:    _EventService_GetPurchaseLimits_Handler,
+		},
+		{
+			MethodName: "SetTransferPolicy",
+			Handler:    _EventService_SetTransferPolicy_Handler,
+		},
+		{
+			MethodName: "GetTransferPolicy",
+			Handler:    _EventService_GetTransferPolicy_Handler,
+		},
+		{
+			MethodName: "SetInventoryPool",
+			Handler:    _EventService_SetInventoryPool_Handler,
+		},
+		{
+			MethodName: "ListInventoryPools",
+			Handler:    _EventService_ListInventoryPools_Handler,
+		},
+		{
+			MethodName: "SetSectorScore",
+			Handler:    _EventService_SetSectorScore_Handler,
+		},
+		{
+			MethodName: "ListSectorScores",
+			Handler:    _EventService_ListSectorScores_Handler,
+		},
+		{
+			MethodName: "UpsertCategory",
+			Handler:    _EventService_UpsertCategory_Handler,
+		},
+		{
+			MethodName: "ListCategories",
+			Handler:    _EventService_ListCategories_Handler,
+		},
+		{
+			MethodName: "SetEventCategories",
+			Handler:    _EventService_SetEventCategories_Handler,
+		},
+		{
+			MethodName: "SetEventTags",
+			Handler:    _EventService_SetEventTags_Handler,
+		},
+		{
+			MethodName: "UpsertCollection",
+			Handler:    _EventService_UpsertCollection_Handler,
+		},
+		{
+			MethodName: "GetCollection",
+			Handler:    _EventService_GetCollection_Handler,
+		},
+		{
+			MethodName: "UploadEventImage",
+			Handler:    _EventService_UploadEventImage_Handler,
+		},
+		{
+			MethodName: "DeleteEventImage",
+			Handler:    _EventService_DeleteEventImage_Handler,
+		},
+		{
+			MethodName: "ImportEvents",
+			Handler:    _EventService_ImportEvents_Handler,
+		},
+		{
+			MethodName: "GetEventsCalendar",
+			Handler:    _EventService_GetEventsCalendar_Handler,
+		},
+		{
+			MethodName: "SetEventTranslation",
+			Handler:    _EventService_SetEventTranslation_Handler,
+		},
+		{
+			MethodName: "DeleteEventTranslation",
+			Handler:    _EventService_DeleteEventTranslation_Handler,
+		},
+		{
+			MethodName: "GetTranslationStatus",
+			Handler:    _EventService_GetTranslationStatus_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -0,0 +1,62 @@
+// Package admission signs and checks the tokens that let a customer out of
+// the waiting room of an event and on to booking it. booking-service issues
+// them and api-gateway checks them, both with the same secret.
+package admission
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrInvalidToken = errors.New("invalid admission token")
+var ErrTokenExpired = errors.New("admission token has expired")
+
+// Ticket is what an admission token vouches for: the user may book the event
+// until ExpiresAt.
+type Ticket struct {
+	EventID   int64     `json:"e"`
+	UserID    int64     `json:"u"`
+	ExpiresAt time.Time `json:"x"`
+}
+
+func Sign(secret []byte, ticket Ticket) string {
+	// a Ticket holds only its event, user and expiry; marshalling it can not fail
+	raw, _ := json.Marshal(ticket)
+	payload := base64.RawURLEncoding.EncodeToString(raw)
+	return payload + "." + signature(secret, payload)
+}
+
+// Verify returns the ticket of a token signed with the secret that has not
+// expired yet.
+func Verify(secret []byte, token string, now time.Time) (Ticket, error) {
+	var ticket Ticket
+
+	payload, sig, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(signature(secret, payload))) {
+		return ticket, ErrInvalidToken
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return ticket, ErrInvalidToken
+	}
+	if err := json.Unmarshal(raw, &ticket); err != nil || ticket.EventID <= 0 || ticket.UserID <= 0 {
+		return ticket, ErrInvalidToken
+	}
+	if !now.Before(ticket.ExpiresAt) {
+		return ticket, ErrTokenExpired
+	}
+
+	return ticket, nil
+}
+
+func signature(secret []byte, payload string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("admission:" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,41 @@
+package admission
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmissionTokenRoundTrip(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	ticket := Ticket{EventID: 7, UserID: 42, ExpiresAt: now.Add(10 * time.Minute)}
+
+	verified, err := Verify(secret, Sign(secret, ticket), now)
+	require.NoError(t, err)
+	require.Equal(t, ticket.EventID, verified.EventID)
+	require.Equal(t, ticket.UserID, verified.UserID)
+	require.True(t, ticket.ExpiresAt.Equal(verified.ExpiresAt))
+
+	_, err = Verify(secret, Sign(secret, ticket), ticket.ExpiresAt)
+	require.ErrorIs(t, err, ErrTokenExpired)
+}
+
+func TestVerifyRejectsForgedTokens(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	token := Sign([]byte("secret"), Ticket{EventID: 7, UserID: 42, ExpiresAt: now.Add(time.Hour)})
+	other := Sign([]byte("secret"), Ticket{EventID: 7, UserID: 43, ExpiresAt: now.Add(time.Hour)})
+	payload, _, _ := strings.Cut(token, ".")
+	_, sig, _ := strings.Cut(other, ".")
+
+	for _, forged := range []string{"", "garbage", token + "x", payload + "." + sig} {
+		_, err := Verify([]byte("secret"), forged, now)
+		require.ErrorIs(t, err, ErrInvalidToken, "token %q should be rejected", forged)
+	}
+
+	_, err := Verify([]byte("another secret"), token, now)
+	require.ErrorIs(t, err, ErrInvalidToken, "a token signed with another secret should be rejected")
+}
+
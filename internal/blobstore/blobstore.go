@@ -0,0 +1,138 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var ErrNotFound = errors.New("blob not found")
+var ErrInvalidKey = errors.New("invalid blob key")
+
+// Store keeps binary objects under slash-separated keys such as
+// "events/1/poster/large.jpg" and knows the public URL of each of them.
+type Store interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+	// Key is the inverse of URL, false for URLs that do not point into the store.
+	Key(url string) (string, bool)
+}
+
+// FileSystem stores blobs as files under root; the files are expected to be
+// served as-is under baseURL.
+type FileSystem struct {
+	root    string
+	baseURL string
+}
+
+var _ Store = (*FileSystem)(nil)
+
+func NewFileSystem(root, baseURL string) *FileSystem {
+	return &FileSystem{root: root, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+func (f *FileSystem) Put(ctx context.Context, key string, r io.Reader) error {
+	const op = "blobstore.Put"
+
+	name, err := f.path(key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return fmt.Errorf("%s: failed to create directory: %w", op, err)
+	}
+
+	// readers never see a half-written file: write aside, then rename
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("%s: failed to create file: %w", op, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("%s: failed to write file: %w", op, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("%s: failed to write file: %w", op, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return fmt.Errorf("%s: failed to move file into place: %w", op, err)
+	}
+
+	return nil
+}
+
+func (f *FileSystem) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	const op = "blobstore.Open"
+
+	name, err := f.path(key)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%s: %w", op, ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return file, nil
+}
+
+func (f *FileSystem) Delete(ctx context.Context, key string) error {
+	const op = "blobstore.Delete"
+
+	name, err := f.path(key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (f *FileSystem) URL(key string) string {
+	return f.baseURL + "/" + key
+}
+
+func (f *FileSystem) Key(url string) (string, bool) {
+	key, ok := strings.CutPrefix(url, f.baseURL+"/")
+	if !ok || validKey(key) != nil {
+		return "", false
+	}
+	return key, true
+}
+
+func (f *FileSystem) path(key string) (string, error) {
+	if err := validKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(f.root, filepath.FromSlash(key)), nil
+}
+
+// validKey keeps keys relative and clean so that none of them can point
+// outside of the store.
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || key == ".." || strings.HasPrefix(key, "../") {
+		return ErrInvalidKey
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestFileSystemRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileSystem(t.TempDir(), "http://localhost:8080/media/")
+
+	if err := store.Put(ctx, "events/1/poster.jpg", strings.NewReader("image")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	r, err := store.Open(ctx, "events/1/poster.jpg")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	content, _ := io.ReadAll(r)
+	r.Close()
+	if string(content) != "image" {
+		t.Errorf("Open() content = %q, want %q", content, "image")
+	}
+
+	url := store.URL("events/1/poster.jpg")
+	if url != "http://localhost:8080/media/events/1/poster.jpg" {
+		t.Errorf("URL() = %q", url)
+	}
+	if key, ok := store.Key(url); !ok || key != "events/1/poster.jpg" {
+		t.Errorf("Key(%q) = %q, %v", url, key, ok)
+	}
+
+	if err := store.Delete(ctx, "events/1/poster.jpg"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Open(ctx, "events/1/poster.jpg"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Open() after Delete() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFileSystemRejectsKeysOutsideRoot(t *testing.T) {
+	store := NewFileSystem(t.TempDir(), "http://localhost:8080/media")
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "events/../../secret", "events//1"} {
+		if err := store.Put(context.Background(), key, strings.NewReader("x")); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Put(%q) error = %v, want ErrInvalidKey", key, err)
+		}
+	}
+
+	if _, ok := store.Key("http://localhost:8080/media/../secret"); ok {
+		t.Error("Key() accepted a URL escaping the store")
+	}
+	if _, ok := store.Key("http://example.com/poster.jpg"); ok {
+		t.Error("Key() accepted a foreign URL")
+	}
+}
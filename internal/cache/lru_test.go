@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRU[string, int](2, time.Minute)
+	c.Add("a", 1)
+	c.Add("b", 2)
+
+	_, ok := c.Get("a")
+	require.True(t, ok)
+
+	c.Add("c", 3)
+
+	_, ok = c.Get("b")
+	require.False(t, ok, "b was used least recently and should be evicted")
+	v, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+	require.Equal(t, 2, c.Len())
+}
+
+func TestLRUExpiresEntries(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	c := NewLRU[int64, string](10, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Add(1, "event")
+	now = now.Add(59 * time.Second)
+	_, ok := c.Get(1)
+	require.True(t, ok)
+
+	now = now.Add(time.Second)
+	_, ok = c.Get(1)
+	require.False(t, ok, "entry should expire after the TTL")
+	require.Zero(t, c.Len(), "expired entry should be dropped")
+}
+
+func TestLRURemoveAndPurge(t *testing.T) {
+	c := NewLRU[int, int](10, time.Minute)
+	for i := range 5 {
+		c.Add(i, i)
+	}
+
+	c.Remove(3)
+	_, ok := c.Get(3)
+	require.False(t, ok)
+
+	c.Purge()
+	require.Zero(t, c.Len())
+	_, ok = c.Get(1)
+	require.False(t, ok)
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 )
 
 type Config struct {
@@ -16,6 +17,20 @@ type Config struct {
 	JWTSecret       		string
 	PaymentWebhookSecret	string
     PaymentServiceURL       string
+    MediaStoragePath        string
+    MediaBaseURL            string
+    CalendarFeedSecret      string
+    // signs the tokens that let customers out of a waiting room
+    WaitingRoomSecret       string
+    // signs the codes printed on tickets, which are scanned at check-in
+    TicketSecret            string
+    DefaultLocale           string
+    // how long an unpaid booking holds its tickets unless its event sets otherwise
+    BookingHoldTime         time.Duration
+    // how long a response stored under an Idempotency-Key is replayed
+    IdempotencyRetention    time.Duration
+    // how long a waitlisted customer has to claim the tickets offered to them
+    WaitlistOfferTime       time.Duration
 }
 
 func getEnv(key, defaultValue string) string {
@@ -39,6 +54,21 @@ func Load() (*Config, error) {
 		)
 	}
 
+	holdTime, err := time.ParseDuration(getEnv("BOOKING_HOLD_TIME", "15m"))
+	if err != nil || holdTime < time.Minute {
+		return nil, fmt.Errorf("BOOKING_HOLD_TIME must be a duration of at least a minute, got %q", getEnv("BOOKING_HOLD_TIME", "15m"))
+	}
+
+	retention, err := time.ParseDuration(getEnv("IDEMPOTENCY_RETENTION", "24h"))
+	if err != nil || retention <= 0 {
+		return nil, fmt.Errorf("IDEMPOTENCY_RETENTION must be a positive duration, got %q", getEnv("IDEMPOTENCY_RETENTION", "24h"))
+	}
+
+	offerTime, err := time.ParseDuration(getEnv("WAITLIST_OFFER_TIME", "30m"))
+	if err != nil || offerTime < time.Minute {
+		return nil, fmt.Errorf("WAITLIST_OFFER_TIME must be a duration of at least a minute, got %q", getEnv("WAITLIST_OFFER_TIME", "30m"))
+	}
+
 	cfg := &Config{
 		APIPort:         		getEnv("API_PORT", "8080"),
 		AuthGRPCPort:    		getEnv("AUTH_GRPC_PORT", "50051"),
@@ -50,6 +80,15 @@ func Load() (*Config, error) {
 		JWTSecret:       		getEnv("JWT_SECRET", ""),
 		PaymentWebhookSecret:   getEnv("PAYMENT_WEBHOOK_SECRET", ""),
         PaymentServiceURL:      getEnv("PAYMENT_SERVICE_URL", ""),
+        MediaStoragePath:       getEnv("MEDIA_STORAGE_PATH", "/media"),
+        MediaBaseURL:           getEnv("MEDIA_BASE_URL", "http://localhost:8080/media"),
+        CalendarFeedSecret:     getEnv("CALENDAR_FEED_SECRET", ""),
+        WaitingRoomSecret:      getEnv("WAITING_ROOM_SECRET", ""),
+        TicketSecret:           getEnv("TICKET_SECRET", ""),
+        DefaultLocale:          getEnv("DEFAULT_LOCALE", "ru"),
+        BookingHoldTime:        holdTime,
+        IdempotencyRetention:   retention,
+        WaitlistOfferTime:      offerTime,
 	}
 
 	return cfg, nil
@@ -0,0 +1,139 @@
+package ical
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ProdID      = "-//ticket-booking-system//EN"
+	ContentType = "text/calendar; charset=utf-8"
+
+	// events have no end time; calendar clients need some length to draw them
+	DefaultEventDuration = 2 * time.Hour
+
+	// content lines longer than this many octets must be folded (RFC 5545, 3.1)
+	maxLineOctets = 75
+)
+
+// BookingUID identifies a booking in calendars. The personal feed and the
+// confirmation attachment share it, so importing both does not duplicate
+// the booking.
+func BookingUID(bookingID int64) string {
+	return fmt.Sprintf("booking-%d@ticket-booking-system", bookingID)
+}
+
+// EventUID identifies an event in every calendar it appears in.
+func EventUID(eventID int64) string {
+	return fmt.Sprintf("event-%d@ticket-booking-system", eventID)
+}
+
+type Calendar struct {
+	// shown by clients as the name of a subscribed calendar
+	Name   string
+	Events []Event
+}
+
+type Event struct {
+	// globally unique and stable, so that clients update instead of duplicating
+	UID         string
+	Start       time.Time
+	Duration    time.Duration
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	// CONFIRMED, TENTATIVE or CANCELLED
+	Status string
+}
+
+// Render writes the calendar as an RFC 5545 iCalendar object; stamp is the
+// DTSTAMP of every event, normally the time the feed is generated.
+func Render(cal Calendar, stamp time.Time) []byte {
+	var b strings.Builder
+
+	line := func(name, value string) {
+		writeFolded(&b, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", ProdID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if cal.Name != "" {
+		line("X-WR-CALNAME", escapeText(cal.Name))
+	}
+
+	for _, event := range cal.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", event.UID)
+		line("DTSTAMP", formatTime(stamp))
+		line("DTSTART", formatTime(event.Start))
+		if event.Duration > 0 {
+			line("DURATION", formatDuration(event.Duration))
+		}
+		line("SUMMARY", escapeText(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION", escapeText(event.Description))
+		}
+		if event.Location != "" {
+			line("LOCATION", escapeText(event.Location))
+		}
+		if event.URL != "" {
+			line("URL", event.URL)
+		}
+		if event.Status != "" {
+			line("STATUS", event.Status)
+		}
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+
+	return []byte(b.String())
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// formatDuration renders whole minutes as an RFC 5545 duration such as PT2H30M.
+func formatDuration(d time.Duration) string {
+	hours, minutes := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case hours == 0:
+		return "PT" + strconv.Itoa(minutes) + "M"
+	case minutes == 0:
+		return "PT" + strconv.Itoa(hours) + "H"
+	}
+	return "PT" + strconv.Itoa(hours) + "H" + strconv.Itoa(minutes) + "M"
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// writeFolded ends the line with CRLF and folds it into continuation lines
+// starting with a space, never splitting a UTF-8 sequence.
+func writeFolded(b *strings.Builder, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// the leading space of a continuation line counts towards its length
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
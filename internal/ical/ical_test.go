@@ -0,0 +1,69 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRender(t *testing.T) {
+	start := time.Date(2026, 12, 30, 19, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	stamp := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	got := string(Render(Calendar{
+		Name: "Concerts",
+		Events: []Event{{
+			UID:         "event-1@ticket-booking-system",
+			Start:       start,
+			Duration:    150 * time.Minute,
+			Summary:     "Bach; Handel, and friends",
+			Description: "Line one\nLine two",
+			Status:      "CONFIRMED",
+		}},
+	}, stamp))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"X-WR-CALNAME:Concerts\r\n",
+		"DTSTAMP:20261018T120000Z\r\n",
+		"DTSTART:20261230T160000Z\r\n",
+		"DURATION:PT2H30M\r\n",
+		`SUMMARY:Bach\; Handel\, and friends` + "\r\n",
+		`DESCRIPTION:Line one\nLine two` + "\r\n",
+		"END:VEVENT\r\nEND:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("calendar does not contain %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderFoldsLongLines(t *testing.T) {
+	summary := strings.Repeat("Щелкунчик ", 20)
+	got := string(Render(Calendar{Events: []Event{{UID: "1", Summary: summary}}}, time.Now()))
+
+	var unfolded strings.Builder
+	for _, line := range strings.Split(strings.TrimSuffix(got, "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line of %d octets: %q", len(line), line)
+		}
+		if !strings.HasPrefix(line, " ") && unfolded.Len() > 0 {
+			unfolded.WriteString("\n")
+		}
+		unfolded.WriteString(strings.TrimPrefix(line, " "))
+	}
+
+	if !strings.Contains(unfolded.String(), "SUMMARY:"+summary) {
+		t.Errorf("unfolding did not restore the summary:\n%s", unfolded.String())
+	}
+}
+
+// already issued feeds and attachments carry these, so they must not change
+func TestUIDs(t *testing.T) {
+	if got := BookingUID(42); got != "booking-42@ticket-booking-system" {
+		t.Errorf("BookingUID(42) = %q", got)
+	}
+	if got := EventUID(7); got != "event-7@ticket-booking-system" {
+		t.Errorf("EventUID(7) = %q", got)
+	}
+}
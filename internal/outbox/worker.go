@@ -1,7 +1,8 @@
-package worker
+package outbox
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -13,23 +14,29 @@ type ChannelProvider interface {
 	GetChannel() (*amqp.Channel, error)
 }
 
-type OutboxWorker struct {
+// Worker relays rows of a service's outbox table (e.g. booking.outbox_messages)
+// to RabbitMQ. The keys of a row's payload listed in its secret_keys, such as
+// a token meant for the recipient of a notification only, are dropped once
+// the row has been published.
+type Worker struct {
 	db       *pgxpool.Pool
+	table    string
 	provider ChannelProvider
 	logger   *slog.Logger
 	ticker   *time.Ticker
 }
 
-func NewOutboxWorker(db *pgxpool.Pool, provider ChannelProvider, logger *slog.Logger, interval time.Duration) *OutboxWorker {
-	return &OutboxWorker{
+func NewWorker(db *pgxpool.Pool, table string, provider ChannelProvider, logger *slog.Logger, interval time.Duration) *Worker {
+	return &Worker{
 		db:       db,
+		table:    table,
 		provider: provider,
 		logger:   logger,
 		ticker:   time.NewTicker(interval),
 	}
 }
 
-func (w *OutboxWorker) Start(ctx context.Context) {
+func (w *Worker) Start(ctx context.Context) {
 	w.logger.Info("Starting Outbox Worker")
 	for {
 		select {
@@ -43,9 +50,9 @@ func (w *OutboxWorker) Start(ctx context.Context) {
 	}
 }
 
-func (w *OutboxWorker) processOutboxMessages(ctx context.Context) {
-	const op = "worker.processOutboxMessages"
-	log := w.logger.With(slog.String("op", op))
+func (w *Worker) processOutboxMessages(ctx context.Context) {
+	const op = "outbox.processOutboxMessages"
+	log := w.logger.With(slog.String("op", op), slog.String("table", w.table))
 
 	ch, err := w.provider.GetChannel()
 	if err != nil {
@@ -69,13 +76,13 @@ func (w *OutboxWorker) processOutboxMessages(ctx context.Context) {
 	defer tx.Rollback(ctx)
 
 	rows, err := tx.Query(
-		ctx, `
-		SELECT id, exchange, routing_key, payload FROM booking.outbox_messages
+		ctx, fmt.Sprintf(`
+		SELECT id, exchange, routing_key, payload FROM %s
 		WHERE processed_at IS NULL
 		ORDER BY created_at
 		LIMIT 10
 		FOR UPDATE SKIP LOCKED 
-		`,
+		`, w.table),
 	)
 	if err != nil {
 		log.Error("Failed to query outbox messages", "error", err)
@@ -138,7 +145,7 @@ func (w *OutboxWorker) processOutboxMessages(ctx context.Context) {
 	if len(successfulMessageIDs) > 0 {
 		_, err := tx.Exec(
 			ctx,
-			"UPDATE booking.outbox_messages SET processed_at = NOW() WHERE id = ANY($1)",
+			fmt.Sprintf("UPDATE %s SET processed_at = NOW(), payload = payload - secret_keys WHERE id = ANY($1)", w.table),
 			successfulMessageIDs,
 		)
 		if err != nil {
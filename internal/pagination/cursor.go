@@ -0,0 +1,37 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// Cursor points at the last row of a page ordered by (created_at DESC, id DESC).
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        int64     `json:"i"`
+}
+
+func EncodeCursor(c Cursor) string {
+	// marshalling a struct of a time and an int can not fail
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(token string) (Cursor, error) {
+	var c Cursor
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, ErrInvalidPageToken
+	}
+
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID <= 0 || c.CreatedAt.IsZero() {
+		return c, ErrInvalidPageToken
+	}
+
+	return c, nil
+}
@@ -0,0 +1,24 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	c := Cursor{CreatedAt: time.Date(2025, 7, 1, 12, 30, 0, 123456000, time.UTC), ID: 42}
+
+	decoded, err := DecodeCursor(EncodeCursor(c))
+	require.NoError(t, err)
+	require.True(t, c.CreatedAt.Equal(decoded.CreatedAt), "created_at should survive the round trip")
+	require.Equal(t, c.ID, decoded.ID)
+}
+
+func TestDecodeCursorRejectsGarbage(t *testing.T) {
+	for _, token := range []string{"", "not base64!", "e30", EncodeCursor(Cursor{ID: 1})} {
+		_, err := DecodeCursor(token)
+		require.ErrorIs(t, err, ErrInvalidPageToken, "token %q should be rejected", token)
+	}
+}
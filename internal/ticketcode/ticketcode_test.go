@@ -0,0 +1,32 @@
+package ticketcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicketCodeRoundTrip(t *testing.T) {
+	secret := []byte("secret")
+	code := Code{BookingID: 7, Version: 3}
+
+	verified, err := Verify(secret, Sign(secret, code))
+	require.NoError(t, err)
+	require.Equal(t, code, verified)
+}
+
+func TestVerifyRejectsForgedCodes(t *testing.T) {
+	token := Sign([]byte("secret"), Code{BookingID: 7, Version: 1})
+	other := Sign([]byte("secret"), Code{BookingID: 7, Version: 2})
+	payload, _, _ := strings.Cut(token, ".")
+	_, sig, _ := strings.Cut(other, ".")
+
+	for _, forged := range []string{"", "garbage", token + "x", payload + "." + sig} {
+		_, err := Verify([]byte("secret"), forged)
+		require.ErrorIs(t, err, ErrInvalidCode, "code %q should be rejected", forged)
+	}
+
+	_, err := Verify([]byte("another secret"), token)
+	require.ErrorIs(t, err, ErrInvalidCode, "a code signed with another secret should be rejected")
+}
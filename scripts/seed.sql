@@ -2,14 +2,15 @@ TRUNCATE TABLE auth.users RESTART IDENTITY CASCADE;
 TRUNCATE TABLE booking.bookings RESTART IDENTITY CASCADE;
 TRUNCATE TABLE event.events RESTART IDENTITY CASCADE;
 TRUNCATE TABLE event.seats RESTART IDENTITY CASCADE;
+TRUNCATE TABLE event.price_tiers RESTART IDENTITY CASCADE;
 
-INSERT INTO auth.users (email, password_hash) VALUES 
-('user@example.com', '$2a$10$8TCbWfBDTxXcuQxputWNwO.shYCNWKMcgMDhAnLDhmJ0Pronahw9W'),
-('admin@example.com', '$2a$10$wje9HxGHD/qTZFN/LVZ8h.HBfeABrWGrLBxrSnqRN9mlFgJdKPanK');
+INSERT INTO auth.users (email, password_hash, role) VALUES 
+('user@example.com', '$2a$10$8TCbWfBDTxXcuQxputWNwO.shYCNWKMcgMDhAnLDhmJ0Pronahw9W', 'USER'),
+('admin@example.com', '$2a$10$wje9HxGHD/qTZFN/LVZ8h.HBfeABrWGrLBxrSnqRN9mlFgJdKPanK', 'ADMIN');
 
-INSERT INTO event.events (title, description) VALUES
-('Shrek', 'A mean lord exiles fairytale creatures to the swamp of a grumpy ogre, who must go on a quest and rescue a princess for the lord in order to get his land back.'),
-('Agil', 'An angelically patient person welcomes a group of dysfunctional friends into their life, who then embark on a quest to test every last one of his boundaries for their own amusement and personal gain.');
+INSERT INTO event.events (title, description, status) VALUES
+('Shrek', 'A mean lord exiles fairytale creatures to the swamp of a grumpy ogre, who must go on a quest and rescue a princess for the lord in order to get his land back.', 'ON_SALE'),
+('Agil', 'An angelically patient person welcomes a group of dysfunctional friends into their life, who then embark on a quest to test every last one of his boundaries for their own amusement and personal gain.', 'ON_SALE');
 
 INSERT INTO event.seats (event_id, seat_number, row_number, sector, status) 
 SELECT
@@ -29,4 +30,17 @@ SELECT
 	'AVAILABLE' AS status
 FROM generate_series(1, 10) AS s(i);
 
+-- цены в копейках: первый ряд Shrek дороже остальных
+INSERT INTO event.price_tiers (event_id, name, amount, currency) VALUES
+(1, 'Standard', 150000, 'RUB'),
+(1, 'VIP', 300000, 'RUB'),
+(2, 'Standard', 120000, 'RUB');
+
+UPDATE event.seats SET price_tier_id = t.id
+FROM event.price_tiers t
+WHERE t.event_id = seats.event_id AND t.name = 'Standard';
+
+UPDATE event.seats SET price_tier_id = (SELECT id FROM event.price_tiers WHERE event_id = 1 AND name = 'VIP')
+WHERE event_id = 1 AND seat_number IN ('A1', 'A2', 'A3');
+
 SELECT setval('event.events_id_seq', (SELECT MAX(id) FROM event.events));
\ No newline at end of file
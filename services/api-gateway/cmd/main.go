@@ -55,6 +55,14 @@ func main() {
         logger.Error("PAYMENT_WEBHOOK_SECRET environmental variable is not set")
         os.Exit(1)
     }
+    if cfg.WaitingRoomSecret == "" {
+        logger.Error("WAITING_ROOM_SECRET environmental variable is not set")
+        os.Exit(1)
+    }
+    if cfg.TicketSecret == "" {
+        logger.Error("TICKET_SECRET environmental variable is not set")
+        os.Exit(1)
+    }
 
 	// ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
 	// defer cancel()
@@ -132,13 +140,65 @@ func main() {
 		}
 	}()
 
-	h := handler.New(authClient, bookingClient, eventClient, cfg.PaymentWebhookSecret, logger)
+	h := handler.New(authClient, bookingClient, eventClient, cfg.PaymentWebhookSecret, cfg.CalendarFeedSecret, cfg.WaitingRoomSecret, cfg.TicketSecret, logger)
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("POST /api/v1/register", h.Register)
 	mux.HandleFunc("POST /api/v1/login", h.Login)
 	mux.HandleFunc("GET /api/v1/events", h.ListEvents)
 	mux.HandleFunc("POST /api/v1/bookings", h.CreateBooking)
+	mux.HandleFunc("GET /api/v1/bookings", h.ListBookings)
+	mux.HandleFunc("GET /api/v1/bookings/{id}", h.GetBooking)
+	mux.HandleFunc("POST /api/v1/bookings/{id}/cancel", h.CancelBooking)
+	mux.HandleFunc("POST /api/v1/bookings/{id}/extend-hold", h.ExtendHold)
+	mux.HandleFunc("POST /api/v1/events/import", h.ImportEvents)
+	mux.HandleFunc("POST /api/v1/events/{id}/cancel", h.CancelEvent)
+	mux.HandleFunc("GET /api/v1/events/{id}/cancellation", h.GetEventCancellation)
+	mux.HandleFunc("GET /api/v1/events/{id}/prices", h.ListPriceTiers)
+	mux.HandleFunc("PUT /api/v1/events/{id}/prices", h.SetPriceTier)
+	mux.HandleFunc("GET /api/v1/events/{id}/refund-policy", h.GetRefundPolicy)
+	mux.HandleFunc("PUT /api/v1/events/{id}/refund-policy", h.SetRefundPolicy)
+	mux.HandleFunc("GET /api/v1/events/{id}/purchase-limits", h.GetPurchaseLimits)
+	mux.HandleFunc("PUT /api/v1/events/{id}/purchase-limits", h.SetPurchaseLimits)
+	mux.HandleFunc("PUT /api/v1/events/{id}/hold-policy", h.SetHoldPolicy)
+	mux.HandleFunc("GET /api/v1/events/{id}/transfer-policy", h.GetTransferPolicy)
+	mux.HandleFunc("PUT /api/v1/events/{id}/transfer-policy", h.SetTransferPolicy)
+	mux.HandleFunc("PUT /api/v1/events/{id}/waiting-room", h.SetWaitingRoom)
+	mux.HandleFunc("POST /api/v1/events/{id}/waiting-room/join", h.JoinWaitingRoom)
+	mux.HandleFunc("GET /api/v1/events/{id}/waiting-room", h.GetWaitingRoomStatus)
+	mux.HandleFunc("POST /api/v1/events/{id}/waitlist", h.JoinWaitlist)
+	mux.HandleFunc("GET /api/v1/events/{id}/waitlist", h.GetWaitlistEntry)
+	mux.HandleFunc("DELETE /api/v1/events/{id}/waitlist", h.LeaveWaitlist)
+	mux.HandleFunc("POST /api/v1/waitlist/claim", h.ClaimWaitlistOffer)
+	mux.HandleFunc("PUT /api/v1/promo-codes/{code}", h.SetPromoCode)
+	mux.HandleFunc("GET /api/v1/promo-codes/{code}", h.GetPromoCode)
+	mux.HandleFunc("POST /api/v1/bookings/{id}/transfers", h.StartTransfer)
+	mux.HandleFunc("GET /api/v1/bookings/{id}/ownership", h.GetOwnershipHistory)
+	mux.HandleFunc("DELETE /api/v1/transfers/{id}", h.CancelTransfer)
+	mux.HandleFunc("POST /api/v1/transfers/accept", h.AcceptTransfer)
+	mux.HandleFunc("POST /api/v1/bookings/{id}/seats/cancel", h.CancelSeats)
+	mux.HandleFunc("POST /api/v1/bookings/{id}/seats/exchange", h.ExchangeSeats)
+	mux.HandleFunc("GET /api/v1/bookings/{id}/seat-changes", h.ListSeatChanges)
+	mux.HandleFunc("GET /api/v1/bookings/{id}/status-history", h.GetBookingStatusHistory)
+	mux.HandleFunc("POST /api/v1/events/{id}/check-ins", h.CheckInBooking)
+	mux.HandleFunc("POST /api/v1/orders", h.CreateOrder)
+	mux.HandleFunc("GET /api/v1/orders/{id}", h.GetOrder)
+	mux.HandleFunc("POST /api/v1/orders/{id}/cancel", h.CancelOrder)
+	mux.HandleFunc("GET /api/v1/events/{id}/pools", h.ListInventoryPools)
+	mux.HandleFunc("PUT /api/v1/events/{id}/pools", h.SetInventoryPool)
+	mux.HandleFunc("GET /api/v1/events/{id}/sectors", h.ListSectorScores)
+	mux.HandleFunc("PUT /api/v1/events/{id}/sectors", h.SetSectorScore)
+	mux.HandleFunc("PUT /api/v1/events/{id}/translations/{locale}", h.SetEventTranslation)
+	mux.HandleFunc("DELETE /api/v1/events/{id}/translations/{locale}", h.DeleteEventTranslation)
+	mux.HandleFunc("GET /api/v1/translations/status", h.GetTranslationStatus)
+	mux.HandleFunc("GET /api/v1/categories", h.ListCategories)
+	mux.HandleFunc("GET /api/v1/collections/{slug}", h.GetCollection)
+	mux.HandleFunc("POST /api/v1/events/{id}/images", h.UploadEventImage)
+	mux.HandleFunc("DELETE /api/v1/events/{id}/images/{image_id}", h.DeleteEventImage)
+	mux.Handle("GET /media/", http.StripPrefix("/media", handler.MediaFiles(cfg.MediaStoragePath)))
+	mux.HandleFunc("GET /api/v1/calendar/events.ics", h.EventsCalendar)
+	mux.HandleFunc("GET /api/v1/calendar/feed-url", h.CalendarFeedURL)
+	mux.HandleFunc("GET /api/v1/calendar/users/{user_id}/bookings.ics", h.UserCalendar)
 	mux.HandleFunc("POST /api/v1/payments/webhook", h.PaymentWebhook)
 	// mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 	// 	if err := dbPool.Ping(r.Context()); err != nil {
@@ -153,7 +213,6 @@ func main() {
 
 	var handlerWithMiddleware http.Handler = mux
 	handlerWithMiddleware = otelhttp.NewHandler(handlerWithMiddleware, "http.server")
-	// handlerWithMiddleware = apimiddleware.Idempotency(handlerWithMiddleware)
 	handlerWithMiddleware = apimiddleware.Metrics(handlerWithMiddleware)
 	handlerWithMiddleware = apimiddleware.RequestID(handlerWithMiddleware)
 
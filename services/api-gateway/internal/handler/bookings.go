@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	bookingv1 "github.com/kay-kewl/ticket-booking-system/gen/go/booking"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetBooking returns a booking of the caller; other users' bookings are not
+// found.
+func (h *Handler) GetBooking(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.GetBooking"
+
+	log := h.logger.With(slog.String("op", op))
+
+	bookingID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || bookingID < 1 {
+		http.Error(w, "invalid booking id", http.StatusBadRequest)
+		return
+	}
+
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	booking, err := h.bookingClient.GetBooking(r.Context(), &bookingv1.GetBookingRequest{
+		BookingId: bookingID,
+		UserId:    userID,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			http.Error(w, "booking not found", http.StatusNotFound)
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "bookingID", bookingID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(booking)
+}
+
+// ListBookings returns the caller's bookings newest first, ?size= at a time,
+// optionally only those in ?status=. The next page is asked for with
+// ?page_token=.
+func (h *Handler) ListBookings(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.ListBookings"
+
+	log := h.logger.With(slog.String("op", op))
+
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var size int
+	if sizeStr := r.URL.Query().Get("size"); sizeStr != "" {
+		var err error
+		size, err = strconv.Atoi(sizeStr)
+		if err != nil || size < 1 {
+			http.Error(w, "Invalid size parameter. Must be a positive integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	bookings, err := h.bookingClient.ListUserBookings(r.Context(), &bookingv1.ListUserBookingsRequest{
+		UserId:    userID,
+		PageSize:  int32(size),
+		PageToken: r.URL.Query().Get("page_token"),
+		Status:    r.URL.Query().Get("status"),
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.InvalidArgument {
+			http.Error(w, st.Message(), http.StatusBadRequest)
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "userID", userID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bookings)
+}
+
+// CancelBooking cancels a booking of the caller. Paid bookings are refunded
+// according to the refund policy of the event.
+func (h *Handler) CancelBooking(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.CancelBooking"
+
+	log := h.logger.With(slog.String("op", op))
+
+	bookingID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || bookingID < 1 {
+		http.Error(w, "invalid booking id", http.StatusBadRequest)
+		return
+	}
+
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.bookingClient.CancelBooking(r.Context(), &bookingv1.CancelBookingRequest{
+		BookingId: bookingID,
+		UserId:    userID,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.NotFound:
+				http.Error(w, "booking not found", http.StatusNotFound)
+				return
+			case codes.FailedPrecondition:
+				http.Error(w, st.Message(), http.StatusConflict)
+				return
+			case codes.Unavailable:
+				http.Error(w, st.Message(), http.StatusServiceUnavailable)
+				return
+			}
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "bookingID", bookingID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// ExtendHold gives the caller more time to pay for a pending booking.
+func (h *Handler) ExtendHold(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.ExtendHold"
+
+	log := h.logger.With(slog.String("op", op))
+
+	bookingID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || bookingID < 1 {
+		http.Error(w, "invalid booking id", http.StatusBadRequest)
+		return
+	}
+
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.bookingClient.ExtendHold(r.Context(), &bookingv1.ExtendHoldRequest{
+		BookingId: bookingID,
+		UserId:    userID,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.NotFound:
+				http.Error(w, "booking not found", http.StatusNotFound)
+				return
+			case codes.FailedPrecondition:
+				http.Error(w, st.Message(), http.StatusConflict)
+				return
+			}
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "bookingID", bookingID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	bookingv1 "github.com/kay-kewl/ticket-booking-system/gen/go/booking"
+	"github.com/kay-kewl/ticket-booking-system/internal/ticketcode"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TicketCode is the code printed on the ticket, see internal/ticketcode.
+type CheckInBookingRequest struct {
+	TicketCode string `json:"ticket_code" validate:"required"`
+}
+
+// GetBookingStatusHistory lists the statuses a booking of the user went
+// through, with who changed them and why.
+func (h *Handler) GetBookingStatusHistory(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.GetBookingStatusHistory"
+
+	log := h.logger.With(slog.String("op", op))
+
+	bookingID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || bookingID < 1 {
+		http.Error(w, "invalid booking id", http.StatusBadRequest)
+		return
+	}
+
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	history, err := h.bookingClient.GetBookingStatusHistory(r.Context(), &bookingv1.GetBookingStatusHistoryRequest{
+		BookingId: bookingID,
+		UserId:    userID,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			http.Error(w, st.Message(), http.StatusNotFound)
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "bookingID", bookingID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(history)
+}
+
+// CheckInBooking lets the holder of a paid booking into the event on the code
+// of their ticket. Only an admin or the organizer of the event can check
+// bookings in.
+func (h *Handler) CheckInBooking(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.CheckInBooking"
+
+	log := h.logger.With(slog.String("op", op))
+	r.Body = http.MaxBytesReader(w, r.Body, 1024*1024)
+
+	eventID, staffID, ok := h.authorizeEventStaff(w, r)
+	if !ok {
+		return
+	}
+
+	var req CheckInBookingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	code, err := ticketcode.Verify(h.ticketSecret, req.TicketCode)
+	if err != nil {
+		http.Error(w, "invalid ticket code", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.bookingClient.CheckInBooking(r.Context(), &bookingv1.CheckInBookingRequest{
+		EventId:       eventID,
+		BookingId:     code.BookingID,
+		StaffId:       staffID,
+		TicketVersion: code.Version,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.NotFound:
+				http.Error(w, st.Message(), http.StatusNotFound)
+				return
+			case codes.FailedPrecondition:
+				http.Error(w, st.Message(), http.StatusConflict)
+				return
+			}
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "eventID", eventID, "bookingID", code.BookingID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// catalogCacheControl lets clients and proxies reuse catalog responses for a
+// short while and revalidate them with the ETag afterwards.
+const catalogCacheControl = "public, max-age=30"
+
+// writeCacheableJSON writes a public catalog response with validators and
+// answers conditional requests for an unchanged body with 304. A zero
+// lastModified leaves out Last-Modified.
+func writeCacheableJSON(w http.ResponseWriter, r *http.Request, v any, lastModified time.Time) {
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(v); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha256.Sum256(body.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", catalogCacheControl)
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(r, etag, lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body.Bytes())
+}
+
+// notModified evaluates If-None-Match and, only when it is absent,
+// If-Modified-Since (RFC 9110, section 13.2.2).
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if header := r.Header.Get("If-None-Match"); header != "" {
+		for _, candidate := range strings.Split(header, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if header := r.Header.Get("If-Modified-Since"); header != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(header)
+		if err != nil {
+			return false
+		}
+		// Last-Modified has a one-second resolution
+		return !lastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
+
+func parseLastModified(value string) time.Time {
+	lastModified, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return lastModified
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	bookingv1 "github.com/kay-kewl/ticket-booking-system/gen/go/booking"
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+	"github.com/kay-kewl/ticket-booking-system/internal/ical"
+)
+
+// EventsCalendar serves the published events of an organizer or a category
+// as an iCalendar feed.
+func (h *Handler) EventsCalendar(w http.ResponseWriter, r *http.Request) {
+	log := h.logger.With(slog.String("op", "handler.EventsCalendar"))
+
+	query := r.URL.Query()
+	var organizerID int64
+	if value := query.Get("organizer_id"); value != "" {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || id <= 0 {
+			http.Error(w, "invalid organizer_id", http.StatusBadRequest)
+			return
+		}
+		organizerID = id
+	}
+
+	feed, err := h.eventClient.GetEventsCalendar(r.Context(), &eventv1.GetEventsCalendarRequest{
+		OrganizerId: organizerID,
+		Category:    query.Get("category"),
+	})
+	if err != nil {
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	writeCalendar(w, "events.ics", feed.GetContent())
+}
+
+// CalendarFeedURL returns the private URL of the caller's bookings feed.
+// Anyone who has the URL can read the feed, so it is only handed out to the
+// authenticated owner.
+func (h *Handler) CalendarFeedURL(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	if len(h.calendarSecret) == 0 {
+		http.Error(w, "calendar feeds are not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	url := fmt.Sprintf("%s://%s/api/v1/calendar/users/%d/bookings.ics?token=%s", scheme, r.Host, userID, h.calendarToken(userID))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"url": url})
+}
+
+// UserCalendar serves the confirmed bookings of a user as an iCalendar feed.
+// Calendar apps cannot send an Authorization header, so the feed is
+// authorized by the signed token in its URL instead.
+func (h *Handler) UserCalendar(w http.ResponseWriter, r *http.Request) {
+	log := h.logger.With(slog.String("op", "handler.UserCalendar"))
+
+	userID, err := strconv.ParseInt(r.PathValue("user_id"), 10, 64)
+	if err != nil || userID <= 0 {
+		http.Error(w, "invalid user id", http.StatusBadRequest)
+		return
+	}
+
+	if len(h.calendarSecret) == 0 {
+		http.Error(w, "calendar feeds are not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	token := r.URL.Query().Get("token")
+	if !hmac.Equal([]byte(token), []byte(h.calendarToken(userID))) {
+		http.Error(w, "invalid calendar token", http.StatusForbidden)
+		return
+	}
+
+	feed, err := h.bookingClient.GetUserCalendar(r.Context(), &bookingv1.GetUserCalendarRequest{UserId: userID})
+	if err != nil {
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "user_id", userID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	writeCalendar(w, "bookings.ics", feed.GetContent())
+}
+
+// calendarToken signs the user id; rotating the secret revokes every feed URL.
+func (h *Handler) calendarToken(userID int64) string {
+	mac := hmac.New(sha256.New, h.calendarSecret)
+	fmt.Fprintf(mac, "calendar:%d", userID)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func writeCalendar(w http.ResponseWriter, filename, content string) {
+	w.Header().Set("Content-Type", ical.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(content))
+}
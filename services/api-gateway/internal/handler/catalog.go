@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListCategories returns the category tree used for catalog navigation.
+func (h *Handler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	log := h.logger.With(slog.String("op", "handler.ListCategories"))
+
+	grpcResp, err := h.eventClient.ListCategories(r.Context(), &eventv1.ListCategoriesRequest{})
+	if err != nil {
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	writeCacheableJSON(w, r, grpcResp, time.Time{})
+}
+
+// GetCollection returns an editorial collection with its events in curated order.
+func (h *Handler) GetCollection(w http.ResponseWriter, r *http.Request) {
+	log := h.logger.With(slog.String("op", "handler.GetCollection"))
+
+	slug := r.PathValue("slug")
+
+	collection, err := h.eventClient.GetCollection(r.Context(), &eventv1.GetCollectionRequest{Slug: slug})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				http.Error(w, st.Message(), http.StatusBadRequest)
+				return
+			case codes.NotFound:
+				http.Error(w, "collection not found", http.StatusNotFound)
+				return
+			}
+		}
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "slug", slug, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// membership changes of a collection are not timestamped, so it is
+	// validated by the ETag alone
+	writeCacheableJSON(w, r, collection, time.Time{})
+}
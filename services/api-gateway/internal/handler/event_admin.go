@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	bookingv1 "github.com/kay-kewl/ticket-booking-system/gen/go/booking"
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	roleOrganizer = "ORGANIZER"
+	roleAdmin     = "ADMIN"
+)
+
+type CancelEventRequest struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+// CancelEvent marks the event cancelled. The refunds run asynchronously in
+// booking-service, their progress is served by GetEventCancellation.
+func (h *Handler) CancelEvent(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.CancelEvent"
+
+	log := h.logger.With(slog.String("op", op))
+	r.Body = http.MaxBytesReader(w, r.Body, 1024*1024)
+
+	eventID, ok := h.authorizeEventManagement(w, r)
+	if !ok {
+		return
+	}
+
+	var req CancelEventRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	event, err := h.eventClient.CancelEvent(r.Context(), &eventv1.CancelEventRequest{
+		EventId: eventID,
+		Reason:  req.Reason,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.NotFound:
+				http.Error(w, "event not found", http.StatusNotFound)
+				return
+			case codes.FailedPrecondition:
+				http.Error(w, st.Message(), http.StatusConflict)
+				return
+			}
+		}
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	log.InfoContext(r.Context(), "Event cancelled", "eventID", eventID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(event)
+}
+
+func (h *Handler) GetEventCancellation(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.GetEventCancellation"
+
+	log := h.logger.With(slog.String("op", op))
+
+	eventID, ok := h.authorizeEventManagement(w, r)
+	if !ok {
+		return
+	}
+
+	job, err := h.bookingClient.GetEventCancellationJob(r.Context(), &bookingv1.GetEventCancellationJobRequest{EventId: eventID})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			http.Error(w, st.Message(), http.StatusNotFound)
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(job)
+}
+
+// authorizeEventManagement lets admins manage any event and organizers only
+// their own. It returns the event id from the path and has already written the
+// error response when ok is false.
+func (h *Handler) authorizeEventManagement(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	eventID, _, ok := h.authorizeEventStaff(w, r)
+	return eventID, ok
+}
+
+// authorizeEventStaff is authorizeEventManagement that also returns the id of
+// the admin or organizer acting on the event.
+func (h *Handler) authorizeEventStaff(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	eventID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || eventID < 1 {
+		http.Error(w, "invalid event id", http.StatusBadRequest)
+		return 0, 0, false
+	}
+
+	userID, role, ok := h.authenticate(w, r)
+	if !ok {
+		return 0, 0, false
+	}
+
+	switch role {
+	case roleAdmin:
+		return eventID, userID, true
+	case roleOrganizer:
+		event, err := h.eventClient.GetEvent(r.Context(), &eventv1.GetEventRequest{EventId: eventID})
+		if err != nil {
+			if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+				http.Error(w, "event not found", http.StatusNotFound)
+				return 0, 0, false
+			}
+			h.logger.ErrorContext(r.Context(), "gRPC call to event-service failed", "eventID", eventID, "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return 0, 0, false
+		}
+		if event.GetOrganizerId() == userID {
+			return eventID, userID, true
+		}
+	}
+
+	h.logger.WarnContext(r.Context(), "Forbidden attempt to manage an event", "userID", userID, "role", role, "eventID", eventID)
+	http.Error(w, "forbidden", http.StatusForbidden)
+	return 0, 0, false
+}
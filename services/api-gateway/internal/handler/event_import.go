@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// matches the limit of event-service
+const maxImportSize = 5 << 20
+
+// ImportEvents takes a CSV (text/csv) or JSON (application/json) file of
+// events and seats. With ?dry_run=true nothing is saved; a file with errors
+// is answered with 422 and the errors by line.
+func (h *Handler) ImportEvents(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.ImportEvents"
+
+	log := h.logger.With(slog.String("op", op))
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	userID, role, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var organizerID int64
+	switch role {
+	case roleAdmin:
+	case roleOrganizer:
+		// organizers import into their own events only
+		organizerID = userID
+	default:
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		switch mediaType {
+		case "text/csv":
+			format = "csv"
+		case "application/json":
+			format = "json"
+		}
+	}
+	if format == "" {
+		http.Error(w, "Content-Type must be text/csv or application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	dryRun, err := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+	if err != nil && r.URL.Query().Has("dry_run") {
+		http.Error(w, "dry_run must be true or false", http.StatusBadRequest)
+		return
+	}
+
+	content, err := io.ReadAll(r.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "file is too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.eventClient.ImportEvents(r.Context(), &eventv1.ImportEventsRequest{
+		Format:      format,
+		Content:     content,
+		DryRun:      dryRun,
+		OrganizerId: organizerID,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.InvalidArgument {
+			http.Error(w, st.Message(), http.StatusBadRequest)
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	log.InfoContext(r.Context(), "Events imported", "userID", userID, "applied", result.GetApplied(), "errors", len(result.GetErrors()))
+
+	code := http.StatusOK
+	if len(result.GetErrors()) > 0 {
+		code = http.StatusUnprocessableEntity
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(result)
+}
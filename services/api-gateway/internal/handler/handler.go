@@ -17,6 +17,7 @@ import (
 	authv1 "github.com/kay-kewl/ticket-booking-system/gen/go/auth"
 	bookingv1 "github.com/kay-kewl/ticket-booking-system/gen/go/booking"
 	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+	"github.com/kay-kewl/ticket-booking-system/internal/cache"
 
 	"github.com/go-playground/validator/v10"
 	"google.golang.org/grpc/codes"
@@ -31,15 +32,24 @@ type Handler struct {
 	eventClient   eventv1.EventServiceClient
 	logger        *slog.Logger
 	webhookSecret []byte
+	calendarSecret []byte
+	waitingRoomSecret []byte
+	ticketSecret []byte
+	// whether an event is behind a waiting room
+	waitingRooms  *cache.LRU[int64, bool]
 }
 
-func New(authClient authv1.AuthClient, bookingClient bookingv1.BookingServiceClient, eventClient eventv1.EventServiceClient, webhookSecret string, logger *slog.Logger) *Handler {
+func New(authClient authv1.AuthClient, bookingClient bookingv1.BookingServiceClient, eventClient eventv1.EventServiceClient, webhookSecret, calendarSecret, waitingRoomSecret, ticketSecret string, logger *slog.Logger) *Handler {
 	return &Handler{
 		authClient:    authClient,
 		bookingClient: bookingClient,
 		eventClient:   eventClient,
 		logger:        logger,
 		webhookSecret: []byte(webhookSecret),
+		calendarSecret: []byte(calendarSecret),
+		waitingRoomSecret: []byte(waitingRoomSecret),
+		ticketSecret: []byte(ticketSecret),
+		waitingRooms:  cache.NewLRU[int64, bool](waitingRoomCacheSize, waitingRoomCacheTTL),
 	}
 }
 
@@ -160,9 +170,25 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
     }
 }
 
+// CreateBookingRequest books reserved seats, general-admission tickets
+// (pool_id with quantity) or both.
 type CreateBookingRequest struct {
-	EventID int64   `json:"event_id" validate:"required"`
-	SeatIDs []int64 `json:"seat_ids" validate:"required"`
+	EventID       int64                 `json:"event_id" validate:"required"`
+	SeatIDs       []int64               `json:"seat_ids" validate:"required_without_all=PoolID BestAvailable,excluded_with=BestAvailable"`
+	PoolID        int64                 `json:"pool_id" validate:"required_with=Quantity"`
+	Quantity      int32                 `json:"quantity" validate:"gte=0,required_with=PoolID"`
+	BestAvailable *BestAvailableRequest `json:"best_available"`
+	PromoCode     string                `json:"promo_code" validate:"max=50"`
+	// stacked on promo_code
+	AdditionalPromoCodes []string `json:"additional_promo_codes" validate:"max=2,dive,max=50"`
+}
+
+// BestAvailableRequest lets the service pick quantity seats next to each
+// other instead of listing them in seat_ids.
+type BestAvailableRequest struct {
+	Quantity int32  `json:"quantity" validate:"required,min=1,max=10"`
+	Sector   string `json:"sector" validate:"max=50"`
+	MaxPrice int64  `json:"max_price" validate:"gte=0"`
 }
 
 func (h *Handler) CreateBooking(w http.ResponseWriter, r *http.Request) {
@@ -171,28 +197,10 @@ func (h *Handler) CreateBooking(w http.ResponseWriter, r *http.Request) {
 	log := h.logger.With(slog.String("op", op))
 	r.Body = http.MaxBytesReader(w, r.Body, 1024*1024)
 
-	authHeader := r.Header.Get("Authorization")
-	if authHeader == "" {
-		http.Error(w, "missing authorization header", http.StatusUnauthorized)
-		return
-	}
-
-	headerParts := strings.Split(authHeader, " ")
-
-	if len(headerParts) != 2 || headerParts[0] != "Bearer" {
-		http.Error(w, "invalid authorization header", http.StatusUnauthorized)
-		return
-	}
-
-	token := headerParts[1]
-
-	validateResp, err := h.authClient.ValidateToken(r.Context(), &authv1.ValidateTokenRequest{Token: token})
-	if err != nil {
-		log.ErrorContext(r.Context(), "Token validation failed", "error", err)
-		http.Error(w, "invalid token", http.StatusUnauthorized)
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
 		return
 	}
-	userID := validateResp.GetUserId()
 	log.Info("Token validated successfully", slog.Int64("userID", userID))
 
 	var req CreateBookingRequest
@@ -207,11 +215,36 @@ func (h *Handler) CreateBooking(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	bookingResp, err := h.bookingClient.CreateBooking(r.Context(), &bookingv1.CreateBookingRequest{
+	if !h.requireAdmission(w, r, req.EventID, userID) {
+		return
+	}
+
+	// a retried request with the same key gets the original booking back
+	idempotencyKey := r.Header.Get("Idempotency-Key")
+	if len(idempotencyKey) > 255 {
+		http.Error(w, "Idempotency-Key must be at most 255 characters", http.StatusBadRequest)
+		return
+	}
+
+	grpcReq := &bookingv1.CreateBookingRequest{
 		UserId:  userID,
-		EventId: req.EventID,
-		SeatIds: req.SeatIDs,
-	})
+		EventId:  req.EventID,
+		SeatIds:  req.SeatIDs,
+		PoolId:   req.PoolID,
+		Quantity: req.Quantity,
+		IdempotencyKey: idempotencyKey,
+		PromoCode: req.PromoCode,
+		AdditionalPromoCodes: req.AdditionalPromoCodes,
+	}
+	if best := req.BestAvailable; best != nil {
+		grpcReq.BestAvailable = &bookingv1.BestAvailable{
+			Quantity: best.Quantity,
+			Sector:   best.Sector,
+			MaxPrice: best.MaxPrice,
+		}
+	}
+
+	bookingResp, err := h.bookingClient.CreateBooking(r.Context(), grpcReq)
 
 	if err != nil {
 		if st, ok := status.FromError(err); ok {
@@ -222,9 +255,43 @@ func (h *Handler) CreateBooking(w http.ResponseWriter, r *http.Request) {
 					http.Error(w, "Payment failed", http.StatusConflict)
 					return
 				}
+				if strings.Contains(st.Message(), "not enough tickets") {
+					log.WarnContext(r.Context(), "Not enough general-admission tickets left", "userID", userID, "poolID", req.PoolID, "quantity", req.Quantity)
+					http.Error(w, "not enough tickets left", http.StatusConflict)
+					return
+				}
+				if strings.Contains(st.Message(), "no price") {
+					log.WarnContext(r.Context(), "Attempt to book seats without a price", "userID", userID, "seats", req.SeatIDs)
+					http.Error(w, "some seats are not for sale", http.StatusConflict)
+					return
+				}
+				if strings.Contains(st.Message(), "not on sale") {
+					log.WarnContext(r.Context(), "Attempt to book an event that is not on sale", "userID", userID, "eventID", req.EventID)
+					http.Error(w, "event is not on sale", http.StatusConflict)
+					return
+				}
+				if strings.Contains(st.Message(), "no available seats together") {
+					http.Error(w, "no available seats together", http.StatusConflict)
+					return
+				}
+				if promoCodeRejected(st) {
+					log.WarnContext(r.Context(), "Promo code refused", "userID", userID, "eventID", req.EventID, "error", st.Message())
+					http.Error(w, st.Message(), http.StatusUnprocessableEntity)
+					return
+				}
 				log.WarnContext(r.Context(), "Attempt to book reserved seats", "userID", userID, "seats", req.SeatIDs, "error", st.Message())
 				http.Error(w, "booked seats have already been reserved", http.StatusConflict)
 				return
+			case codes.InvalidArgument:
+				http.Error(w, st.Message(), http.StatusBadRequest)
+				return
+			case codes.AlreadyExists:
+				http.Error(w, "Idempotency-Key was already used for a different request", http.StatusUnprocessableEntity)
+				return
+			case codes.ResourceExhausted:
+				log.WarnContext(r.Context(), "Booking exceeds the purchase limits of the event", "userID", userID, "eventID", req.EventID, "error", st.Message())
+				http.Error(w, st.Message(), http.StatusForbidden)
+				return
 			default:
 				log.ErrorContext(r.Context(), "Unhandled gRPC error from booking-service", "userID", userID, "code", st.Code(), "error", st.Message())
 				http.Error(w, "Failed to create booking due to an internal error", http.StatusInternalServerError)
@@ -246,15 +313,16 @@ func (h *Handler) ListEvents(w http.ResponseWriter, r *http.Request) {
 
 	log.InfoContext(r.Context(), "request received")
 
-	pageStr := r.URL.Query().Get("page")
-	if pageStr == "" {
-		pageStr = "1"
-	}
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		log.WarnContext(r.Context(), "Invalid page parameter. Must be a positive integer", "value", pageStr, "error", err)
-		http.Error(w, "Invalid page parameter. Must be a positive integer", http.StatusBadRequest)
-		return
+	// ?page= keeps the old page-number mode, everything else is cursor based
+	var page int
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		var err error
+		page, err = strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			log.WarnContext(r.Context(), "Invalid page parameter. Must be a positive integer", "value", pageStr, "error", err)
+			http.Error(w, "Invalid page parameter. Must be a positive integer", http.StatusBadRequest)
+			return
+		}
 	}
 
 	sizeStr := r.URL.Query().Get("size")
@@ -271,16 +339,52 @@ func (h *Handler) ListEvents(w http.ResponseWriter, r *http.Request) {
 	grpcResp, err := h.eventClient.ListEvents(r.Context(), &eventv1.ListEventsRequest{
 		PageNumber: int32(page),
 		PageSize:   int32(size),
+		PageToken:  r.URL.Query().Get("page_token"),
+		Category:   r.URL.Query().Get("category"),
+		Tag:        r.URL.Query().Get("tag"),
+		Locale:     requestLocale(r),
 	})
 	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.InvalidArgument {
+			http.Error(w, st.Message(), http.StatusBadRequest)
+			return
+		}
 		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "error", err)
 		http.Error(w, "internal server error", http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(grpcResp)
+	// the text depends on the language of the client
+	w.Header().Set("Vary", "Accept-Language")
+	writeCacheableJSON(w, r, grpcResp, parseLastModified(grpcResp.GetLastModified()))
+}
+
+// authenticate validates the bearer token and returns the caller's id and
+// role. On failure it has already written the 401 response.
+func (h *Handler) authenticate(w http.ResponseWriter, r *http.Request) (int64, string, bool) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		http.Error(w, "missing authorization header", http.StatusUnauthorized)
+		return 0, "", false
+	}
+
+	headerParts := strings.Split(authHeader, " ")
+
+	if len(headerParts) != 2 || headerParts[0] != "Bearer" {
+		http.Error(w, "invalid authorization header", http.StatusUnauthorized)
+		return 0, "", false
+	}
+
+	token := headerParts[1]
+
+	validateResp, err := h.authClient.ValidateToken(r.Context(), &authv1.ValidateTokenRequest{Token: token})
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "Token validation failed", "error", err)
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return 0, "", false
+	}
+
+	return validateResp.GetUserId(), validateResp.GetRole(), true
 }
 
 type paymentWebhookPayload struct {
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *Handler) ListInventoryPools(w http.ResponseWriter, r *http.Request) {
+	log := h.logger.With(slog.String("op", "handler.ListInventoryPools"))
+
+	eventID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || eventID < 1 {
+		http.Error(w, "invalid event id", http.StatusBadRequest)
+		return
+	}
+
+	grpcResp, err := h.eventClient.ListInventoryPools(r.Context(), &eventv1.ListInventoryPoolsRequest{EventId: eventID})
+	if err != nil {
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(grpcResp)
+}
+
+type SetInventoryPoolRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Capacity    int32  `json:"capacity" validate:"gte=0"`
+	PriceTierID int64  `json:"price_tier_id"`
+}
+
+// SetInventoryPool creates or resizes a general-admission pool of the event.
+func (h *Handler) SetInventoryPool(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.SetInventoryPool"
+
+	log := h.logger.With(slog.String("op", op))
+	r.Body = http.MaxBytesReader(w, r.Body, 1024*1024)
+
+	eventID, ok := h.authorizeEventManagement(w, r)
+	if !ok {
+		return
+	}
+
+	var req SetInventoryPoolRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pool, err := h.eventClient.SetInventoryPool(r.Context(), &eventv1.SetInventoryPoolRequest{
+		EventId:     eventID,
+		Name:        req.Name,
+		Capacity:    req.Capacity,
+		PriceTierId: req.PriceTierID,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				http.Error(w, st.Message(), http.StatusBadRequest)
+				return
+			case codes.NotFound:
+				http.Error(w, "event not found", http.StatusNotFound)
+				return
+			case codes.FailedPrecondition:
+				http.Error(w, st.Message(), http.StatusConflict)
+				return
+			}
+		}
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(pool)
+}
+
+func (h *Handler) ListSectorScores(w http.ResponseWriter, r *http.Request) {
+	log := h.logger.With(slog.String("op", "handler.ListSectorScores"))
+
+	eventID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || eventID < 1 {
+		http.Error(w, "invalid event id", http.StatusBadRequest)
+		return
+	}
+
+	grpcResp, err := h.eventClient.ListSectorScores(r.Context(), &eventv1.ListSectorScoresRequest{EventId: eventID})
+	if err != nil {
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(grpcResp)
+}
+
+type SetSectorScoreRequest struct {
+	Sector string `json:"sector" validate:"required,max=50"`
+	Score  int32  `json:"score" validate:"gte=0,lte=100"`
+}
+
+// SetSectorScore rates the seats of a sector for best-available bookings.
+func (h *Handler) SetSectorScore(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.SetSectorScore"
+
+	log := h.logger.With(slog.String("op", op))
+	r.Body = http.MaxBytesReader(w, r.Body, 1024*1024)
+
+	eventID, ok := h.authorizeEventManagement(w, r)
+	if !ok {
+		return
+	}
+
+	var req SetSectorScoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	score, err := h.eventClient.SetSectorScore(r.Context(), &eventv1.SetSectorScoreRequest{
+		EventId: eventID,
+		Sector:  req.Sector,
+		Score:   req.Score,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				http.Error(w, st.Message(), http.StatusBadRequest)
+				return
+			case codes.NotFound:
+				http.Error(w, "event not found", http.StatusNotFound)
+				return
+			}
+		}
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(score)
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// matches the limit of event-service
+const maxImageUploadSize = 10 << 20
+
+// UploadEventImage accepts a multipart form with an "image" file and a "kind"
+// field (POSTER or GALLERY) and returns the stored renditions.
+func (h *Handler) UploadEventImage(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.UploadEventImage"
+
+	log := h.logger.With(slog.String("op", op))
+	// the extra megabyte leaves room for the multipart envelope
+	r.Body = http.MaxBytesReader(w, r.Body, maxImageUploadSize+1<<20)
+
+	eventID, ok := h.authorizeEventManagement(w, r)
+	if !ok {
+		return
+	}
+
+	file, _, err := r.FormFile("image")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "image is too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "multipart form with an image file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxImageUploadSize+1))
+	if err != nil {
+		http.Error(w, "failed to read image", http.StatusBadRequest)
+		return
+	}
+	if len(content) > maxImageUploadSize {
+		http.Error(w, "image is too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	kind := strings.ToUpper(r.FormValue("kind"))
+	if kind == "" {
+		kind = "GALLERY"
+	}
+
+	image, err := h.eventClient.UploadEventImage(r.Context(), &eventv1.UploadEventImageRequest{
+		EventId: eventID,
+		Kind:    kind,
+		Content: content,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				http.Error(w, st.Message(), http.StatusBadRequest)
+				return
+			case codes.NotFound:
+				http.Error(w, "event not found", http.StatusNotFound)
+				return
+			case codes.FailedPrecondition:
+				http.Error(w, st.Message(), http.StatusConflict)
+				return
+			}
+		}
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	log.InfoContext(r.Context(), "Event image uploaded", "eventID", eventID, "imageID", image.GetId(), "kind", kind)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(image)
+}
+
+func (h *Handler) DeleteEventImage(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.DeleteEventImage"
+
+	log := h.logger.With(slog.String("op", op))
+
+	eventID, ok := h.authorizeEventManagement(w, r)
+	if !ok {
+		return
+	}
+
+	imageID, err := strconv.ParseInt(r.PathValue("image_id"), 10, 64)
+	if err != nil || imageID < 1 {
+		http.Error(w, "invalid image id", http.StatusBadRequest)
+		return
+	}
+
+	event, err := h.eventClient.DeleteEventImage(r.Context(), &eventv1.DeleteEventImageRequest{
+		EventId: eventID,
+		ImageId: imageID,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			http.Error(w, "image not found", http.StatusNotFound)
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "eventID", eventID, "imageID", imageID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(event)
+}
+
+// MediaFiles serves the filesystem blob store. Every upload is written under
+// a fresh key, so the files can be cached forever; directories are not listed.
+func MediaFiles(root string) http.Handler {
+	files := http.FileServer(http.Dir(root))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		files.ServeHTTP(w, r)
+	})
+}
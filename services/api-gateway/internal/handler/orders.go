@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	bookingv1 "github.com/kay-kewl/ticket-booking-system/gen/go/booking"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CreateOrderRequest struct {
+	Items []OrderItemRequest `json:"items" validate:"required,min=1,max=10,dive"`
+}
+
+// OrderItemRequest is what the order books of one event, seats or tickets
+// of a pool.
+type OrderItemRequest struct {
+	EventID    int64    `json:"event_id" validate:"required,gt=0"`
+	SeatIDs    []int64  `json:"seat_ids" validate:"required_without=PoolID"`
+	PoolID     int64    `json:"pool_id" validate:"required_with=Quantity"`
+	Quantity   int32    `json:"quantity" validate:"gte=0,required_with=PoolID"`
+	PromoCodes []string `json:"promo_codes" validate:"max=3,dive,max=50"`
+	// admission token from the waiting room of the event, if it has one;
+	// X-Admission-Token is used for items without it
+	AdmissionToken string `json:"admission_token"`
+}
+
+// CreateOrder books tickets of several events at once and pays for them in
+// one payment; either every event is booked or none is. Events behind a
+// waiting room need the caller's admission token for each of them.
+func (h *Handler) CreateOrder(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.CreateOrder"
+
+	log := h.logger.With(slog.String("op", op))
+	r.Body = http.MaxBytesReader(w, r.Body, 1024*1024)
+
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req CreateOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// a retried request with the same key gets the original order back
+	idempotencyKey := r.Header.Get("Idempotency-Key")
+	if len(idempotencyKey) > 255 {
+		http.Error(w, "Idempotency-Key must be at most 255 characters", http.StatusBadRequest)
+		return
+	}
+
+	grpcReq := &bookingv1.CreateOrderRequest{UserId: userID, IdempotencyKey: idempotencyKey}
+	for _, item := range req.Items {
+		token := item.AdmissionToken
+		if token == "" {
+			token = r.Header.Get("X-Admission-Token")
+		}
+		admitted, err := h.admitted(r, token, item.EventID, userID)
+		if err != nil {
+			log.ErrorContext(r.Context(), "Failed to check the waiting room of the event", "eventID", item.EventID, "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if !admitted {
+			log.WarnContext(r.Context(), "Order without admission from the waiting room", "userID", userID, "eventID", item.EventID)
+			http.Error(w, "join the waiting room of event "+strconv.FormatInt(item.EventID, 10)+" first", http.StatusForbidden)
+			return
+		}
+
+		grpcReq.Items = append(grpcReq.Items, &bookingv1.OrderItem{
+			EventId:    item.EventID,
+			SeatIds:    item.SeatIDs,
+			PoolId:     item.PoolID,
+			Quantity:   item.Quantity,
+			PromoCodes: item.PromoCodes,
+		})
+	}
+
+	resp, err := h.bookingClient.CreateOrder(r.Context(), grpcReq)
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.FailedPrecondition:
+				if promoCodeRejected(st) {
+					http.Error(w, st.Message(), http.StatusUnprocessableEntity)
+					return
+				}
+				log.WarnContext(r.Context(), "Order refused", "userID", userID, "error", st.Message())
+				http.Error(w, st.Message(), http.StatusConflict)
+				return
+			case codes.InvalidArgument:
+				http.Error(w, st.Message(), http.StatusBadRequest)
+				return
+			case codes.AlreadyExists:
+				http.Error(w, "Idempotency-Key was already used for a different request", http.StatusUnprocessableEntity)
+				return
+			case codes.ResourceExhausted:
+				http.Error(w, st.Message(), http.StatusForbidden)
+				return
+			}
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "userID", userID, "error", err)
+		http.Error(w, "failed to create order", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetOrder returns an order of the caller with its bookings.
+func (h *Handler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.GetOrder"
+
+	log := h.logger.With(slog.String("op", op))
+
+	orderID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || orderID < 1 {
+		http.Error(w, "invalid order id", http.StatusBadRequest)
+		return
+	}
+
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	order, err := h.bookingClient.GetOrder(r.Context(), &bookingv1.GetOrderRequest{
+		OrderId: orderID,
+		UserId:  userID,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			http.Error(w, "order not found", http.StatusNotFound)
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "orderID", orderID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(order)
+}
+
+// CancelOrder cancels an unpaid order of the caller with all of its
+// bookings.
+func (h *Handler) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.CancelOrder"
+
+	log := h.logger.With(slog.String("op", op))
+
+	orderID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || orderID < 1 {
+		http.Error(w, "invalid order id", http.StatusBadRequest)
+		return
+	}
+
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	order, err := h.bookingClient.CancelOrder(r.Context(), &bookingv1.CancelOrderRequest{
+		OrderId: orderID,
+		UserId:  userID,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.NotFound:
+				http.Error(w, "order not found", http.StatusNotFound)
+				return
+			case codes.FailedPrecondition:
+				http.Error(w, st.Message(), http.StatusConflict)
+				return
+			}
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "orderID", orderID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(order)
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authv1 "github.com/kay-kewl/ticket-booking-system/gen/go/auth"
+	bookingv1 "github.com/kay-kewl/ticket-booking-system/gen/go/booking"
+	"github.com/kay-kewl/ticket-booking-system/internal/admission"
+)
+
+type stubAuthClient struct {
+	authv1.AuthClient
+	userID int64
+}
+
+func (c stubAuthClient) ValidateToken(ctx context.Context, in *authv1.ValidateTokenRequest, opts ...grpc.CallOption) (*authv1.ValidateTokenResponse, error) {
+	return &authv1.ValidateTokenResponse{UserId: c.userID}, nil
+}
+
+type stubOrderClient struct {
+	bookingv1.BookingServiceClient
+	// events behind a waiting room
+	waitingRooms map[int64]bool
+	orders       []*bookingv1.CreateOrderRequest
+}
+
+func (c *stubOrderClient) GetWaitingRoom(ctx context.Context, in *bookingv1.GetWaitingRoomRequest, opts ...grpc.CallOption) (*bookingv1.WaitingRoom, error) {
+	if !c.waitingRooms[in.GetEventId()] {
+		return nil, status.Error(codes.NotFound, "event has no waiting room")
+	}
+	return &bookingv1.WaitingRoom{EventId: in.GetEventId()}, nil
+}
+
+func (c *stubOrderClient) CreateOrder(ctx context.Context, in *bookingv1.CreateOrderRequest, opts ...grpc.CallOption) (*bookingv1.CreateOrderResponse, error) {
+	c.orders = append(c.orders, in)
+	return &bookingv1.CreateOrderResponse{OrderId: 1}, nil
+}
+
+func TestCreateOrderNeedsAdmissionToEveryProtectedEvent(t *testing.T) {
+	const userID = 42
+	secret := []byte("waiting-room-secret")
+	token := func(eventID int64) string {
+		return admission.Sign(secret, admission.Ticket{EventID: eventID, UserID: userID, ExpiresAt: time.Now().Add(time.Hour)})
+	}
+
+	tests := []struct {
+		name   string
+		body   string
+		header string
+		want   int
+	}{
+		{
+			name: "a token per event",
+			body: `{"items": [{"event_id": 1, "seat_ids": [11], "admission_token": "` + token(1) + `"}, {"event_id": 2, "seat_ids": [21], "admission_token": "` + token(2) + `"}, {"event_id": 3, "seat_ids": [31]}]}`,
+			want: http.StatusCreated,
+		},
+		{
+			name:   "the header covers items without a token",
+			body:   `{"items": [{"event_id": 1, "seat_ids": [11]}, {"event_id": 2, "seat_ids": [21], "admission_token": "` + token(2) + `"}]}`,
+			header: token(1),
+			want:   http.StatusCreated,
+		},
+		{
+			name:   "one token for both events",
+			body:   `{"items": [{"event_id": 1, "seat_ids": [11]}, {"event_id": 2, "seat_ids": [21]}]}`,
+			header: token(1),
+			want:   http.StatusForbidden,
+		},
+		{
+			name: "a token of another event",
+			body: `{"items": [{"event_id": 1, "seat_ids": [11], "admission_token": "` + token(1) + `"}, {"event_id": 2, "seat_ids": [21], "admission_token": "` + token(1) + `"}]}`,
+			want: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookingClient := &stubOrderClient{waitingRooms: map[int64]bool{1: true, 2: true}}
+			h := New(stubAuthClient{userID: userID}, bookingClient, nil, "", "", string(secret), "", slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer token")
+			if tt.header != "" {
+				req.Header.Set("X-Admission-Token", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			h.CreateOrder(rec, req)
+
+			require.Equal(t, tt.want, rec.Code, rec.Body.String())
+			if tt.want == http.StatusCreated {
+				require.Len(t, bookingClient.orders, 1)
+				require.Equal(t, int64(userID), bookingClient.orders[0].GetUserId())
+			} else {
+				require.Empty(t, bookingClient.orders, "An order without admission must not reach booking-service")
+			}
+		})
+	}
+}
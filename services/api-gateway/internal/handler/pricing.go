@@ -0,0 +1,402 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *Handler) ListPriceTiers(w http.ResponseWriter, r *http.Request) {
+	log := h.logger.With(slog.String("op", "handler.ListPriceTiers"))
+
+	eventID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || eventID < 1 {
+		http.Error(w, "invalid event id", http.StatusBadRequest)
+		return
+	}
+
+	grpcResp, err := h.eventClient.ListPriceTiers(r.Context(), &eventv1.ListPriceTiersRequest{EventId: eventID})
+	if err != nil {
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(grpcResp)
+}
+
+type SetPriceTierRequest struct {
+	Name     string   `json:"name" validate:"required,max=100"`
+	Amount   int64    `json:"amount" validate:"gte=0"`
+	Currency string   `json:"currency" validate:"required,len=3"`
+	Sectors  []string `json:"sectors"`
+	SeatIDs  []int64  `json:"seat_ids"`
+}
+
+// SetPriceTier creates or updates a price tier of the event and assigns it to
+// sectors and individual seats. Amounts are in minor units of the currency.
+func (h *Handler) SetPriceTier(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.SetPriceTier"
+
+	log := h.logger.With(slog.String("op", op))
+	r.Body = http.MaxBytesReader(w, r.Body, 1024*1024)
+
+	eventID, ok := h.authorizeEventManagement(w, r)
+	if !ok {
+		return
+	}
+
+	var req SetPriceTierRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tier, err := h.eventClient.SetPriceTier(r.Context(), &eventv1.SetPriceTierRequest{
+		EventId:  eventID,
+		Name:     req.Name,
+		Amount:   req.Amount,
+		Currency: req.Currency,
+		Sectors:  req.Sectors,
+		SeatIds:  req.SeatIDs,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				http.Error(w, st.Message(), http.StatusBadRequest)
+				return
+			case codes.NotFound:
+				http.Error(w, "event not found", http.StatusNotFound)
+				return
+			case codes.FailedPrecondition:
+				http.Error(w, st.Message(), http.StatusConflict)
+				return
+			}
+		}
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(tier)
+}
+
+func (h *Handler) GetRefundPolicy(w http.ResponseWriter, r *http.Request) {
+	log := h.logger.With(slog.String("op", "handler.GetRefundPolicy"))
+
+	eventID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || eventID < 1 {
+		http.Error(w, "invalid event id", http.StatusBadRequest)
+		return
+	}
+
+	policy, err := h.eventClient.GetRefundPolicy(r.Context(), &eventv1.GetRefundPolicyRequest{EventId: eventID})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			http.Error(w, "event not found", http.StatusNotFound)
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(policy)
+}
+
+type SetRefundPolicyRequest struct {
+	NonRefundable        bool  `json:"non_refundable"`
+	FullRefundHours      int32 `json:"full_refund_hours" validate:"gte=0,gtefield=PartialRefundHours"`
+	PartialRefundHours   int32 `json:"partial_refund_hours" validate:"gte=0"`
+	PartialRefundPercent int32 `json:"partial_refund_percent" validate:"gte=0,lte=100"`
+}
+
+// SetRefundPolicy decides how much of a confirmed booking is refunded when
+// the customer cancels it, depending on how long before the event they do.
+func (h *Handler) SetRefundPolicy(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.SetRefundPolicy"
+
+	log := h.logger.With(slog.String("op", op))
+	r.Body = http.MaxBytesReader(w, r.Body, 1024*1024)
+
+	eventID, ok := h.authorizeEventManagement(w, r)
+	if !ok {
+		return
+	}
+
+	var req SetRefundPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	policy, err := h.eventClient.SetRefundPolicy(r.Context(), &eventv1.RefundPolicy{
+		EventId:              eventID,
+		NonRefundable:        req.NonRefundable,
+		FullRefundHours:      req.FullRefundHours,
+		PartialRefundHours:   req.PartialRefundHours,
+		PartialRefundPercent: req.PartialRefundPercent,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				http.Error(w, st.Message(), http.StatusBadRequest)
+				return
+			case codes.NotFound:
+				http.Error(w, "event not found", http.StatusNotFound)
+				return
+			}
+		}
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(policy)
+}
+
+func (h *Handler) GetPurchaseLimits(w http.ResponseWriter, r *http.Request) {
+	log := h.logger.With(slog.String("op", "handler.GetPurchaseLimits"))
+
+	eventID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || eventID < 1 {
+		http.Error(w, "invalid event id", http.StatusBadRequest)
+		return
+	}
+
+	limits, err := h.eventClient.GetPurchaseLimits(r.Context(), &eventv1.GetPurchaseLimitsRequest{EventId: eventID})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			http.Error(w, "event not found", http.StatusNotFound)
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(limits)
+}
+
+type SetPurchaseLimitsRequest struct {
+	MaxTicketsPerUser    int32 `json:"max_tickets_per_user" validate:"gte=0"`
+	MaxPendingBookings   int32 `json:"max_pending_bookings" validate:"gte=0"`
+	MaxTicketsPerBooking int32 `json:"max_tickets_per_booking" validate:"gte=0"`
+}
+
+// SetPurchaseLimits caps what a single customer may buy of the event; zero
+// lifts a limit.
+func (h *Handler) SetPurchaseLimits(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.SetPurchaseLimits"
+
+	log := h.logger.With(slog.String("op", op))
+	r.Body = http.MaxBytesReader(w, r.Body, 1024*1024)
+
+	eventID, ok := h.authorizeEventManagement(w, r)
+	if !ok {
+		return
+	}
+
+	var req SetPurchaseLimitsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	limits, err := h.eventClient.SetPurchaseLimits(r.Context(), &eventv1.PurchaseLimits{
+		EventId:              eventID,
+		MaxTicketsPerUser:    req.MaxTicketsPerUser,
+		MaxPendingBookings:   req.MaxPendingBookings,
+		MaxTicketsPerBooking: req.MaxTicketsPerBooking,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				http.Error(w, st.Message(), http.StatusBadRequest)
+				return
+			case codes.NotFound:
+				http.Error(w, "event not found", http.StatusNotFound)
+				return
+			}
+		}
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(limits)
+}
+
+type SetHoldPolicyRequest struct {
+	HoldMinutes       int32  `json:"hold_minutes" validate:"gte=0,lte=1440"`
+	MaxHoldExtensions *int32 `json:"max_hold_extensions"`
+}
+
+// SetHoldPolicy sets how long the event's bookings hold their tickets unpaid
+// and how many times a buyer may extend the hold; zero minutes and a missing
+// extension limit restore the defaults. The event is returned with them.
+func (h *Handler) SetHoldPolicy(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.SetHoldPolicy"
+
+	log := h.logger.With(slog.String("op", op))
+	r.Body = http.MaxBytesReader(w, r.Body, 1024*1024)
+
+	eventID, ok := h.authorizeEventManagement(w, r)
+	if !ok {
+		return
+	}
+
+	var req SetHoldPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	event, err := h.eventClient.SetHoldPolicy(r.Context(), &eventv1.SetHoldPolicyRequest{
+		EventId:           eventID,
+		HoldMinutes:       req.HoldMinutes,
+		MaxHoldExtensions: req.MaxHoldExtensions,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				http.Error(w, st.Message(), http.StatusBadRequest)
+				return
+			case codes.NotFound:
+				http.Error(w, "event not found", http.StatusNotFound)
+				return
+			}
+		}
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(event)
+}
+
+func (h *Handler) GetTransferPolicy(w http.ResponseWriter, r *http.Request) {
+	log := h.logger.With(slog.String("op", "handler.GetTransferPolicy"))
+
+	eventID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || eventID < 1 {
+		http.Error(w, "invalid event id", http.StatusBadRequest)
+		return
+	}
+
+	policy, err := h.eventClient.GetTransferPolicy(r.Context(), &eventv1.GetTransferPolicyRequest{EventId: eventID})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			http.Error(w, "event not found", http.StatusNotFound)
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(policy)
+}
+
+type SetTransferPolicyRequest struct {
+	TransfersAllowed bool  `json:"transfers_allowed"`
+	CutoffHours      int32 `json:"cutoff_hours" validate:"gte=0"`
+	MaxTransfers     int32 `json:"max_transfers" validate:"gte=0"`
+}
+
+// SetTransferPolicy decides whether customers may hand tickets of the event
+// over to someone else.
+func (h *Handler) SetTransferPolicy(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.SetTransferPolicy"
+
+	log := h.logger.With(slog.String("op", op))
+	r.Body = http.MaxBytesReader(w, r.Body, 1024*1024)
+
+	eventID, ok := h.authorizeEventManagement(w, r)
+	if !ok {
+		return
+	}
+
+	var req SetTransferPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	policy, err := h.eventClient.SetTransferPolicy(r.Context(), &eventv1.TransferPolicy{
+		EventId:          eventID,
+		TransfersAllowed: req.TransfersAllowed,
+		CutoffHours:      req.CutoffHours,
+		MaxTransfers:     req.MaxTransfers,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				http.Error(w, st.Message(), http.StatusBadRequest)
+				return
+			case codes.NotFound:
+				http.Error(w, "event not found", http.StatusNotFound)
+				return
+			}
+		}
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(policy)
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	bookingv1 "github.com/kay-kewl/ticket-booking-system/gen/go/booking"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SetPromoCodeRequest describes a PERCENT code by percent, a FIXED one by
+// amount in minor units of currency. Zero caps mean no cap.
+type SetPromoCodeRequest struct {
+	DiscountType          string  `json:"discount_type" validate:"required,oneof=PERCENT FIXED"`
+	Percent               int32   `json:"percent" validate:"gte=0,lte=100"`
+	Amount                int64   `json:"amount" validate:"gte=0"`
+	Currency              string  `json:"currency" validate:"omitempty,len=3"`
+	ValidFrom             string  `json:"valid_from"`
+	ValidUntil            string  `json:"valid_until"`
+	MaxRedemptions        int32   `json:"max_redemptions" validate:"gte=0"`
+	MaxRedemptionsPerUser int32   `json:"max_redemptions_per_user" validate:"gte=0"`
+	EventID               int64   `json:"event_id" validate:"gte=0"`
+	PriceTierIDs          []int64 `json:"price_tier_ids" validate:"dive,gt=0"`
+	Stackable             bool    `json:"stackable"`
+	Active                *bool   `json:"active"`
+}
+
+// SetPromoCode creates the promo code of the path or replaces its terms;
+// codes are not case sensitive. Admins only.
+func (h *Handler) SetPromoCode(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.SetPromoCode"
+
+	log := h.logger.With(slog.String("op", op))
+	r.Body = http.MaxBytesReader(w, r.Body, 1024*1024)
+
+	_, role, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+	if role != roleAdmin {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	var req SetPromoCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// a new code is active unless told otherwise
+	active := req.Active == nil || *req.Active
+
+	promo, err := h.bookingClient.SetPromoCode(r.Context(), &bookingv1.PromoCode{
+		Code:                  r.PathValue("code"),
+		DiscountType:          req.DiscountType,
+		Percent:               req.Percent,
+		Amount:                req.Amount,
+		Currency:              req.Currency,
+		ValidFrom:             req.ValidFrom,
+		ValidUntil:            req.ValidUntil,
+		MaxRedemptions:        req.MaxRedemptions,
+		MaxRedemptionsPerUser: req.MaxRedemptionsPerUser,
+		EventId:               req.EventID,
+		PriceTierIds:          req.PriceTierIDs,
+		Stackable:             req.Stackable,
+		Active:                active,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				http.Error(w, st.Message(), http.StatusBadRequest)
+				return
+			case codes.FailedPrecondition:
+				http.Error(w, st.Message(), http.StatusConflict)
+				return
+			}
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "code", r.PathValue("code"), "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(promo)
+}
+
+// GetPromoCode shows the terms of the promo code and how often it was
+// redeemed. Admins only.
+func (h *Handler) GetPromoCode(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.GetPromoCode"
+
+	log := h.logger.With(slog.String("op", op))
+
+	_, role, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+	if role != roleAdmin {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	promo, err := h.bookingClient.GetPromoCode(r.Context(), &bookingv1.GetPromoCodeRequest{Code: r.PathValue("code")})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			http.Error(w, "promo code not found", http.StatusNotFound)
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "code", r.PathValue("code"), "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(promo)
+}
+
+// promoCodeRejected reports whether booking-service refused a booking because
+// of its promo codes, which it marks with an ErrorInfo detail.
+func promoCodeRejected(st *status.Status) bool {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetReason() == "PROMO_CODE_REJECTED" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	bookingv1 "github.com/kay-kewl/ticket-booking-system/gen/go/booking"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CancelSeatsRequest struct {
+	SeatIDs []int64 `json:"seat_ids" validate:"required,min=1,max=100,dive,gt=0"`
+}
+
+type ExchangeSeatsRequest struct {
+	// seats of the booking to give back
+	ReleaseSeatIDs []int64 `json:"release_seat_ids" validate:"required,min=1,max=100,dive,gt=0"`
+	// as many available seats of the event to take instead
+	SeatIDs []int64 `json:"seat_ids" validate:"required,min=1,max=100,dive,gt=0"`
+}
+
+// CancelSeats gives some seats of a paid booking of the user back, with the
+// refund the event's policy allows.
+func (h *Handler) CancelSeats(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.CancelSeats"
+
+	log := h.logger.With(slog.String("op", op))
+	r.Body = http.MaxBytesReader(w, r.Body, 1024*1024)
+
+	bookingID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || bookingID < 1 {
+		http.Error(w, "invalid booking id", http.StatusBadRequest)
+		return
+	}
+
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req CancelSeatsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	change, err := h.bookingClient.CancelSeats(r.Context(), &bookingv1.CancelSeatsRequest{
+		BookingId: bookingID,
+		UserId:    userID,
+		SeatIds:   req.SeatIDs,
+	})
+	if err != nil {
+		if writeSeatChangeError(w, err) {
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "bookingID", bookingID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(change)
+}
+
+// ExchangeSeats swaps seats of a paid booking of the user for others of the
+// event. When the new seats cost more, the change is PENDING until the
+// difference is paid. An event behind a waiting room needs the caller's
+// admission, since new seats are taken.
+func (h *Handler) ExchangeSeats(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.ExchangeSeats"
+
+	log := h.logger.With(slog.String("op", op))
+	r.Body = http.MaxBytesReader(w, r.Body, 1024*1024)
+
+	bookingID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || bookingID < 1 {
+		http.Error(w, "invalid booking id", http.StatusBadRequest)
+		return
+	}
+
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req ExchangeSeatsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	booking, err := h.bookingClient.GetBooking(r.Context(), &bookingv1.GetBookingRequest{
+		BookingId: bookingID,
+		UserId:    userID,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			http.Error(w, "booking not found", http.StatusNotFound)
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "bookingID", bookingID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if !h.requireAdmission(w, r, booking.GetEventId(), userID) {
+		return
+	}
+
+	change, err := h.bookingClient.ExchangeSeats(r.Context(), &bookingv1.ExchangeSeatsRequest{
+		BookingId:      bookingID,
+		UserId:         userID,
+		ReleaseSeatIds: req.ReleaseSeatIDs,
+		SeatIds:        req.SeatIDs,
+	})
+	if err != nil {
+		if writeSeatChangeError(w, err) {
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "bookingID", bookingID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	code := http.StatusOK
+	if change.GetStatus() == "PENDING" {
+		code = http.StatusAccepted
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(change)
+}
+
+// ListSeatChanges lists the seat changes of a booking of the user.
+func (h *Handler) ListSeatChanges(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.ListSeatChanges"
+
+	log := h.logger.With(slog.String("op", op))
+
+	bookingID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || bookingID < 1 {
+		http.Error(w, "invalid booking id", http.StatusBadRequest)
+		return
+	}
+
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	changes, err := h.bookingClient.ListSeatChanges(r.Context(), &bookingv1.ListSeatChangesRequest{
+		BookingId: bookingID,
+		UserId:    userID,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			http.Error(w, st.Message(), http.StatusNotFound)
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "bookingID", bookingID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(changes)
+}
+
+// writeSeatChangeError answers with the status of a failed seat change and
+// reports whether it did.
+func writeSeatChangeError(w http.ResponseWriter, err error) bool {
+	if writeTransferError(w, err) {
+		return true
+	}
+	if st, ok := status.FromError(err); ok && st.Code() == codes.Unavailable {
+		http.Error(w, st.Message(), http.StatusServiceUnavailable)
+		return true
+	}
+	return false
+}
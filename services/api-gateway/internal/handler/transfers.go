@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	bookingv1 "github.com/kay-kewl/ticket-booking-system/gen/go/booking"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type StartTransferRequest struct {
+	RecipientEmail string `json:"recipient_email" validate:"required,email,max=255"`
+	// only these seats of the booking; all of its tickets when empty
+	SeatIDs []int64 `json:"seat_ids" validate:"max=100,dive,gt=0"`
+}
+
+type AcceptTransferRequest struct {
+	AcceptToken string `json:"accept_token" validate:"required"`
+}
+
+// StartTransfer offers tickets of a paid booking of the user to someone
+// else, who gets the accept token by email.
+func (h *Handler) StartTransfer(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.StartTransfer"
+
+	log := h.logger.With(slog.String("op", op))
+	r.Body = http.MaxBytesReader(w, r.Body, 1024*1024)
+
+	bookingID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || bookingID < 1 {
+		http.Error(w, "invalid booking id", http.StatusBadRequest)
+		return
+	}
+
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req StartTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	transfer, err := h.bookingClient.StartTransfer(r.Context(), &bookingv1.StartTransferRequest{
+		BookingId:      bookingID,
+		UserId:         userID,
+		RecipientEmail: req.RecipientEmail,
+		SeatIds:        req.SeatIDs,
+	})
+	if err != nil {
+		if writeTransferError(w, err) {
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "bookingID", bookingID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(transfer)
+}
+
+// CancelTransfer takes back a transfer of the user nobody accepted yet.
+func (h *Handler) CancelTransfer(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.CancelTransfer"
+
+	log := h.logger.With(slog.String("op", op))
+
+	transferID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || transferID < 1 {
+		http.Error(w, "invalid transfer id", http.StatusBadRequest)
+		return
+	}
+
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	transfer, err := h.bookingClient.CancelTransfer(r.Context(), &bookingv1.CancelTransferRequest{
+		TransferId: transferID,
+		UserId:     userID,
+	})
+	if err != nil {
+		if writeTransferError(w, err) {
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "transferID", transferID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(transfer)
+}
+
+// AcceptTransfer moves the tickets of a transfer to the user, who must be
+// signed in with the email the transfer was sent to.
+func (h *Handler) AcceptTransfer(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.AcceptTransfer"
+
+	log := h.logger.With(slog.String("op", op))
+	r.Body = http.MaxBytesReader(w, r.Body, 1024*1024)
+
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req AcceptTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	transfer, err := h.bookingClient.AcceptTransfer(r.Context(), &bookingv1.AcceptTransferRequest{
+		UserId:      userID,
+		AcceptToken: req.AcceptToken,
+	})
+	if err != nil {
+		if writeTransferError(w, err) {
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "userID", userID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(transfer)
+}
+
+// GetOwnershipHistory lists who held the tickets of a booking of the user,
+// from the purchase on.
+func (h *Handler) GetOwnershipHistory(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.GetOwnershipHistory"
+
+	log := h.logger.With(slog.String("op", op))
+
+	bookingID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || bookingID < 1 {
+		http.Error(w, "invalid booking id", http.StatusBadRequest)
+		return
+	}
+
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	history, err := h.bookingClient.GetOwnershipHistory(r.Context(), &bookingv1.GetOwnershipHistoryRequest{
+		BookingId: bookingID,
+		UserId:    userID,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			http.Error(w, st.Message(), http.StatusNotFound)
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "bookingID", bookingID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(history)
+}
+
+// writeTransferError answers with the status of a failed transfer call and
+// reports whether it did.
+func writeTransferError(w http.ResponseWriter, err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	switch st.Code() {
+	case codes.InvalidArgument:
+		http.Error(w, st.Message(), http.StatusBadRequest)
+	case codes.NotFound:
+		http.Error(w, st.Message(), http.StatusNotFound)
+	case codes.FailedPrecondition:
+		http.Error(w, st.Message(), http.StatusConflict)
+	default:
+		return false
+	}
+	return true
+}
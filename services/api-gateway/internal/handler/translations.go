@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"cmp"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type SetEventTranslationRequest struct {
+	Title       string `json:"title" validate:"required,max=255"`
+	Description string `json:"description"`
+}
+
+func (h *Handler) SetEventTranslation(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.SetEventTranslation"
+
+	log := h.logger.With(slog.String("op", op))
+	r.Body = http.MaxBytesReader(w, r.Body, 1024*1024)
+
+	eventID, ok := h.authorizeEventManagement(w, r)
+	if !ok {
+		return
+	}
+
+	var req SetEventTranslationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	event, err := h.eventClient.SetEventTranslation(r.Context(), &eventv1.SetEventTranslationRequest{
+		EventId:     eventID,
+		Locale:      r.PathValue("locale"),
+		Title:       req.Title,
+		Description: req.Description,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				http.Error(w, st.Message(), http.StatusBadRequest)
+				return
+			case codes.NotFound:
+				http.Error(w, "event not found", http.StatusNotFound)
+				return
+			}
+		}
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(event)
+}
+
+func (h *Handler) DeleteEventTranslation(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.DeleteEventTranslation"
+
+	log := h.logger.With(slog.String("op", op))
+
+	eventID, ok := h.authorizeEventManagement(w, r)
+	if !ok {
+		return
+	}
+
+	event, err := h.eventClient.DeleteEventTranslation(r.Context(), &eventv1.DeleteEventTranslationRequest{
+		EventId: eventID,
+		Locale:  r.PathValue("locale"),
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				http.Error(w, st.Message(), http.StatusBadRequest)
+				return
+			case codes.NotFound:
+				http.Error(w, "translation not found", http.StatusNotFound)
+				return
+			}
+		}
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(event)
+}
+
+// GetTranslationStatus reports which locales the events are translated to.
+// Organizers see their own events, admins everyone's or, with
+// ?organizer_id=, those of one organizer. ?locales=en,de picks the locales.
+func (h *Handler) GetTranslationStatus(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.GetTranslationStatus"
+
+	log := h.logger.With(slog.String("op", op))
+
+	userID, role, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var organizerID int64
+	switch role {
+	case roleAdmin:
+		if value := r.URL.Query().Get("organizer_id"); value != "" {
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || id <= 0 {
+				http.Error(w, "invalid organizer_id", http.StatusBadRequest)
+				return
+			}
+			organizerID = id
+		}
+	case roleOrganizer:
+		organizerID = userID
+	default:
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	var locales []string
+	if value := r.URL.Query().Get("locales"); value != "" {
+		locales = strings.Split(value, ",")
+	}
+
+	report, err := h.eventClient.GetTranslationStatus(r.Context(), &eventv1.GetTranslationStatusRequest{
+		OrganizerId: organizerID,
+		Locales:     locales,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.InvalidArgument {
+			http.Error(w, st.Message(), http.StatusBadRequest)
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to event-service failed", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
+
+// requestLocale returns the preferred locales of the client, best first:
+// ?locale= when given, otherwise Accept-Language ordered by quality.
+func requestLocale(r *http.Request) string {
+	if locale := r.URL.Query().Get("locale"); locale != "" {
+		return locale
+	}
+
+	type weighted struct {
+		locale  string
+		quality float64
+	}
+	var preferences []weighted
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		locale, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		locale = strings.TrimSpace(locale)
+		if locale == "" || locale == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			q, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = q
+		}
+		if quality <= 0 {
+			continue
+		}
+		preferences = append(preferences, weighted{locale: locale, quality: quality})
+	}
+
+	slices.SortStableFunc(preferences, func(a, b weighted) int {
+		return cmp.Compare(b.quality, a.quality)
+	})
+
+	locales := make([]string, 0, len(preferences))
+	for _, p := range preferences {
+		locales = append(locales, p.locale)
+	}
+	return strings.Join(locales, ",")
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	bookingv1 "github.com/kay-kewl/ticket-booking-system/gen/go/booking"
+	"github.com/kay-kewl/ticket-booking-system/internal/admission"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// a waiting room put in place takes at most this long to be enforced
+	waitingRoomCacheTTL  = 10 * time.Second
+	waitingRoomCacheSize = 10000
+)
+
+type SetWaitingRoomRequest struct {
+	AdmitPerMinute   int32 `json:"admit_per_minute" validate:"gte=0,lte=100000"`
+	AdmissionMinutes int32 `json:"admission_minutes" validate:"gte=0,lte=1440"`
+}
+
+// SetWaitingRoom puts the on-sale of the event behind a waiting room;
+// admit_per_minute of 0 removes it.
+func (h *Handler) SetWaitingRoom(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.SetWaitingRoom"
+
+	log := h.logger.With(slog.String("op", op))
+	r.Body = http.MaxBytesReader(w, r.Body, 1024*1024)
+
+	eventID, ok := h.authorizeEventManagement(w, r)
+	if !ok {
+		return
+	}
+
+	var req SetWaitingRoomRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	room, err := h.bookingClient.SetWaitingRoom(r.Context(), &bookingv1.WaitingRoom{
+		EventId:          eventID,
+		AdmitPerMinute:   req.AdmitPerMinute,
+		AdmissionMinutes: req.AdmissionMinutes,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.InvalidArgument {
+			http.Error(w, st.Message(), http.StatusBadRequest)
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	h.waitingRooms.Remove(eventID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(room)
+}
+
+// JoinWaitingRoom queues the user for the event and tells them where they
+// stand; joining again does not lose their place.
+func (h *Handler) JoinWaitingRoom(w http.ResponseWriter, r *http.Request) {
+	h.waitingRoomStatus(w, r, "handler.JoinWaitingRoom", h.bookingClient.JoinWaitingRoom)
+}
+
+// GetWaitingRoomStatus tells the user where they stand in the queue and,
+// once admitted, hands out the token to book the event with.
+func (h *Handler) GetWaitingRoomStatus(w http.ResponseWriter, r *http.Request) {
+	h.waitingRoomStatus(w, r, "handler.GetWaitingRoomStatus", h.bookingClient.GetWaitingRoomStatus)
+}
+
+func (h *Handler) waitingRoomStatus(w http.ResponseWriter, r *http.Request, op string, call func(ctx context.Context, in *bookingv1.WaitingRoomRequest, opts ...grpc.CallOption) (*bookingv1.WaitingRoomStatus, error)) {
+	log := h.logger.With(slog.String("op", op))
+
+	eventID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || eventID < 1 {
+		http.Error(w, "invalid event id", http.StatusBadRequest)
+		return
+	}
+
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := call(r.Context(), &bookingv1.WaitingRoomRequest{EventId: eventID, UserId: userID})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			http.Error(w, st.Message(), http.StatusNotFound)
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// requireAdmission checks the X-Admission-Token of the caller for an event
+// behind a waiting room and has already written the error response when it
+// returns false.
+func (h *Handler) requireAdmission(w http.ResponseWriter, r *http.Request, eventID, userID int64) bool {
+	admitted, err := h.admitted(r, r.Header.Get("X-Admission-Token"), eventID, userID)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "Failed to check the waiting room of the event", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return false
+	}
+	if !admitted {
+		h.logger.WarnContext(r.Context(), "Request without admission from the waiting room", "userID", userID, "eventID", eventID)
+		http.Error(w, "join the waiting room of the event first", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// admitted reports whether the user may book the event: either it has no
+// waiting room or token is the user's admission token for it.
+func (h *Handler) admitted(r *http.Request, token string, eventID, userID int64) (bool, error) {
+	if token != "" {
+		ticket, err := admission.Verify(h.waitingRoomSecret, token, time.Now())
+		if err == nil && ticket.EventID == eventID && ticket.UserID == userID {
+			return true, nil
+		}
+	}
+
+	protected, ok := h.waitingRooms.Get(eventID)
+	if !ok {
+		_, err := h.bookingClient.GetWaitingRoom(r.Context(), &bookingv1.GetWaitingRoomRequest{EventId: eventID})
+		if err != nil {
+			if st, ok := status.FromError(err); !ok || st.Code() != codes.NotFound {
+				return false, err
+			}
+		}
+		protected = err == nil
+		h.waitingRooms.Add(eventID, protected)
+	}
+
+	return !protected, nil
+}
@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	bookingv1 "github.com/kay-kewl/ticket-booking-system/gen/go/booking"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type JoinWaitlistRequest struct {
+	Quantity int32 `json:"quantity" validate:"required,gte=1,lte=10"`
+	// general-admission tickets of the pool instead of seats
+	PoolID int64 `json:"pool_id" validate:"gte=0"`
+}
+
+type ClaimWaitlistOfferRequest struct {
+	EventID    int64  `json:"event_id" validate:"required,gt=0"`
+	ClaimToken string `json:"claim_token" validate:"required"`
+}
+
+// JoinWaitlist puts the user in line for tickets of a sold out event.
+func (h *Handler) JoinWaitlist(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.JoinWaitlist"
+
+	log := h.logger.With(slog.String("op", op))
+	r.Body = http.MaxBytesReader(w, r.Body, 1024*1024)
+
+	eventID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || eventID < 1 {
+		http.Error(w, "invalid event id", http.StatusBadRequest)
+		return
+	}
+
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req JoinWaitlistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entry, err := h.bookingClient.JoinWaitlist(r.Context(), &bookingv1.JoinWaitlistRequest{
+		EventId:  eventID,
+		UserId:   userID,
+		Quantity: req.Quantity,
+		PoolId:   req.PoolID,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				http.Error(w, st.Message(), http.StatusBadRequest)
+				return
+			case codes.FailedPrecondition:
+				http.Error(w, st.Message(), http.StatusConflict)
+				return
+			}
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(entry)
+}
+
+// GetWaitlistEntry shows the user's place on the waitlist of the event, or
+// the offer they got.
+func (h *Handler) GetWaitlistEntry(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.GetWaitlistEntry"
+
+	log := h.logger.With(slog.String("op", op))
+
+	eventID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || eventID < 1 {
+		http.Error(w, "invalid event id", http.StatusBadRequest)
+		return
+	}
+
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	entry, err := h.bookingClient.GetWaitlistEntry(r.Context(), &bookingv1.WaitlistRequest{EventId: eventID, UserId: userID})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			http.Error(w, st.Message(), http.StatusNotFound)
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(entry)
+}
+
+// LeaveWaitlist takes the user off the waitlist of the event, passing on an
+// offer they did not want.
+func (h *Handler) LeaveWaitlist(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.LeaveWaitlist"
+
+	log := h.logger.With(slog.String("op", op))
+
+	eventID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || eventID < 1 {
+		http.Error(w, "invalid event id", http.StatusBadRequest)
+		return
+	}
+
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	_, err = h.bookingClient.LeaveWaitlist(r.Context(), &bookingv1.WaitlistRequest{EventId: eventID, UserId: userID})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			http.Error(w, st.Message(), http.StatusNotFound)
+			return
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "eventID", eventID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ClaimWaitlistOffer books the tickets offered to the user with the claim
+// token from their notification. An event behind a waiting room needs the
+// caller's admission like any other booking.
+func (h *Handler) ClaimWaitlistOffer(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.ClaimWaitlistOffer"
+
+	log := h.logger.With(slog.String("op", op))
+	r.Body = http.MaxBytesReader(w, r.Body, 1024*1024)
+
+	userID, _, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req ClaimWaitlistOfferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !h.requireAdmission(w, r, req.EventID, userID) {
+		return
+	}
+
+	bookingResp, err := h.bookingClient.ClaimWaitlistOffer(r.Context(), &bookingv1.ClaimWaitlistOfferRequest{
+		EventId:    req.EventID,
+		UserId:     userID,
+		ClaimToken: req.ClaimToken,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.NotFound:
+				http.Error(w, st.Message(), http.StatusNotFound)
+				return
+			case codes.InvalidArgument:
+				http.Error(w, st.Message(), http.StatusBadRequest)
+				return
+			case codes.FailedPrecondition:
+				http.Error(w, st.Message(), http.StatusConflict)
+				return
+			case codes.ResourceExhausted:
+				http.Error(w, st.Message(), http.StatusForbidden)
+				return
+			}
+		}
+		log.ErrorContext(r.Context(), "gRPC call to booking-service failed", "userID", userID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(bookingResp)
+}
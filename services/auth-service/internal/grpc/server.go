@@ -15,7 +15,7 @@ import (
 type Auth interface {
 	Login(ctx context.Context, email string, password string) (token string, err error)
 	Register(ctx context.Context, email string, password string) (userID int64, err error)
-	ValidateToken(ctx context.Context, token string) (userID int64, err error)
+	ValidateToken(ctx context.Context, token string) (userID int64, role string, err error)
     GetUserDetails(ctx context.Context, userID int64) (email string, err error)
 }
 
@@ -54,12 +54,12 @@ func (s *serverAPI) Login(ctx context.Context, req *authv1.LoginRequest) (*authv
 }
 
 func (s *serverAPI) ValidateToken(ctx context.Context, req *authv1.ValidateTokenRequest) (*authv1.ValidateTokenResponse, error) {
-	userID, err := s.auth.ValidateToken(ctx, req.GetToken())
+	userID, role, err := s.auth.ValidateToken(ctx, req.GetToken())
 	if err != nil {
 		return nil, status.Errorf(codes.Unauthenticated, "invalid token")
 	}
 
-	return &authv1.ValidateTokenResponse{UserId: userID}, nil
+	return &authv1.ValidateTokenResponse{UserId: userID, Role: role}, nil
 }
 
 func (s *serverAPI) GetUserDetails(ctx context.Context, req *authv1.GetUserDetailsRequest) (*authv1.GetUserDetailsResponse, error) {
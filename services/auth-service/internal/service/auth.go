@@ -15,12 +15,18 @@ import (
 var ErrUserExists = errors.New("user already exists")
 var ErrInvalidCredentials = errors.New("invalid credentials")
 
+const (
+	RoleUser      = "USER"
+	RoleOrganizer = "ORGANIZER"
+	RoleAdmin     = "ADMIN"
+)
+
 type UserSaver interface {
 	SaveUser(ctx context.Context, email string, passHash []byte) (int64, error)
 }
 
 type UserProvider interface {
-	User(ctx context.Context, email string) (id int64, passHash []byte, err error)
+	User(ctx context.Context, email string) (id int64, passHash []byte, role string, err error)
     UserDetails(ctx context.Context, userID int64) (email string, err error)
 }
 
@@ -66,7 +72,7 @@ func (a *Auth) Login(ctx context.Context, email string, password string) (string
 
 	// TODO: validate
 
-	id, passHash, err := a.userProvider.User(ctx, email)
+	id, passHash, role, err := a.userProvider.User(ctx, email)
 	if err != nil {
 		// TODO: not found error
 		if errors.Is(err, storage.ErrUserNotFound) {
@@ -81,8 +87,9 @@ func (a *Auth) Login(ctx context.Context, email string, password string) (string
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"uid": id,
-		"exp": time.Now().Add(a.tokenTTL).Unix(),
+		"uid":  id,
+		"role": role,
+		"exp":  time.Now().Add(a.tokenTTL).Unix(),
 	})
 
 	// TODO: hide the secret
@@ -94,7 +101,7 @@ func (a *Auth) Login(ctx context.Context, email string, password string) (string
 	return tokenString, nil
 }
 
-func (a *Auth) ValidateToken(ctx context.Context, tokenString string) (int64, error) {
+func (a *Auth) ValidateToken(ctx context.Context, tokenString string) (int64, string, error) {
 	const op = "Auth.ValidateToken"
 
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
@@ -106,16 +113,21 @@ func (a *Auth) ValidateToken(ctx context.Context, tokenString string) (int64, er
 	})
 
 	if err != nil {
-		return 0, fmt.Errorf("%s: %w", op, err)
+		return 0, "", fmt.Errorf("%s: %w", op, err)
 	}
 
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 		if uidFloat, ok := claims["uid"].(float64); ok {
-			return int64(uidFloat), nil
+			// tokens issued before roles were introduced carry no role claim
+			role, ok := claims["role"].(string)
+			if !ok {
+				role = RoleUser
+			}
+			return int64(uidFloat), role, nil
 		}
 	}
 
-	return 0, fmt.Errorf("%s: invalid token", op)
+	return 0, "", fmt.Errorf("%s: invalid token", op)
 }
 
 func (a *Auth) GetUserDetails(ctx context.Context, userID int64) (string, error) {
@@ -39,24 +39,25 @@ func (s *Storage) SaveUser(ctx context.Context, email string, passHash []byte) (
 	return id, nil
 }
 
-func (s *Storage) User(ctx context.Context, email string) (int64, []byte, error) {
+func (s *Storage) User(ctx context.Context, email string) (int64, []byte, string, error) {
 	const op = "storage.User"
 
-	query := "SELECT id, password_hash FROM auth.users WHERE email = $1"
+	query := "SELECT id, password_hash, role FROM auth.users WHERE email = $1"
 
 	var id int64
 	var passHash []byte
+	var role string
 
-	err := s.db.QueryRow(ctx, query, email).Scan(&id, &passHash)
+	err := s.db.QueryRow(ctx, query, email).Scan(&id, &passHash, &role)
 	if err != nil {
 		// TODO: pgx.ErrNoRows
 		if errors.Is(err, pgx.ErrNoRows) {
-			return 0, nil, fmt.Errorf("%s: %w", op, ErrUserNotFound)
+			return 0, nil, "", fmt.Errorf("%s: %w", op, ErrUserNotFound)
 		}
-		return 0, nil, fmt.Errorf("%s: %w", op, err)
+		return 0, nil, "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	return id, passHash, nil
+	return id, passHash, role, nil
 }
 
 func (s *Storage) UserDetails(ctx context.Context, userID int64) (email string, err error) {
@@ -0,0 +1,3 @@
+CREATE TYPE user_role AS ENUM ('USER', 'ORGANIZER', 'ADMIN');
+
+ALTER TABLE users ADD COLUMN role user_role NOT NULL DEFAULT 'USER';
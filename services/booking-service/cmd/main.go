@@ -29,6 +29,7 @@ import (
 	"github.com/kay-kewl/ticket-booking-system/internal/database"
 	"github.com/kay-kewl/ticket-booking-system/internal/grpc/interceptors"
 	"github.com/kay-kewl/ticket-booking-system/internal/logging"
+	"github.com/kay-kewl/ticket-booking-system/internal/outbox"
 	"github.com/kay-kewl/ticket-booking-system/internal/rabbitmq"
 	"github.com/kay-kewl/ticket-booking-system/internal/telemetry"
 	grpcserver "github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/grpc"
@@ -68,6 +69,10 @@ func main() {
 		logger.Error("Failed to load configuration", "error", err)
 		os.Exit(1)
 	}
+	if cfg.WaitingRoomSecret == "" {
+		logger.Error("WAITING_ROOM_SECRET environmental variable is not set")
+		os.Exit(1)
+	}
 
 	rabbitmqManager := rabbitmq.NewConnectionManager(cfg.RabbitMQURL, logger)
 	defer rabbitmqManager.Close()
@@ -114,9 +119,10 @@ func main() {
     defer eventServiceConn.Close()
     eventClient := eventv1.NewEventServiceClient(eventServiceConn)
 
-	bookingStorage := storage.New(dbPool, authClient, eventClient)
+	bookingStorage := storage.New(dbPool, authClient, eventClient, cfg.BookingHoldTime, cfg.WaitlistOfferTime)
     paymentGateway := service.NewHTTPPaymentGateway(cfg.PaymentServiceURL)
 	bookingService := service.New(bookingStorage, paymentGateway)
+	waitingRoom := service.NewWaitingRoom(bookingStorage, cfg.WaitingRoomSecret)
 
 	l, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.BookingGRPCPort))
 	if err != nil {
@@ -127,10 +133,28 @@ func main() {
 	workerCtx, workerCancel := context.WithCancel(context.Background())
 	defer workerCancel()
 
-	outboxWorker := worker.NewOutboxWorker(dbPool, rabbitmqManager, logger, 10*time.Second)
+	outboxWorker := outbox.NewWorker(dbPool, "booking.outbox_messages", rabbitmqManager, logger, 10*time.Second)
 	go outboxWorker.Start(workerCtx)
 
-	go runExpirationWorker(workerCtx, rabbitmqManager, bookingService, logger)
+	go runEventCancellationConsumer(workerCtx, rabbitmqManager, bookingService, logger)
+
+	cancellationWorker := worker.NewCancellationWorker(bookingService, logger, 5*time.Second, 50)
+	go cancellationWorker.Start(workerCtx)
+
+	expirationWorker := worker.NewExpirationWorker(bookingService, logger, 5*time.Second, 100)
+	go expirationWorker.Start(workerCtx)
+
+	refundWorker := worker.NewRefundWorker(bookingService, logger, 5*time.Second, 50)
+	go refundWorker.Start(workerCtx)
+
+	idempotencyPurgeWorker := worker.NewIdempotencyPurgeWorker(bookingService, logger, time.Minute, cfg.IdempotencyRetention)
+	go idempotencyPurgeWorker.Start(workerCtx)
+
+	admissionWorker := worker.NewAdmissionWorker(waitingRoom, logger, 5*time.Second)
+	go admissionWorker.Start(workerCtx)
+
+	waitlistWorker := worker.NewWaitlistWorker(bookingService, logger, 5*time.Second, 100)
+	go waitlistWorker.Start(workerCtx)
 
 	logger.Info("Booking Service ready. gRPC server listening", "address", l.Addr().String())
 
@@ -145,7 +169,7 @@ func main() {
 			interceptors.ServerRequestIDInterceptor(),
 		),
 	)
-	grpcserver.Register(grpcSrv, bookingService)
+	grpcserver.Register(grpcSrv, bookingService, waitingRoom)
 	grpc_health_v1.RegisterHealthServer(grpcSrv, healthSrv)
 	reflection.Register(grpcSrv)
 	healthSrv.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
@@ -173,55 +197,76 @@ func setupRabbitMQTopology(ch *amqp.Channel) error {
 		return fmt.Errorf("failed to declare exchange: %w", err)
 	}
 
-	err = ch.ExchangeDeclare("bookings_dlx", "fanout", true, false, false, false, nil)
+	// owned by event-service, declared here as well so that startup order does not matter
+	err = ch.ExchangeDeclare("events_exchange", "topic", true, false, false, false, nil)
 	if err != nil {
-		return fmt.Errorf("failed to declare dead-letter exchange: %w", err)
+		return fmt.Errorf("failed to declare events exchange: %w", err)
 	}
 
-	_, err = ch.QueueDeclare("bookings_expired_queue", true, false, false, false, nil)
+	_, err = ch.QueueDeclare("booking_event_cancelled_queue", true, false, false, false, nil)
 	if err != nil {
-		return fmt.Errorf("failed to declare worker queue: %w", err)
+		return fmt.Errorf("failed to declare event cancellation queue: %w", err)
 	}
 
-	err = ch.QueueBind("bookings_expired_queue", "", "bookings_dlx", false, nil)
+	err = ch.QueueBind("booking_event_cancelled_queue", "event.cancelled", "events_exchange", false, nil)
 	if err != nil {
-		return fmt.Errorf("failed to bind worker queue: %w", err)
+		return fmt.Errorf("failed to bind event cancellation queue: %w", err)
 	}
 
-	_, err = ch.QueueDeclare("bookings_delay_15m", true, false, false, false, amqp.Table{
-		"x-message-ttl":          900000,
-		"x-dead-letter-exchange": "bookings_dlx",
-	})
-	if err != nil {
-		return fmt.Errorf("failed to declare delay queue: %w", err)
-	}
-
-	err = ch.QueueBind("bookings_delay_15m", "booking.created", "bookings_exchange", false, nil)
-	if err != nil {
-		return fmt.Errorf("failed to bind delay queue: %w", err)
-	}
+	// holds used to expire through a fixed-TTL delay queue; the expiration
+	// worker has replaced it, so the old topology is dropped where it is left
+	_, _ = ch.QueueDelete("bookings_delay_15m", false, false, false)
+	_, _ = ch.QueueDelete("bookings_expired_queue", false, false, false)
+	_ = ch.ExchangeDelete("bookings_dlx", false, false)
 
 	return nil
 }
 
-func runExpirationWorker(ctx context.Context, provider worker.ChannelProvider, bs *service.Booking, logger *slog.Logger) {
-	logger.Info("Starting expiration worker")
+// runEventCancellationConsumer turns event.cancelled notifications into bulk
+// cancellation jobs; redeliveries are harmless since a job is created once per event.
+func runEventCancellationConsumer(ctx context.Context, provider outbox.ChannelProvider, bs *service.Booking, logger *slog.Logger) {
+	runConsumer(ctx, provider, "booking_event_cancelled_queue", "Event cancellation consumer", logger, func(opCtx context.Context, body []byte) (bool, error) {
+		var msgBody struct {
+			EventID int64  `json:"event_id"`
+			Reason  string `json:"reason"`
+		}
+		if err := json.Unmarshal(body, &msgBody); err != nil {
+			return false, fmt.Errorf("failed to unmarshal event cancellation message: %w", err)
+		}
+		if msgBody.EventID == 0 {
+			return false, fmt.Errorf("invalid message format: %s", string(body))
+		}
+
+		jobID, err := bs.StartEventCancellation(opCtx, msgBody.EventID, msgBody.Reason)
+		if err != nil {
+			return true, fmt.Errorf("failed to start cancellation of event %d: %w", msgBody.EventID, err)
+		}
+
+		logger.Info("Started event cancellation job", "event_id", msgBody.EventID, "job_id", jobID)
+		return false, nil
+	})
+}
+
+// runConsumer keeps a consumer on the queue alive across reconnects. handle
+// reports whether a failed message should be requeued or discarded.
+func runConsumer(ctx context.Context, provider outbox.ChannelProvider, queue, name string, logger *slog.Logger, handle func(ctx context.Context, body []byte) (bool, error)) {
+	logger.Info("Starting " + name)
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Info("Expiration worker stopping...")
+			logger.Info(name + " stopping...")
 			return
 		default:
 		}
 		ch, err := provider.GetChannel()
 		if err != nil {
-			logger.Error("Expiration worker: failed to get channel, retrying...", "error", err)
+			logger.Error(name+": failed to get channel, retrying...", "error", err)
 			time.Sleep(10 * time.Second)
 			continue
 		}
 
 		msgs, err := ch.Consume(
-			"bookings_expired_queue",
+			queue,
 			"",
 			false,
 			false,
@@ -230,60 +275,45 @@ func runExpirationWorker(ctx context.Context, provider worker.ChannelProvider, b
 			nil,
 		)
 		if err != nil {
-			logger.Error("Expiration worker: failed to start consumer, retrying...", "error", err)
+			logger.Error(name+": failed to start consumer, retrying...", "error", err)
 			ch.Close()
 			time.Sleep(10 * time.Second)
 			continue
 		}
 
-		logger.Info("Expiration worker started. Waiting for messages...")
+		logger.Info(name + " started. Waiting for messages...")
 
 	processLoop:
 		for {
 			select {
 			case <-ctx.Done():
-				logger.Info("Expiration worker stopping...")
+				logger.Info(name + " stopping...")
 				ch.Close()
 				return
 			case d, ok := <-msgs:
 				if !ok {
-					logger.Warn("Expiration worker: message channel closed. Reconnecting...")
+					logger.Warn(name + ": message channel closed. Reconnecting...")
 					ch.Close()
 					break processLoop
 				}
 
-				logger.Info("Received an expired booking message", "body", string(d.Body))
-
-				var msgBody map[string]int64
-				if err := json.Unmarshal(d.Body, &msgBody); err != nil {
-					logger.Error("Failed to unmarshal expiration message, discarding", "error", err)
-                    if err := d.Nack(false, false); err != nil {
-                        logger.Error("Failed to Nack message", "error", err)
-                    }
-					continue
-				}
-
-				bookingID, ok := msgBody["booking_id"]
-				if !ok {
-					logger.Error("Invalid message format, discarding", "body", string(d.Body))
-                    if err := d.Nack(false, false); err != nil {
-                        logger.Error("Failed to Nack message", "error", err)
-                    }
-					continue
-				}
+				logger.Info("Received a message", "queue", queue, "body", string(d.Body))
 
 				opCtx, opCancel := context.WithTimeout(context.Background(), 1*time.Minute)
-				if err := bs.CancelBooking(opCtx, bookingID); err != nil {
-					logger.Error("Failed to process expired booking, retrying", "booking_id", bookingID, "error", err)
-                    if err := d.Nack(false, true); err != nil {
-                        logger.Error("Failed to Nack message for retry", "error", err)
-                    }
-					opCancel()
+				requeue, err := handle(opCtx, d.Body)
+				opCancel()
+				if err != nil {
+					if requeue {
+						logger.Error(name+": failed to process message, retrying", "error", err)
+					} else {
+						logger.Error(name+": invalid message, discarding", "error", err)
+					}
+					if err := d.Nack(false, requeue); err != nil {
+						logger.Error("Failed to Nack message", "error", err)
+					}
 					continue
 				}
-				opCancel()
 
-				logger.Info("Successfully cancelled expired booking", "booking_id", bookingID)
 				if err := d.Ack(false); err != nil {
 					logger.Error("Failed to acknowledge message", "error", err)
 				}
@@ -5,6 +5,9 @@ import (
     "errors"
 	"log/slog"
 	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -13,7 +16,12 @@ import (
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
+	"google.golang.org/grpc"
 
+	authv1 "github.com/kay-kewl/ticket-booking-system/gen/go/auth"
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+	"github.com/kay-kewl/ticket-booking-system/internal/admission"
+	"github.com/kay-kewl/ticket-booking-system/internal/requestid"
 	bookingservice "github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/service"
 	bookingstorage "github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/storage"
 )
@@ -26,7 +34,7 @@ func NewSimulatorPaymentGateway(sim func() bool) bookingservice.PaymentGateway {
     return &simulatorPaymentGateway{simulator: sim}
 }
 
-func (g *simulatorPaymentGateway) InitiatePayment(ctx context.Context, bookingID int64, amount float64) error {
+func (g *simulatorPaymentGateway) InitiatePayment(ctx context.Context, bookingID int64, amount int64, currency string) error {
     if g.simulator() {
         return nil
     }
@@ -34,6 +42,36 @@ func (g *simulatorPaymentGateway) InitiatePayment(ctx context.Context, bookingID
     return errors.New("payment failed by simulator")
 }
 
+func (g *simulatorPaymentGateway) RefundPayment(ctx context.Context, bookingID int64, key string) error {
+    if g.simulator() {
+        return nil
+    }
+
+    return errors.New("refund failed by simulator")
+}
+
+func (g *simulatorPaymentGateway) RefundAmount(ctx context.Context, bookingID int64, amount int64, currency string, key string) error {
+    return g.RefundPayment(ctx, bookingID, key)
+}
+
+// stubAuthClient knows the emails of the test users.
+type stubAuthClient struct {
+    authv1.AuthClient
+    emails map[int64]string
+}
+
+func (c stubAuthClient) GetUserDetails(ctx context.Context, in *authv1.GetUserDetailsRequest, opts ...grpc.CallOption) (*authv1.GetUserDetailsResponse, error) {
+    return &authv1.GetUserDetailsResponse{UserId: in.GetUserId(), Email: c.emails[in.GetUserId()]}, nil
+}
+
+type stubEventClient struct {
+    eventv1.EventServiceClient
+}
+
+func (stubEventClient) GetEvent(ctx context.Context, in *eventv1.GetEventRequest, opts ...grpc.CallOption) (*eventv1.Event, error) {
+    return &eventv1.Event{Id: in.GetEventId(), Title: "Test Event"}, nil
+}
+
 func TestBookingService_Integration(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test in short mode")
@@ -69,7 +107,7 @@ func TestBookingService_Integration(t *testing.T) {
 
 	applyMigrations(t, pool)
 
-	storage := bookingstorage.New(pool)
+	storage := bookingstorage.New(pool, nil, nil, 15*time.Minute, 30*time.Minute)
 
 	t.Run("Happy Path - Successful Booking", func(t *testing.T) {
         successGateway := NewSimulatorPaymentGateway(func() bool { return true })
@@ -80,10 +118,16 @@ func TestBookingService_Integration(t *testing.T) {
 		seatIDs := []int64{1, 2}
 		seedTestData(t, pool, userID, eventID, seatIDs)
 
-		bookingID, err := service.CreateBooking(ctx, userID, eventID, seatIDs)
+		bookingID, total, err := service.CreateBooking(ctx, userID, eventID, seatIDs, bookingstorage.GeneralAdmission{}, nil)
 
 		require.NoError(t, err, "CreateBooking should not return an error on happy path")
 		require.NotZero(t, bookingID, "Booking ID should not be zero")
+		require.Equal(t, bookingstorage.Price{Amount: int64(len(seatIDs)) * testSeatPrice, Currency: "RUB"}, total, "Total should be the sum of the seat prices")
+
+		var snapshotTotal int64
+		err = pool.QueryRow(ctx, "SELECT SUM(price_amount) FROM booking.booking_seats WHERE booking_id = $1", bookingID).Scan(&snapshotTotal)
+		require.NoError(t, err, "Should be able to query seat price snapshots")
+		require.Equal(t, total.Amount, snapshotTotal, "Seat prices should be stored with the booking")
 
 		var bookingStatus string
 		err = pool.QueryRow(
@@ -92,7 +136,7 @@ func TestBookingService_Integration(t *testing.T) {
 			bookingID,
 		).Scan(&bookingStatus)
 		require.NoError(t, err, "Should be able to query booking status")
-		require.Equal(t, "PENDING", bookingStatus, "Booking status should be PENDING")
+		require.Equal(t, "AWAITING_PAYMENT", bookingStatus, "Booking status should be AWAITING_PAYMENT once the payment is started")
 
 		var seatStatus string
 		err = pool.QueryRow(
@@ -113,7 +157,7 @@ func TestBookingService_Integration(t *testing.T) {
 		seatIDs := []int64{11}
 		seedTestData(t, pool, userID, eventID, seatIDs)
 
-		_, err := service.CreateBooking(ctx, userID, eventID, seatIDs)
+		_, _, err := service.CreateBooking(ctx, userID, eventID, seatIDs, bookingstorage.GeneralAdmission{}, nil)
 
 		require.Error(t, err, "CreateBooking should return an error on payment failure")
 		require.ErrorIs(t, err, bookingservice.ErrPaymentFailed, "Error should be of type ErrPaymentFailed")
@@ -136,6 +180,1162 @@ func TestBookingService_Integration(t *testing.T) {
 		require.NoError(t, err, "Should be able to query seat status")
 		require.Equal(t, "AVAILABLE", seatStatus, "Seat status should be AVAILABLE after compensation")
 	})
+
+	t.Run("Failed Path - Event Is Not On Sale", func(t *testing.T) {
+		successGateway := NewSimulatorPaymentGateway(func() bool { return true })
+		service := bookingservice.New(storage, successGateway)
+
+		userID := int64(3)
+		seatIDs := []int64{21}
+
+		draftEventID := int64(3)
+		seedTestData(t, pool, userID, draftEventID, seatIDs)
+		_, err := pool.Exec(ctx, "UPDATE event.events SET status = 'DRAFT' WHERE id = $1", draftEventID)
+		require.NoError(t, err)
+
+		_, _, err = service.CreateBooking(ctx, userID, draftEventID, seatIDs, bookingstorage.GeneralAdmission{}, nil)
+		require.ErrorIs(t, err, bookingservice.ErrEventNotOnSale, "Draft events must not be bookable")
+
+		closedEventID := int64(4)
+		closedSeatIDs := []int64{31}
+		seedTestData(t, pool, userID, closedEventID, closedSeatIDs)
+		_, err = pool.Exec(ctx, "UPDATE event.events SET off_sale_at = NOW() - INTERVAL '1 hour' WHERE id = $1", closedEventID)
+		require.NoError(t, err)
+
+		_, _, err = service.CreateBooking(ctx, userID, closedEventID, closedSeatIDs, bookingstorage.GeneralAdmission{}, nil)
+		require.ErrorIs(t, err, bookingservice.ErrEventNotOnSale, "Events past their off-sale time must not be bookable")
+
+		var seatStatus string
+		err = pool.QueryRow(ctx, "SELECT status FROM event.seats WHERE id = $1", seatIDs[0]).Scan(&seatStatus)
+		require.NoError(t, err)
+		require.Equal(t, "AVAILABLE", seatStatus, "Seat must stay AVAILABLE when the booking is refused")
+	})
+
+	t.Run("Event Cancellation - Bookings Are Refunded and Seats Released", func(t *testing.T) {
+		successGateway := NewSimulatorPaymentGateway(func() bool { return true })
+		service := bookingservice.New(storage, successGateway)
+
+		userID := int64(5)
+		eventID := int64(5)
+		seatIDs := []int64{41, 42}
+		seedTestData(t, pool, userID, eventID, seatIDs)
+
+		pendingID, _, err := service.CreateBooking(ctx, userID, eventID, seatIDs[:1], bookingstorage.GeneralAdmission{}, nil)
+		require.NoError(t, err)
+		paidID, _, err := service.CreateBooking(ctx, userID, eventID, seatIDs[1:], bookingstorage.GeneralAdmission{}, nil)
+		require.NoError(t, err)
+		_, err = pool.Exec(ctx, "UPDATE booking.bookings SET status = 'CONFIRMED' WHERE id = $1", paidID)
+		require.NoError(t, err)
+
+		jobID, err := service.StartEventCancellation(ctx, eventID, "venue flooded")
+		require.NoError(t, err)
+		againID, err := service.StartEventCancellation(ctx, eventID, "venue flooded")
+		require.NoError(t, err)
+		require.Equal(t, jobID, againID, "A redelivered cancellation must not start a second job")
+
+		processed, err := service.ProcessEventCancellations(ctx, 10)
+		require.NoError(t, err)
+		require.Equal(t, 2, processed)
+
+		for bookingID, expected := range map[int64]string{pendingID: "EVENT_CANCELLED", paidID: "REFUNDED"} {
+			var bookingStatus string
+			err = pool.QueryRow(ctx, "SELECT status FROM booking.bookings WHERE id = $1", bookingID).Scan(&bookingStatus)
+			require.NoError(t, err)
+			require.Equal(t, expected, bookingStatus)
+		}
+
+		var available int
+		err = pool.QueryRow(ctx, "SELECT COUNT(*) FROM event.seats WHERE id = ANY($1) AND status = 'AVAILABLE'", seatIDs).Scan(&available)
+		require.NoError(t, err)
+		require.Equal(t, len(seatIDs), available, "All seats of the cancelled event should be released")
+
+		var refundAmount int64
+		err = pool.QueryRow(ctx, "SELECT refund_amount FROM booking.bookings WHERE id = $1", paidID).Scan(&refundAmount)
+		require.NoError(t, err)
+		require.Equal(t, testSeatPrice, refundAmount, "The paid booking should be refunded what its tickets were paid")
+
+		var queued int64
+		err = pool.QueryRow(ctx, "SELECT amount FROM booking.refund_jobs WHERE booking_id = $1", paidID).Scan(&queued)
+		require.NoError(t, err)
+		require.Equal(t, testSeatPrice, queued, "The refund should be queued with the cancellation and not for the whole payment")
+
+		job, err := service.GetEventCancellationJob(ctx, eventID)
+		require.NoError(t, err)
+		require.Equal(t, "COMPLETED", job.Status)
+		require.EqualValues(t, 2, job.Total)
+		require.EqualValues(t, 2, job.Processed)
+		require.Empty(t, job.FailedItems)
+	})
+
+	t.Run("General Admission - Concurrent Bookings Never Oversell", func(t *testing.T) {
+		successGateway := NewSimulatorPaymentGateway(func() bool { return true })
+		service := bookingservice.New(storage, successGateway)
+
+		userID := int64(6)
+		eventID := int64(6)
+		seedTestData(t, pool, userID, eventID, nil)
+
+		const capacity = 10
+		var poolID int64
+		err := pool.QueryRow(
+			ctx,
+			"INSERT INTO event.inventory_pools (event_id, name, capacity, price_tier_id) SELECT $1, 'Dance floor', $2, id FROM event.price_tiers WHERE event_id = $1 RETURNING id",
+			eventID,
+			capacity,
+		).Scan(&poolID)
+		require.NoError(t, err)
+
+		const attempts = 40
+		var (
+			wg        sync.WaitGroup
+			succeeded atomic.Int32
+			soldOut   atomic.Int32
+		)
+		for i := 0; i < attempts; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _, err := service.CreateBooking(ctx, userID, eventID, nil, bookingstorage.GeneralAdmission{PoolID: poolID, Quantity: 1}, nil)
+				switch {
+				case err == nil:
+					succeeded.Add(1)
+				case errors.Is(err, bookingservice.ErrNotEnoughTickets):
+					soldOut.Add(1)
+				default:
+					t.Errorf("unexpected error: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		require.EqualValues(t, capacity, succeeded.Load(), "Exactly the pool capacity should be sold")
+		require.EqualValues(t, attempts-capacity, soldOut.Load(), "Every other attempt should be refused")
+
+		var held, bookedTickets int
+		err = pool.QueryRow(ctx, "SELECT held FROM event.inventory_pools WHERE id = $1", poolID).Scan(&held)
+		require.NoError(t, err)
+		err = pool.QueryRow(ctx, "SELECT COALESCE(SUM(quantity), 0) FROM booking.booking_pool_items WHERE pool_id = $1", poolID).Scan(&bookedTickets)
+		require.NoError(t, err)
+		require.Equal(t, capacity, held, "Held counter should match the pool capacity")
+		require.Equal(t, capacity, bookedTickets, "Booked tickets should match the held counter")
+
+		var bookingID int64
+		err = pool.QueryRow(ctx, "SELECT booking_id FROM booking.booking_pool_items WHERE pool_id = $1 LIMIT 1", poolID).Scan(&bookingID)
+		require.NoError(t, err)
+		require.NoError(t, service.CancelBooking(ctx, bookingID))
+
+		_, total, err := service.CreateBooking(ctx, userID, eventID, nil, bookingstorage.GeneralAdmission{PoolID: poolID, Quantity: 1}, nil)
+		require.NoError(t, err, "A cancelled ticket should go back to the pool")
+		require.Equal(t, testSeatPrice, total.Amount)
+	})
+
+	t.Run("Bookings Are Read Back Only By Their Owner", func(t *testing.T) {
+		successGateway := NewSimulatorPaymentGateway(func() bool { return true })
+		service := bookingservice.New(storage, successGateway)
+
+		userID := int64(7)
+		otherUserID := int64(8)
+		eventID := int64(7)
+		seedTestData(t, pool, userID, eventID, []int64{71, 72, 73})
+		seedTestData(t, pool, otherUserID, eventID, nil)
+
+		firstID, _, err := service.CreateBooking(ctx, userID, eventID, []int64{71, 72}, bookingstorage.GeneralAdmission{}, nil)
+		require.NoError(t, err)
+		secondID, _, err := service.CreateBooking(ctx, userID, eventID, []int64{73}, bookingstorage.GeneralAdmission{}, nil)
+		require.NoError(t, err)
+		_, err = pool.Exec(ctx, "UPDATE booking.bookings SET status = 'CONFIRMED' WHERE id = $1", secondID)
+		require.NoError(t, err)
+
+		booking, err := service.GetBooking(ctx, firstID, userID)
+		require.NoError(t, err)
+		require.Equal(t, "AWAITING_PAYMENT", booking.Status)
+		require.Equal(t, bookingstorage.Price{Amount: 2 * testSeatPrice, Currency: "RUB"}, booking.Total)
+		require.Len(t, booking.Seats, 2)
+		require.Equal(t, testSeatPrice, booking.Seats[0].Price.Amount, "Seats should carry the price they were booked at")
+
+		_, err = service.GetBooking(ctx, firstID, otherUserID)
+		require.ErrorIs(t, err, bookingservice.ErrBookingNotFound, "Someone else's booking should not be found")
+
+		page, next, err := service.ListUserBookings(ctx, userID, "", nil, 1)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		require.Equal(t, secondID, page[0].ID, "The newest booking should come first")
+		require.NotNil(t, next)
+
+		page, next, err = service.ListUserBookings(ctx, userID, "", next, 1)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		require.Equal(t, firstID, page[0].ID)
+		require.Nil(t, next, "There should be no page after the last booking")
+
+		page, _, err = service.ListUserBookings(ctx, userID, "confirmed", nil, 10)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		require.Equal(t, secondID, page[0].ID)
+
+		_, _, err = service.ListUserBookings(ctx, userID, "LOST", nil, 10)
+		require.ErrorIs(t, err, bookingservice.ErrInvalidBookingStatus)
+
+		page, _, err = service.ListUserBookings(ctx, otherUserID, "", nil, 10)
+		require.NoError(t, err)
+		require.Empty(t, page)
+	})
+
+	t.Run("Customer Cancellation - Refund Follows The Event Policy", func(t *testing.T) {
+		successGateway := NewSimulatorPaymentGateway(func() bool { return true })
+		service := bookingservice.New(storage, successGateway)
+
+		userID := int64(9)
+		eventID := int64(9)
+		seedTestData(t, pool, userID, eventID, []int64{91, 92, 93})
+
+		// 30 hours before the start: past the full refund cutoff, within the partial one
+		_, err := pool.Exec(ctx, "UPDATE event.events SET starts_at = NOW() + INTERVAL '30 hours' WHERE id = $1", eventID)
+		require.NoError(t, err)
+		_, err = pool.Exec(
+			ctx,
+			"INSERT INTO event.refund_policies (event_id, full_refund_hours, partial_refund_hours, partial_refund_percent) VALUES ($1, 48, 24, 50)",
+			eventID,
+		)
+		require.NoError(t, err)
+
+		pendingID, _, err := service.CreateBooking(ctx, userID, eventID, []int64{91}, bookingstorage.GeneralAdmission{}, nil)
+		require.NoError(t, err)
+		paidID, _, err := service.CreateBooking(ctx, userID, eventID, []int64{92, 93}, bookingstorage.GeneralAdmission{}, nil)
+		require.NoError(t, err)
+		_, err = pool.Exec(ctx, "UPDATE booking.bookings SET status = 'CONFIRMED' WHERE id = $1", paidID)
+		require.NoError(t, err)
+		_, err = pool.Exec(ctx, "UPDATE event.seats SET status = 'BOOKED' WHERE id = ANY($1)", []int64{92, 93})
+		require.NoError(t, err)
+
+		_, err = service.CancelUserBooking(ctx, paidID, userID+1)
+		require.ErrorIs(t, err, bookingservice.ErrBookingNotFound, "Only the owner should be able to cancel")
+
+		refund, err := service.CancelUserBooking(ctx, pendingID, userID)
+		require.NoError(t, err)
+		require.Zero(t, refund.Amount, "An unpaid booking has nothing to refund")
+
+		refund, err = service.CancelUserBooking(ctx, paidID, userID)
+		require.NoError(t, err)
+		require.Equal(t, bookingstorage.Price{Amount: testSeatPrice, Currency: "RUB"}, refund, "Half of the two seats should be refunded")
+
+		booking, err := service.GetBooking(ctx, paidID, userID)
+		require.NoError(t, err)
+		require.Equal(t, "CANCELLED", booking.Status)
+		require.NotNil(t, booking.RefundAmount)
+		require.Equal(t, testSeatPrice, *booking.RefundAmount)
+
+		var available int
+		err = pool.QueryRow(ctx, "SELECT COUNT(*) FROM event.seats WHERE id = ANY($1) AND status = 'AVAILABLE'", []int64{91, 92, 93}).Scan(&available)
+		require.NoError(t, err)
+		require.Equal(t, 3, available, "Cancelled seats should be for sale again")
+
+		var refundMessages int
+		err = pool.QueryRow(
+			ctx,
+			"SELECT COUNT(*) FROM booking.outbox_messages WHERE routing_key = 'booking.cancelled' AND (payload->>'booking_id')::bigint = $1 AND (payload->>'refund_amount')::bigint = $2",
+			paidID,
+			testSeatPrice,
+		).Scan(&refundMessages)
+		require.NoError(t, err)
+		require.Equal(t, 1, refundMessages, "booking.cancelled should carry the refund amount")
+
+		_, err = service.CancelUserBooking(ctx, paidID, userID)
+		require.ErrorIs(t, err, bookingservice.ErrBookingNotCancellable, "A booking is cancelled only once")
+
+		var refunds int
+		err = pool.QueryRow(ctx, "SELECT COUNT(*) FROM booking.refund_jobs WHERE booking_id = $1 AND amount = $2", paidID, testSeatPrice).Scan(&refunds)
+		require.NoError(t, err)
+		require.Equal(t, 1, refunds, "The refund should be queued once, with the cancellation")
+	})
+
+	t.Run("Hold Expiry - Bookings Expire At Their Own Time", func(t *testing.T) {
+		successGateway := NewSimulatorPaymentGateway(func() bool { return true })
+		service := bookingservice.New(storage, successGateway)
+
+		userID := int64(10)
+		seedTestData(t, pool, userID, 10, []int64{101})
+		seedTestData(t, pool, userID, 11, []int64{111})
+		_, err := pool.Exec(ctx, "UPDATE event.events SET hold_minutes = 30 WHERE id = 10")
+		require.NoError(t, err)
+
+		longHoldID, _, err := service.CreateBooking(ctx, userID, 10, []int64{101}, bookingstorage.GeneralAdmission{}, nil)
+		require.NoError(t, err)
+		defaultHoldID, _, err := service.CreateBooking(ctx, userID, 11, []int64{111}, bookingstorage.GeneralAdmission{}, nil)
+		require.NoError(t, err)
+
+		holdMinutes := func(bookingID int64) float64 {
+			var minutes float64
+			err := pool.QueryRow(ctx, "SELECT EXTRACT(EPOCH FROM expires_at - created_at)::float8 / 60 FROM booking.bookings WHERE id = $1", bookingID).Scan(&minutes)
+			require.NoError(t, err)
+			return minutes
+		}
+		require.InDelta(t, 30, holdMinutes(longHoldID), 1, "The event's hold time should be used")
+		require.InDelta(t, 15, holdMinutes(defaultHoldID), 1, "Events without a hold time should get the default")
+
+		expired, err := service.ExpireDueBookings(ctx, 100)
+		require.NoError(t, err)
+		require.Zero(t, expired, "Nothing should expire before its time")
+
+		_, err = pool.Exec(ctx, "UPDATE booking.bookings SET expires_at = NOW() - INTERVAL '1 second' WHERE id = $1", defaultHoldID)
+		require.NoError(t, err)
+
+		expired, err = service.ExpireDueBookings(ctx, 100)
+		require.NoError(t, err)
+		require.Equal(t, 1, expired)
+
+		booking, err := service.GetBooking(ctx, defaultHoldID, userID)
+		require.NoError(t, err)
+		require.Equal(t, "EXPIRED", booking.Status, "An expired hold should not look like a cancellation")
+		require.Nil(t, booking.ExpiresAt, "Only pending bookings have an expiry")
+
+		booking, err = service.GetBooking(ctx, longHoldID, userID)
+		require.NoError(t, err)
+		require.Equal(t, "AWAITING_PAYMENT", booking.Status)
+		require.NotNil(t, booking.ExpiresAt)
+
+		err = service.ExpireBooking(ctx, longHoldID)
+		require.ErrorIs(t, err, bookingstorage.ErrBookingCannotBeChanged, "A hold that has not run out should be kept")
+
+		var seatStatus string
+		err = pool.QueryRow(ctx, "SELECT status FROM event.seats WHERE id = 111").Scan(&seatStatus)
+		require.NoError(t, err)
+		require.Equal(t, "AVAILABLE", seatStatus, "The seat of an expired booking should be for sale again")
+
+		var expiredMessages int
+		err = pool.QueryRow(
+			ctx,
+			"SELECT COUNT(*) FROM booking.outbox_messages WHERE routing_key = 'booking.expired' AND (payload->>'booking_id')::bigint = $1",
+			defaultHoldID,
+		).Scan(&expiredMessages)
+		require.NoError(t, err)
+		require.Equal(t, 1, expiredMessages)
+	})
+
+	t.Run("Hold Extension - Pushes The Deadline A Limited Number Of Times", func(t *testing.T) {
+		successGateway := NewSimulatorPaymentGateway(func() bool { return true })
+		service := bookingservice.New(storage, successGateway)
+
+		userID := int64(12)
+		eventID := int64(12)
+		seedTestData(t, pool, userID, eventID, []int64{121, 122})
+		_, err := pool.Exec(ctx, "UPDATE event.events SET hold_minutes = 10, max_hold_extensions = 1 WHERE id = $1", eventID)
+		require.NoError(t, err)
+
+		bookingID, _, err := service.CreateBooking(ctx, userID, eventID, []int64{121}, bookingstorage.GeneralAdmission{}, nil)
+		require.NoError(t, err)
+		booking, err := service.GetBooking(ctx, bookingID, userID)
+		require.NoError(t, err)
+		deadline := *booking.ExpiresAt
+
+		_, _, err = service.ExtendHold(ctx, bookingID, userID+1)
+		require.ErrorIs(t, err, bookingservice.ErrBookingNotFound, "Only the owner should be able to extend the hold")
+
+		expiresAt, left, err := service.ExtendHold(ctx, bookingID, userID)
+		require.NoError(t, err)
+		require.Equal(t, deadline.Add(10*time.Minute).Unix(), expiresAt.Unix(), "The event's hold time should be added to the deadline")
+		require.Zero(t, left)
+
+		_, _, err = service.ExtendHold(ctx, bookingID, userID)
+		require.ErrorIs(t, err, bookingservice.ErrHoldExtensionLimit)
+
+		due, err := storage.ListDueBookings(ctx, 100)
+		require.NoError(t, err)
+		require.NotContains(t, due, bookingID)
+		err = service.ExpireBooking(ctx, bookingID)
+		require.ErrorIs(t, err, bookingstorage.ErrBookingCannotBeChanged, "The expiration worker should go by the extended deadline")
+
+		expiredID, _, err := service.CreateBooking(ctx, userID, eventID, []int64{122}, bookingstorage.GeneralAdmission{}, nil)
+		require.NoError(t, err)
+		_, err = pool.Exec(ctx, "UPDATE booking.bookings SET expires_at = NOW() - INTERVAL '1 second' WHERE id = $1", expiredID)
+		require.NoError(t, err)
+
+		_, _, err = service.ExtendHold(ctx, expiredID, userID)
+		require.ErrorIs(t, err, bookingservice.ErrHoldNotExtendable, "A hold that has run out cannot be brought back")
+	})
+
+	t.Run("Idempotency Key - Concurrent Retries Create One Booking", func(t *testing.T) {
+		successGateway := NewSimulatorPaymentGateway(func() bool { return true })
+		service := bookingservice.New(storage, successGateway)
+
+		userID := int64(13)
+		eventID := int64(13)
+		seedTestData(t, pool, userID, eventID, []int64{131})
+
+		var runs atomic.Int32
+		create := func(ctx context.Context) ([]byte, error) {
+			runs.Add(1)
+			bookingID, _, err := service.CreateBooking(ctx, userID, eventID, []int64{131}, bookingstorage.GeneralAdmission{}, nil)
+			if err != nil {
+				return nil, err
+			}
+			return []byte(strconv.FormatInt(bookingID, 10)), nil
+		}
+		replay := func(ctx context.Context, bookingID int64) ([]byte, error) {
+			return []byte(strconv.FormatInt(bookingID, 10)), nil
+		}
+
+		var wg sync.WaitGroup
+		responses := make([][]byte, 5)
+		errs := make([]error, 5)
+		for i := range responses {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				responses[i], errs[i] = service.Idempotent(ctx, userID, "checkout-1", []byte("seat 131"), create, replay)
+			}()
+		}
+		wg.Wait()
+
+		for i := range responses {
+			require.NoError(t, errs[i], "Retries should get the original response instead of a reserved seat error")
+			require.Equal(t, responses[0], responses[i])
+		}
+		require.Equal(t, int32(1), runs.Load(), "The booking should be created once")
+
+		_, err := service.Idempotent(ctx, userID, "checkout-1", []byte("seat 132"), create, replay)
+		require.ErrorIs(t, err, bookingservice.ErrIdempotencyKeyReused)
+
+		failed := errors.New("payment failed")
+		_, err = service.Idempotent(ctx, userID, "checkout-2", []byte("seat 131"), func(ctx context.Context) ([]byte, error) {
+			return nil, failed
+		}, replay)
+		require.ErrorIs(t, err, failed)
+		response, err := service.Idempotent(ctx, userID, "checkout-2", []byte("seat 131"), func(ctx context.Context) ([]byte, error) {
+			return []byte("retried"), nil
+		}, replay)
+		require.NoError(t, err)
+		require.Equal(t, []byte("retried"), response, "A failed request should not be stored")
+
+		bookingID, err := strconv.ParseInt(string(responses[0]), 10, 64)
+		require.NoError(t, err)
+		_, err = pool.Exec(
+			ctx,
+			"INSERT INTO booking.idempotency_keys (user_id, key, fingerprint, booking_id, lease_until) VALUES ($1, 'checkout-3', $2, $3, NOW() - INTERVAL '1 second')",
+			userID,
+			[]byte("seat 131"),
+			bookingID,
+		)
+		require.NoError(t, err)
+		response, err = service.Idempotent(ctx, userID, "checkout-3", []byte("seat 131"), create, replay)
+		require.NoError(t, err)
+		require.Equal(t, responses[0], response, "A request that died after booking should be replayed from its booking")
+		require.Equal(t, int32(1), runs.Load(), "A request that died after booking should not be run again")
+
+		_, err = pool.Exec(
+			ctx,
+			"INSERT INTO booking.idempotency_keys (user_id, key, fingerprint, lease_until) VALUES ($1, 'checkout-4', $2, NOW() + INTERVAL '1 minute')",
+			userID,
+			[]byte("seat 131"),
+		)
+		require.NoError(t, err)
+		waitCtx, cancelWait := context.WithTimeout(ctx, 500*time.Millisecond)
+		defer cancelWait()
+		_, err = service.Idempotent(waitCtx, userID, "checkout-4", []byte("seat 131"), create, replay)
+		require.ErrorIs(t, err, context.DeadlineExceeded, "A claim still held should be waited for, not taken over")
+		require.Equal(t, int32(1), runs.Load())
+
+		_, err = pool.Exec(ctx, "UPDATE booking.idempotency_keys SET created_at = NOW() - INTERVAL '2 days' WHERE user_id = $1 AND key IN ('checkout-1', 'checkout-4')", userID)
+		require.NoError(t, err)
+		purged, err := service.PurgeIdempotencyKeys(ctx, 24*time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), purged, "Only finished keys past the retention should be purged")
+
+		var held bool
+		err = pool.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM booking.idempotency_keys WHERE user_id = $1 AND key = 'checkout-4')", userID).Scan(&held)
+		require.NoError(t, err)
+		require.True(t, held, "A claim still held should survive the purge")
+	})
+
+	t.Run("Purchase Limits - One Customer Cannot Buy Past The Event's Limits", func(t *testing.T) {
+		successGateway := NewSimulatorPaymentGateway(func() bool { return true })
+		service := bookingservice.New(storage, successGateway)
+
+		userID := int64(14)
+		eventID := int64(14)
+		seedTestData(t, pool, userID, eventID, []int64{141, 142, 143, 144, 145})
+		_, err := pool.Exec(
+			ctx,
+			"INSERT INTO event.purchase_limits (event_id, max_tickets_per_user, max_pending_bookings, max_tickets_per_booking) VALUES ($1, 3, 2, 2)",
+			eventID,
+		)
+		require.NoError(t, err)
+
+		_, _, err = service.CreateBooking(ctx, userID, eventID, []int64{141, 142, 143}, bookingstorage.GeneralAdmission{}, nil)
+		require.ErrorIs(t, err, bookingservice.ErrBookingTooLarge)
+
+		paidID, _, err := service.CreateBooking(ctx, userID, eventID, []int64{141, 142}, bookingstorage.GeneralAdmission{}, nil)
+		require.NoError(t, err)
+		heldID, _, err := service.CreateBooking(ctx, userID, eventID, []int64{143}, bookingstorage.GeneralAdmission{}, nil)
+		require.NoError(t, err)
+
+		_, _, err = service.CreateBooking(ctx, userID, eventID, []int64{144}, bookingstorage.GeneralAdmission{}, nil)
+		require.ErrorIs(t, err, bookingservice.ErrTooManyPendingBookings)
+
+		_, err = pool.Exec(ctx, "UPDATE booking.bookings SET status = 'CONFIRMED' WHERE id = $1", paidID)
+		require.NoError(t, err)
+		_, _, err = service.CreateBooking(ctx, userID, eventID, []int64{144}, bookingstorage.GeneralAdmission{}, nil)
+		require.ErrorIs(t, err, bookingservice.ErrTicketLimitReached, "Paid and held tickets together should count")
+
+		_, err = pool.Exec(ctx, "UPDATE booking.bookings SET expires_at = NOW() - INTERVAL '1 second' WHERE id = $1", heldID)
+		require.NoError(t, err)
+		_, _, err = service.CreateBooking(ctx, userID, eventID, []int64{144}, bookingstorage.GeneralAdmission{}, nil)
+		require.NoError(t, err, "A hold that has run out should not count")
+
+		var seatStatus string
+		err = pool.QueryRow(ctx, "SELECT status FROM event.seats WHERE id = 145").Scan(&seatStatus)
+		require.NoError(t, err)
+		require.Equal(t, "AVAILABLE", seatStatus)
+
+		seedTestData(t, pool, userID+1, eventID, nil)
+		_, _, err = service.CreateBooking(ctx, userID+1, eventID, []int64{145}, bookingstorage.GeneralAdmission{}, nil)
+		require.NoError(t, err, "Limits apply to each customer separately")
+	})
+
+	t.Run("Waiting Room - Users Are Admitted In Order At The Room's Rate", func(t *testing.T) {
+		waitingRoom := bookingservice.NewWaitingRoom(storage, "test-secret")
+
+		eventID := int64(16)
+		require.NoError(t, waitingRoom.Configure(ctx, eventID, 2, 10))
+
+		for i, userID := range []int64{16, 17, 18} {
+			queueStatus, err := waitingRoom.Join(ctx, eventID, userID)
+			require.NoError(t, err)
+			require.Equal(t, int64(i+1), queueStatus.Position)
+			require.Empty(t, queueStatus.Token)
+		}
+
+		queueStatus, err := waitingRoom.Join(ctx, eventID, 16)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), queueStatus.Position, "Joining again should keep the user's place")
+
+		queueStatus, err = waitingRoom.Status(ctx, eventID, 18)
+		require.NoError(t, err)
+		require.Equal(t, 90*time.Second, queueStatus.EstimatedWait)
+
+		admitted, err := waitingRoom.AdmitWaitingUsers(ctx)
+		require.NoError(t, err)
+		require.Zero(t, admitted, "Nobody should be admitted before the rate allows")
+
+		_, err = pool.Exec(ctx, "UPDATE booking.waiting_rooms SET last_admission_at = NOW() - INTERVAL '5 minutes' WHERE event_id = $1", eventID)
+		require.NoError(t, err)
+		admitted, err = waitingRoom.AdmitWaitingUsers(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), admitted, "At most a minute's worth should be admitted at once")
+
+		queueStatus, err = waitingRoom.Status(ctx, eventID, 16)
+		require.NoError(t, err)
+		require.Zero(t, queueStatus.Position)
+		ticket, err := admission.Verify([]byte("test-secret"), queueStatus.Token, time.Now())
+		require.NoError(t, err)
+		require.Equal(t, eventID, ticket.EventID)
+		require.Equal(t, int64(16), ticket.UserID)
+
+		queueStatus, err = waitingRoom.Status(ctx, eventID, 18)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), queueStatus.Position)
+
+		_, err = waitingRoom.Join(ctx, eventID, 19)
+		require.NoError(t, err)
+		_, err = pool.Exec(ctx, "UPDATE booking.waiting_rooms SET last_admission_at = NOW() - INTERVAL '45 seconds' WHERE event_id = $1", eventID)
+		require.NoError(t, err)
+		admitted, err = waitingRoom.AdmitWaitingUsers(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), admitted)
+		var carried float64
+		err = pool.QueryRow(ctx, "SELECT EXTRACT(EPOCH FROM NOW() - last_admission_at)::float8 FROM booking.waiting_rooms WHERE event_id = $1", eventID).Scan(&carried)
+		require.NoError(t, err)
+		require.InDelta(t, 15, carried, 1, "The time not used up by the admitted user should carry over")
+
+		_, err = pool.Exec(ctx, "UPDATE booking.waiting_room_entries SET admitted_at = NOW() - INTERVAL '11 minutes' WHERE event_id = $1 AND user_id = 16", eventID)
+		require.NoError(t, err)
+		queueStatus, err = waitingRoom.Join(ctx, eventID, 16)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), queueStatus.Position, "An expired admission should send the user to the back")
+
+		require.NoError(t, waitingRoom.Configure(ctx, eventID, 0, 0))
+		_, err = waitingRoom.Status(ctx, eventID, 16)
+		require.ErrorIs(t, err, bookingservice.ErrNotInWaitingRoom)
+	})
+
+	t.Run("Waitlist - Released Seats Are Offered In Turn", func(t *testing.T) {
+		successGateway := NewSimulatorPaymentGateway(func() bool { return true })
+		service := bookingservice.New(storage, successGateway)
+
+		eventID := int64(17)
+		seedTestData(t, pool, 17, eventID, []int64{171, 172})
+		seedTestData(t, pool, 18, eventID, nil)
+		seedTestData(t, pool, 19, eventID, nil)
+
+		// the token itself only goes out by email, so the test swaps in one it knows
+		claimToken := func(userID int64) string {
+			token := "claim-token-" + strconv.FormatInt(userID, 10)
+			tag, err := pool.Exec(
+				ctx,
+				"UPDATE booking.waitlist_entries SET offer_token_hash = sha256(convert_to($3, 'UTF8')) WHERE event_id = $1 AND user_id = $2 AND status = 'OFFERED'",
+				eventID,
+				userID,
+				token,
+			)
+			require.NoError(t, err)
+			require.EqualValues(t, 1, tag.RowsAffected())
+			return token
+		}
+
+		_, err := service.JoinWaitlist(ctx, eventID, 18, 0, 1)
+		require.ErrorIs(t, err, bookingservice.ErrTicketsAvailable)
+
+		bookingID, _, err := service.CreateBooking(ctx, 17, eventID, []int64{171, 172}, bookingstorage.GeneralAdmission{}, nil)
+		require.NoError(t, err)
+
+		entry, err := service.JoinWaitlist(ctx, eventID, 18, 0, 1)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), entry.Position)
+		entry, err = service.JoinWaitlist(ctx, eventID, 19, 0, 2)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), entry.Position)
+		_, err = service.JoinWaitlist(ctx, eventID, 18, 0, 1)
+		require.ErrorIs(t, err, bookingservice.ErrAlreadyWaitlisted)
+
+		require.NoError(t, storage.CancelBooking(ctx, bookingID))
+
+		entry, err = service.WaitlistEntry(ctx, eventID, 18)
+		require.NoError(t, err)
+		require.Equal(t, "OFFERED", entry.Status)
+		require.Equal(t, []int64{171}, entry.OfferedSeatIDs)
+		entry, err = service.WaitlistEntry(ctx, eventID, 19)
+		require.NoError(t, err)
+		require.Equal(t, "WAITING", entry.Status, "One released seat is not enough for two")
+
+		var seatStatus string
+		err = pool.QueryRow(ctx, "SELECT status FROM event.seats WHERE id = 171").Scan(&seatStatus)
+		require.NoError(t, err)
+		require.Equal(t, "RESERVED", seatStatus, "An offered seat should not be bookable by others")
+
+		token := claimToken(18)
+		_, _, _, err = service.ClaimWaitlistOffer(ctx, eventID, 19, token)
+		require.ErrorIs(t, err, bookingservice.ErrOfferNotFound, "Only the waitlisted user can claim the offer")
+		_, _, _, err = service.ClaimWaitlistOffer(ctx, eventID+1, 18, token)
+		require.ErrorIs(t, err, bookingservice.ErrOfferNotFound, "The token is only good for the event of the offer")
+
+		claimedID, total, seatIDs, err := service.ClaimWaitlistOffer(ctx, eventID, 18, token)
+		require.NoError(t, err)
+		require.Equal(t, []int64{171}, seatIDs)
+		require.Equal(t, testSeatPrice, total.Amount)
+		_, _, _, err = service.ClaimWaitlistOffer(ctx, eventID, 18, token)
+		require.ErrorIs(t, err, bookingservice.ErrOfferNotFound, "An offer can be claimed once")
+
+		require.NoError(t, storage.CancelBooking(ctx, claimedID))
+		entry, err = service.WaitlistEntry(ctx, eventID, 19)
+		require.NoError(t, err)
+		require.Equal(t, "OFFERED", entry.Status)
+		require.ElementsMatch(t, []int64{171, 172}, entry.OfferedSeatIDs)
+
+		_, err = pool.Exec(ctx, "UPDATE booking.waitlist_entries SET offer_expires_at = NOW() - INTERVAL '1 second' WHERE id = $1", entry.ID)
+		require.NoError(t, err)
+		expired, _, err := service.ServeWaitlists(ctx, 10)
+		require.NoError(t, err)
+		require.Equal(t, 1, expired)
+
+		entry, err = service.WaitlistEntry(ctx, eventID, 19)
+		require.NoError(t, err)
+		require.Equal(t, "EXPIRED", entry.Status)
+		err = pool.QueryRow(ctx, "SELECT status FROM event.seats WHERE id = 172").Scan(&seatStatus)
+		require.NoError(t, err)
+		require.Equal(t, "AVAILABLE", seatStatus, "Seats of an unclaimed offer go back on sale when nobody else waits")
+	})
+
+	t.Run("Promo Codes - Caps Hold Under Concurrency", func(t *testing.T) {
+		successGateway := NewSimulatorPaymentGateway(func() bool { return true })
+		service := bookingservice.New(storage, successGateway)
+
+		eventID := int64(20)
+		seedTestData(t, pool, 20, eventID, []int64{201, 202, 203, 204, 205})
+		seedTestData(t, pool, 21, eventID, nil)
+
+		for _, code := range []bookingstorage.PromoCode{
+			{Code: "spring10", DiscountType: bookingstorage.DiscountPercent, Percent: 10, MaxRedemptions: 1, Stackable: true, Active: true},
+			{Code: "MINUS500", DiscountType: bookingstorage.DiscountFixed, Amount: bookingstorage.Price{Amount: 50000, Currency: "RUB"}, Stackable: true, Active: true},
+			{Code: "SOLO", DiscountType: bookingstorage.DiscountPercent, Percent: 50, Active: true},
+			{Code: "ONCE", DiscountType: bookingstorage.DiscountPercent, Percent: 20, MaxRedemptions: 1, Active: true},
+		} {
+			_, err := service.SetPromoCode(ctx, code)
+			require.NoError(t, err)
+		}
+
+		firstID, total, err := service.CreateBooking(ctx, 20, eventID, []int64{201}, bookingstorage.GeneralAdmission{}, []string{"Spring10"})
+		require.NoError(t, err)
+		require.Equal(t, testSeatPrice*9/10, total.Amount)
+		booking, err := service.GetBooking(ctx, firstID, 20)
+		require.NoError(t, err)
+		require.Equal(t, testSeatPrice/10, booking.Discount)
+
+		_, _, err = service.CreateBooking(ctx, 21, eventID, []int64{202}, bookingstorage.GeneralAdmission{}, []string{"SPRING10"})
+		require.ErrorIs(t, err, bookingservice.ErrPromoCodeUsedUp)
+
+		require.NoError(t, storage.CancelBooking(ctx, firstID))
+		_, total, err = service.CreateBooking(ctx, 21, eventID, []int64{202}, bookingstorage.GeneralAdmission{}, []string{"MINUS500", "SPRING10"})
+		require.NoError(t, err, "A cancelled booking should give its promo code back")
+		require.Equal(t, testSeatPrice*9/10-50000, total.Amount, "Percentages should apply before fixed amounts")
+
+		_, _, err = service.CreateBooking(ctx, 20, eventID, []int64{203}, bookingstorage.GeneralAdmission{}, []string{"MINUS500", "SOLO"})
+		require.ErrorIs(t, err, bookingservice.ErrPromoCodesNotStackable)
+
+		var wg sync.WaitGroup
+		results := make(chan error, 2)
+		for i, seatID := range []int64{204, 205} {
+			wg.Add(1)
+			go func(userID, seatID int64) {
+				defer wg.Done()
+				_, _, err := service.CreateBooking(ctx, userID, eventID, []int64{seatID}, bookingstorage.GeneralAdmission{}, []string{"ONCE"})
+				results <- err
+			}(int64(20+i), seatID)
+		}
+		wg.Wait()
+		close(results)
+
+		var succeeded, usedUp int
+		for err := range results {
+			switch {
+			case err == nil:
+				succeeded++
+			case errors.Is(err, bookingservice.ErrPromoCodeUsedUp):
+				usedUp++
+			default:
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}
+		require.Equal(t, 1, succeeded, "A code capped at one redemption should be redeemed once")
+		require.Equal(t, 1, usedUp)
+
+		promo, err := service.GetPromoCode(ctx, "once")
+		require.NoError(t, err)
+		require.Equal(t, int32(1), promo.Redemptions)
+	})
+
+	t.Run("Ticket Transfers - Seats Change Hands Once, With Their History", func(t *testing.T) {
+		successGateway := NewSimulatorPaymentGateway(func() bool { return true })
+		auth := stubAuthClient{emails: map[int64]string{22: "owner@example.com", 23: "friend@example.com", 24: "other@example.com"}}
+		service := bookingservice.New(bookingstorage.New(pool, auth, stubEventClient{}, 15*time.Minute, 30*time.Minute), successGateway)
+
+		eventID := int64(22)
+		seedTestData(t, pool, 22, eventID, []int64{221, 222, 223})
+		seedTestData(t, pool, 23, eventID, nil)
+		seedTestData(t, pool, 24, eventID, nil)
+
+		bookingID, _, err := service.CreateBooking(ctx, 22, eventID, []int64{221, 222, 223}, bookingstorage.GeneralAdmission{}, nil)
+		require.NoError(t, err)
+
+		_, _, err = service.StartTransfer(ctx, bookingID, 22, "friend@example.com", []int64{223})
+		require.ErrorIs(t, err, bookingservice.ErrBookingNotTransferable, "Unpaid tickets cannot be transferred")
+
+		require.NoError(t, service.ConfirmBooking(ctx, bookingID))
+
+		_, _, err = service.StartTransfer(ctx, bookingID, 23, "friend@example.com", []int64{223})
+		require.ErrorIs(t, err, bookingservice.ErrBookingNotFound, "Only the owner can transfer tickets")
+		_, _, err = service.StartTransfer(ctx, bookingID, 22, "friend@example.com", []int64{231})
+		require.ErrorIs(t, err, bookingservice.ErrSeatNotInBooking)
+
+		transfer, token, err := service.StartTransfer(ctx, bookingID, 22, "Friend@Example.com", []int64{223})
+		require.NoError(t, err)
+		require.Equal(t, "friend@example.com", transfer.RecipientEmail)
+		require.NotEmpty(t, token)
+
+		_, _, err = service.StartTransfer(ctx, bookingID, 22, "other@example.com", nil)
+		require.ErrorIs(t, err, bookingservice.ErrTransferPending)
+
+		_, err = service.AcceptTransfer(ctx, token, 24)
+		require.ErrorIs(t, err, bookingservice.ErrTransferNotFound, "Only the recipient can accept a transfer")
+
+		var wg sync.WaitGroup
+		results := make(chan error, 2)
+		for range 2 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := service.AcceptTransfer(ctx, token, 23)
+				results <- err
+			}()
+		}
+		wg.Wait()
+		close(results)
+
+		var accepted, closed int
+		for err := range results {
+			switch {
+			case err == nil:
+				accepted++
+			case errors.Is(err, bookingservice.ErrTransferClosed):
+				closed++
+			default:
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}
+		require.Equal(t, 1, accepted, "A transfer should be accepted once")
+		require.Equal(t, 1, closed)
+
+		original, err := service.GetBooking(ctx, bookingID, 22)
+		require.NoError(t, err)
+		require.Len(t, original.Seats, 2)
+		require.Equal(t, 2*testSeatPrice, original.Total.Amount)
+		require.Equal(t, int32(2), original.TicketVersion, "The sender's old ticket should be voided")
+
+		bookings, _, err := service.ListUserBookings(ctx, 23, "CONFIRMED", nil, 10)
+		require.NoError(t, err)
+		require.Len(t, bookings, 1)
+		received := bookings[0]
+		require.Equal(t, bookingID, received.TransferredFromID)
+		require.Equal(t, testSeatPrice, received.Total.Amount)
+		require.Len(t, received.Seats, 1)
+		require.Equal(t, int64(223), received.Seats[0].ID)
+
+		history, err := service.OwnershipHistory(ctx, received.ID, 23)
+		require.NoError(t, err)
+		require.Len(t, history, 2, "The received seat should share the purchase of the booking it came from")
+		require.Nil(t, history[0].FromUserID)
+		require.Equal(t, int64(22), history[0].ToUserID)
+		require.Equal(t, int64(22), *history[1].FromUserID)
+		require.Equal(t, int64(23), history[1].ToUserID)
+
+		history, err = service.OwnershipHistory(ctx, bookingID, 22)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		_, err = service.OwnershipHistory(ctx, bookingID, 23)
+		require.ErrorIs(t, err, bookingservice.ErrBookingNotFound)
+
+		var reissued int
+		err = pool.QueryRow(ctx, "SELECT COUNT(*) FROM booking.outbox_messages WHERE routing_key = 'ticket.reissued' AND (payload->>'booking_id')::bigint IN ($1, $2)", bookingID, received.ID).Scan(&reissued)
+		require.NoError(t, err)
+		require.Equal(t, 2, reissued, "Both owners should get new tickets")
+
+		_, err = pool.Exec(ctx, "INSERT INTO event.transfer_policies (event_id, transfers_allowed) VALUES ($1, FALSE)", eventID)
+		require.NoError(t, err)
+		_, _, err = service.StartTransfer(ctx, received.ID, 23, "owner@example.com", nil)
+		require.ErrorIs(t, err, bookingservice.ErrTransferNotAllowed)
+	})
+
+	t.Run("Seat Changes - Partial Cancellation And Exchange Reprice The Booking", func(t *testing.T) {
+		successGateway := NewSimulatorPaymentGateway(func() bool { return true })
+		auth := stubAuthClient{emails: map[int64]string{25: "owner@example.com"}}
+		service := bookingservice.New(bookingstorage.New(pool, auth, stubEventClient{}, 15*time.Minute, 30*time.Minute), successGateway)
+
+		userID := int64(25)
+		eventID := int64(24)
+		seedTestData(t, pool, userID, eventID, []int64{241, 242, 243, 244})
+		_, err := pool.Exec(
+			ctx,
+			`WITH tier AS (INSERT INTO event.price_tiers (event_id, name, amount, currency) VALUES ($1, 'VIP', $2, 'RUB') RETURNING id)
+			INSERT INTO event.seats (id, event_id, status, price_tier_id) SELECT 245, $1, 'AVAILABLE', id FROM tier`,
+			eventID,
+			2*testSeatPrice,
+		)
+		require.NoError(t, err)
+
+		bookingID, _, err := service.CreateBooking(ctx, userID, eventID, []int64{241, 242, 243}, bookingstorage.GeneralAdmission{}, nil)
+		require.NoError(t, err)
+
+		_, err = service.CancelSeats(ctx, bookingID, userID, []int64{241})
+		require.ErrorIs(t, err, bookingservice.ErrSeatsNotChangeable, "Seats of an unpaid booking cannot be changed")
+
+		require.NoError(t, service.ConfirmBooking(ctx, bookingID))
+
+		_, err = service.CancelSeats(ctx, bookingID, userID, []int64{241, 242, 243})
+		require.ErrorIs(t, err, bookingservice.ErrNoTicketsLeft)
+		_, err = service.CancelSeats(ctx, bookingID, userID+1, []int64{241})
+		require.ErrorIs(t, err, bookingservice.ErrBookingNotFound)
+
+		cancelled, err := service.CancelSeats(ctx, bookingID, userID, []int64{241})
+		require.NoError(t, err)
+		require.Equal(t, testSeatPrice, *cancelled.RefundAmount, "An event without a policy refunds the seat in full")
+		require.Equal(t, "PENDING", cancelled.RefundStatus, "The refund is sent after the change is saved")
+
+		var queued int64
+		err = pool.QueryRow(
+			ctx,
+			"SELECT amount FROM booking.refund_jobs WHERE seat_change_id = $1 AND payment_booking_id = $2 AND status = 'PENDING'",
+			cancelled.ID,
+			bookingID,
+		).Scan(&queued)
+		require.NoError(t, err, "The refund should be queued with the change")
+		require.Equal(t, testSeatPrice, queued)
+
+		sent, err := service.ProcessRefunds(ctx, 10)
+		require.NoError(t, err)
+		require.Equal(t, 1, sent)
+		sent, err = service.ProcessRefunds(ctx, 10)
+		require.NoError(t, err)
+		require.Zero(t, sent, "A sent refund is not sent again")
+
+		exchanged, err := service.ExchangeSeats(ctx, bookingID, userID, []int64{242}, []int64{244})
+		require.NoError(t, err)
+		require.Equal(t, "COMPLETED", exchanged.Status, "A seat of the same price is swapped at once")
+		require.Zero(t, *exchanged.RefundAmount)
+
+		_, err = service.ExchangeSeats(ctx, bookingID, userID, []int64{243}, []int64{242, 245})
+		require.ErrorIs(t, err, bookingservice.ErrInvalidSeatChange)
+
+		upgrade, err := service.ExchangeSeats(ctx, bookingID, userID, []int64{243}, []int64{245})
+		require.NoError(t, err)
+		require.Equal(t, "PENDING", upgrade.Status)
+		require.Equal(t, testSeatPrice, upgrade.AmountDue)
+
+		_, err = service.CancelSeats(ctx, bookingID, userID, []int64{244})
+		require.ErrorIs(t, err, bookingservice.ErrSeatChangePending)
+
+		var seatStatus string
+		err = pool.QueryRow(ctx, "SELECT status FROM event.seats WHERE id = 245").Scan(&seatStatus)
+		require.NoError(t, err)
+		require.Equal(t, "RESERVED", seatStatus, "The dearer seat should be held while the difference is paid")
+
+		// the payment webhook of the difference
+		require.NoError(t, service.ConfirmBooking(ctx, bookingID))
+
+		booking, err := service.GetBooking(ctx, bookingID, userID)
+		require.NoError(t, err)
+		require.Equal(t, "CONFIRMED", booking.Status)
+		require.Equal(t, 3*testSeatPrice, booking.Total.Amount)
+		require.Len(t, booking.Seats, 2)
+		require.Equal(t, int32(4), booking.TicketVersion, "Every change should reissue the tickets")
+		require.Equal(t, testSeatPrice, *booking.RefundAmount)
+
+		var available int
+		err = pool.QueryRow(ctx, "SELECT COUNT(*) FROM event.seats WHERE id = ANY($1) AND status = 'AVAILABLE'", []int64{241, 242, 243}).Scan(&available)
+		require.NoError(t, err)
+		require.Equal(t, 3, available, "Seats given back should be for sale again")
+
+		changes, err := service.SeatChanges(ctx, bookingID, userID)
+		require.NoError(t, err)
+		require.Len(t, changes, 3)
+		require.Equal(t, "SENT", changes[0].RefundStatus)
+		require.Empty(t, changes[1].RefundStatus, "A swap at the same price refunds nothing")
+		require.Equal(t, "COMPLETED", changes[2].Status)
+		require.Equal(t, []int64{245}, changes[2].AddedSeatIDs)
+
+		_, err = pool.Exec(ctx, "INSERT INTO event.seats (id, event_id, status, price_tier_id) SELECT 246, event_id, 'AVAILABLE', price_tier_id FROM event.seats WHERE id = 245")
+		require.NoError(t, err)
+		late, err := service.ExchangeSeats(ctx, bookingID, userID, []int64{244}, []int64{246})
+		require.NoError(t, err)
+		_, err = pool.Exec(ctx, "UPDATE booking.seat_changes SET expires_at = NOW() - INTERVAL '1 minute' WHERE id = $1", late.ID)
+		require.NoError(t, err)
+		_, err = service.ExpireDueBookings(ctx, 10)
+		require.NoError(t, err)
+
+		// the payment of the expired exchange comes in, and is reported twice
+		require.NoError(t, service.ConfirmBooking(ctx, bookingID))
+		require.Error(t, service.ConfirmBooking(ctx, bookingID), "A payment already sent back is not settled again")
+
+		var refunds int
+		err = pool.QueryRow(
+			ctx,
+			"SELECT COUNT(*) FROM booking.refund_jobs WHERE seat_change_id = $1 AND payment_booking_id = $2 AND amount = $3",
+			late.ID,
+			bookingID,
+			testSeatPrice,
+		).Scan(&refunds)
+		require.NoError(t, err)
+		require.Equal(t, 1, refunds, "The payment of a dropped exchange should be refunded once")
+	})
+
+	t.Run("Status History - Every Transition Is Recorded With Its Cause", func(t *testing.T) {
+		successGateway := NewSimulatorPaymentGateway(func() bool { return true })
+		service := bookingservice.New(storage, successGateway)
+
+		userID := int64(26)
+		staffID := int64(27)
+		eventID := int64(25)
+		seedTestData(t, pool, userID, eventID, []int64{251})
+
+		requestCtx := context.WithValue(ctx, requestid.Key, "req-checkout")
+		bookingID, _, err := service.CreateBooking(requestCtx, userID, eventID, []int64{251}, bookingstorage.GeneralAdmission{}, nil)
+		require.NoError(t, err)
+
+		err = service.CheckInBooking(ctx, eventID, bookingID, 1, staffID)
+		require.ErrorIs(t, err, bookingservice.ErrCheckInNotPossible, "An unpaid booking cannot be checked in")
+
+		require.NoError(t, service.ConfirmBooking(ctx, bookingID))
+
+		err = service.CheckInBooking(ctx, eventID+1, bookingID, 1, staffID)
+		require.ErrorIs(t, err, bookingservice.ErrBookingNotFound, "A booking is checked in only at its own event")
+
+		// the tickets are reissued, e.g. by a seat change
+		_, err = pool.Exec(ctx, "UPDATE booking.bookings SET ticket_version = 2 WHERE id = $1", bookingID)
+		require.NoError(t, err)
+		err = service.CheckInBooking(ctx, eventID, bookingID, 1, staffID)
+		require.ErrorIs(t, err, bookingservice.ErrTicketReissued, "A voided ticket must not get in")
+
+		require.NoError(t, service.CheckInBooking(ctx, eventID, bookingID, 2, staffID))
+		err = service.CheckInBooking(ctx, eventID, bookingID, 2, staffID)
+		require.ErrorIs(t, err, bookingservice.ErrCheckInNotPossible, "A booking is checked in only once")
+
+		_, err = service.CancelUserBooking(ctx, bookingID, userID)
+		require.ErrorIs(t, err, bookingservice.ErrBookingNotCancellable, "A checked in booking cannot be cancelled")
+
+		_, err = service.BookingStatusHistory(ctx, bookingID, userID+1)
+		require.ErrorIs(t, err, bookingservice.ErrBookingNotFound)
+
+		history, err := service.BookingStatusHistory(ctx, bookingID, userID)
+		require.NoError(t, err)
+		require.Len(t, history, 4)
+
+		require.Nil(t, history[0].FromStatus)
+		require.Equal(t, "PENDING", history[0].ToStatus)
+		require.Equal(t, "user:26", history[0].Actor)
+		require.Equal(t, "BOOKED", history[0].Reason)
+		require.NotNil(t, history[0].CorrelationID)
+		require.Equal(t, "req-checkout", *history[0].CorrelationID)
+
+		require.Equal(t, "PENDING", *history[1].FromStatus)
+		require.Equal(t, "AWAITING_PAYMENT", history[1].ToStatus)
+		require.Equal(t, "req-checkout", *history[1].CorrelationID, "The payment is started by the same request")
+
+		require.Equal(t, "CONFIRMED", history[2].ToStatus)
+		require.Equal(t, "payment", history[2].Actor)
+		require.Nil(t, history[2].CorrelationID)
+
+		require.Equal(t, "CONFIRMED", *history[3].FromStatus)
+		require.Equal(t, "CHECKED_IN", history[3].ToStatus)
+		require.Equal(t, "user:27", history[3].Actor)
+	})
+
+	t.Run("Orders - Bookings Of Several Events Are Bought Together", func(t *testing.T) {
+		successGateway := NewSimulatorPaymentGateway(func() bool { return true })
+		service := bookingservice.New(storage, successGateway)
+
+		userID := int64(28)
+		seedTestData(t, pool, userID, 26, []int64{261, 262, 263})
+		seedTestData(t, pool, userID, 27, []int64{271, 272, 273})
+		_, err := pool.Exec(ctx, "UPDATE event.events SET hold_minutes = 30 WHERE id = 26")
+		require.NoError(t, err)
+
+		order := func(seat26, seat27 int64) []bookingstorage.OrderItem {
+			return []bookingstorage.OrderItem{
+				{EventID: 27, SeatIDs: []int64{seat27}},
+				{EventID: 26, SeatIDs: []int64{seat26}},
+			}
+		}
+		seatStatus := func(seatID int64) string {
+			var status string
+			err := pool.QueryRow(ctx, "SELECT status FROM event.seats WHERE id = $1", seatID).Scan(&status)
+			require.NoError(t, err)
+			return status
+		}
+
+		_, _, err = service.CreateOrder(ctx, userID, []bookingstorage.OrderItem{
+			{EventID: 26, SeatIDs: []int64{261}},
+			{EventID: 26, SeatIDs: []int64{262}},
+		})
+		require.ErrorIs(t, err, bookingservice.ErrInvalidOrder, "An order books each event once")
+
+		_, _, err = service.CreateOrder(ctx, userID, []bookingstorage.OrderItem{
+			{EventID: 26, SeatIDs: []int64{261}},
+			{EventID: 27, SeatIDs: []int64{999}},
+		})
+		require.Error(t, err)
+		require.Equal(t, "AVAILABLE", seatStatus(261), "A failed order should book nothing")
+
+		orderID, total, err := service.CreateOrder(ctx, userID, order(261, 271))
+		require.NoError(t, err)
+		require.Equal(t, 2*testSeatPrice, total.Amount)
+		require.Equal(t, "RUB", total.Currency)
+
+		_, err = service.GetOrder(ctx, orderID, userID+1)
+		require.ErrorIs(t, err, bookingservice.ErrOrderNotFound, "Another user must not see the order")
+
+		paid, err := service.GetOrder(ctx, orderID, userID)
+		require.NoError(t, err)
+		require.Equal(t, "PENDING", paid.Status)
+		require.Len(t, paid.Bookings, 2)
+		require.Equal(t, paid.Bookings[0].ID, paid.PaymentBookingID)
+		for _, booking := range paid.Bookings {
+			require.Equal(t, "AWAITING_PAYMENT", booking.Status)
+			require.Equal(t, orderID, booking.OrderID)
+			require.NotNil(t, booking.ExpiresAt)
+			require.True(t, booking.ExpiresAt.Equal(*paid.ExpiresAt), "The bookings of an order are held until the same time")
+		}
+		require.WithinDuration(t, paid.CreatedAt.Add(15*time.Minute), *paid.ExpiresAt, time.Minute, "The shortest hold of the events should be used")
+
+		require.NoError(t, service.ConfirmBooking(ctx, paid.PaymentBookingID))
+
+		paid, err = service.GetOrder(ctx, orderID, userID)
+		require.NoError(t, err)
+		require.Equal(t, "CONFIRMED", paid.Status)
+		require.Nil(t, paid.ExpiresAt)
+		for _, booking := range paid.Bookings {
+			require.Equal(t, "CONFIRMED", booking.Status, "The payment of the order should confirm all of its bookings")
+		}
+		require.Equal(t, "BOOKED", seatStatus(261))
+		require.Equal(t, "BOOKED", seatStatus(271))
+
+		err = service.CancelOrder(ctx, orderID, userID)
+		require.ErrorIs(t, err, bookingservice.ErrOrderNotPending, "A paid order is cancelled a booking at a time")
+
+		expiringID, _, err := service.CreateOrder(ctx, userID, order(262, 272))
+		require.NoError(t, err)
+		expiring, err := service.GetOrder(ctx, expiringID, userID)
+		require.NoError(t, err)
+
+		_, err = pool.Exec(ctx, "UPDATE booking.bookings SET expires_at = NOW() - INTERVAL '1 second' WHERE id = $1", expiring.Bookings[1].ID)
+		require.NoError(t, err)
+		require.NoError(t, service.ExpireBooking(ctx, expiring.Bookings[1].ID))
+
+		expiring, err = service.GetOrder(ctx, expiringID, userID)
+		require.NoError(t, err)
+		require.Equal(t, "EXPIRED", expiring.Status)
+		for _, booking := range expiring.Bookings {
+			require.Equal(t, "EXPIRED", booking.Status, "One booking of an order running out should release the rest")
+		}
+		require.Equal(t, "AVAILABLE", seatStatus(262))
+		require.Equal(t, "AVAILABLE", seatStatus(272))
+
+		cancelledID, _, err := service.CreateOrder(ctx, userID, order(263, 273))
+		require.NoError(t, err)
+		require.NoError(t, service.CancelOrder(ctx, cancelledID, userID))
+
+		cancelled, err := service.GetOrder(ctx, cancelledID, userID)
+		require.NoError(t, err)
+		require.Equal(t, "CANCELLED", cancelled.Status)
+		for _, booking := range cancelled.Bookings {
+			require.Equal(t, "CANCELLED", booking.Status)
+		}
+		require.Equal(t, "AVAILABLE", seatStatus(263))
+		require.Equal(t, "AVAILABLE", seatStatus(273))
+
+		err = service.CancelOrder(ctx, cancelledID, userID)
+		require.ErrorIs(t, err, bookingservice.ErrOrderNotPending)
+
+		var runs atomic.Int32
+		create := func(ctx context.Context) ([]byte, error) {
+			runs.Add(1)
+			orderID, _, err := service.CreateOrder(ctx, userID, order(262, 272))
+			if err != nil {
+				return nil, err
+			}
+			return []byte(strconv.FormatInt(orderID, 10)), nil
+		}
+		replay := func(ctx context.Context, bookingID int64) ([]byte, error) {
+			booking, err := service.GetBooking(ctx, bookingID, userID)
+			if err != nil {
+				return nil, err
+			}
+			return []byte(strconv.FormatInt(booking.OrderID, 10)), nil
+		}
+
+		response, err := service.Idempotent(ctx, userID, "order-1", []byte("seats 262 272"), create, replay)
+		require.NoError(t, err)
+		retried, err := service.Idempotent(ctx, userID, "order-1", []byte("seats 262 272"), create, replay)
+		require.NoError(t, err)
+		require.Equal(t, response, retried, "A retried order should get the original order back")
+		require.Equal(t, int32(1), runs.Load(), "The order should be created once")
+
+		keyedID, err := strconv.ParseInt(string(response), 10, 64)
+		require.NoError(t, err)
+		keyed, err := service.GetOrder(ctx, keyedID, userID)
+		require.NoError(t, err)
+		var linkedID int64
+		err = pool.QueryRow(ctx, "SELECT booking_id FROM booking.idempotency_keys WHERE user_id = $1 AND key = 'order-1'", userID).Scan(&linkedID)
+		require.NoError(t, err)
+		require.Equal(t, keyed.PaymentBookingID, linkedID, "The key should be tied to the payment booking so a died request is replayed")
+	})
 }
 
 func applyMigrations(t *testing.T, pool *pgxpool.Pool) {
@@ -144,13 +1344,39 @@ func applyMigrations(t *testing.T, pool *pgxpool.Pool) {
 		`CREATE SCHEMA IF NOT EXISTS event;`,
 		`CREATE SCHEMA IF NOT EXISTS booking;`,
 		`CREATE TABLE IF NOT EXISTS auth.users (id BIGSERIAL PRIMARY KEY);`,
-		`CREATE TABLE IF NOT EXISTS event.events (id BIGSERIAL PRIMARY KEY);`,
-		`CREATE TYPE booking_status AS ENUM ('PENDING', 'CONFIRMED', 'CANCELLED', 'EXPIRED');`,
-		`CREATE TABLE IF NOT EXISTS booking.bookings (id BIGSERIAL PRIMARY KEY, user_id BIGINT REFERENCES auth.users(id), event_id BIGINT REFERENCES event.events(id), status booking_status);`,
+		`CREATE TYPE event_status AS ENUM ('DRAFT', 'PUBLISHED', 'ON_SALE', 'SOLD_OUT', 'CANCELLED');`,
+		`CREATE TABLE IF NOT EXISTS event.events (id BIGSERIAL PRIMARY KEY, title VARCHAR(255) NOT NULL DEFAULT '', starts_at TIMESTAMPTZ, status event_status NOT NULL DEFAULT 'ON_SALE', on_sale_at TIMESTAMPTZ, off_sale_at TIMESTAMPTZ, hold_minutes INT, max_hold_extensions INT);`,
+		`CREATE TABLE IF NOT EXISTS event.transfer_policies (event_id BIGINT PRIMARY KEY REFERENCES event.events(id), transfers_allowed BOOLEAN NOT NULL DEFAULT TRUE, cutoff_hours INT NOT NULL DEFAULT 0, max_transfers INT NOT NULL DEFAULT 0);`,
+		`CREATE TYPE booking_status AS ENUM ('PENDING', 'CONFIRMED', 'CANCELLED', 'EXPIRED', 'REFUNDED', 'EVENT_CANCELLED', 'AWAITING_PAYMENT', 'CHECKED_IN');`,
+		`CREATE TABLE IF NOT EXISTS booking.bookings (id BIGSERIAL PRIMARY KEY, user_id BIGINT REFERENCES auth.users(id), event_id BIGINT REFERENCES event.events(id), status booking_status, total_amount BIGINT, discount_amount BIGINT NOT NULL DEFAULT 0, currency CHAR(3), refund_amount BIGINT, expires_at TIMESTAMPTZ, hold_extensions INT NOT NULL DEFAULT 0, transferred_from_id BIGINT REFERENCES booking.bookings(id), transfer_count INT NOT NULL DEFAULT 0, ticket_version INT NOT NULL DEFAULT 1, created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(), updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW());`,
+		`CREATE TABLE IF NOT EXISTS event.price_tiers (id BIGSERIAL PRIMARY KEY, event_id BIGINT NOT NULL REFERENCES event.events(id), name VARCHAR(100) NOT NULL, amount BIGINT NOT NULL, currency CHAR(3) NOT NULL, UNIQUE (event_id, name));`,
 		`CREATE TYPE seat_status AS ENUM ('AVAILABLE', 'BOOKED', 'RESERVED');`,
-		`CREATE TABLE IF NOT EXISTS event.seats (id BIGSERIAL PRIMARY KEY, event_id BIGINT REFERENCES event.events(id), status seat_status);`,
-		`CREATE TABLE IF NOT EXISTS booking.booking_seats (booking_id BIGINT REFERENCES booking.bookings(id), seat_id BIGINT REFERENCES event.seats(id));`,
-		`CREATE TABLE IF NOT EXISTS booking.outbox_messages (id BIGSERIAL PRIMARY KEY, exchange TEXT NOT NULL, routing_key TEXT NOT NULL, payload JSONB NOT NULL, created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(), processed_at TIMESTAMPTZ);`,
+		`CREATE TABLE IF NOT EXISTS event.seats (id BIGSERIAL PRIMARY KEY, event_id BIGINT REFERENCES event.events(id), seat_number VARCHAR(10), row_number INT, sector VARCHAR(50), status seat_status, price_tier_id BIGINT REFERENCES event.price_tiers(id));`,
+		`CREATE TABLE IF NOT EXISTS booking.booking_seats (booking_id BIGINT REFERENCES booking.bookings(id), seat_id BIGINT REFERENCES event.seats(id), price_amount BIGINT, price_currency CHAR(3));`,
+		`CREATE TABLE IF NOT EXISTS booking.outbox_messages (id BIGSERIAL PRIMARY KEY, exchange TEXT NOT NULL, routing_key TEXT NOT NULL, payload JSONB NOT NULL, secret_keys TEXT[] NOT NULL DEFAULT '{}', created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(), processed_at TIMESTAMPTZ);`,
+		`CREATE TABLE IF NOT EXISTS event.inventory_pools (id BIGSERIAL PRIMARY KEY, event_id BIGINT NOT NULL REFERENCES event.events(id), name VARCHAR(100) NOT NULL, capacity INT NOT NULL, sold INT NOT NULL DEFAULT 0 CHECK (sold >= 0), held INT NOT NULL DEFAULT 0 CHECK (held >= 0), price_tier_id BIGINT REFERENCES event.price_tiers(id), CHECK (sold + held <= capacity));`,
+		`CREATE TABLE IF NOT EXISTS booking.booking_pool_items (booking_id BIGINT NOT NULL REFERENCES booking.bookings(id), pool_id BIGINT NOT NULL REFERENCES event.inventory_pools(id), quantity INT NOT NULL, price_amount BIGINT NOT NULL, price_currency CHAR(3) NOT NULL, PRIMARY KEY (booking_id, pool_id));`,
+		`CREATE TABLE IF NOT EXISTS event.refund_policies (event_id BIGINT PRIMARY KEY REFERENCES event.events(id), non_refundable BOOLEAN NOT NULL DEFAULT FALSE, full_refund_hours INT NOT NULL DEFAULT 0, partial_refund_hours INT NOT NULL DEFAULT 0, partial_refund_percent INT NOT NULL DEFAULT 0);`,
+		`CREATE TABLE IF NOT EXISTS event.purchase_limits (event_id BIGINT PRIMARY KEY REFERENCES event.events(id), max_tickets_per_user INT NOT NULL DEFAULT 0, max_pending_bookings INT NOT NULL DEFAULT 0, max_tickets_per_booking INT NOT NULL DEFAULT 0);`,
+		`CREATE TABLE IF NOT EXISTS booking.idempotency_keys (user_id BIGINT NOT NULL, key VARCHAR(255) NOT NULL, fingerprint BYTEA NOT NULL, response BYTEA, created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(), claim_id BIGSERIAL UNIQUE, lease_until TIMESTAMPTZ NOT NULL DEFAULT NOW(), booking_id BIGINT REFERENCES booking.bookings(id), PRIMARY KEY (user_id, key));`,
+		`CREATE TABLE IF NOT EXISTS booking.waiting_rooms (event_id BIGINT PRIMARY KEY, admit_per_minute INT NOT NULL, admission_minutes INT NOT NULL, last_admission_at TIMESTAMPTZ NOT NULL DEFAULT NOW());`,
+		`CREATE TABLE IF NOT EXISTS booking.waiting_room_entries (id BIGSERIAL PRIMARY KEY, event_id BIGINT NOT NULL REFERENCES booking.waiting_rooms(event_id) ON DELETE CASCADE, user_id BIGINT NOT NULL, joined_at TIMESTAMPTZ NOT NULL DEFAULT NOW(), admitted_at TIMESTAMPTZ, UNIQUE (event_id, user_id));`,
+		`CREATE TABLE IF NOT EXISTS booking.waitlist_entries (id BIGSERIAL PRIMARY KEY, event_id BIGINT NOT NULL, user_id BIGINT NOT NULL, pool_id BIGINT, quantity INT NOT NULL, status VARCHAR(20) NOT NULL DEFAULT 'WAITING', offer_token_hash BYTEA UNIQUE, offered_seat_ids BIGINT[] NOT NULL DEFAULT '{}', offer_expires_at TIMESTAMPTZ, booking_id BIGINT REFERENCES booking.bookings(id), created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(), updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW());`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_waitlist_entries_on_event_id_user_id ON booking.waitlist_entries (event_id, user_id) WHERE status IN ('WAITING', 'OFFERED');`,
+		`CREATE TABLE IF NOT EXISTS booking.promo_codes (id BIGSERIAL PRIMARY KEY, code VARCHAR(50) NOT NULL UNIQUE, discount_type VARCHAR(10) NOT NULL, percent INT NOT NULL DEFAULT 0, amount BIGINT NOT NULL DEFAULT 0, currency CHAR(3), valid_from TIMESTAMPTZ, valid_until TIMESTAMPTZ, max_redemptions INT NOT NULL DEFAULT 0, max_redemptions_per_user INT NOT NULL DEFAULT 0, redemptions INT NOT NULL DEFAULT 0, event_id BIGINT, price_tier_ids BIGINT[] NOT NULL DEFAULT '{}', stackable BOOLEAN NOT NULL DEFAULT FALSE, active BOOLEAN NOT NULL DEFAULT TRUE, created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(), CONSTRAINT chk_promo_codes_within_cap CHECK (max_redemptions = 0 OR redemptions <= max_redemptions));`,
+		`CREATE TABLE IF NOT EXISTS booking.promo_redemptions (promo_code_id BIGINT NOT NULL REFERENCES booking.promo_codes(id), booking_id BIGINT NOT NULL REFERENCES booking.bookings(id), user_id BIGINT NOT NULL, discount_amount BIGINT NOT NULL, created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(), PRIMARY KEY (promo_code_id, booking_id));`,
+		`CREATE TABLE IF NOT EXISTS booking.event_cancellation_jobs (id BIGSERIAL PRIMARY KEY, event_id BIGINT NOT NULL UNIQUE REFERENCES event.events(id), reason TEXT NOT NULL DEFAULT '', status TEXT NOT NULL DEFAULT 'RUNNING', created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(), finished_at TIMESTAMPTZ);`,
+		`CREATE TABLE IF NOT EXISTS booking.event_cancellation_items (job_id BIGINT NOT NULL REFERENCES booking.event_cancellation_jobs(id), booking_id BIGINT NOT NULL REFERENCES booking.bookings(id), status TEXT NOT NULL DEFAULT 'PENDING', attempts INT NOT NULL DEFAULT 0, last_error TEXT, updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(), PRIMARY KEY (job_id, booking_id));`,
+		`CREATE TABLE IF NOT EXISTS booking.ticket_transfers (id BIGSERIAL PRIMARY KEY, booking_id BIGINT NOT NULL REFERENCES booking.bookings(id), sender_id BIGINT NOT NULL, recipient_email VARCHAR(255) NOT NULL, seat_ids BIGINT[] NOT NULL DEFAULT '{}', status VARCHAR(20) NOT NULL DEFAULT 'PENDING', token_hash BYTEA NOT NULL UNIQUE, expires_at TIMESTAMPTZ NOT NULL, recipient_id BIGINT, recipient_booking_id BIGINT REFERENCES booking.bookings(id), created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(), updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW());`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_ticket_transfers_on_booking_id ON booking.ticket_transfers (booking_id) WHERE status = 'PENDING';`,
+		`CREATE TABLE IF NOT EXISTS booking.ownership_changes (id BIGSERIAL PRIMARY KEY, booking_id BIGINT NOT NULL REFERENCES booking.bookings(id), from_booking_id BIGINT REFERENCES booking.bookings(id), seat_ids BIGINT[] NOT NULL DEFAULT '{}', from_user_id BIGINT, to_user_id BIGINT NOT NULL, transfer_id BIGINT REFERENCES booking.ticket_transfers(id), created_at TIMESTAMPTZ NOT NULL DEFAULT NOW());`,
+		`CREATE TABLE IF NOT EXISTS booking.seat_changes (id BIGSERIAL PRIMARY KEY, booking_id BIGINT NOT NULL REFERENCES booking.bookings(id), change_type VARCHAR(20) NOT NULL, status VARCHAR(20) NOT NULL, released_seat_ids BIGINT[] NOT NULL DEFAULT '{}', released_amount BIGINT NOT NULL DEFAULT 0, released_discount BIGINT NOT NULL DEFAULT 0, added_seat_ids BIGINT[] NOT NULL DEFAULT '{}', added_seat_prices BIGINT[] NOT NULL DEFAULT '{}', amount_due BIGINT NOT NULL DEFAULT 0, refund_amount BIGINT, currency CHAR(3) NOT NULL, expires_at TIMESTAMPTZ, created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(), updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW());`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_seat_changes_pending_on_booking_id ON booking.seat_changes (booking_id) WHERE status = 'PENDING';`,
+		`CREATE TABLE IF NOT EXISTS booking.booking_status_history (id BIGSERIAL PRIMARY KEY, booking_id BIGINT NOT NULL REFERENCES booking.bookings(id), from_status booking_status, to_status booking_status NOT NULL, actor VARCHAR(50) NOT NULL, reason VARCHAR(50) NOT NULL, correlation_id VARCHAR(255), created_at TIMESTAMPTZ NOT NULL DEFAULT NOW());`,
+		`CREATE TABLE IF NOT EXISTS booking.orders (id BIGSERIAL PRIMARY KEY, user_id BIGINT NOT NULL, status VARCHAR(20) NOT NULL DEFAULT 'PENDING', total_amount BIGINT NOT NULL DEFAULT 0, currency CHAR(3), payment_booking_id BIGINT REFERENCES booking.bookings(id), created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(), updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW());`,
+		`ALTER TABLE booking.bookings ADD COLUMN IF NOT EXISTS order_id BIGINT REFERENCES booking.orders(id);`,
+		`CREATE TABLE IF NOT EXISTS booking.refund_jobs (id BIGSERIAL PRIMARY KEY, idempotency_key VARCHAR(100) NOT NULL UNIQUE, booking_id BIGINT NOT NULL REFERENCES booking.bookings(id), payment_booking_id BIGINT NOT NULL REFERENCES booking.bookings(id), amount BIGINT, currency CHAR(3), seat_change_id BIGINT REFERENCES booking.seat_changes(id), status VARCHAR(20) NOT NULL DEFAULT 'PENDING', attempts INT NOT NULL DEFAULT 0, last_error TEXT, next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(), created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(), completed_at TIMESTAMPTZ);`,
+		`ALTER TABLE booking.seat_changes ADD COLUMN IF NOT EXISTS refund_status VARCHAR(20);`,
 	}
 	for _, migration := range migrations {
 		_, err := pool.Exec(context.Background(), migration)
@@ -158,6 +1384,8 @@ func applyMigrations(t *testing.T, pool *pgxpool.Pool) {
 	}
 }
 
+const testSeatPrice = int64(150000)
+
 func seedTestData(t *testing.T, pool *pgxpool.Pool, userID, eventID int64, seatIDs []int64) {
 	_, err := pool.Exec(
 		context.Background(),
@@ -173,12 +1401,22 @@ func seedTestData(t *testing.T, pool *pgxpool.Pool, userID, eventID int64, seatI
 	)
 	require.NoError(t, err)
 
+	var tierID int64
+	err = pool.QueryRow(
+		context.Background(),
+		"INSERT INTO event.price_tiers (event_id, name, amount, currency) VALUES ($1, 'Standard', $2, 'RUB') ON CONFLICT (event_id, name) DO UPDATE SET amount = EXCLUDED.amount RETURNING id;",
+		eventID,
+		testSeatPrice,
+	).Scan(&tierID)
+	require.NoError(t, err)
+
 	for _, seatID := range seatIDs {
 		_, err = pool.Exec(
 			context.Background(),
-			"INSERT INTO event.seats (id, event_id, status) VALUES ($1, $2, 'AVAILABLE') ON CONFLICT DO NOTHING;",
+			"INSERT INTO event.seats (id, event_id, status, price_tier_id) VALUES ($1, $2, 'AVAILABLE', $3) ON CONFLICT DO NOTHING;",
 			seatID,
 			eventID,
+			tierID,
 		)
 		require.NoError(t, err)
 	}
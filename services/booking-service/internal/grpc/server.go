@@ -2,48 +2,223 @@ package grpc
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
     "log/slog"
 	"strings"
+	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	bookingv1 "github.com/kay-kewl/ticket-booking-system/gen/go/booking"
+	"github.com/kay-kewl/ticket-booking-system/internal/pagination"
 	"github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/service"
 	"github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/storage"
 )
 
 type Booking interface {
-	CreateBooking(ctx context.Context, userID, eventID int64, seatIDs []int64) (int64, error)
+	CreateBooking(ctx context.Context, userID, eventID int64, seatIDs []int64, ga storage.GeneralAdmission, promoCodes []string) (int64, storage.Price, error)
+	CreateBestAvailableBooking(ctx context.Context, userID, eventID int64, request service.BestAvailable, ga storage.GeneralAdmission, promoCodes []string) (int64, storage.Price, []storage.CandidateSeat, error)
 	ConfirmBooking(ctx context.Context, bookingID int64) error
 	CancelBooking(ctx context.Context, bookingID int64) error
+	GetEventCancellationJob(ctx context.Context, eventID int64) (*storage.CancellationJob, error)
+	UserCalendar(ctx context.Context, userID int64) ([]byte, error)
+	GetBooking(ctx context.Context, bookingID, userID int64) (*storage.Booking, error)
+	ListUserBookings(ctx context.Context, userID int64, status string, after *pagination.Cursor, pageSize int32) ([]storage.Booking, *pagination.Cursor, error)
+	CancelUserBooking(ctx context.Context, bookingID, userID int64) (storage.Price, error)
+	Idempotent(ctx context.Context, userID int64, key string, fingerprint []byte, run func(ctx context.Context) ([]byte, error), replay func(ctx context.Context, bookingID int64) ([]byte, error)) ([]byte, error)
+	ExtendHold(ctx context.Context, bookingID, userID int64) (time.Time, int32, error)
+	JoinWaitlist(ctx context.Context, eventID, userID, poolID int64, quantity int32) (*storage.WaitlistEntry, error)
+	WaitlistEntry(ctx context.Context, eventID, userID int64) (*storage.WaitlistEntry, error)
+	LeaveWaitlist(ctx context.Context, eventID, userID int64) error
+	ClaimWaitlistOffer(ctx context.Context, eventID, userID int64, token string) (int64, storage.Price, []int64, error)
+	SetPromoCode(ctx context.Context, code storage.PromoCode) (*storage.PromoCode, error)
+	GetPromoCode(ctx context.Context, code string) (*storage.PromoCode, error)
+	StartTransfer(ctx context.Context, bookingID, senderID int64, email string, seatIDs []int64) (*storage.Transfer, string, error)
+	CancelTransfer(ctx context.Context, transferID, senderID int64) (*storage.Transfer, error)
+	AcceptTransfer(ctx context.Context, token string, recipientID int64) (*storage.Transfer, error)
+	OwnershipHistory(ctx context.Context, bookingID, userID int64) ([]storage.OwnershipChange, error)
+	CancelSeats(ctx context.Context, bookingID, userID int64, seatIDs []int64) (*storage.SeatChange, error)
+	ExchangeSeats(ctx context.Context, bookingID, userID int64, releaseIDs, seatIDs []int64) (*storage.SeatChange, error)
+	SeatChanges(ctx context.Context, bookingID, userID int64) ([]storage.SeatChange, error)
+	BookingStatusHistory(ctx context.Context, bookingID, userID int64) ([]storage.StatusChange, error)
+	CheckInBooking(ctx context.Context, eventID, bookingID int64, ticketVersion int32, staffID int64) error
+	CreateOrder(ctx context.Context, userID int64, items []storage.OrderItem) (int64, storage.Price, error)
+	GetOrder(ctx context.Context, orderID, userID int64) (*storage.Order, error)
+	CancelOrder(ctx context.Context, orderID, userID int64) error
+}
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+
+	maxIdempotencyKeyLength = 255
+)
+
+type WaitingRoom interface {
+	Configure(ctx context.Context, eventID int64, admitPerMinute, admissionMinutes int32) error
+	GetWaitingRoom(ctx context.Context, eventID int64) (*storage.WaitingRoom, error)
+	Join(ctx context.Context, eventID, userID int64) (*service.QueueStatus, error)
+	Status(ctx context.Context, eventID, userID int64) (*service.QueueStatus, error)
 }
 
 type serverAPI struct {
 	bookingv1.UnimplementedBookingServiceServer
-	booking Booking
+	booking     Booking
+	waitingRoom WaitingRoom
 }
 
-func Register(gRPCServer *grpc.Server, booking Booking) {
-	bookingv1.RegisterBookingServiceServer(gRPCServer, &serverAPI{booking: booking})
+func Register(gRPCServer *grpc.Server, booking Booking, waitingRoom WaitingRoom) {
+	bookingv1.RegisterBookingServiceServer(gRPCServer, &serverAPI{booking: booking, waitingRoom: waitingRoom})
 }
 
 func (s *serverAPI) CreateBooking(ctx context.Context, req *bookingv1.CreateBookingRequest) (*bookingv1.CreateBookingResponse, error) {
-	bookingID, err := s.booking.CreateBooking(ctx, req.GetUserId(), req.GetEventId(), req.GetSeatIds())
+	key := req.GetIdempotencyKey()
+	if key == "" {
+		return s.createBooking(ctx, req)
+	}
+
+	resp := &bookingv1.CreateBookingResponse{}
+	err := s.idempotent(ctx, req.GetUserId(), key, req, resp, "booking", func(ctx context.Context) (proto.Message, error) {
+		return s.createBooking(ctx, req)
+	}, func(ctx context.Context, bookingID int64) (proto.Message, error) {
+		return s.replayBooking(ctx, req, bookingID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// idempotent runs create once per user and key and fills resp with its
+// response, the stored one on a retry. what names the created thing in errors.
+func (s *serverAPI) idempotent(ctx context.Context, userID int64, key string, req, resp proto.Message, what string,
+	create func(ctx context.Context) (proto.Message, error), replay func(ctx context.Context, bookingID int64) (proto.Message, error)) error {
+	if len(key) > maxIdempotencyKeyLength {
+		return status.Error(codes.InvalidArgument, "idempotency_key must be at most 255 characters")
+	}
+
+	fingerprint, err := requestFingerprint(req)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to create "+what)
+	}
+
+	// errors of the request itself are already gRPC statuses and pass as they are
+	var createErr error
+	response, err := s.booking.Idempotent(ctx, userID, key, fingerprint, func(ctx context.Context) ([]byte, error) {
+		created, err := create(ctx)
+		if err != nil {
+			createErr = err
+			return nil, err
+		}
+		return proto.Marshal(created)
+	}, func(ctx context.Context, bookingID int64) ([]byte, error) {
+		replayed, err := replay(ctx, bookingID)
+		if err != nil {
+			return nil, err
+		}
+		return proto.Marshal(replayed)
+	})
+	if createErr != nil {
+		return createErr
+	}
+	if err != nil {
+		if errors.Is(err, service.ErrIdempotencyKeyReused) {
+			return status.Error(codes.AlreadyExists, "idempotency key was used for a different request")
+		}
+		slog.ErrorContext(ctx, "Failed to create "+what+" idempotently", "user_id", userID, "error", err)
+		return status.Error(codes.Internal, "failed to create "+what)
+	}
+
+	if err := proto.Unmarshal(response, resp); err != nil {
+		slog.ErrorContext(ctx, "Failed to decode stored "+what+" response", "user_id", userID, "error", err)
+		return status.Error(codes.Internal, "failed to create "+what)
+	}
+
+	return nil
+}
+
+// replayBooking rebuilds the response of a request that made the booking
+// but died before its response was stored.
+func (s *serverAPI) replayBooking(ctx context.Context, req *bookingv1.CreateBookingRequest, bookingID int64) (*bookingv1.CreateBookingResponse, error) {
+	booking, err := s.booking.GetBooking(ctx, bookingID, req.GetUserId())
 	if err != nil {
-		// slog.Logger.Error("Failed to create booking (internal)", "error", err)
-		if errors.Is(err, service.ErrSeatNotAvailable) {
-			return nil, status.Error(codes.FailedPrecondition, "seat has already been reserved")
+		return nil, err
+	}
+
+	resp := &bookingv1.CreateBookingResponse{
+		BookingId:   booking.ID,
+		TotalAmount: booking.Total.Amount,
+		Currency:    booking.Total.Currency,
+	}
+	// only best available seats are listed, the caller picked the others
+	if req.GetBestAvailable() != nil {
+		for _, seat := range booking.Seats {
+			resp.SeatIds = append(resp.SeatIds, seat.ID)
+			resp.SeatLabels = append(resp.SeatLabels, seat.Label)
 		}
-		if errors.Is(err, service.ErrPaymentFailed) {
-			return nil, status.Error(codes.FailedPrecondition, "payment failed")
+	}
+
+	return resp, nil
+}
+
+// requestFingerprint hashes everything of the request but its idempotency key.
+func requestFingerprint(req proto.Message) ([]byte, error) {
+	clone := proto.Clone(req).ProtoReflect()
+	if field := clone.Descriptor().Fields().ByName("idempotency_key"); field != nil {
+		clone.Clear(field)
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(clone.Interface())
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	return sum[:], nil
+}
+
+func (s *serverAPI) createBooking(ctx context.Context, req *bookingv1.CreateBookingRequest) (*bookingv1.CreateBookingResponse, error) {
+	ga := storage.GeneralAdmission{PoolID: req.GetPoolId(), Quantity: req.GetQuantity()}
+	promoCodes := append([]string{req.GetPromoCode()}, req.GetAdditionalPromoCodes()...)
+
+	var (
+		bookingID int64
+		total     storage.Price
+		seats     []storage.CandidateSeat
+		err       error
+	)
+	if best := req.GetBestAvailable(); best != nil {
+		if len(req.GetSeatIds()) > 0 {
+			return nil, status.Error(codes.InvalidArgument, "seat_ids and best_available cannot be combined")
 		}
-		return nil, status.Error(codes.Internal, "failed to create booking")
+		bookingID, total, seats, err = s.booking.CreateBestAvailableBooking(ctx, req.GetUserId(), req.GetEventId(), service.BestAvailable{
+			Quantity: best.GetQuantity(),
+			Sector:   best.GetSector(),
+			MaxPrice: best.GetMaxPrice(),
+		}, ga, promoCodes)
+	} else {
+		bookingID, total, err = s.booking.CreateBooking(ctx, req.GetUserId(), req.GetEventId(), req.GetSeatIds(), ga, promoCodes)
+	}
+	if err != nil {
+		return nil, bookingError(err)
+	}
+
+	resp := &bookingv1.CreateBookingResponse{
+		BookingId:   bookingID,
+		TotalAmount: total.Amount,
+		Currency:    total.Currency,
+	}
+	for _, seat := range seats {
+		resp.SeatIds = append(resp.SeatIds, seat.ID)
+		resp.SeatLabels = append(resp.SeatLabels, seat.Label)
 	}
 
-	return &bookingv1.CreateBookingResponse{BookingId: bookingID}, nil
+	return resp, nil
 }
 
 func (s *serverAPI) HandlePaymentWebhook(ctx context.Context, req *bookingv1.HandlePaymentWebhookRequest) (*bookingv1.HandlePaymentWebhookResponse, error) {
@@ -69,3 +244,953 @@ func (s *serverAPI) HandlePaymentWebhook(ctx context.Context, req *bookingv1.Han
 
 	return &bookingv1.HandlePaymentWebhookResponse{}, nil
 }
+
+func (s *serverAPI) GetEventCancellationJob(ctx context.Context, req *bookingv1.GetEventCancellationJobRequest) (*bookingv1.EventCancellationJob, error) {
+	job, err := s.booking.GetEventCancellationJob(ctx, req.GetEventId())
+	if err != nil {
+		if errors.Is(err, service.ErrJobNotFound) {
+			return nil, status.Error(codes.NotFound, "no cancellation job for this event")
+		}
+		return nil, status.Error(codes.Internal, "failed to get cancellation job")
+	}
+
+	resp := &bookingv1.EventCancellationJob{
+		JobId:     job.ID,
+		EventId:   job.EventID,
+		Status:    job.Status,
+		Total:     job.Total,
+		Processed: job.Processed,
+		Failed:    job.Failed,
+		CreatedAt: job.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if job.FinishedAt != nil {
+		resp.FinishedAt = job.FinishedAt.UTC().Format(time.RFC3339)
+	}
+	for _, item := range job.FailedItems {
+		resp.FailedItems = append(resp.FailedItems, &bookingv1.FailedCancellationItem{
+			BookingId: item.BookingID,
+			Attempts:  item.Attempts,
+			Error:     item.Error,
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) GetUserCalendar(ctx context.Context, req *bookingv1.GetUserCalendarRequest) (*bookingv1.UserCalendar, error) {
+	if req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id must be positive")
+	}
+
+	content, err := s.booking.UserCalendar(ctx, req.GetUserId())
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to render user calendar", "user_id", req.GetUserId(), "error", err)
+		return nil, status.Error(codes.Internal, "failed to render calendar")
+	}
+
+	return &bookingv1.UserCalendar{Content: string(content)}, nil
+}
+
+func (s *serverAPI) GetBooking(ctx context.Context, req *bookingv1.GetBookingRequest) (*bookingv1.Booking, error) {
+	if req.GetBookingId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "booking_id and user_id must be positive")
+	}
+
+	booking, err := s.booking.GetBooking(ctx, req.GetBookingId(), req.GetUserId())
+	if err != nil {
+		if errors.Is(err, service.ErrBookingNotFound) {
+			return nil, status.Error(codes.NotFound, "booking not found")
+		}
+		slog.ErrorContext(ctx, "Failed to get booking", "booking_id", req.GetBookingId(), "error", err)
+		return nil, status.Error(codes.Internal, "failed to get booking")
+	}
+
+	return toProtoBooking(booking), nil
+}
+
+func (s *serverAPI) ListUserBookings(ctx context.Context, req *bookingv1.ListUserBookingsRequest) (*bookingv1.ListUserBookingsResponse, error) {
+	if req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id must be positive")
+	}
+
+	pageSize := req.GetPageSize()
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	var after *pagination.Cursor
+	if req.GetPageToken() != "" {
+		cursor, err := pagination.DecodeCursor(req.GetPageToken())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+		after = &cursor
+	}
+
+	bookings, next, err := s.booking.ListUserBookings(ctx, req.GetUserId(), req.GetStatus(), after, pageSize)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidBookingStatus) {
+			return nil, status.Error(codes.InvalidArgument, "unknown booking status")
+		}
+		slog.ErrorContext(ctx, "Failed to list bookings", "user_id", req.GetUserId(), "error", err)
+		return nil, status.Error(codes.Internal, "failed to list bookings")
+	}
+
+	resp := &bookingv1.ListUserBookingsResponse{}
+	for i := range bookings {
+		resp.Bookings = append(resp.Bookings, toProtoBooking(&bookings[i]))
+	}
+	if next != nil {
+		resp.NextPageToken = pagination.EncodeCursor(*next)
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) CancelBooking(ctx context.Context, req *bookingv1.CancelBookingRequest) (*bookingv1.CancelBookingResponse, error) {
+	if req.GetBookingId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "booking_id and user_id must be positive")
+	}
+
+	refund, err := s.booking.CancelUserBooking(ctx, req.GetBookingId(), req.GetUserId())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBookingNotFound):
+			return nil, status.Error(codes.NotFound, "booking not found")
+		case errors.Is(err, service.ErrBookingNotCancellable):
+			return nil, status.Error(codes.FailedPrecondition, "booking can no longer be cancelled")
+		}
+		slog.ErrorContext(ctx, "Failed to cancel booking", "booking_id", req.GetBookingId(), "error", err)
+		return nil, status.Error(codes.Internal, "failed to cancel booking")
+	}
+
+	return &bookingv1.CancelBookingResponse{
+		BookingId:    req.GetBookingId(),
+		Status:       service.StatusCancelled,
+		RefundAmount: refund.Amount,
+		Currency:     refund.Currency,
+	}, nil
+}
+
+func (s *serverAPI) ExtendHold(ctx context.Context, req *bookingv1.ExtendHoldRequest) (*bookingv1.ExtendHoldResponse, error) {
+	if req.GetBookingId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "booking_id and user_id must be positive")
+	}
+
+	expiresAt, left, err := s.booking.ExtendHold(ctx, req.GetBookingId(), req.GetUserId())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBookingNotFound):
+			return nil, status.Error(codes.NotFound, "booking not found")
+		case errors.Is(err, service.ErrHoldNotExtendable):
+			return nil, status.Error(codes.FailedPrecondition, "booking is no longer held")
+		case errors.Is(err, service.ErrHoldExtensionLimit):
+			return nil, status.Error(codes.FailedPrecondition, "hold cannot be extended any more")
+		}
+		slog.ErrorContext(ctx, "Failed to extend hold", "booking_id", req.GetBookingId(), "error", err)
+		return nil, status.Error(codes.Internal, "failed to extend hold")
+	}
+
+	return &bookingv1.ExtendHoldResponse{
+		BookingId:      req.GetBookingId(),
+		ExpiresAt:      expiresAt.UTC().Format(time.RFC3339),
+		ExtensionsLeft: left,
+	}, nil
+}
+
+func toProtoBooking(booking *storage.Booking) *bookingv1.Booking {
+	resp := &bookingv1.Booking{
+		BookingId:    booking.ID,
+		UserId:       booking.UserID,
+		EventId:      booking.EventID,
+		EventTitle:   booking.EventTitle,
+		Status:       booking.Status,
+		TotalAmount:  booking.Total.Amount,
+		Currency:       booking.Total.Currency,
+		DiscountAmount: booking.Discount,
+		RefundAmount:   booking.RefundAmount,
+		TransferredFromId: booking.TransferredFromID,
+		TicketVersion:     booking.TicketVersion,
+		OrderId:           booking.OrderID,
+		CreatedAt:      booking.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:      booking.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+	if booking.EventStartsAt != nil {
+		resp.EventStartsAt = booking.EventStartsAt.UTC().Format(time.RFC3339)
+	}
+	if booking.ExpiresAt != nil {
+		resp.ExpiresAt = booking.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	for _, seat := range booking.Seats {
+		resp.Seats = append(resp.Seats, &bookingv1.BookedSeat{
+			SeatId:      seat.ID,
+			Label:       seat.Label,
+			Sector:      seat.Sector,
+			Row:         seat.Row,
+			PriceAmount: seat.Price.Amount,
+			Currency:    seat.Price.Currency,
+		})
+	}
+	for _, item := range booking.PoolItems {
+		resp.PoolItems = append(resp.PoolItems, &bookingv1.BookedPoolItem{
+			PoolId:      item.PoolID,
+			Name:        item.Name,
+			Quantity:    item.Quantity,
+			PriceAmount: item.Price.Amount,
+			Currency:    item.Price.Currency,
+		})
+	}
+
+	return resp
+}
+
+func (s *serverAPI) SetWaitingRoom(ctx context.Context, req *bookingv1.WaitingRoom) (*bookingv1.WaitingRoom, error) {
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	err := s.waitingRoom.Configure(ctx, req.GetEventId(), req.GetAdmitPerMinute(), req.GetAdmissionMinutes())
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidWaitingRoom) {
+			return nil, status.Error(codes.InvalidArgument, "admit_per_minute must be between 0 and 100000 and admission_minutes between 1 and 1440")
+		}
+		slog.ErrorContext(ctx, "Failed to set waiting room", "event_id", req.GetEventId(), "error", err)
+		return nil, status.Error(codes.Internal, "failed to set waiting room")
+	}
+
+	return req, nil
+}
+
+func (s *serverAPI) GetWaitingRoom(ctx context.Context, req *bookingv1.GetWaitingRoomRequest) (*bookingv1.WaitingRoom, error) {
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	room, err := s.waitingRoom.GetWaitingRoom(ctx, req.GetEventId())
+	if err != nil {
+		if errors.Is(err, service.ErrWaitingRoomNotFound) {
+			return nil, status.Error(codes.NotFound, "event has no waiting room")
+		}
+		slog.ErrorContext(ctx, "Failed to get waiting room", "event_id", req.GetEventId(), "error", err)
+		return nil, status.Error(codes.Internal, "failed to get waiting room")
+	}
+
+	return &bookingv1.WaitingRoom{
+		EventId:          room.EventID,
+		AdmitPerMinute:   room.AdmitPerMinute,
+		AdmissionMinutes: room.AdmissionMinutes,
+	}, nil
+}
+
+func (s *serverAPI) JoinWaitingRoom(ctx context.Context, req *bookingv1.WaitingRoomRequest) (*bookingv1.WaitingRoomStatus, error) {
+	if req.GetEventId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id and user_id must be positive")
+	}
+
+	queueStatus, err := s.waitingRoom.Join(ctx, req.GetEventId(), req.GetUserId())
+	if err != nil {
+		if errors.Is(err, service.ErrWaitingRoomNotFound) {
+			return nil, status.Error(codes.NotFound, "event has no waiting room")
+		}
+		slog.ErrorContext(ctx, "Failed to join waiting room", "event_id", req.GetEventId(), "error", err)
+		return nil, status.Error(codes.Internal, "failed to join waiting room")
+	}
+
+	return waitingRoomStatusToProto(queueStatus), nil
+}
+
+func (s *serverAPI) GetWaitingRoomStatus(ctx context.Context, req *bookingv1.WaitingRoomRequest) (*bookingv1.WaitingRoomStatus, error) {
+	if req.GetEventId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id and user_id must be positive")
+	}
+
+	queueStatus, err := s.waitingRoom.Status(ctx, req.GetEventId(), req.GetUserId())
+	if err != nil {
+		if errors.Is(err, service.ErrNotInWaitingRoom) {
+			return nil, status.Error(codes.NotFound, "user is not in the waiting room")
+		}
+		slog.ErrorContext(ctx, "Failed to get waiting room status", "event_id", req.GetEventId(), "error", err)
+		return nil, status.Error(codes.Internal, "failed to get waiting room status")
+	}
+
+	return waitingRoomStatusToProto(queueStatus), nil
+}
+
+func waitingRoomStatusToProto(queueStatus *service.QueueStatus) *bookingv1.WaitingRoomStatus {
+	resp := &bookingv1.WaitingRoomStatus{
+		EventId:              queueStatus.EventID,
+		Position:             queueStatus.Position,
+		EstimatedWaitSeconds: int64(queueStatus.EstimatedWait / time.Second),
+		AdmissionToken:       queueStatus.Token,
+	}
+	if !queueStatus.TokenExpiresAt.IsZero() {
+		resp.TokenExpiresAt = queueStatus.TokenExpiresAt.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
+
+// bookingError turns an error of creating a booking into its gRPC status.
+func bookingError(err error) error {
+	if errors.Is(err, service.ErrSeatNotAvailable) {
+		return status.Error(codes.FailedPrecondition, "seat has already been reserved")
+	}
+	if errors.Is(err, service.ErrPaymentFailed) {
+		return status.Error(codes.FailedPrecondition, "payment failed")
+	}
+	if errors.Is(err, service.ErrEventNotOnSale) {
+		return status.Error(codes.FailedPrecondition, "event is not on sale")
+	}
+	if errors.Is(err, service.ErrTicketNotPriced) {
+		return status.Error(codes.FailedPrecondition, "ticket has no price")
+	}
+	if errors.Is(err, service.ErrNotEnoughTickets) {
+		return status.Error(codes.FailedPrecondition, "not enough tickets left in the pool")
+	}
+	if errors.Is(err, service.ErrNoSeatsTogether) {
+		return status.Error(codes.FailedPrecondition, "no available seats together")
+	}
+	if errors.Is(err, service.ErrBookingTooLarge) {
+		return status.Error(codes.ResourceExhausted, "too many tickets in one booking for this event")
+	}
+	if errors.Is(err, service.ErrTicketLimitReached) {
+		return status.Error(codes.ResourceExhausted, "ticket limit per customer for this event reached")
+	}
+	if errors.Is(err, service.ErrTooManyPendingBookings) {
+		return status.Error(codes.ResourceExhausted, "too many unpaid bookings for this event, pay or cancel one first")
+	}
+	if errors.Is(err, service.ErrIdempotencyKeyTakenOver) {
+		return status.Error(codes.Aborted, "request was taken over by a retry with the same idempotency key")
+	}
+	if errors.Is(err, service.ErrInvalidBooking) {
+		return status.Error(codes.InvalidArgument, "booking must contain seats or a positive quantity of pool tickets")
+	}
+	if errors.Is(err, service.ErrPoolNotFound) {
+		return status.Error(codes.InvalidArgument, "inventory pool does not belong to the event")
+	}
+	if errors.Is(err, service.ErrPromoCodeNotFound) {
+		return promoCodeRejected("promo code does not exist")
+	}
+	if errors.Is(err, service.ErrPromoCodeNotValid) {
+		return promoCodeRejected("promo code is not active or outside its validity window")
+	}
+	if errors.Is(err, service.ErrPromoCodeNotApplicable) {
+		return promoCodeRejected("promo code does not apply to these tickets")
+	}
+	if errors.Is(err, service.ErrPromoCodeUsedUp) {
+		return promoCodeRejected("promo code has reached its redemption limit")
+	}
+	if errors.Is(err, service.ErrPromoCodesNotStackable) {
+		return promoCodeRejected("promo codes cannot be combined")
+	}
+	return status.Error(codes.Internal, "failed to create booking")
+}
+
+// promoCodeRejectedReason marks a booking refused because of its promo codes,
+// so callers can tell it from the other FAILED_PRECONDITION refusals.
+const promoCodeRejectedReason = "PROMO_CODE_REJECTED"
+
+func promoCodeRejected(message string) error {
+	st, err := status.New(codes.FailedPrecondition, message).WithDetails(&errdetails.ErrorInfo{
+		Reason: promoCodeRejectedReason,
+		Domain: "booking",
+	})
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, message)
+	}
+	return st.Err()
+}
+
+func (s *serverAPI) JoinWaitlist(ctx context.Context, req *bookingv1.JoinWaitlistRequest) (*bookingv1.WaitlistEntry, error) {
+	if req.GetEventId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id and user_id must be positive")
+	}
+
+	entry, err := s.booking.JoinWaitlist(ctx, req.GetEventId(), req.GetUserId(), req.GetPoolId(), req.GetQuantity())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidWaitlistEntry):
+			return nil, status.Error(codes.InvalidArgument, "quantity must be between 1 and 10")
+		case errors.Is(err, service.ErrPoolNotFound):
+			return nil, status.Error(codes.InvalidArgument, "inventory pool does not belong to the event")
+		case errors.Is(err, service.ErrEventNotOnSale):
+			return nil, status.Error(codes.FailedPrecondition, "event is not on sale")
+		case errors.Is(err, service.ErrTicketsAvailable):
+			return nil, status.Error(codes.FailedPrecondition, "tickets are still available, book them instead")
+		case errors.Is(err, service.ErrAlreadyWaitlisted):
+			return nil, status.Error(codes.FailedPrecondition, "already on the waitlist of this event")
+		}
+		slog.ErrorContext(ctx, "Failed to join waitlist", "event_id", req.GetEventId(), "error", err)
+		return nil, status.Error(codes.Internal, "failed to join waitlist")
+	}
+
+	return waitlistEntryToProto(entry), nil
+}
+
+func (s *serverAPI) GetWaitlistEntry(ctx context.Context, req *bookingv1.WaitlistRequest) (*bookingv1.WaitlistEntry, error) {
+	if req.GetEventId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id and user_id must be positive")
+	}
+
+	entry, err := s.booking.WaitlistEntry(ctx, req.GetEventId(), req.GetUserId())
+	if err != nil {
+		if errors.Is(err, service.ErrWaitlistEntryNotFound) {
+			return nil, status.Error(codes.NotFound, "user is not on the waitlist")
+		}
+		slog.ErrorContext(ctx, "Failed to get waitlist entry", "event_id", req.GetEventId(), "error", err)
+		return nil, status.Error(codes.Internal, "failed to get waitlist entry")
+	}
+
+	return waitlistEntryToProto(entry), nil
+}
+
+func (s *serverAPI) LeaveWaitlist(ctx context.Context, req *bookingv1.WaitlistRequest) (*bookingv1.LeaveWaitlistResponse, error) {
+	if req.GetEventId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id and user_id must be positive")
+	}
+
+	if err := s.booking.LeaveWaitlist(ctx, req.GetEventId(), req.GetUserId()); err != nil {
+		if errors.Is(err, service.ErrWaitlistEntryNotFound) {
+			return nil, status.Error(codes.NotFound, "user is not on the waitlist")
+		}
+		slog.ErrorContext(ctx, "Failed to leave waitlist", "event_id", req.GetEventId(), "error", err)
+		return nil, status.Error(codes.Internal, "failed to leave waitlist")
+	}
+
+	return &bookingv1.LeaveWaitlistResponse{}, nil
+}
+
+func (s *serverAPI) ClaimWaitlistOffer(ctx context.Context, req *bookingv1.ClaimWaitlistOfferRequest) (*bookingv1.CreateBookingResponse, error) {
+	if req.GetEventId() <= 0 || req.GetUserId() <= 0 || req.GetClaimToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "event_id, user_id and claim_token are required")
+	}
+
+	bookingID, total, seatIDs, err := s.booking.ClaimWaitlistOffer(ctx, req.GetEventId(), req.GetUserId(), req.GetClaimToken())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrOfferNotFound):
+			return nil, status.Error(codes.NotFound, "waitlist offer not found")
+		case errors.Is(err, service.ErrOfferExpired):
+			return nil, status.Error(codes.FailedPrecondition, "waitlist offer has expired")
+		}
+		return nil, bookingError(err)
+	}
+
+	return &bookingv1.CreateBookingResponse{
+		BookingId:   bookingID,
+		TotalAmount: total.Amount,
+		Currency:    total.Currency,
+		SeatIds:     seatIDs,
+	}, nil
+}
+
+func waitlistEntryToProto(entry *storage.WaitlistEntry) *bookingv1.WaitlistEntry {
+	resp := &bookingv1.WaitlistEntry{
+		EventId:  entry.EventID,
+		Status:   entry.Status,
+		Quantity: entry.Quantity,
+		PoolId:   entry.PoolID,
+		Position: entry.Position,
+	}
+	if entry.Status == "OFFERED" {
+		resp.OfferedSeatIds = entry.OfferedSeatIDs
+		resp.OfferExpiresAt = entry.OfferExpiresAt.UTC().Format(time.RFC3339)
+	}
+	if entry.BookingID != nil {
+		resp.BookingId = *entry.BookingID
+	}
+	return resp
+}
+
+func (s *serverAPI) SetPromoCode(ctx context.Context, req *bookingv1.PromoCode) (*bookingv1.PromoCode, error) {
+	code := storage.PromoCode{
+		Code:                  req.GetCode(),
+		DiscountType:          strings.ToUpper(req.GetDiscountType()),
+		Percent:               req.GetPercent(),
+		Amount:                storage.Price{Amount: req.GetAmount(), Currency: req.GetCurrency()},
+		MaxRedemptions:        req.GetMaxRedemptions(),
+		MaxRedemptionsPerUser: req.GetMaxRedemptionsPerUser(),
+		EventID:               req.GetEventId(),
+		PriceTierIDs:          req.GetPriceTierIds(),
+		Stackable:             req.GetStackable(),
+		Active:                req.GetActive(),
+	}
+	var err error
+	code.ValidFrom, err = parseOptionalTime(req.GetValidFrom())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "valid_from must be an RFC 3339 time")
+	}
+	code.ValidUntil, err = parseOptionalTime(req.GetValidUntil())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "valid_until must be an RFC 3339 time")
+	}
+
+	saved, err := s.booking.SetPromoCode(ctx, code)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPromoCode):
+			return nil, status.Error(codes.InvalidArgument, "invalid promo code: check the code, discount and validity window")
+		case errors.Is(err, service.ErrPromoCodeUsedUp):
+			return nil, status.Error(codes.FailedPrecondition, "promo code has been redeemed more often than the new cap")
+		}
+		slog.ErrorContext(ctx, "Failed to set promo code", "code", req.GetCode(), "error", err)
+		return nil, status.Error(codes.Internal, "failed to set promo code")
+	}
+
+	return promoCodeToProto(saved), nil
+}
+
+func (s *serverAPI) GetPromoCode(ctx context.Context, req *bookingv1.GetPromoCodeRequest) (*bookingv1.PromoCode, error) {
+	promo, err := s.booking.GetPromoCode(ctx, req.GetCode())
+	if err != nil {
+		if errors.Is(err, service.ErrPromoCodeNotFound) {
+			return nil, status.Error(codes.NotFound, "promo code not found")
+		}
+		slog.ErrorContext(ctx, "Failed to get promo code", "code", req.GetCode(), "error", err)
+		return nil, status.Error(codes.Internal, "failed to get promo code")
+	}
+
+	return promoCodeToProto(promo), nil
+}
+
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func promoCodeToProto(promo *storage.PromoCode) *bookingv1.PromoCode {
+	resp := &bookingv1.PromoCode{
+		Code:                  promo.Code,
+		DiscountType:          promo.DiscountType,
+		Percent:               promo.Percent,
+		Amount:                promo.Amount.Amount,
+		Currency:              promo.Amount.Currency,
+		MaxRedemptions:        promo.MaxRedemptions,
+		MaxRedemptionsPerUser: promo.MaxRedemptionsPerUser,
+		EventId:               promo.EventID,
+		PriceTierIds:          promo.PriceTierIDs,
+		Stackable:             promo.Stackable,
+		Active:                promo.Active,
+		Redemptions:           promo.Redemptions,
+	}
+	if promo.ValidFrom != nil {
+		resp.ValidFrom = promo.ValidFrom.UTC().Format(time.RFC3339)
+	}
+	if promo.ValidUntil != nil {
+		resp.ValidUntil = promo.ValidUntil.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
+
+func (s *serverAPI) StartTransfer(ctx context.Context, req *bookingv1.StartTransferRequest) (*bookingv1.Transfer, error) {
+	if req.GetBookingId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "booking_id and user_id must be positive")
+	}
+
+	transfer, _, err := s.booking.StartTransfer(ctx, req.GetBookingId(), req.GetUserId(), req.GetRecipientEmail(), req.GetSeatIds())
+	if err != nil {
+		return nil, transferError(ctx, "Failed to start transfer", err)
+	}
+
+	return transferToProto(transfer), nil
+}
+
+func (s *serverAPI) CancelTransfer(ctx context.Context, req *bookingv1.CancelTransferRequest) (*bookingv1.Transfer, error) {
+	if req.GetTransferId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "transfer_id and user_id must be positive")
+	}
+
+	transfer, err := s.booking.CancelTransfer(ctx, req.GetTransferId(), req.GetUserId())
+	if err != nil {
+		return nil, transferError(ctx, "Failed to cancel transfer", err)
+	}
+
+	return transferToProto(transfer), nil
+}
+
+func (s *serverAPI) AcceptTransfer(ctx context.Context, req *bookingv1.AcceptTransferRequest) (*bookingv1.Transfer, error) {
+	if req.GetUserId() <= 0 || req.GetAcceptToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and accept_token are required")
+	}
+
+	transfer, err := s.booking.AcceptTransfer(ctx, req.GetAcceptToken(), req.GetUserId())
+	if err != nil {
+		return nil, transferError(ctx, "Failed to accept transfer", err)
+	}
+
+	return transferToProto(transfer), nil
+}
+
+func (s *serverAPI) GetOwnershipHistory(ctx context.Context, req *bookingv1.GetOwnershipHistoryRequest) (*bookingv1.OwnershipHistory, error) {
+	if req.GetBookingId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "booking_id and user_id must be positive")
+	}
+
+	changes, err := s.booking.OwnershipHistory(ctx, req.GetBookingId(), req.GetUserId())
+	if err != nil {
+		if errors.Is(err, service.ErrBookingNotFound) {
+			return nil, status.Error(codes.NotFound, "booking not found")
+		}
+		slog.ErrorContext(ctx, "Failed to get ownership history", "booking_id", req.GetBookingId(), "error", err)
+		return nil, status.Error(codes.Internal, "failed to get ownership history")
+	}
+
+	resp := &bookingv1.OwnershipHistory{}
+	for _, c := range changes {
+		change := &bookingv1.OwnershipChange{
+			BookingId: c.BookingID,
+			SeatIds:   c.SeatIDs,
+			ToUserId:  c.ToUserID,
+			ChangedAt: c.ChangedAt.UTC().Format(time.RFC3339),
+		}
+		if c.FromBookingID != nil {
+			change.FromBookingId = *c.FromBookingID
+		}
+		if c.FromUserID != nil {
+			change.FromUserId = *c.FromUserID
+		}
+		if c.TransferID != nil {
+			change.TransferId = *c.TransferID
+		}
+		resp.Changes = append(resp.Changes, change)
+	}
+
+	return resp, nil
+}
+
+// transferError turns an error of a ticket transfer into its gRPC status.
+func transferError(ctx context.Context, msg string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidTransfer):
+		return status.Error(codes.InvalidArgument, "recipient_email must be a valid email address")
+	case errors.Is(err, service.ErrSeatNotInBooking):
+		return status.Error(codes.InvalidArgument, "seat is not part of the booking")
+	case errors.Is(err, service.ErrBookingNotFound):
+		return status.Error(codes.NotFound, "booking not found")
+	case errors.Is(err, service.ErrTransferNotFound):
+		return status.Error(codes.NotFound, "transfer not found")
+	case errors.Is(err, service.ErrBookingNotTransferable):
+		return status.Error(codes.FailedPrecondition, "transfer needs a confirmed booking of the sender")
+	case errors.Is(err, service.ErrTransferNotAllowed):
+		return status.Error(codes.FailedPrecondition, "transfer is not allowed for this event (anymore)")
+	case errors.Is(err, service.ErrTransferLimitReached):
+		return status.Error(codes.FailedPrecondition, "transfer limit of these tickets reached")
+	case errors.Is(err, service.ErrTransferPending):
+		return status.Error(codes.FailedPrecondition, "transfer of this booking is already pending")
+	case errors.Is(err, service.ErrTransferClosed):
+		return status.Error(codes.FailedPrecondition, "transfer is no longer pending")
+	case errors.Is(err, service.ErrTransferExpired):
+		return status.Error(codes.FailedPrecondition, "transfer has expired")
+	case errors.Is(err, service.ErrTransferToSelf):
+		return status.Error(codes.FailedPrecondition, "transfer cannot be accepted by its sender")
+	}
+	slog.ErrorContext(ctx, msg, "error", err)
+	return status.Error(codes.Internal, strings.ToLower(msg))
+}
+
+func transferToProto(transfer *storage.Transfer) *bookingv1.Transfer {
+	resp := &bookingv1.Transfer{
+		TransferId:     transfer.ID,
+		BookingId:      transfer.BookingID,
+		RecipientEmail: transfer.RecipientEmail,
+		SeatIds:        transfer.SeatIDs,
+		Status:         transfer.Status,
+		ExpiresAt:      transfer.ExpiresAt.UTC().Format(time.RFC3339),
+		CreatedAt:      transfer.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if transfer.RecipientBookingID != nil {
+		resp.RecipientBookingId = *transfer.RecipientBookingID
+	}
+	return resp
+}
+
+func (s *serverAPI) CancelSeats(ctx context.Context, req *bookingv1.CancelSeatsRequest) (*bookingv1.SeatChange, error) {
+	if req.GetBookingId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "booking_id and user_id must be positive")
+	}
+
+	change, err := s.booking.CancelSeats(ctx, req.GetBookingId(), req.GetUserId(), req.GetSeatIds())
+	if err != nil {
+		return nil, seatChangeError(ctx, "Failed to cancel seats", err)
+	}
+
+	return seatChangeToProto(change), nil
+}
+
+func (s *serverAPI) ExchangeSeats(ctx context.Context, req *bookingv1.ExchangeSeatsRequest) (*bookingv1.SeatChange, error) {
+	if req.GetBookingId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "booking_id and user_id must be positive")
+	}
+
+	change, err := s.booking.ExchangeSeats(ctx, req.GetBookingId(), req.GetUserId(), req.GetReleaseSeatIds(), req.GetSeatIds())
+	if err != nil {
+		return nil, seatChangeError(ctx, "Failed to exchange seats", err)
+	}
+
+	return seatChangeToProto(change), nil
+}
+
+func (s *serverAPI) ListSeatChanges(ctx context.Context, req *bookingv1.ListSeatChangesRequest) (*bookingv1.SeatChanges, error) {
+	if req.GetBookingId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "booking_id and user_id must be positive")
+	}
+
+	changes, err := s.booking.SeatChanges(ctx, req.GetBookingId(), req.GetUserId())
+	if err != nil {
+		if errors.Is(err, service.ErrBookingNotFound) {
+			return nil, status.Error(codes.NotFound, "booking not found")
+		}
+		slog.ErrorContext(ctx, "Failed to list seat changes", "booking_id", req.GetBookingId(), "error", err)
+		return nil, status.Error(codes.Internal, "failed to list seat changes")
+	}
+
+	resp := &bookingv1.SeatChanges{}
+	for i := range changes {
+		resp.Changes = append(resp.Changes, seatChangeToProto(&changes[i]))
+	}
+
+	return resp, nil
+}
+
+// seatChangeError turns an error of a seat change into its gRPC status.
+func seatChangeError(ctx context.Context, msg string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidSeatChange):
+		return status.Error(codes.InvalidArgument, "seat_ids must name seats to give back, and an exchange as many other seats")
+	case errors.Is(err, service.ErrSeatNotInBooking):
+		return status.Error(codes.InvalidArgument, "seat is not part of the booking")
+	case errors.Is(err, service.ErrBookingNotFound):
+		return status.Error(codes.NotFound, "booking not found")
+	case errors.Is(err, service.ErrSeatsNotChangeable):
+		return status.Error(codes.FailedPrecondition, "seats of the booking can no longer be changed")
+	case errors.Is(err, service.ErrSeatChangePending):
+		return status.Error(codes.FailedPrecondition, "a seat exchange of this booking is waiting for payment")
+	case errors.Is(err, service.ErrNoTicketsLeft):
+		return status.Error(codes.FailedPrecondition, "a booking cannot give back all of its tickets, cancel it instead")
+	case errors.Is(err, service.ErrSeatNotAvailable), errors.Is(err, service.ErrPaymentFailed),
+		errors.Is(err, service.ErrEventNotOnSale), errors.Is(err, service.ErrTicketNotPriced):
+		return bookingError(err)
+	}
+	slog.ErrorContext(ctx, msg, "error", err)
+	return status.Error(codes.Internal, strings.ToLower(msg))
+}
+
+func seatChangeToProto(change *storage.SeatChange) *bookingv1.SeatChange {
+	resp := &bookingv1.SeatChange{
+		ChangeId:        change.ID,
+		BookingId:       change.BookingID,
+		ChangeType:      change.Type,
+		Status:          change.Status,
+		ReleasedSeatIds: change.ReleasedSeatIDs,
+		AddedSeatIds:    change.AddedSeatIDs,
+		AmountDue:       change.AmountDue,
+		Currency:        change.Currency,
+		CreatedAt:       change.CreatedAt.UTC().Format(time.RFC3339),
+		RefundStatus:    change.RefundStatus,
+	}
+	if change.RefundAmount != nil {
+		resp.RefundAmount = *change.RefundAmount
+	}
+	if change.ExpiresAt != nil {
+		resp.ExpiresAt = change.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
+
+func (s *serverAPI) GetBookingStatusHistory(ctx context.Context, req *bookingv1.GetBookingStatusHistoryRequest) (*bookingv1.BookingStatusHistory, error) {
+	if req.GetBookingId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "booking_id and user_id must be positive")
+	}
+
+	changes, err := s.booking.BookingStatusHistory(ctx, req.GetBookingId(), req.GetUserId())
+	if err != nil {
+		if errors.Is(err, service.ErrBookingNotFound) {
+			return nil, status.Error(codes.NotFound, "booking not found")
+		}
+		slog.ErrorContext(ctx, "Failed to get booking status history", "booking_id", req.GetBookingId(), "error", err)
+		return nil, status.Error(codes.Internal, "failed to get booking status history")
+	}
+
+	resp := &bookingv1.BookingStatusHistory{}
+	for _, c := range changes {
+		change := &bookingv1.BookingStatusChange{
+			ToStatus:  c.ToStatus,
+			Actor:     c.Actor,
+			Reason:    c.Reason,
+			ChangedAt: c.ChangedAt.UTC().Format(time.RFC3339),
+		}
+		if c.FromStatus != nil {
+			change.FromStatus = *c.FromStatus
+		}
+		if c.CorrelationID != nil {
+			change.CorrelationId = *c.CorrelationID
+		}
+		resp.Changes = append(resp.Changes, change)
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) CheckInBooking(ctx context.Context, req *bookingv1.CheckInBookingRequest) (*bookingv1.CheckInBookingResponse, error) {
+	if req.GetEventId() <= 0 || req.GetBookingId() <= 0 || req.GetStaffId() <= 0 || req.GetTicketVersion() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id, booking_id, ticket_version and staff_id must be positive")
+	}
+
+	err := s.booking.CheckInBooking(ctx, req.GetEventId(), req.GetBookingId(), req.GetTicketVersion(), req.GetStaffId())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBookingNotFound):
+			return nil, status.Error(codes.NotFound, "booking not found for this event")
+		case errors.Is(err, service.ErrCheckInNotPossible):
+			return nil, status.Error(codes.FailedPrecondition, "only a paid booking can be checked in, and only once")
+		case errors.Is(err, service.ErrTicketReissued):
+			return nil, status.Error(codes.FailedPrecondition, "ticket has been reissued, only the latest one is valid")
+		}
+		slog.ErrorContext(ctx, "Failed to check in booking", "booking_id", req.GetBookingId(), "error", err)
+		return nil, status.Error(codes.Internal, "failed to check in booking")
+	}
+
+	return &bookingv1.CheckInBookingResponse{BookingId: req.GetBookingId(), Status: service.StatusCheckedIn}, nil
+}
+
+func (s *serverAPI) CreateOrder(ctx context.Context, req *bookingv1.CreateOrderRequest) (*bookingv1.CreateOrderResponse, error) {
+	if req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id must be positive")
+	}
+
+	items := make([]storage.OrderItem, 0, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		if item.GetEventId() <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "event_id of every item must be positive")
+		}
+		items = append(items, storage.OrderItem{
+			EventID:    item.GetEventId(),
+			SeatIDs:    item.GetSeatIds(),
+			GA:         storage.GeneralAdmission{PoolID: item.GetPoolId(), Quantity: item.GetQuantity()},
+			PromoCodes: item.GetPromoCodes(),
+		})
+	}
+
+	key := req.GetIdempotencyKey()
+	if key == "" {
+		return s.createOrder(ctx, req.GetUserId(), items)
+	}
+
+	resp := &bookingv1.CreateOrderResponse{}
+	err := s.idempotent(ctx, req.GetUserId(), key, req, resp, "order", func(ctx context.Context) (proto.Message, error) {
+		return s.createOrder(ctx, req.GetUserId(), items)
+	}, func(ctx context.Context, bookingID int64) (proto.Message, error) {
+		return s.replayOrder(ctx, req.GetUserId(), bookingID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) createOrder(ctx context.Context, userID int64, items []storage.OrderItem) (*bookingv1.CreateOrderResponse, error) {
+	orderID, total, err := s.booking.CreateOrder(ctx, userID, items)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidOrder):
+			return nil, status.Error(codes.InvalidArgument, "order must book 1 to 10 different events")
+		case errors.Is(err, service.ErrOrderCurrencyMismatch):
+			return nil, status.Error(codes.FailedPrecondition, "events of an order must be priced in one currency")
+		}
+		st := bookingError(err)
+		if status.Code(st) == codes.Internal {
+			slog.ErrorContext(ctx, "Failed to create order", "user_id", userID, "error", err)
+			return nil, status.Error(codes.Internal, "failed to create order")
+		}
+		return nil, st
+	}
+
+	return &bookingv1.CreateOrderResponse{
+		OrderId:     orderID,
+		TotalAmount: total.Amount,
+		Currency:    total.Currency,
+	}, nil
+}
+
+// replayOrder rebuilds the response of a request that made the order but
+// died before its response was stored, from the booking that pays for it.
+func (s *serverAPI) replayOrder(ctx context.Context, userID, bookingID int64) (*bookingv1.CreateOrderResponse, error) {
+	booking, err := s.booking.GetBooking(ctx, bookingID, userID)
+	if err != nil {
+		return nil, err
+	}
+	order, err := s.booking.GetOrder(ctx, booking.OrderID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bookingv1.CreateOrderResponse{
+		OrderId:     order.ID,
+		TotalAmount: order.Total.Amount,
+		Currency:    order.Total.Currency,
+	}, nil
+}
+
+func (s *serverAPI) GetOrder(ctx context.Context, req *bookingv1.GetOrderRequest) (*bookingv1.Order, error) {
+	if req.GetOrderId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "order_id and user_id must be positive")
+	}
+
+	order, err := s.booking.GetOrder(ctx, req.GetOrderId(), req.GetUserId())
+	if err != nil {
+		if errors.Is(err, service.ErrOrderNotFound) {
+			return nil, status.Error(codes.NotFound, "order not found")
+		}
+		slog.ErrorContext(ctx, "Failed to get order", "order_id", req.GetOrderId(), "error", err)
+		return nil, status.Error(codes.Internal, "failed to get order")
+	}
+
+	return orderToProto(order), nil
+}
+
+func (s *serverAPI) CancelOrder(ctx context.Context, req *bookingv1.CancelOrderRequest) (*bookingv1.Order, error) {
+	if req.GetOrderId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "order_id and user_id must be positive")
+	}
+
+	if err := s.booking.CancelOrder(ctx, req.GetOrderId(), req.GetUserId()); err != nil {
+		switch {
+		case errors.Is(err, service.ErrOrderNotFound):
+			return nil, status.Error(codes.NotFound, "order not found")
+		case errors.Is(err, service.ErrOrderNotPending):
+			return nil, status.Error(codes.FailedPrecondition, "only an unpaid order can be cancelled, cancel its bookings instead")
+		}
+		slog.ErrorContext(ctx, "Failed to cancel order", "order_id", req.GetOrderId(), "error", err)
+		return nil, status.Error(codes.Internal, "failed to cancel order")
+	}
+
+	return s.GetOrder(ctx, &bookingv1.GetOrderRequest{OrderId: req.GetOrderId(), UserId: req.GetUserId()})
+}
+
+func orderToProto(order *storage.Order) *bookingv1.Order {
+	resp := &bookingv1.Order{
+		OrderId:          order.ID,
+		Status:           order.Status,
+		TotalAmount:      order.Total.Amount,
+		Currency:         order.Total.Currency,
+		PaymentBookingId: order.PaymentBookingID,
+		CreatedAt:        order.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if order.ExpiresAt != nil {
+		resp.ExpiresAt = order.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	for i := range order.Bookings {
+		resp.Bookings = append(resp.Bookings, toProtoBooking(&order.Bookings[i]))
+	}
+
+	return resp
+}
@@ -16,16 +16,44 @@ import (
 
 var ErrSeatNotAvailable = errors.New("seat is not available")
 var ErrPaymentFailed = errors.New("failed to initiate payment")
+var ErrEventNotOnSale = errors.New("event is not on sale")
+var ErrTicketNotPriced = errors.New("ticket has no price")
+var ErrInvalidBooking = errors.New("booking must contain seats or a positive quantity of pool tickets")
+var ErrPoolNotFound = errors.New("inventory pool not found")
+var ErrNotEnoughTickets = errors.New("not enough tickets left in the pool")
+var ErrBookingTooLarge = errors.New("too many tickets in one booking")
+var ErrTicketLimitReached = errors.New("ticket limit per customer reached")
+var ErrTooManyPendingBookings = errors.New("too many unpaid bookings")
 
 type BookingCreator interface {
-	CreateBooking(ctx context.Context, userID, eventID int64, seatIDs []int64) (int64, error)
+	CreateBooking(ctx context.Context, userID, eventID int64, seatIDs []int64, ga storage.GeneralAdmission, promoCodes []string) (int64, storage.Price, error)
 	ConfirmBooking(ctx context.Context, bookingID int64) error
 	CancelBooking(ctx context.Context, bookingID int64) error
 	ExpireBooking(ctx context.Context, bookingID int64) error
+	BookingStatus(ctx context.Context, bookingID int64) (string, error)
+	RefundCancelledEventBooking(ctx context.Context, bookingID int64) error
+	EventCancellationStorage
+	BookingCalendarStorage
+	SeatingStorage
+	BookingReader
+	RefundStorage
+	ExpirationStorage
+	IdempotencyStorage
+	WaitlistStorage
+	PromotionStorage
+	TransferStorage
+	SeatChangeStorage
+	BookingStatusStorage
+	OrderStorage
 }
 
 type PaymentGateway interface {
-    InitiatePayment(ctx context.Context, bookingID int64, amount float64) error
+    // amount is in minor units of currency
+    InitiatePayment(ctx context.Context, bookingID int64, amount int64, currency string) error
+    // a refund sent again under the same key is not paid out a second time
+    RefundPayment(ctx context.Context, bookingID int64, key string) error
+    // RefundAmount pays back part of a payment, or all of it when amount is the full price
+    RefundAmount(ctx context.Context, bookingID int64, amount int64, currency string, key string) error
 }
 
 type Booking struct {
@@ -52,18 +80,44 @@ func NewHTTPPaymentGateway(url string) PaymentGateway {
     }
 }
 
-func (g *httpPaymentGateway) InitiatePayment(ctx context.Context, bookingID int64, amount float64) error {
+func (g *httpPaymentGateway) InitiatePayment(ctx context.Context, bookingID int64, amount int64, currency string) error {
     payload := map[string]any{
         "booking_id":   bookingID,
         "amount":       amount,
+        "currency":     currency,
     }
 
+    return g.post(ctx, g.url, payload)
+}
+
+// RefundPayment is safe to repeat: the payment service pays a refund out once per key
+func (g *httpPaymentGateway) RefundPayment(ctx context.Context, bookingID int64, key string) error {
+    payload := map[string]any{
+        "booking_id":       bookingID,
+        "idempotency_key":  key,
+    }
+
+    return g.post(ctx, g.url+"/refunds", payload)
+}
+
+func (g *httpPaymentGateway) RefundAmount(ctx context.Context, bookingID int64, amount int64, currency string, key string) error {
+    payload := map[string]any{
+        "booking_id":       bookingID,
+        "amount":           amount,
+        "currency":         currency,
+        "idempotency_key":  key,
+    }
+
+    return g.post(ctx, g.url+"/refunds", payload)
+}
+
+func (g *httpPaymentGateway) post(ctx context.Context, url string, payload map[string]any) error {
     body, err := json.Marshal(payload)
     if err != nil {
         return fmt.Errorf("failed to marshal payment payload: %w", err)
     }
 
-    req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.url, bytes.NewReader(body))
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
     if err != nil {
         return fmt.Errorf("failed to build payment request: %w", err)
     }
@@ -80,22 +134,78 @@ func (g *httpPaymentGateway) InitiatePayment(ctx context.Context, bookingID int6
         return fmt.Errorf("payment service returned status %d: %s", resp.StatusCode, string(respBody))
     }
 
-    return err
+    return nil
 }
 
-func (b *Booking) CreateBooking(ctx context.Context, userID, eventID int64, seatIDs []int64) (int64, error) {
+func (b *Booking) CreateBooking(ctx context.Context, userID, eventID int64, seatIDs []int64, ga storage.GeneralAdmission, promoCodes []string) (int64, storage.Price, error) {
 	const op = "service.CreateBooking"
 
+	if ga.Quantity < 0 || (ga.Quantity > 0 && ga.PoolID <= 0) || (len(seatIDs) == 0 && ga.Quantity == 0) {
+		return 0, storage.Price{}, fmt.Errorf("%s: %w", op, ErrInvalidBooking)
+	}
+
+	promoCodes, err := normalizePromoCodes(promoCodes)
+	if err != nil {
+		return 0, storage.Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	// TODO: validate seats
-	bookingID, err := b.bookingCreator.CreateBooking(ctx, userID, eventID, seatIDs)
+	bookingID, total, err := b.bookingCreator.CreateBooking(ctx, userID, eventID, seatIDs, ga, promoCodes)
 	if err != nil {
-		if errors.Is(err, storage.ErrSeatNotAvailable) {
-			return 0, fmt.Errorf("%s: %w", op, ErrSeatNotAvailable)
+		if errors.Is(err, storage.ErrIdempotencyClaimLost) {
+			return 0, storage.Price{}, fmt.Errorf("%s: %w", op, ErrIdempotencyKeyTakenOver)
 		}
-		return 0, fmt.Errorf("%s: %w", op, err)
+		if bookErr := bookTicketsError(err); bookErr != nil {
+			return 0, storage.Price{}, fmt.Errorf("%s: %w", op, bookErr)
+		}
+		return 0, storage.Price{}, fmt.Errorf("%s: %w", op, err)
 	}
-    
-    err = b.paymentGateway.InitiatePayment(ctx, bookingID, 1500.0)
+
+    if err := b.initiatePayment(ctx, bookingID, total); err != nil {
+        return 0, storage.Price{}, err
+    }
+
+    return bookingID, total, nil
+}
+
+// bookTicketsError maps the storage error of booking tickets, nil for any
+// other error.
+func bookTicketsError(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrSeatNotAvailable):
+		return ErrSeatNotAvailable
+	case errors.Is(err, storage.ErrEventNotOnSale):
+		return ErrEventNotOnSale
+	case errors.Is(err, storage.ErrTicketNotPriced):
+		return ErrTicketNotPriced
+	case errors.Is(err, storage.ErrPoolNotFound):
+		return ErrPoolNotFound
+	case errors.Is(err, storage.ErrNotEnoughTickets):
+		return ErrNotEnoughTickets
+	case errors.Is(err, storage.ErrBookingTooLarge):
+		return ErrBookingTooLarge
+	case errors.Is(err, storage.ErrTicketLimitReached):
+		return ErrTicketLimitReached
+	case errors.Is(err, storage.ErrTooManyPendingBookings):
+		return ErrTooManyPendingBookings
+	}
+	return promoCodeError(err)
+}
+
+// initiatePayment asks for the payment of a new booking and cancels the
+// booking when that fails. A booking that costs nothing is confirmed right
+// away.
+func (b *Booking) initiatePayment(ctx context.Context, bookingID int64, total storage.Price) error {
+    // promo codes took off everything, there is nothing to pay
+    if total.Amount == 0 {
+        if err := b.ConfirmBooking(ctx, bookingID); err != nil {
+            return err
+        }
+        slog.Info("Booking created and confirmed without payment", "booking_id", bookingID)
+        return nil
+    }
+
+    err := b.paymentGateway.InitiatePayment(ctx, bookingID, total.Amount, total.Currency)
     if err != nil {
         slog.Error("failed to initiate payment, compensating booking", "booking_id", bookingID, "error", err)
         compensationCtx, cancel := context.WithTimeout(context.Background(), 1 * time.Minute)
@@ -103,11 +213,16 @@ func (b *Booking) CreateBooking(ctx context.Context, userID, eventID int64, seat
         if compensationErr := b.bookingCreator.CancelBooking(compensationCtx, bookingID); compensationErr != nil {
             slog.Error("critical: failed to compensate booking", "booking_id", bookingID, "error", compensationErr)
         }
-        return 0, ErrPaymentFailed
+        return ErrPaymentFailed
     }
 
-    slog.Info("Booking created and payment initiated successfully", "booking_id", bookingID)
-    return bookingID, nil
+    // a payment confirmed before this call got here leaves the booking alone
+    if err := b.bookingCreator.MarkAwaitingPayment(ctx, bookingID); err != nil && !errors.Is(err, storage.ErrBookingCannotBeChanged) {
+        slog.Error("failed to mark booking as awaiting payment", "booking_id", bookingID, "error", err)
+    }
+
+    slog.Info("Booking created and payment initiated successfully", "booking_id", bookingID, "amount", total.Amount, "currency", total.Currency)
+    return nil
 }
 
 func (b *Booking) ConfirmBooking(ctx context.Context, bookingID int64) error {
@@ -115,17 +230,56 @@ func (b *Booking) ConfirmBooking(ctx context.Context, bookingID int64) error {
 
     err := b.bookingCreator.ConfirmBooking(ctx, bookingID)
     if err != nil {
+        if errors.Is(err, storage.ErrBookingCannotBeChanged) {
+            // a paid booking may be paying for a seat exchange
+            if settleErr := b.settleSeatExchange(ctx, bookingID, err); !errors.Is(settleErr, storage.ErrBookingCannotBeChanged) {
+                return settleErr
+            }
+            return b.refundLatePayment(ctx, bookingID, err)
+        }
         return fmt.Errorf("%s: %w", op, err)
     }
 
     return nil
 }
 
+// refundLatePayment handles a payment that succeeded after the event or the
+// booking had already been cancelled, or the hold had expired: the money is
+// sent back instead of confirming.
+func (b *Booking) refundLatePayment(ctx context.Context, bookingID int64, confirmErr error) error {
+    const op = "service.refundLatePayment"
+
+    status, err := b.bookingCreator.BookingStatus(ctx, bookingID)
+    if err != nil {
+        return fmt.Errorf("%s: %w", op, confirmErr)
+    }
+    if status == StatusCancelled || status == StatusExpired {
+        return b.refundCancelledBookingPayment(ctx, bookingID, confirmErr)
+    }
+    if status != StatusEventCancelled {
+        return fmt.Errorf("%s: %w", op, confirmErr)
+    }
+
+    // the refund is queued with the status change, a repeated payment finds the booking refunded
+    if err := b.bookingCreator.RefundCancelledEventBooking(ctx, bookingID); err != nil {
+        return fmt.Errorf("%s: %w", op, err)
+    }
+
+    slog.InfoContext(ctx, "Queued refund of a payment received for a cancelled event", "booking_id", bookingID)
+    return nil
+}
+
 func (b *Booking) CancelBooking(ctx context.Context, bookingID int64) error {
 	const op = "service.CancelBooking"
 
 	err := b.bookingCreator.CancelBooking(ctx, bookingID)
 	if err != nil {
+		// a paid booking may have failed to pay for a seat exchange
+		if errors.Is(err, storage.ErrBookingCannotBeChanged) {
+			if exchangeErr := b.bookingCreator.CancelSeatExchange(ctx, bookingID); !errors.Is(exchangeErr, storage.ErrSeatChangeNotFound) {
+				return exchangeErr
+			}
+		}
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
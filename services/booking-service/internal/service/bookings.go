@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kay-kewl/ticket-booking-system/internal/pagination"
+	"github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/storage"
+)
+
+var ErrBookingNotFound = errors.New("booking not found")
+var ErrInvalidBookingStatus = errors.New("unknown booking status")
+
+var bookingStatuses = []string{"PENDING", "AWAITING_PAYMENT", "CONFIRMED", "CANCELLED", "EXPIRED", "REFUNDED", "EVENT_CANCELLED", "CHECKED_IN"}
+
+type BookingReader interface {
+	GetBooking(ctx context.Context, bookingID, userID int64) (*storage.Booking, error)
+	ListUserBookings(ctx context.Context, userID int64, status string, after *pagination.Cursor, pageSize int32) ([]storage.Booking, *pagination.Cursor, error)
+}
+
+// GetBooking returns the booking if it belongs to the user; someone else's
+// booking is reported as not found.
+func (b *Booking) GetBooking(ctx context.Context, bookingID, userID int64) (*storage.Booking, error) {
+	const op = "service.GetBooking"
+
+	booking, err := b.bookingCreator.GetBooking(ctx, bookingID, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrBookingNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrBookingNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return booking, nil
+}
+
+// ListUserBookings returns a page of the user's bookings, newest first, and
+// the cursor of the next page, nil on the last one.
+func (b *Booking) ListUserBookings(ctx context.Context, userID int64, status string, after *pagination.Cursor, pageSize int32) ([]storage.Booking, *pagination.Cursor, error) {
+	const op = "service.ListUserBookings"
+
+	status = strings.ToUpper(status)
+	if status != "" && !slices.Contains(bookingStatuses, status) {
+		return nil, nil, fmt.Errorf("%s: %q: %w", op, status, ErrInvalidBookingStatus)
+	}
+
+	bookings, next, err := b.bookingCreator.ListUserBookings(ctx, userID, status, after, pageSize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return bookings, next, nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kay-kewl/ticket-booking-system/internal/ical"
+	"github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/storage"
+)
+
+type BookingCalendarStorage interface {
+	ListCalendarBookings(ctx context.Context, userID int64) ([]storage.CalendarBooking, error)
+}
+
+// UserCalendar renders the user's upcoming confirmed bookings as an iCalendar feed.
+func (b *Booking) UserCalendar(ctx context.Context, userID int64) ([]byte, error) {
+	const op = "service.UserCalendar"
+
+	bookings, err := b.bookingCreator.ListCalendarBookings(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	cal := ical.Calendar{Name: "My bookings"}
+	for _, booking := range bookings {
+		cal.Events = append(cal.Events, ical.Event{
+			UID:         ical.BookingUID(booking.BookingID),
+			Start:       booking.StartsAt,
+			Duration:    ical.DefaultEventDuration,
+			Summary:     booking.Title,
+			Description: bookingDescription(booking),
+			Status:      "CONFIRMED",
+		})
+	}
+
+	return ical.Render(cal, time.Now()), nil
+}
+
+func bookingDescription(booking storage.CalendarBooking) string {
+	lines := []string{fmt.Sprintf("Booking #%d", booking.BookingID)}
+	if len(booking.Seats) > 0 {
+		lines = append(lines, "Seats: "+strings.Join(booking.Seats, ", "))
+	}
+	if booking.Tickets > 0 {
+		lines = append(lines, fmt.Sprintf("General admission tickets: %d", booking.Tickets))
+	}
+	if booking.Description != "" {
+		lines = append(lines, "", booking.Description)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/storage"
+)
+
+const (
+	StatusPending         = "PENDING"
+	StatusAwaitingPayment = "AWAITING_PAYMENT"
+	StatusConfirmed       = "CONFIRMED"
+	StatusCancelled       = "CANCELLED"
+	StatusExpired         = "EXPIRED"
+	StatusRefunded        = "REFUNDED"
+	StatusEventCancelled  = "EVENT_CANCELLED"
+	StatusCheckedIn       = "CHECKED_IN"
+)
+
+// after this many failed attempts an item is reported in the job status and left alone
+const maxCancellationAttempts = 5
+
+var ErrJobNotFound = errors.New("cancellation job not found")
+
+type EventCancellationStorage interface {
+	StartEventCancellation(ctx context.Context, eventID int64, reason string) (int64, error)
+	ClaimCancellationItems(ctx context.Context, limit int) ([]storage.CancellationItem, error)
+	SettleCancelledEventBooking(ctx context.Context, item storage.CancellationItem, fromStatus string) error
+	SkipCancellationItem(ctx context.Context, item storage.CancellationItem) error
+	FailCancellationItem(ctx context.Context, item storage.CancellationItem, cause error, maxAttempts int) error
+	FinishCancellationJobs(ctx context.Context) error
+	EventCancellationJob(ctx context.Context, eventID int64) (*storage.CancellationJob, error)
+}
+
+func (b *Booking) StartEventCancellation(ctx context.Context, eventID int64, reason string) (int64, error) {
+	const op = "service.StartEventCancellation"
+
+	jobID, err := b.bookingCreator.StartEventCancellation(ctx, eventID, reason)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return jobID, nil
+}
+
+// ProcessEventCancellations works off one batch of pending job items and
+// returns how many were claimed.
+func (b *Booking) ProcessEventCancellations(ctx context.Context, batchSize int) (int, error) {
+	const op = "service.ProcessEventCancellations"
+
+	items, err := b.bookingCreator.ClaimCancellationItems(ctx, batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, item := range items {
+		if err := b.cancelEventBooking(ctx, item); err != nil {
+			slog.WarnContext(ctx, "Failed to cancel booking of cancelled event", "job_id", item.JobID, "booking_id", item.BookingID, "error", err)
+			if err := b.bookingCreator.FailCancellationItem(ctx, item, err, maxCancellationAttempts); err != nil {
+				return 0, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	if err := b.bookingCreator.FinishCancellationJobs(ctx); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return len(items), nil
+}
+
+func (b *Booking) cancelEventBooking(ctx context.Context, item storage.CancellationItem) error {
+	status, err := b.bookingCreator.BookingStatus(ctx, item.BookingID)
+	if err != nil {
+		return err
+	}
+
+	switch status {
+	case StatusPending, StatusAwaitingPayment:
+		// a payment confirmed later is refunded by ConfirmBooking
+		return b.bookingCreator.SettleCancelledEventBooking(ctx, item, status)
+	case StatusConfirmed:
+		// the refund is queued with the status change and sent by the refund worker
+		return b.bookingCreator.SettleCancelledEventBooking(ctx, item, StatusConfirmed)
+	default:
+		return b.bookingCreator.SkipCancellationItem(ctx, item)
+	}
+}
+
+func (b *Booking) GetEventCancellationJob(ctx context.Context, eventID int64) (*storage.CancellationJob, error) {
+	const op = "service.GetEventCancellationJob"
+
+	job, err := b.bookingCreator.EventCancellationJob(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, storage.ErrJobNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrJobNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return job, nil
+}
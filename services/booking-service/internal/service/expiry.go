@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/storage"
+)
+
+var ErrHoldNotExtendable = errors.New("booking is no longer held")
+var ErrHoldExtensionLimit = errors.New("hold cannot be extended any more")
+
+type ExpirationStorage interface {
+	ListDueBookings(ctx context.Context, limit int) ([]int64, error)
+	ExtendHold(ctx context.Context, bookingID, userID int64) (time.Time, int32, error)
+}
+
+// ExtendHold gives the buyer of a pending booking more time to pay and
+// returns the new deadline and how many more times it can be extended.
+func (b *Booking) ExtendHold(ctx context.Context, bookingID, userID int64) (time.Time, int32, error) {
+	const op = "service.ExtendHold"
+
+	expiresAt, left, err := b.bookingCreator.ExtendHold(ctx, bookingID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrBookingNotFound):
+			return time.Time{}, 0, fmt.Errorf("%s: %w", op, ErrBookingNotFound)
+		case errors.Is(err, storage.ErrBookingCannotBeChanged):
+			return time.Time{}, 0, fmt.Errorf("%s: %w", op, ErrHoldNotExtendable)
+		case errors.Is(err, storage.ErrHoldExtensionLimit):
+			return time.Time{}, 0, fmt.Errorf("%s: %w", op, ErrHoldExtensionLimit)
+		}
+		return time.Time{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	slog.InfoContext(ctx, "Booking hold extended", "booking_id", bookingID, "expires_at", expiresAt, "extensions_left", left)
+	return expiresAt, left, nil
+}
+
+// ExpireDueBookings expires up to batchSize bookings whose hold has run out
+// and returns how many it dealt with. A booking paid or cancelled in the
+// meantime counts as dealt with; one that fails is logged and retried on the
+// next run.
+func (b *Booking) ExpireDueBookings(ctx context.Context, batchSize int) (int, error) {
+	const op = "service.ExpireDueBookings"
+
+	due, err := b.bookingCreator.ListDueBookings(ctx, batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	expired := 0
+	for _, bookingID := range due {
+		err := b.bookingCreator.ExpireBooking(ctx, bookingID)
+		if err != nil && !errors.Is(err, storage.ErrBookingCannotBeChanged) {
+			slog.ErrorContext(ctx, "Failed to expire booking", "booking_id", bookingID, "error", err)
+			continue
+		}
+		expired++
+	}
+
+	// seat exchanges whose price difference was not paid in time
+	exchanges, err := b.bookingCreator.ExpireSeatChanges(ctx, batchSize)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to expire seat exchanges", "error", err)
+	}
+
+	return expired + exchanges, nil
+}
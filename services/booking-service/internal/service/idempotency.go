@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/storage"
+)
+
+var ErrIdempotencyKeyReused = errors.New("idempotency key was used for a different request")
+var ErrIdempotencyKeyTakenOver = errors.New("request was taken over by a retry with the same idempotency key")
+
+type IdempotencyStorage interface {
+	RunIdempotent(ctx context.Context, userID int64, key string, fingerprint []byte, run func(ctx context.Context) ([]byte, error), replay func(ctx context.Context, bookingID int64) ([]byte, error)) ([]byte, error)
+	PurgeIdempotencyKeys(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+// Idempotent runs the request identified by the user's key once and replays
+// its response on retries. fingerprint tells the requests apart, so a key
+// cannot be reused for something else. replay builds the response from the
+// booking of a request that died before its response was stored.
+func (b *Booking) Idempotent(ctx context.Context, userID int64, key string, fingerprint []byte, run func(ctx context.Context) ([]byte, error), replay func(ctx context.Context, bookingID int64) ([]byte, error)) ([]byte, error) {
+	const op = "service.Idempotent"
+
+	response, err := b.bookingCreator.RunIdempotent(ctx, userID, key, fingerprint, run, replay)
+	if err != nil {
+		if errors.Is(err, storage.ErrIdempotencyKeyReused) {
+			return nil, fmt.Errorf("%s: %w", op, ErrIdempotencyKeyReused)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return response, nil
+}
+
+func (b *Booking) PurgeIdempotencyKeys(ctx context.Context, retention time.Duration) (int64, error) {
+	const op = "service.PurgeIdempotencyKeys"
+
+	purged, err := b.bookingCreator.PurgeIdempotencyKeys(ctx, retention)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return purged, nil
+}
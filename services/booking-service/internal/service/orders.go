@@ -0,0 +1,121 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/storage"
+)
+
+var ErrInvalidOrder = errors.New("order must book 1 to 10 different events")
+var ErrOrderNotFound = errors.New("order not found")
+var ErrOrderCurrencyMismatch = errors.New("events of an order must be priced in one currency")
+var ErrOrderNotPending = errors.New("order is no longer pending")
+
+const maxOrderItems = 10
+
+type OrderStorage interface {
+	CreateOrder(ctx context.Context, userID int64, items []storage.OrderItem) (int64, int64, storage.Price, error)
+	GetOrder(ctx context.Context, orderID, userID int64) (*storage.Order, error)
+}
+
+// CreateOrder books tickets of several events for the user at once, a
+// booking per event, and starts a single payment of their total. Either
+// every event is booked or none is.
+func (b *Booking) CreateOrder(ctx context.Context, userID int64, items []storage.OrderItem) (int64, storage.Price, error) {
+	const op = "service.CreateOrder"
+
+	if len(items) == 0 || len(items) > maxOrderItems {
+		return 0, storage.Price{}, fmt.Errorf("%s: %w", op, ErrInvalidOrder)
+	}
+
+	items = slices.Clone(items)
+	for i := range items {
+		item := &items[i]
+		ga := item.GA
+		if ga.Quantity < 0 || (ga.Quantity > 0 && ga.PoolID <= 0) || (len(item.SeatIDs) == 0 && ga.Quantity == 0) {
+			return 0, storage.Price{}, fmt.Errorf("%s: event %d: %w", op, item.EventID, ErrInvalidBooking)
+		}
+
+		promoCodes, err := normalizePromoCodes(item.PromoCodes)
+		if err != nil {
+			return 0, storage.Price{}, fmt.Errorf("%s: event %d: %w", op, item.EventID, err)
+		}
+		item.PromoCodes = promoCodes
+	}
+
+	// concurrent orders lock the events and their seats in the same order
+	slices.SortFunc(items, func(a, b storage.OrderItem) int { return cmp.Compare(a.EventID, b.EventID) })
+	for i := 1; i < len(items); i++ {
+		if items[i].EventID == items[i-1].EventID {
+			return 0, storage.Price{}, fmt.Errorf("%s: %w", op, ErrInvalidOrder)
+		}
+	}
+
+	orderID, paymentBookingID, total, err := b.bookingCreator.CreateOrder(ctx, userID, items)
+	if err != nil {
+		if errors.Is(err, storage.ErrIdempotencyClaimLost) {
+			return 0, storage.Price{}, fmt.Errorf("%s: %w", op, ErrIdempotencyKeyTakenOver)
+		}
+		if errors.Is(err, storage.ErrOrderCurrencyMismatch) {
+			return 0, storage.Price{}, fmt.Errorf("%s: %w", op, ErrOrderCurrencyMismatch)
+		}
+		if bookErr := bookTicketsError(err); bookErr != nil {
+			return 0, storage.Price{}, fmt.Errorf("%s: %w", op, bookErr)
+		}
+		return 0, storage.Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// a failed payment releases the whole order with its payment booking
+	if err := b.initiatePayment(ctx, paymentBookingID, total); err != nil {
+		return 0, storage.Price{}, err
+	}
+
+	slog.InfoContext(ctx, "Order created", "order_id", orderID, "events", len(items), "amount", total.Amount, "currency", total.Currency)
+	return orderID, total, nil
+}
+
+// GetOrder returns the order with its bookings if it belongs to the user;
+// someone else's order is reported as not found.
+func (b *Booking) GetOrder(ctx context.Context, orderID, userID int64) (*storage.Order, error) {
+	const op = "service.GetOrder"
+
+	order, err := b.bookingCreator.GetOrder(ctx, orderID, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrOrderNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrOrderNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return order, nil
+}
+
+// CancelOrder cancels an unpaid order of the user with all of its bookings.
+// Once paid, its bookings are cancelled one at a time like any other.
+func (b *Booking) CancelOrder(ctx context.Context, orderID, userID int64) error {
+	const op = "service.CancelOrder"
+
+	order, err := b.GetOrder(ctx, orderID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if order.Status != StatusPending {
+		return fmt.Errorf("%s: %w", op, ErrOrderNotPending)
+	}
+
+	// cancelling a held booking of an order releases the rest of it
+	if _, err := b.CancelUserBooking(ctx, order.PaymentBookingID, userID); err != nil {
+		if errors.Is(err, ErrBookingNotCancellable) {
+			return fmt.Errorf("%s: %w", op, ErrOrderNotPending)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	slog.InfoContext(ctx, "Order cancelled by the customer", "order_id", orderID)
+	return nil
+}
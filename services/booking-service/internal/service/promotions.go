@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/storage"
+)
+
+var ErrInvalidPromoCode = errors.New("invalid promo code")
+var ErrPromoCodeNotFound = errors.New("promo code not found")
+var ErrPromoCodeNotValid = errors.New("promo code is not valid now")
+var ErrPromoCodeNotApplicable = errors.New("promo code does not apply to these tickets")
+var ErrPromoCodeUsedUp = errors.New("promo code has been used up")
+var ErrPromoCodesNotStackable = errors.New("promo codes cannot be combined")
+
+// maxPromoCodes is how many codes a single booking may stack.
+const maxPromoCodes = 3
+
+var promoCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,50}$`)
+
+type PromotionStorage interface {
+	SetPromoCode(ctx context.Context, code storage.PromoCode) (*storage.PromoCode, error)
+	GetPromoCode(ctx context.Context, code string) (*storage.PromoCode, error)
+}
+
+// SetPromoCode creates the promo code or replaces its terms. Redemptions made
+// so far are kept.
+func (b *Booking) SetPromoCode(ctx context.Context, code storage.PromoCode) (*storage.PromoCode, error) {
+	const op = "service.SetPromoCode"
+
+	code.Code = strings.ToUpper(strings.TrimSpace(code.Code))
+	code.Amount.Currency = strings.ToUpper(code.Amount.Currency)
+	if err := validatePromoCode(code); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	saved, err := b.bookingCreator.SetPromoCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, storage.ErrPromoCodeUsedUp) {
+			return nil, fmt.Errorf("%s: %w", op, ErrPromoCodeUsedUp)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	slog.InfoContext(ctx, "Promo code saved", "code", saved.Code, "type", saved.DiscountType, "active", saved.Active)
+	return saved, nil
+}
+
+func (b *Booking) GetPromoCode(ctx context.Context, code string) (*storage.PromoCode, error) {
+	const op = "service.GetPromoCode"
+
+	promo, err := b.bookingCreator.GetPromoCode(ctx, strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		if errors.Is(err, storage.ErrPromoCodeNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrPromoCodeNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return promo, nil
+}
+
+func validatePromoCode(code storage.PromoCode) error {
+	if !promoCodePattern.MatchString(code.Code) {
+		return ErrInvalidPromoCode
+	}
+
+	switch code.DiscountType {
+	case storage.DiscountPercent:
+		if code.Percent < 1 || code.Percent > 100 || code.Amount.Amount != 0 || code.Amount.Currency != "" {
+			return ErrInvalidPromoCode
+		}
+	case storage.DiscountFixed:
+		if code.Amount.Amount <= 0 || len(code.Amount.Currency) != 3 || code.Percent != 0 {
+			return ErrInvalidPromoCode
+		}
+	default:
+		return ErrInvalidPromoCode
+	}
+
+	if code.ValidFrom != nil && code.ValidUntil != nil && !code.ValidUntil.After(*code.ValidFrom) {
+		return ErrInvalidPromoCode
+	}
+	if code.MaxRedemptions < 0 || code.MaxRedemptionsPerUser < 0 || code.EventID < 0 {
+		return ErrInvalidPromoCode
+	}
+	for _, tierID := range code.PriceTierIDs {
+		if tierID <= 0 {
+			return ErrInvalidPromoCode
+		}
+	}
+
+	return nil
+}
+
+// normalizePromoCodes upper-cases the codes a customer entered and drops
+// empty ones. Codes are matched case-insensitively, so the same code twice
+// is rejected rather than redeemed twice.
+func normalizePromoCodes(codes []string) ([]string, error) {
+	var normalized []string
+	for _, code := range codes {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code == "" {
+			continue
+		}
+		if !promoCodePattern.MatchString(code) {
+			return nil, ErrPromoCodeNotFound
+		}
+		for _, seen := range normalized {
+			if seen == code {
+				return nil, ErrPromoCodesNotStackable
+			}
+		}
+		normalized = append(normalized, code)
+	}
+
+	if len(normalized) > maxPromoCodes {
+		return nil, ErrPromoCodesNotStackable
+	}
+	return normalized, nil
+}
+
+// promoCodeError maps the storage error of a promo code, nil for any other error.
+func promoCodeError(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrPromoCodeNotFound):
+		return ErrPromoCodeNotFound
+	case errors.Is(err, storage.ErrPromoCodeNotValid):
+		return ErrPromoCodeNotValid
+	case errors.Is(err, storage.ErrPromoCodeNotApplicable):
+		return ErrPromoCodeNotApplicable
+	case errors.Is(err, storage.ErrPromoCodeUsedUp):
+		return ErrPromoCodeUsedUp
+	case errors.Is(err, storage.ErrPromoCodesNotStackable):
+		return ErrPromoCodesNotStackable
+	}
+	return nil
+}
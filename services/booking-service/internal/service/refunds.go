@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/storage"
+)
+
+var ErrBookingNotCancellable = errors.New("booking can no longer be cancelled")
+
+type RefundStorage interface {
+	CancellationTerms(ctx context.Context, bookingID int64) (*storage.CancellationTerms, error)
+	CancelUserBooking(ctx context.Context, bookingID int64, fromStatus string, refund storage.RefundFunc) (storage.Price, error)
+	RefundCancelledBookingPayment(ctx context.Context, bookingID int64) error
+	ClaimRefundJobs(ctx context.Context, limit int) ([]storage.RefundJob, error)
+	CompleteRefundJob(ctx context.Context, jobID int64) error
+	RetryRefundJob(ctx context.Context, jobID int64, cause error) error
+}
+
+// CancelUserBooking cancels a booking of the user and returns what is
+// refunded. Unpaid bookings are just cancelled; paid ones until the event
+// starts, with the refund its policy allows at this point. The refund is
+// sent by the refund worker once the cancellation has been saved.
+func (b *Booking) CancelUserBooking(ctx context.Context, bookingID, userID int64) (storage.Price, error) {
+	const op = "service.CancelUserBooking"
+
+	terms, err := b.bookingCreator.CancellationTerms(ctx, bookingID)
+	if err != nil {
+		if errors.Is(err, storage.ErrBookingNotFound) {
+			return storage.Price{}, fmt.Errorf("%s: %w", op, ErrBookingNotFound)
+		}
+		return storage.Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if terms.UserID != userID {
+		return storage.Price{}, fmt.Errorf("%s: %w", op, ErrBookingNotFound)
+	}
+
+	now := time.Now()
+	switch terms.Status {
+	case StatusPending, StatusAwaitingPayment:
+		// a payment that still goes through is refunded by ConfirmBooking
+	case StatusConfirmed:
+		if terms.EventStartsAt != nil && !now.Before(*terms.EventStartsAt) {
+			return storage.Price{}, fmt.Errorf("%s: event has started: %w", op, ErrBookingNotCancellable)
+		}
+	default:
+		return storage.Price{}, fmt.Errorf("%s: booking is %s: %w", op, terms.Status, ErrBookingNotCancellable)
+	}
+
+	// decided on the total the booking holds once it is locked
+	refund, err := b.bookingCreator.CancelUserBooking(ctx, bookingID, terms.Status, func(value storage.Price) storage.Price {
+		locked := *terms
+		locked.Total = value
+		return refundFor(&locked, now)
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrBookingCannotBeChanged) {
+			return storage.Price{}, fmt.Errorf("%s: %w", op, ErrBookingNotCancellable)
+		}
+		return storage.Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	slog.InfoContext(ctx, "Booking cancelled by the customer", "booking_id", bookingID, "status", terms.Status, "refund", refund.Amount)
+	return refund, nil
+}
+
+// refundFor applies the refund policy of the event to a confirmed booking
+// cancelled at now. Partial refunds are rounded down.
+func refundFor(terms *storage.CancellationTerms, now time.Time) storage.Price {
+	refund := storage.Price{Currency: terms.Total.Currency}
+	policy := terms.Policy
+
+	if policy.NonRefundable {
+		return refund
+	}
+	if terms.EventStartsAt == nil {
+		refund.Amount = terms.Total.Amount
+		return refund
+	}
+
+	left := terms.EventStartsAt.Sub(now)
+	switch {
+	case left <= 0:
+	case left >= time.Duration(policy.FullRefundHours)*time.Hour:
+		refund.Amount = terms.Total.Amount
+	case left >= time.Duration(policy.PartialRefundHours)*time.Hour:
+		refund.Amount = terms.Total.Amount * int64(policy.PartialRefundPercent) / 100
+	}
+
+	return refund
+}
+
+// refundCancelledBookingPayment sends back a payment that arrived after the
+// customer had cancelled the unpaid booking or its hold had expired. Nothing
+// was sold, so all of it goes back, once.
+func (b *Booking) refundCancelledBookingPayment(ctx context.Context, bookingID int64, confirmErr error) error {
+	const op = "service.refundCancelledBookingPayment"
+
+	// a payment reported again finds the refund claimed
+	if err := b.bookingCreator.RefundCancelledBookingPayment(ctx, bookingID); err != nil {
+		if errors.Is(err, storage.ErrBookingCannotBeChanged) {
+			return fmt.Errorf("%s: %w", op, confirmErr)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	slog.InfoContext(ctx, "Queued refund of a payment received for a cancelled booking", "booking_id", bookingID)
+	return nil
+}
+
+// ProcessRefunds sends up to batchSize refunds that are due and returns how
+// many it claimed. A refund that fails is logged and sent again later under
+// the same key.
+func (b *Booking) ProcessRefunds(ctx context.Context, batchSize int) (int, error) {
+	const op = "service.ProcessRefunds"
+
+	jobs, err := b.bookingCreator.ClaimRefundJobs(ctx, batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, job := range jobs {
+		if job.Amount == nil {
+			err = b.paymentGateway.RefundPayment(ctx, job.PaymentBookingID, job.Key)
+		} else {
+			err = b.paymentGateway.RefundAmount(ctx, job.PaymentBookingID, *job.Amount, job.Currency, job.Key)
+		}
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to send refund", "refund_id", job.ID, "booking_id", job.BookingID, "attempts", job.Attempts, "error", err)
+			if err := b.bookingCreator.RetryRefundJob(ctx, job.ID, err); err != nil {
+				slog.ErrorContext(ctx, "Failed to reschedule refund", "refund_id", job.ID, "error", err)
+			}
+			continue
+		}
+
+		// sent again after the lease if this fails, which the key makes harmless
+		if err := b.bookingCreator.CompleteRefundJob(ctx, job.ID); err != nil {
+			slog.ErrorContext(ctx, "Failed to complete refund", "refund_id", job.ID, "error", err)
+			continue
+		}
+		slog.InfoContext(ctx, "Refund sent", "refund_id", job.ID, "booking_id", job.BookingID, "payment_booking_id", job.PaymentBookingID)
+	}
+
+	return len(jobs), nil
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/storage"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefundFor(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	policy := storage.RefundPolicy{FullRefundHours: 72, PartialRefundHours: 24, PartialRefundPercent: 30}
+
+	tests := []struct {
+		name     string
+		startsIn *time.Duration
+		policy   storage.RefundPolicy
+		want     int64
+	}{
+		{name: "before the full refund cutoff", startsIn: hoursFromNow(100), policy: policy, want: 10001},
+		{name: "exactly at the full refund cutoff", startsIn: hoursFromNow(72), policy: policy, want: 10001},
+		{name: "partial refund rounds down", startsIn: hoursFromNow(48), policy: policy, want: 3000},
+		{name: "past both cutoffs", startsIn: hoursFromNow(2), policy: policy, want: 0},
+		{name: "non-refundable", startsIn: hoursFromNow(100), policy: storage.RefundPolicy{NonRefundable: true}, want: 0},
+		{name: "no policy refunds until the start", startsIn: hoursFromNow(1), want: 10001},
+		{name: "nothing after the start", startsIn: hoursFromNow(-1), want: 0},
+		{name: "events without a start refund in full", policy: policy, want: 10001},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			terms := &storage.CancellationTerms{Total: storage.Price{Amount: 10001, Currency: "RUB"}, Policy: tt.policy}
+			if tt.startsIn != nil {
+				startsAt := now.Add(*tt.startsIn)
+				terms.EventStartsAt = &startsAt
+			}
+
+			refund := refundFor(terms, now)
+			require.Equal(t, tt.want, refund.Amount)
+			require.Equal(t, "RUB", refund.Currency)
+		})
+	}
+}
+
+func hoursFromNow(hours int) *time.Duration {
+	d := time.Duration(hours) * time.Hour
+	return &d
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/storage"
+)
+
+var ErrInvalidSeatChange = errors.New("seat change needs seats to give back and, for an exchange, as many other seats")
+var ErrSeatsNotChangeable = errors.New("seats of the booking can no longer be changed")
+var ErrSeatChangePending = errors.New("booking already has a seat exchange waiting for payment")
+var ErrNoTicketsLeft = errors.New("a booking cannot give back all of its tickets, cancel it instead")
+
+type SeatChangeStorage interface {
+	CancelSeats(ctx context.Context, bookingID, userID int64, seatIDs []int64, refund storage.RefundFunc) (*storage.SeatChange, error)
+	ExchangeSeats(ctx context.Context, bookingID, userID int64, releaseIDs, seatIDs []int64, refund storage.RefundFunc) (*storage.SeatChange, error)
+	CompleteSeatExchange(ctx context.Context, bookingID int64) (*storage.SeatChange, error)
+	CancelSeatExchange(ctx context.Context, bookingID int64) error
+	ExpireSeatChanges(ctx context.Context, limit int) (int, error)
+	RefundDroppedSeatExchange(ctx context.Context, bookingID int64) (*storage.SeatChange, error)
+	SeatChanges(ctx context.Context, bookingID int64) ([]storage.SeatChange, error)
+}
+
+// CancelSeats gives seatIDs of a paid booking of the user back before the
+// event starts. What they were paid, after their share of the discount, is
+// refunded as the refund policy of the event allows at this point.
+func (b *Booking) CancelSeats(ctx context.Context, bookingID, userID int64, seatIDs []int64) (*storage.SeatChange, error) {
+	const op = "service.CancelSeats"
+
+	seatIDs = compactSeatIDs(seatIDs)
+	if len(seatIDs) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidSeatChange)
+	}
+
+	refund, err := b.seatChangeRefund(ctx, bookingID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	change, err := b.bookingCreator.CancelSeats(ctx, bookingID, userID, seatIDs, refund)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, seatChangeError(err))
+	}
+
+	slog.InfoContext(ctx, "Seats of a booking cancelled", "booking_id", bookingID, "seats", len(seatIDs), "refund", *change.RefundAmount)
+	return change, nil
+}
+
+// ExchangeSeats swaps releaseIDs of a paid booking of the user for as many
+// available seats of the event. Cheaper seats are refunded the difference
+// like a cancellation; dearer ones are held until the difference is paid,
+// and the booking keeps its old seats until then.
+func (b *Booking) ExchangeSeats(ctx context.Context, bookingID, userID int64, releaseIDs, seatIDs []int64) (*storage.SeatChange, error) {
+	const op = "service.ExchangeSeats"
+
+	releaseIDs = compactSeatIDs(releaseIDs)
+	seatIDs = compactSeatIDs(seatIDs)
+	if len(releaseIDs) == 0 || len(releaseIDs) != len(seatIDs) {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidSeatChange)
+	}
+	for _, id := range seatIDs {
+		if _, found := slices.BinarySearch(releaseIDs, id); found {
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidSeatChange)
+		}
+	}
+
+	refund, err := b.seatChangeRefund(ctx, bookingID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	change, err := b.bookingCreator.ExchangeSeats(ctx, bookingID, userID, releaseIDs, seatIDs, refund)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, seatChangeError(err))
+	}
+
+	if change.AmountDue > 0 {
+		err := b.paymentGateway.InitiatePayment(ctx, bookingID, change.AmountDue, change.Currency)
+		if err != nil {
+			slog.Error("failed to initiate payment, compensating seat exchange", "booking_id", bookingID, "error", err)
+			compensationCtx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+			defer cancel()
+			if compensationErr := b.bookingCreator.CancelSeatExchange(compensationCtx, bookingID); compensationErr != nil {
+				slog.Error("critical: failed to compensate seat exchange", "booking_id", bookingID, "error", compensationErr)
+			}
+			return nil, fmt.Errorf("%s: %w", op, ErrPaymentFailed)
+		}
+	}
+
+	slog.InfoContext(ctx, "Seats of a booking exchanged", "booking_id", bookingID, "change_id", change.ID, "status", change.Status, "amount_due", change.AmountDue)
+	return change, nil
+}
+
+// SeatChanges lists the seat changes of a booking of the user, oldest first.
+func (b *Booking) SeatChanges(ctx context.Context, bookingID, userID int64) ([]storage.SeatChange, error) {
+	const op = "service.SeatChanges"
+
+	if _, err := b.GetBooking(ctx, bookingID, userID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	changes, err := b.bookingCreator.SeatChanges(ctx, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return changes, nil
+}
+
+// seatChangeRefund checks that the seats of the booking can still be
+// changed and returns how seats it gives back are refunded: like a
+// cancellation of a booking worth what they were paid.
+func (b *Booking) seatChangeRefund(ctx context.Context, bookingID, userID int64) (storage.RefundFunc, error) {
+	terms, err := b.bookingCreator.CancellationTerms(ctx, bookingID)
+	if err != nil {
+		if errors.Is(err, storage.ErrBookingNotFound) {
+			return nil, ErrBookingNotFound
+		}
+		return nil, err
+	}
+	if terms.UserID != userID {
+		return nil, ErrBookingNotFound
+	}
+
+	now := time.Now()
+	if terms.Status != StatusConfirmed {
+		return nil, fmt.Errorf("booking is %s: %w", terms.Status, ErrSeatsNotChangeable)
+	}
+	if terms.EventStartsAt != nil && !now.Before(*terms.EventStartsAt) {
+		return nil, fmt.Errorf("event has started: %w", ErrSeatsNotChangeable)
+	}
+
+	return func(value storage.Price) storage.Price {
+		seats := *terms
+		seats.Total = value
+		return refundFor(&seats, now)
+	}, nil
+}
+
+// settleSeatExchange handles a payment for a booking that is no longer
+// pending: the price difference of a seat exchange. The exchange is carried
+// out, or its payment sent back when the exchange was dropped before the
+// payment came in; the refund is sent by the refund worker. confirmErr is
+// returned for payments of no exchange.
+func (b *Booking) settleSeatExchange(ctx context.Context, bookingID int64, confirmErr error) error {
+	const op = "service.settleSeatExchange"
+
+	change, err := b.bookingCreator.CompleteSeatExchange(ctx, bookingID)
+	if errors.Is(err, storage.ErrSeatChangeNotFound) {
+		change, err = b.bookingCreator.RefundDroppedSeatExchange(ctx, bookingID)
+		if errors.Is(err, storage.ErrSeatChangeNotFound) {
+			return confirmErr
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if change.Status == "COMPLETED" {
+		slog.InfoContext(ctx, "Seat exchange paid and completed", "booking_id", bookingID, "change_id", change.ID)
+		return nil
+	}
+
+	slog.InfoContext(ctx, "Queued refund of a payment received for a dropped seat exchange", "booking_id", bookingID, "change_id", change.ID)
+	return nil
+}
+
+func compactSeatIDs(seatIDs []int64) []int64 {
+	seatIDs = slices.Clone(seatIDs)
+	slices.Sort(seatIDs)
+	return slices.Compact(seatIDs)
+}
+
+func seatChangeError(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrBookingNotFound):
+		return ErrBookingNotFound
+	case errors.Is(err, storage.ErrBookingCannotBeChanged):
+		return ErrSeatsNotChangeable
+	case errors.Is(err, storage.ErrSeatNotInBooking):
+		return ErrSeatNotInBooking
+	case errors.Is(err, storage.ErrSeatChangePending):
+		return ErrSeatChangePending
+	case errors.Is(err, storage.ErrNoTicketsLeft):
+		return ErrNoTicketsLeft
+	case errors.Is(err, storage.ErrSeatNotAvailable):
+		return ErrSeatNotAvailable
+	case errors.Is(err, storage.ErrEventNotOnSale):
+		return ErrEventNotOnSale
+	case errors.Is(err, storage.ErrTicketNotPriced):
+		return ErrTicketNotPriced
+	}
+	return err
+}
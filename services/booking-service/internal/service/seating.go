@@ -0,0 +1,109 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/storage"
+)
+
+var ErrNoSeatsTogether = errors.New("no available seats together")
+
+const (
+	maxBestAvailableSeats    = 10
+	maxBestAvailableAttempts = 5
+)
+
+// BestAvailable describes the seats a buyer wants without picking them.
+type BestAvailable struct {
+	Quantity int32
+	// empty for any sector
+	Sector string
+	// the most a single seat may cost, in minor units; 0 for no cap
+	MaxPrice int64
+}
+
+type SeatingStorage interface {
+	ListCandidateSeats(ctx context.Context, eventID int64, sector string, maxPrice int64) ([]storage.CandidateSeat, error)
+}
+
+// seatBlock is a run of neighbouring seats in one row.
+type seatBlock struct {
+	seats []storage.CandidateSeat
+	// how far the middle of the block is from the middle of the row, doubled
+	// to stay in integers
+	offCentre int32
+}
+
+// CreateBestAvailableBooking books the best block of seats next to each
+// other and returns the seats it got. When another booking takes some of
+// the seats first, it looks again and tries the next best block.
+func (b *Booking) CreateBestAvailableBooking(ctx context.Context, userID, eventID int64, request BestAvailable, ga storage.GeneralAdmission, promoCodes []string) (int64, storage.Price, []storage.CandidateSeat, error) {
+	const op = "service.CreateBestAvailableBooking"
+
+	if request.Quantity < 1 || request.Quantity > maxBestAvailableSeats || request.MaxPrice < 0 {
+		return 0, storage.Price{}, nil, fmt.Errorf("%s: %w", op, ErrInvalidBooking)
+	}
+
+	for attempt := 1; ; attempt++ {
+		candidates, err := b.bookingCreator.ListCandidateSeats(ctx, eventID, request.Sector, request.MaxPrice)
+		if err != nil {
+			return 0, storage.Price{}, nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		blocks := rankSeatBlocks(candidates, int(request.Quantity))
+		if len(blocks) == 0 {
+			return 0, storage.Price{}, nil, fmt.Errorf("%s: %w", op, ErrNoSeatsTogether)
+		}
+		seats := blocks[0].seats
+
+		seatIDs := make([]int64, 0, len(seats))
+		for _, seat := range seats {
+			seatIDs = append(seatIDs, seat.ID)
+		}
+
+		bookingID, total, err := b.CreateBooking(ctx, userID, eventID, seatIDs, ga, promoCodes)
+		if err == nil {
+			return bookingID, total, seats, nil
+		}
+		if !errors.Is(err, ErrSeatNotAvailable) || attempt == maxBestAvailableAttempts {
+			return 0, storage.Price{}, nil, fmt.Errorf("%s: %w", op, err)
+		}
+		slog.InfoContext(ctx, "Best available seats were taken, retrying", "event_id", eventID, "attempt", attempt)
+	}
+}
+
+// rankSeatBlocks finds every run of quantity neighbouring seats in a row and
+// orders them best first: by the score of the sector, then rows closer to
+// the stage, then blocks closer to the middle of the row. The seats must be
+// ordered by sector, row and position.
+func rankSeatBlocks(seats []storage.CandidateSeat, quantity int) []seatBlock {
+	var blocks []seatBlock
+	for start := 0; start+quantity <= len(seats); start++ {
+		first, last := seats[start], seats[start+quantity-1]
+		if first.Sector != last.Sector || first.Row != last.Row || int(last.Position-first.Position) != quantity-1 {
+			continue
+		}
+
+		offCentre := first.Position + last.Position - first.RowFirst - first.RowLast
+		if offCentre < 0 {
+			offCentre = -offCentre
+		}
+		blocks = append(blocks, seatBlock{seats: seats[start : start+quantity], offCentre: offCentre})
+	}
+
+	slices.SortStableFunc(blocks, func(a, b seatBlock) int {
+		return cmp.Or(
+			cmp.Compare(b.seats[0].SectorScore, a.seats[0].SectorScore),
+			cmp.Compare(a.seats[0].Row, b.seats[0].Row),
+			cmp.Compare(a.offCentre, b.offCentre),
+			cmp.Compare(a.seats[0].ID, b.seats[0].ID),
+		)
+	})
+
+	return blocks
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/storage"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankSeatBlocks(t *testing.T) {
+	row := func(firstID int64, sector string, score, number int32, positions ...int32) []storage.CandidateSeat {
+		var seats []storage.CandidateSeat
+		for i, position := range positions {
+			seats = append(seats, storage.CandidateSeat{
+				ID:          firstID + int64(i),
+				Sector:      sector,
+				Row:         number,
+				Position:    position,
+				SectorScore: score,
+				RowFirst:    1,
+				RowLast:     10,
+			})
+		}
+		return seats
+	}
+
+	var seats []storage.CandidateSeat
+	seats = append(seats, row(1, "A", 50, 1, 1, 2, 5, 6, 9, 10)...)
+	seats = append(seats, row(11, "A", 50, 2, 1, 2, 3, 4)...)
+	seats = append(seats, row(21, "B", 80, 7, 4, 6, 8)...)
+
+	blocks := rankSeatBlocks(seats, 2)
+	require.Len(t, blocks, 6, "a gap or another row breaks a block")
+
+	ids := func(block seatBlock) []int64 {
+		var ids []int64
+		for _, seat := range block.seats {
+			ids = append(ids, seat.ID)
+		}
+		return ids
+	}
+	require.Equal(t, []int64{3, 4}, ids(blocks[0]), "the middle of the front row comes first")
+	require.Equal(t, []int64{1, 2}, ids(blocks[1]), "equally far from the middle, the lower seat wins")
+	require.Equal(t, []int64{5, 6}, ids(blocks[2]))
+	require.Equal(t, []int64{13, 14}, ids(blocks[3]))
+
+	blocks = rankSeatBlocks(seats, 1)
+	require.Equal(t, []int64{22}, ids(blocks[0]), "a better sector beats a closer row")
+
+	require.Empty(t, rankSeatBlocks(seats, 5))
+}
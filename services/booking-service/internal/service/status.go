@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/storage"
+)
+
+var ErrCheckInNotPossible = errors.New("only a paid booking can be checked in, and only once")
+var ErrTicketReissued = errors.New("ticket has been reissued")
+
+type BookingStatusStorage interface {
+	MarkAwaitingPayment(ctx context.Context, bookingID int64) error
+	CheckInBooking(ctx context.Context, eventID, bookingID int64, ticketVersion int32, staffID int64) error
+	BookingStatusHistory(ctx context.Context, bookingID int64) ([]storage.StatusChange, error)
+}
+
+// CheckInBooking admits the holder of a paid booking of the event at the
+// door, provided the ticket they show is the latest one. staffID is who let
+// them in.
+func (b *Booking) CheckInBooking(ctx context.Context, eventID, bookingID int64, ticketVersion int32, staffID int64) error {
+	const op = "service.CheckInBooking"
+
+	err := b.bookingCreator.CheckInBooking(ctx, eventID, bookingID, ticketVersion, staffID)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrBookingNotFound):
+			return fmt.Errorf("%s: %w", op, ErrBookingNotFound)
+		case errors.Is(err, storage.ErrBookingCannotBeChanged):
+			return fmt.Errorf("%s: %w", op, ErrCheckInNotPossible)
+		case errors.Is(err, storage.ErrTicketReissued):
+			return fmt.Errorf("%s: %w", op, ErrTicketReissued)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	slog.InfoContext(ctx, "Booking checked in", "booking_id", bookingID, "event_id", eventID, "staff_id", staffID)
+	return nil
+}
+
+// BookingStatusHistory lists the statuses a booking of the user went
+// through, oldest first.
+func (b *Booking) BookingStatusHistory(ctx context.Context, bookingID, userID int64) ([]storage.StatusChange, error) {
+	const op = "service.BookingStatusHistory"
+
+	if _, err := b.GetBooking(ctx, bookingID, userID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	changes, err := b.bookingCreator.BookingStatusHistory(ctx, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return changes, nil
+}
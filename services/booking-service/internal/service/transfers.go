@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"slices"
+	"strings"
+
+	"github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/storage"
+)
+
+var ErrInvalidTransfer = errors.New("transfer needs a valid recipient email")
+var ErrBookingNotTransferable = errors.New("only tickets of a paid booking can be transferred")
+var ErrTransferNotFound = errors.New("transfer not found")
+var ErrTransferClosed = errors.New("transfer is no longer pending")
+var ErrTransferExpired = errors.New("transfer has expired")
+var ErrTransferPending = errors.New("booking already has a pending transfer")
+var ErrTransferNotAllowed = errors.New("tickets of the event cannot be transferred")
+var ErrTransferLimitReached = errors.New("tickets have been transferred too often")
+var ErrTransferToSelf = errors.New("tickets cannot be transferred to their owner")
+var ErrSeatNotInBooking = errors.New("seat is not part of the booking")
+
+type TransferStorage interface {
+	StartTransfer(ctx context.Context, bookingID, senderID int64, email string, seatIDs []int64) (*storage.Transfer, string, error)
+	CancelTransfer(ctx context.Context, transferID, senderID int64) (*storage.Transfer, error)
+	AcceptTransfer(ctx context.Context, token string, recipientID int64) (*storage.Transfer, error)
+	OwnershipHistory(ctx context.Context, bookingID int64) ([]storage.OwnershipChange, error)
+}
+
+// StartTransfer offers the tickets of a paid booking of the sender, or only
+// seatIDs of it, to whoever signs in with the email. The returned token is
+// what the recipient accepts the transfer with.
+func (b *Booking) StartTransfer(ctx context.Context, bookingID, senderID int64, email string, seatIDs []int64) (*storage.Transfer, string, error) {
+	const op = "service.StartTransfer"
+
+	address, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil || address.Name != "" {
+		return nil, "", fmt.Errorf("%s: %w", op, ErrInvalidTransfer)
+	}
+	email = strings.ToLower(address.Address)
+
+	slices.Sort(seatIDs)
+	seatIDs = slices.Compact(seatIDs)
+
+	transfer, token, err := b.bookingCreator.StartTransfer(ctx, bookingID, senderID, email, seatIDs)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, transferError(err))
+	}
+
+	slog.InfoContext(ctx, "Ticket transfer started", "transfer_id", transfer.ID, "booking_id", bookingID, "seats", len(transfer.SeatIDs))
+	return transfer, token, nil
+}
+
+func (b *Booking) CancelTransfer(ctx context.Context, transferID, senderID int64) (*storage.Transfer, error) {
+	const op = "service.CancelTransfer"
+
+	transfer, err := b.bookingCreator.CancelTransfer(ctx, transferID, senderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, transferError(err))
+	}
+
+	slog.InfoContext(ctx, "Ticket transfer cancelled", "transfer_id", transferID, "booking_id", transfer.BookingID)
+	return transfer, nil
+}
+
+// AcceptTransfer hands the tickets of the transfer over to the recipient.
+func (b *Booking) AcceptTransfer(ctx context.Context, token string, recipientID int64) (*storage.Transfer, error) {
+	const op = "service.AcceptTransfer"
+
+	if token == "" {
+		return nil, fmt.Errorf("%s: %w", op, ErrTransferNotFound)
+	}
+
+	transfer, err := b.bookingCreator.AcceptTransfer(ctx, token, recipientID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, transferError(err))
+	}
+
+	slog.InfoContext(ctx, "Ticket transfer accepted", "transfer_id", transfer.ID, "booking_id", transfer.BookingID, "recipient_booking_id", *transfer.RecipientBookingID)
+	return transfer, nil
+}
+
+// OwnershipHistory lists who held the tickets of the booking, oldest first.
+// Only its current owner may see it.
+func (b *Booking) OwnershipHistory(ctx context.Context, bookingID, userID int64) ([]storage.OwnershipChange, error) {
+	const op = "service.OwnershipHistory"
+
+	if _, err := b.GetBooking(ctx, bookingID, userID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	changes, err := b.bookingCreator.OwnershipHistory(ctx, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return changes, nil
+}
+
+func transferError(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrBookingNotFound):
+		return ErrBookingNotFound
+	case errors.Is(err, storage.ErrBookingCannotBeChanged):
+		return ErrBookingNotTransferable
+	case errors.Is(err, storage.ErrTransferNotFound):
+		return ErrTransferNotFound
+	case errors.Is(err, storage.ErrTransferClosed):
+		return ErrTransferClosed
+	case errors.Is(err, storage.ErrTransferExpired):
+		return ErrTransferExpired
+	case errors.Is(err, storage.ErrTransferPending):
+		return ErrTransferPending
+	case errors.Is(err, storage.ErrTransferNotAllowed):
+		return ErrTransferNotAllowed
+	case errors.Is(err, storage.ErrTransferLimitReached):
+		return ErrTransferLimitReached
+	case errors.Is(err, storage.ErrTransferToSelf):
+		return ErrTransferToSelf
+	case errors.Is(err, storage.ErrSeatNotInBooking):
+		return ErrSeatNotInBooking
+	}
+	return err
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kay-kewl/ticket-booking-system/internal/admission"
+	"github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/storage"
+)
+
+var ErrWaitingRoomNotFound = errors.New("event has no waiting room")
+var ErrNotInWaitingRoom = errors.New("user is not in the waiting room")
+var ErrInvalidWaitingRoom = errors.New("invalid waiting room settings")
+
+const (
+	maxAdmitPerMinute   = 100000
+	maxAdmissionMinutes = 24 * 60
+)
+
+type WaitingRoomStorage interface {
+	SetWaitingRoom(ctx context.Context, room storage.WaitingRoom) error
+	RemoveWaitingRoom(ctx context.Context, eventID int64) error
+	GetWaitingRoom(ctx context.Context, eventID int64) (*storage.WaitingRoom, error)
+	JoinWaitingRoom(ctx context.Context, eventID, userID int64) (*storage.QueueEntry, error)
+	WaitingRoomEntry(ctx context.Context, eventID, userID int64) (*storage.QueueEntry, error)
+	AdmitFromWaitingRooms(ctx context.Context) (int64, error)
+}
+
+// QueueStatus is where a user stands in the waiting room of an event. Once
+// admitted, Token lets them book the event until TokenExpiresAt.
+type QueueStatus struct {
+	EventID int64
+	// 0 once admitted
+	Position      int64
+	EstimatedWait time.Duration
+	Token         string
+	// zero until admitted
+	TokenExpiresAt time.Time
+}
+
+// WaitingRoom lets customers of a high-demand on-sale through to booking a
+// few at a time, in the order they arrived.
+type WaitingRoom struct {
+	storage WaitingRoomStorage
+	secret  []byte
+}
+
+func NewWaitingRoom(storage WaitingRoomStorage, secret string) *WaitingRoom {
+	return &WaitingRoom{
+		storage: storage,
+		secret:  []byte(secret),
+	}
+}
+
+// Configure puts the event behind a waiting room admitting admitPerMinute
+// users a minute, each for admissionMinutes; admitPerMinute of 0 opens the
+// event to everyone again.
+func (w *WaitingRoom) Configure(ctx context.Context, eventID int64, admitPerMinute, admissionMinutes int32) error {
+	const op = "service.ConfigureWaitingRoom"
+
+	if admitPerMinute == 0 {
+		if err := w.storage.RemoveWaitingRoom(ctx, eventID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		slog.InfoContext(ctx, "Waiting room removed", "event_id", eventID)
+		return nil
+	}
+
+	if admitPerMinute < 0 || admitPerMinute > maxAdmitPerMinute || admissionMinutes < 1 || admissionMinutes > maxAdmissionMinutes {
+		return fmt.Errorf("%s: %w", op, ErrInvalidWaitingRoom)
+	}
+
+	err := w.storage.SetWaitingRoom(ctx, storage.WaitingRoom{
+		EventID:          eventID,
+		AdmitPerMinute:   admitPerMinute,
+		AdmissionMinutes: admissionMinutes,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	slog.InfoContext(ctx, "Waiting room configured", "event_id", eventID, "admit_per_minute", admitPerMinute, "admission_minutes", admissionMinutes)
+	return nil
+}
+
+func (w *WaitingRoom) GetWaitingRoom(ctx context.Context, eventID int64) (*storage.WaitingRoom, error) {
+	const op = "service.GetWaitingRoom"
+
+	room, err := w.storage.GetWaitingRoom(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, storage.ErrWaitingRoomNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrWaitingRoomNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return room, nil
+}
+
+// Join queues the user for the event, or tells them where they already are.
+func (w *WaitingRoom) Join(ctx context.Context, eventID, userID int64) (*QueueStatus, error) {
+	const op = "service.JoinWaitingRoom"
+
+	entry, err := w.storage.JoinWaitingRoom(ctx, eventID, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrWaitingRoomNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrWaitingRoomNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return w.queueStatus(eventID, userID, entry), nil
+}
+
+// Status tells the user where they stand in the queue and, once admitted,
+// hands them their admission token.
+func (w *WaitingRoom) Status(ctx context.Context, eventID, userID int64) (*QueueStatus, error) {
+	const op = "service.WaitingRoomStatus"
+
+	entry, err := w.storage.WaitingRoomEntry(ctx, eventID, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotInWaitingRoom) {
+			return nil, fmt.Errorf("%s: %w", op, ErrNotInWaitingRoom)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return w.queueStatus(eventID, userID, entry), nil
+}
+
+// AdmitWaitingUsers lets the next users of every waiting room through and
+// returns how many it admitted.
+func (w *WaitingRoom) AdmitWaitingUsers(ctx context.Context) (int64, error) {
+	const op = "service.AdmitWaitingUsers"
+
+	admitted, err := w.storage.AdmitFromWaitingRooms(ctx)
+	if err != nil {
+		return admitted, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return admitted, nil
+}
+
+func (w *WaitingRoom) queueStatus(eventID, userID int64, entry *storage.QueueEntry) *QueueStatus {
+	status := &QueueStatus{EventID: eventID, Position: entry.Position}
+	if entry.AdmittedAt == nil {
+		status.EstimatedWait = estimatedWait(entry.Position, entry.Room.AdmitPerMinute)
+		return status
+	}
+
+	// an expired admission is still reported; joining again requeues the user
+	status.TokenExpiresAt = entry.AdmittedAt.Add(time.Duration(entry.Room.AdmissionMinutes) * time.Minute)
+	status.Token = admission.Sign(w.secret, admission.Ticket{
+		EventID:   eventID,
+		UserID:    userID,
+		ExpiresAt: status.TokenExpiresAt,
+	})
+	return status
+}
+
+// estimatedWait is how long until the user at position gets in when users
+// are admitted at admitPerMinute.
+func estimatedWait(position int64, admitPerMinute int32) time.Duration {
+	if admitPerMinute <= 0 {
+		return 0
+	}
+	return time.Duration(position) * time.Minute / time.Duration(admitPerMinute)
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/kay-kewl/ticket-booking-system/services/booking-service/internal/storage"
+)
+
+var ErrAlreadyWaitlisted = errors.New("user is already on the waitlist")
+var ErrTicketsAvailable = errors.New("tickets are still available")
+var ErrWaitlistEntryNotFound = errors.New("user is not on the waitlist")
+var ErrInvalidWaitlistEntry = errors.New("waitlist quantity must be between 1 and 10")
+var ErrOfferNotFound = errors.New("waitlist offer not found")
+var ErrOfferExpired = errors.New("waitlist offer has expired")
+
+const maxWaitlistQuantity = 10
+
+type WaitlistStorage interface {
+	JoinWaitlist(ctx context.Context, eventID, userID, poolID int64, quantity int32) (*storage.WaitlistEntry, error)
+	WaitlistEntry(ctx context.Context, eventID, userID int64) (*storage.WaitlistEntry, error)
+	LeaveWaitlist(ctx context.Context, eventID, userID int64) error
+	ClaimWaitlistOffer(ctx context.Context, eventID, userID int64, token string) (int64, storage.Price, []int64, error)
+	ExpireWaitlistOffers(ctx context.Context, limit int) (int, error)
+	OfferWaitlistTickets(ctx context.Context) (int, error)
+}
+
+// JoinWaitlist puts the user in line for quantity seats of a sold out event,
+// or quantity tickets of the pool when poolID is set.
+func (b *Booking) JoinWaitlist(ctx context.Context, eventID, userID, poolID int64, quantity int32) (*storage.WaitlistEntry, error) {
+	const op = "service.JoinWaitlist"
+
+	if quantity < 1 || quantity > maxWaitlistQuantity || poolID < 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidWaitlistEntry)
+	}
+
+	entry, err := b.bookingCreator.JoinWaitlist(ctx, eventID, userID, poolID, quantity)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrEventNotOnSale):
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotOnSale)
+		case errors.Is(err, storage.ErrPoolNotFound):
+			return nil, fmt.Errorf("%s: %w", op, ErrPoolNotFound)
+		case errors.Is(err, storage.ErrTicketsAvailable):
+			return nil, fmt.Errorf("%s: %w", op, ErrTicketsAvailable)
+		case errors.Is(err, storage.ErrAlreadyWaitlisted):
+			return nil, fmt.Errorf("%s: %w", op, ErrAlreadyWaitlisted)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	slog.InfoContext(ctx, "User joined waitlist", "event_id", eventID, "user_id", userID, "quantity", quantity, "position", entry.Position)
+	return entry, nil
+}
+
+func (b *Booking) WaitlistEntry(ctx context.Context, eventID, userID int64) (*storage.WaitlistEntry, error) {
+	const op = "service.WaitlistEntry"
+
+	entry, err := b.bookingCreator.WaitlistEntry(ctx, eventID, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrWaitlistEntryNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrWaitlistEntryNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entry, nil
+}
+
+func (b *Booking) LeaveWaitlist(ctx context.Context, eventID, userID int64) error {
+	const op = "service.LeaveWaitlist"
+
+	if err := b.bookingCreator.LeaveWaitlist(ctx, eventID, userID); err != nil {
+		if errors.Is(err, storage.ErrWaitlistEntryNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrWaitlistEntryNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	slog.InfoContext(ctx, "User left waitlist", "event_id", eventID, "user_id", userID)
+	return nil
+}
+
+// ClaimWaitlistOffer books the tickets offered to the user and starts the
+// payment, like CreateBooking does. It returns the offered seats as well.
+func (b *Booking) ClaimWaitlistOffer(ctx context.Context, eventID, userID int64, token string) (int64, storage.Price, []int64, error) {
+	const op = "service.ClaimWaitlistOffer"
+
+	bookingID, total, seatIDs, err := b.bookingCreator.ClaimWaitlistOffer(ctx, eventID, userID, token)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrOfferNotFound):
+			return 0, storage.Price{}, nil, fmt.Errorf("%s: %w", op, ErrOfferNotFound)
+		case errors.Is(err, storage.ErrOfferExpired):
+			return 0, storage.Price{}, nil, fmt.Errorf("%s: %w", op, ErrOfferExpired)
+		case errors.Is(err, storage.ErrEventNotOnSale):
+			return 0, storage.Price{}, nil, fmt.Errorf("%s: %w", op, ErrEventNotOnSale)
+		case errors.Is(err, storage.ErrTicketNotPriced):
+			return 0, storage.Price{}, nil, fmt.Errorf("%s: %w", op, ErrTicketNotPriced)
+		case errors.Is(err, storage.ErrBookingTooLarge):
+			return 0, storage.Price{}, nil, fmt.Errorf("%s: %w", op, ErrBookingTooLarge)
+		case errors.Is(err, storage.ErrTicketLimitReached):
+			return 0, storage.Price{}, nil, fmt.Errorf("%s: %w", op, ErrTicketLimitReached)
+		case errors.Is(err, storage.ErrTooManyPendingBookings):
+			return 0, storage.Price{}, nil, fmt.Errorf("%s: %w", op, ErrTooManyPendingBookings)
+		}
+		return 0, storage.Price{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := b.initiatePayment(ctx, bookingID, total); err != nil {
+		return 0, storage.Price{}, nil, err
+	}
+
+	slog.InfoContext(ctx, "Waitlist offer claimed", "booking_id", bookingID, "user_id", userID)
+	return bookingID, total, seatIDs, nil
+}
+
+// ServeWaitlists closes up to batchSize offers that ran out, handing their
+// tickets to the next in line, then offers whatever else has become
+// available, such as newly added seats. It returns how many offers expired
+// and how many were made.
+func (b *Booking) ServeWaitlists(ctx context.Context, batchSize int) (int, int, error) {
+	const op = "service.ServeWaitlists"
+
+	expired, err := b.bookingCreator.ExpireWaitlistOffers(ctx, batchSize)
+	if err != nil {
+		return expired, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	offered, err := b.bookingCreator.OfferWaitlistTickets(ctx)
+	if err != nil {
+		return expired, offered, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return expired, offered, nil
+}
@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/kay-kewl/ticket-booking-system/internal/pagination"
+)
+
+var ErrBookingNotFound = errors.New("booking not found")
+
+// BookedSeat is a seat of a booking with the price it was booked at.
+type BookedSeat struct {
+	ID     int64  `json:"id"`
+	Label  string `json:"label"`
+	Sector string `json:"sector"`
+	Row    int32  `json:"row"`
+	Price  Price  `json:"price"`
+}
+
+// BookedPoolItem is a number of general-admission tickets of a booking; the
+// price is per ticket.
+type BookedPoolItem struct {
+	PoolID   int64  `json:"pool_id"`
+	Name     string `json:"name"`
+	Quantity int32  `json:"quantity"`
+	Price    Price  `json:"price"`
+}
+
+// Booking is a booking as shown to the user who made it.
+type Booking struct {
+	ID         int64
+	UserID     int64
+	EventID    int64
+	EventTitle string
+	// nil when the event has no start date
+	EventStartsAt *time.Time
+	Status        string
+	Total         Price
+	// what the promo codes took off, Total is after it
+	Discount      int64
+	// set once the customer has cancelled a confirmed booking
+	RefundAmount  *int64
+	// when the hold runs out, set only while the booking is pending
+	ExpiresAt     *time.Time
+	// the booking the seats were transferred out of, 0 for bought ones
+	TransferredFromID int64
+	// the order the booking was bought with, 0 for one bought alone
+	OrderID       int64
+	// tickets issued for lower versions are void
+	TicketVersion int32
+	Seats         []BookedSeat
+	PoolItems     []BookedPoolItem
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+const bookingQuery = `SELECT b.id, b.user_id, b.event_id, e.title, e.starts_at, b.status::text,
+		COALESCE(b.total_amount, 0), COALESCE(b.currency, ''), b.discount_amount, b.refund_amount,
+		CASE WHEN b.status IN ('PENDING', 'AWAITING_PAYMENT') THEN b.expires_at END, COALESCE(b.transferred_from_id, 0), COALESCE(b.order_id, 0), b.ticket_version,
+		b.created_at, b.updated_at,
+		(SELECT COALESCE(json_agg(json_build_object(
+				'id', s.id, 'label', s.seat_number, 'sector', COALESCE(s.sector, ''), 'row', COALESCE(s.row_number, 0),
+				'price', json_build_object('amount', COALESCE(bs.price_amount, 0), 'currency', COALESCE(bs.price_currency, ''))
+			) ORDER BY s.seat_number), '[]')
+			FROM booking.booking_seats bs JOIN event.seats s ON s.id = bs.seat_id WHERE bs.booking_id = b.id),
+		(SELECT COALESCE(json_agg(json_build_object(
+				'pool_id', p.id, 'name', p.name, 'quantity', pi.quantity,
+				'price', json_build_object('amount', pi.price_amount, 'currency', pi.price_currency)
+			) ORDER BY p.id), '[]')
+			FROM booking.booking_pool_items pi JOIN event.inventory_pools p ON p.id = pi.pool_id WHERE pi.booking_id = b.id)
+	FROM booking.bookings b
+	JOIN event.events e ON e.id = b.event_id`
+
+// GetBooking returns the booking if it belongs to the user.
+func (s *Storage) GetBooking(ctx context.Context, bookingID, userID int64) (*Booking, error) {
+	const op = "storage.GetBooking"
+
+	rows, err := s.db.Query(ctx, bookingQuery+" WHERE b.id = $1 AND b.user_id = $2", bookingID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	booking, err := pgx.CollectExactlyOneRow(rows, scanBooking)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrBookingNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &booking, nil
+}
+
+// ListUserBookings returns the user's bookings newest first, optionally only
+// those in the given status, starting after the cursor (or from the newest
+// when it is nil), and the cursor of the next page, nil on the last one.
+func (s *Storage) ListUserBookings(ctx context.Context, userID int64, status string, after *pagination.Cursor, pageSize int32) ([]Booking, *pagination.Cursor, error) {
+	const op = "storage.ListUserBookings"
+
+	args := []any{pageSize + 1, userID, status}
+	query := bookingQuery + " WHERE b.user_id = $2 AND ($3 = '' OR b.status::text = $3)"
+	if after != nil {
+		args = append(args, after.CreatedAt, after.ID)
+		query += " AND (b.created_at, b.id) < ($4, $5)"
+	}
+	query += " ORDER BY b.created_at DESC, b.id DESC LIMIT $1"
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	bookings, err := pgx.CollectRows(rows, scanBooking)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if int32(len(bookings)) <= pageSize {
+		return bookings, nil, nil
+	}
+	// the extra row only tells us that another page exists
+	bookings = bookings[:pageSize]
+	last := bookings[len(bookings)-1]
+
+	return bookings, &pagination.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}, nil
+}
+
+func scanBooking(row pgx.CollectableRow) (Booking, error) {
+	var b Booking
+	err := row.Scan(
+		&b.ID, &b.UserID, &b.EventID, &b.EventTitle, &b.EventStartsAt, &b.Status,
+		&b.Total.Amount, &b.Total.Currency, &b.Discount, &b.RefundAmount, &b.ExpiresAt,
+		&b.TransferredFromID, &b.OrderID, &b.TicketVersion, &b.CreatedAt, &b.UpdatedAt,
+		&b.Seats, &b.PoolItems,
+	)
+	return b, err
+}
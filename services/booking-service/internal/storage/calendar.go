@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CalendarBooking is a confirmed booking of an event with a known start.
+type CalendarBooking struct {
+	BookingID   int64
+	EventID     int64
+	Title       string
+	Description string
+	StartsAt    time.Time
+	Seats       []string
+	// general-admission tickets
+	Tickets int32
+}
+
+// ListCalendarBookings returns the user's confirmed bookings of events that
+// have a start date and have not passed more than a day ago.
+func (s *Storage) ListCalendarBookings(ctx context.Context, userID int64) ([]CalendarBooking, error) {
+	const op = "storage.ListCalendarBookings"
+
+	rows, err := s.db.Query(
+		ctx,
+		`SELECT b.id, e.id, e.title, COALESCE(e.description, ''), e.starts_at,
+			ARRAY(SELECT s.seat_number FROM booking.booking_seats bs JOIN event.seats s ON s.id = bs.seat_id
+				WHERE bs.booking_id = b.id ORDER BY s.seat_number),
+			COALESCE((SELECT SUM(quantity) FROM booking.booking_pool_items pi WHERE pi.booking_id = b.id), 0)
+		FROM booking.bookings b
+		JOIN event.events e ON e.id = b.event_id
+		WHERE b.user_id = $1 AND b.status IN ('CONFIRMED', 'CHECKED_IN') AND e.starts_at > NOW() - INTERVAL '1 day'
+		ORDER BY e.starts_at, b.id`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	bookings, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (CalendarBooking, error) {
+		var b CalendarBooking
+		err := row.Scan(&b.BookingID, &b.EventID, &b.Title, &b.Description, &b.StartsAt, &b.Seats, &b.Tickets)
+		return b, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return bookings, nil
+}
@@ -0,0 +1,456 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+)
+
+var ErrJobNotFound = errors.New("cancellation job not found")
+
+// an item that stays PROCESSING longer than this belongs to a crashed worker
+const cancellationItemLease = 5 * time.Minute
+
+type CancellationItem struct {
+	JobID     int64
+	BookingID int64
+	Reason    string
+}
+
+type FailedCancellationItem struct {
+	BookingID int64
+	Attempts  int32
+	Error     string
+}
+
+type CancellationJob struct {
+	ID          int64
+	EventID     int64
+	Status      string
+	Total       int32
+	Processed   int32
+	Failed      int32
+	FailedItems []FailedCancellationItem
+	CreatedAt   time.Time
+	FinishedAt  *time.Time
+}
+
+// StartEventCancellation creates the job for the event together with one item
+// per active booking. It is idempotent: a second call returns the existing job.
+func (s *Storage) StartEventCancellation(ctx context.Context, eventID int64, reason string) (int64, error) {
+	const op = "storage.StartEventCancellation"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var jobID int64
+	err = tx.QueryRow(
+		ctx,
+		"INSERT INTO booking.event_cancellation_jobs (event_id, reason) VALUES ($1, $2) ON CONFLICT (event_id) DO NOTHING RETURNING id",
+		eventID,
+		reason,
+	).Scan(&jobID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = tx.QueryRow(ctx, "SELECT id FROM booking.event_cancellation_jobs WHERE event_id = $1", eventID).Scan(&jobID)
+		if err != nil {
+			return 0, fmt.Errorf("%s: failed to get existing job: %w", op, err)
+		}
+		return jobID, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to create job: %w", op, err)
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO booking.event_cancellation_items (job_id, booking_id)
+		SELECT $1, id FROM booking.bookings WHERE event_id = $2 AND status IN ('PENDING', 'AWAITING_PAYMENT', 'CONFIRMED')`,
+		jobID,
+		eventID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to create job items: %w", op, err)
+	}
+
+	return jobID, tx.Commit(ctx)
+}
+
+// ClaimCancellationItems leases up to limit pending items of running jobs.
+// Items whose lease ran out are handed out again, which makes the job resumable
+// after a crash.
+func (s *Storage) ClaimCancellationItems(ctx context.Context, limit int) ([]CancellationItem, error) {
+	const op = "storage.ClaimCancellationItems"
+
+	rows, err := s.db.Query(
+		ctx,
+		`UPDATE booking.event_cancellation_items i SET status = 'PROCESSING', updated_at = NOW()
+		FROM booking.event_cancellation_jobs j
+		WHERE j.id = i.job_id AND (i.job_id, i.booking_id) IN (
+			SELECT job_id, booking_id FROM booking.event_cancellation_items
+			WHERE status = 'PENDING' OR (status = 'PROCESSING' AND updated_at < NOW() - $2::interval)
+			ORDER BY job_id, booking_id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING i.job_id, i.booking_id, j.reason`,
+		limit,
+		cancellationItemLease.String(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	items, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (CancellationItem, error) {
+		var item CancellationItem
+		err := row.Scan(&item.JobID, &item.BookingID, &item.Reason)
+		return item, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return items, nil
+}
+
+func (s *Storage) BookingStatus(ctx context.Context, bookingID int64) (string, error) {
+	const op = "storage.BookingStatus"
+
+	var status string
+	err := s.db.QueryRow(ctx, "SELECT status FROM booking.bookings WHERE id = $1", bookingID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", fmt.Errorf("%s: %w", op, ErrBookingCannotBeChanged)
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return status, nil
+}
+
+// SettleCancelledEventBooking moves a booking of a cancelled event from the
+// status the caller has acted upon to its final status, releases its seats and
+// marks the job item as done, all in one transaction. Unpaid bookings end up
+// EVENT_CANCELLED, paid ones REFUNDED with a refund of what their tickets
+// were paid queued.
+func (s *Storage) SettleCancelledEventBooking(ctx context.Context, item CancellationItem, fromStatus string) error {
+	const op = "storage.SettleCancelledEventBooking"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	orderID, err := lockOrderOf(ctx, tx, item.BookingID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var eventID int64
+	total := Price{}
+	err = tx.QueryRow(
+		ctx,
+		"SELECT event_id, COALESCE(total_amount, 0), COALESCE(currency, '') FROM booking.bookings WHERE id = $1 FOR UPDATE",
+		item.BookingID,
+	).Scan(&eventID, &total.Amount, &total.Currency)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, ErrBookingCannotBeChanged)
+		}
+		return fmt.Errorf("%s: failed to lock booking: %w", op, err)
+	}
+
+	toStatus, err := transitionBooking(ctx, tx, item.BookingID, fromStatus, TriggerEventCancelled, actorSystem)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	refund := Price{Currency: total.Currency}
+	if toStatus == "REFUNDED" {
+		refund = total
+		if err := queueEventCancellationRefund(ctx, tx, item.BookingID, total); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE event.seats SET status = 'AVAILABLE' WHERE id IN (SELECT seat_id FROM booking.booking_seats WHERE booking_id = $1)",
+		item.BookingID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: failed to release seats: %w", op, err)
+	}
+
+	if err := releasePoolTickets(ctx, tx, item.BookingID, fromStatus); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// an unpaid order cannot be bought without this booking
+	if toStatus == "EVENT_CANCELLED" {
+		if err := s.releaseOrder(ctx, tx, orderID, TriggerOrderReleased, actorSystem, "booking.cancelled"); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"booking_id":    item.BookingID,
+		"event_id":      eventID,
+		"reason":        item.Reason,
+		"refund_amount": refund.Amount,
+		"currency":      refund.Currency,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to marshal outbox payload: %w", op, err)
+	}
+
+	routingKey := "booking.event_cancelled"
+	if toStatus == "REFUNDED" {
+		routingKey = "booking.refunded"
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"INSERT INTO booking.outbox_messages (exchange, routing_key, payload) VALUES ($1, $2, $3::jsonb)",
+		"bookings_exchange",
+		routingKey,
+		payload,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: failed to save outbox message: %w", op, err)
+	}
+
+	if err := completeCancellationItem(ctx, tx, item); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// RefundCancelledEventBooking records the refund of a payment that arrived
+// after its booking had already been cancelled together with the event, and
+// queues all of it to go back.
+func (s *Storage) RefundCancelledEventBooking(ctx context.Context, bookingID int64) error {
+	const op = "storage.RefundCancelledEventBooking"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var eventID int64
+	err = tx.QueryRow(ctx, "SELECT event_id FROM booking.bookings WHERE id = $1 FOR UPDATE", bookingID).Scan(&eventID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, ErrBookingCannotBeChanged)
+		}
+		return fmt.Errorf("%s: failed to lock booking: %w", op, err)
+	}
+
+	if _, err := transitionBooking(ctx, tx, bookingID, "EVENT_CANCELLED", TriggerLatePaymentRefunded, actorPayment); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := queueRefund(ctx, tx, fmt.Sprintf("booking-%d-late-payment", bookingID), bookingID, bookingID, nil, nil); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	payload, err := json.Marshal(map[string]any{"booking_id": bookingID, "event_id": eventID})
+	if err != nil {
+		return fmt.Errorf("%s: failed to marshal outbox payload: %w", op, err)
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"INSERT INTO booking.outbox_messages (exchange, routing_key, payload) VALUES ($1, $2, $3::jsonb)",
+		"bookings_exchange",
+		"booking.refunded",
+		payload,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: failed to save outbox message: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// SkipCancellationItem closes an item whose booking has already left the
+// active states on its own, e.g. expired or was cancelled by the user.
+func (s *Storage) SkipCancellationItem(ctx context.Context, item CancellationItem) error {
+	const op = "storage.SkipCancellationItem"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := completeCancellationItem(ctx, tx, item); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// FailCancellationItem records a failed attempt. The item goes back to PENDING
+// until maxAttempts is reached, after which it is reported as FAILED.
+func (s *Storage) FailCancellationItem(ctx context.Context, item CancellationItem, cause error, maxAttempts int) error {
+	const op = "storage.FailCancellationItem"
+
+	_, err := s.db.Exec(
+		ctx,
+		`UPDATE booking.event_cancellation_items
+		SET attempts = attempts + 1,
+			last_error = $3,
+			status = CASE WHEN attempts + 1 >= $4 THEN 'FAILED' ELSE 'PENDING' END,
+			updated_at = NOW()
+		WHERE job_id = $1 AND booking_id = $2`,
+		item.JobID,
+		item.BookingID,
+		cause.Error(),
+		maxAttempts,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// FinishCancellationJobs closes running jobs that have no outstanding items.
+func (s *Storage) FinishCancellationJobs(ctx context.Context) error {
+	const op = "storage.FinishCancellationJobs"
+
+	_, err := s.db.Exec(
+		ctx,
+		`UPDATE booking.event_cancellation_jobs j
+		SET status = CASE
+				WHEN EXISTS (SELECT 1 FROM booking.event_cancellation_items WHERE job_id = j.id AND status = 'FAILED')
+				THEN 'COMPLETED_WITH_ERRORS'
+				ELSE 'COMPLETED'
+			END,
+			finished_at = NOW()
+		WHERE j.status = 'RUNNING'
+		AND NOT EXISTS (SELECT 1 FROM booking.event_cancellation_items WHERE job_id = j.id AND status IN ('PENDING', 'PROCESSING'))`,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) EventCancellationJob(ctx context.Context, eventID int64) (*CancellationJob, error) {
+	const op = "storage.EventCancellationJob"
+
+	var job CancellationJob
+	err := s.db.QueryRow(
+		ctx,
+		`SELECT j.id, j.event_id, j.status, j.created_at, j.finished_at,
+			COUNT(i.booking_id),
+			COUNT(i.booking_id) FILTER (WHERE i.status = 'DONE'),
+			COUNT(i.booking_id) FILTER (WHERE i.status = 'FAILED')
+		FROM booking.event_cancellation_jobs j
+		LEFT JOIN booking.event_cancellation_items i ON i.job_id = j.id
+		WHERE j.event_id = $1
+		GROUP BY j.id`,
+		eventID,
+	).Scan(&job.ID, &job.EventID, &job.Status, &job.CreatedAt, &job.FinishedAt, &job.Total, &job.Processed, &job.Failed)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrJobNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(
+		ctx,
+		"SELECT booking_id, attempts, COALESCE(last_error, '') FROM booking.event_cancellation_items WHERE job_id = $1 AND status = 'FAILED' ORDER BY booking_id",
+		job.ID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query failed items: %w", op, err)
+	}
+
+	job.FailedItems, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (FailedCancellationItem, error) {
+		var item FailedCancellationItem
+		err := row.Scan(&item.BookingID, &item.Attempts, &item.Error)
+		return item, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to scan failed items: %w", op, err)
+	}
+
+	return &job, nil
+}
+
+// queueEventCancellationRefund pays a paid booking of a cancelled event back
+// in full and records the refund. total is what its tickets were paid now,
+// seats given back before having left it with their own refunds. Price
+// differences of seat exchanges were paid against the booking itself, and
+// go back there; the rest goes back out of the payment the booking was
+// bought with.
+func queueEventCancellationRefund(ctx context.Context, tx pgx.Tx, bookingID int64, total Price) error {
+	if total.Amount == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, "UPDATE booking.bookings SET refund_amount = COALESCE(refund_amount, 0) + $2 WHERE id = $1", bookingID, total.Amount)
+	if err != nil {
+		return fmt.Errorf("failed to record refund: %w", err)
+	}
+
+	paymentBookingID, err := paymentBookingOf(ctx, tx, bookingID)
+	if err != nil {
+		return err
+	}
+
+	var paidHere int64
+	if paymentBookingID != bookingID {
+		err = tx.QueryRow(
+			ctx,
+			"SELECT COALESCE(SUM(amount_due), 0) FROM booking.seat_changes WHERE booking_id = $1 AND status = 'COMPLETED'",
+			bookingID,
+		).Scan(&paidHere)
+		if err != nil {
+			return fmt.Errorf("failed to sum paid seat exchanges: %w", err)
+		}
+		paidHere = min(paidHere, total.Amount)
+	}
+
+	if paidHere > 0 {
+		exchanges := Price{Amount: paidHere, Currency: total.Currency}
+		if err := queueRefund(ctx, tx, fmt.Sprintf("booking-%d-event-cancelled-exchanges", bookingID), bookingID, bookingID, &exchanges, nil); err != nil {
+			return err
+		}
+	}
+	if rest := total.Amount - paidHere; rest > 0 {
+		tickets := Price{Amount: rest, Currency: total.Currency}
+		if err := queueRefund(ctx, tx, fmt.Sprintf("booking-%d-event-cancelled", bookingID), bookingID, paymentBookingID, &tickets, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func completeCancellationItem(ctx context.Context, tx pgx.Tx, item CancellationItem) error {
+	_, err := tx.Exec(
+		ctx,
+		"UPDATE booking.event_cancellation_items SET status = 'DONE', updated_at = NOW() WHERE job_id = $1 AND booking_id = $2",
+		item.JobID,
+		item.BookingID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to complete job item: %w", err)
+	}
+	return nil
+}
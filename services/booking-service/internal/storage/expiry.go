@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrHoldExtensionLimit = errors.New("hold cannot be extended any more")
+
+// how many times a hold may be extended when the event does not say
+const defaultMaxHoldExtensions = 2
+
+// ListDueBookings returns up to limit pending bookings whose hold has run
+// out, the longest overdue first.
+func (s *Storage) ListDueBookings(ctx context.Context, limit int) ([]int64, error) {
+	const op = "storage.ListDueBookings"
+
+	rows, err := s.db.Query(
+		ctx,
+		"SELECT id FROM booking.bookings WHERE status IN ('PENDING', 'AWAITING_PAYMENT') AND expires_at <= NOW() ORDER BY expires_at LIMIT $1",
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
+
+// ExtendHold pushes the expiry of the user's pending booking forward by the
+// hold time of its event and returns the new expiry and how many extensions
+// are left. A hold that has already run out cannot be extended. The bookings
+// of an order are held as one, by the shortest hold time and the fewest
+// extensions their events allow.
+func (s *Storage) ExtendHold(ctx context.Context, bookingID, userID int64) (time.Time, int32, error) {
+	const op = "storage.ExtendHold"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	orderID, err := lockOrderOf(ctx, tx, bookingID)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	type heldBooking struct {
+		id            int64
+		held          bool
+		expiresAt     time.Time
+		extensions    int32
+		maxExtensions int32
+		holdMinutes   *int32
+	}
+	// the lock orders this against ExpireBooking
+	rows, err := tx.Query(
+		ctx,
+		`SELECT b.id, COALESCE(b.status IN ('PENDING', 'AWAITING_PAYMENT') AND b.expires_at > NOW(), FALSE), COALESCE(b.expires_at, NOW()), b.hold_extensions,
+			COALESCE(e.max_hold_extensions, $3), e.hold_minutes
+		FROM booking.bookings b
+		JOIN event.events e ON e.id = b.event_id
+		WHERE (b.id = $1 OR b.order_id = $4) AND b.user_id = $2
+		ORDER BY b.id
+		FOR UPDATE OF b`,
+		bookingID,
+		userID,
+		defaultMaxHoldExtensions,
+		orderID,
+	)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("%s: failed to lock booking: %w", op, err)
+	}
+	bookings, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (heldBooking, error) {
+		var b heldBooking
+		err := row.Scan(&b.id, &b.held, &b.expiresAt, &b.extensions, &b.maxExtensions, &b.holdMinutes)
+		return b, err
+	})
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("%s: failed to lock booking: %w", op, err)
+	}
+	if len(bookings) == 0 {
+		return time.Time{}, 0, fmt.Errorf("%s: %w", op, ErrBookingNotFound)
+	}
+
+	ids := make([]int64, 0, len(bookings))
+	expiresAt, extensions, maxExtensions := bookings[0].expiresAt, bookings[0].extensions, bookings[0].maxExtensions
+	hold := time.Duration(0)
+	for _, b := range bookings {
+		if !b.held {
+			return time.Time{}, 0, fmt.Errorf("%s: %w", op, ErrBookingCannotBeChanged)
+		}
+		ids = append(ids, b.id)
+		expiresAt = minTime(expiresAt, b.expiresAt)
+		extensions = max(extensions, b.extensions)
+		maxExtensions = min(maxExtensions, b.maxExtensions)
+
+		bookingHold := s.defaultHold
+		if b.holdMinutes != nil {
+			bookingHold = time.Duration(*b.holdMinutes) * time.Minute
+		}
+		if hold == 0 || bookingHold < hold {
+			hold = bookingHold
+		}
+	}
+	if extensions >= maxExtensions {
+		return time.Time{}, 0, fmt.Errorf("%s: %w", op, ErrHoldExtensionLimit)
+	}
+
+	expiresAt = expiresAt.Add(hold)
+
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE booking.bookings SET expires_at = $2, hold_extensions = $3 WHERE id = ANY($1)",
+		ids,
+		expiresAt,
+		extensions+1,
+	)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("%s: failed to extend hold: %w", op, err)
+	}
+
+	return expiresAt, maxExtensions - extensions - 1, tx.Commit(ctx)
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,223 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrIdempotencyKeyReused = errors.New("idempotency key was used for a different request")
+var ErrIdempotencyClaimLost = errors.New("idempotency key was taken over by a retry")
+
+const (
+	// how often a duplicate checks whether the first request has finished
+	idempotencyPollInterval = 100 * time.Millisecond
+	// how long a claim stays held without its request renewing it; the
+	// request renews it every third of that for as long as it runs
+	idempotencyLease = 30 * time.Second
+)
+
+type idempotencyClaimKey struct{}
+
+// RunIdempotent runs a request once per user and key and returns its
+// response; a retry gets the stored response back instead. The first
+// request claims the key with an empty response and holds it on a lease it
+// keeps renewing, and duplicates sent while it runs wait for the response
+// without holding a connection. The booking the request makes is tied to
+// the claim in its own transaction, so a request that dies before storing
+// its response is never run again: replay rebuilds the response from the
+// booking. A request that failed or died before booking gives the key up,
+// so it can be retried under it.
+func (s *Storage) RunIdempotent(ctx context.Context, userID int64, key string, fingerprint []byte, run func(ctx context.Context) ([]byte, error), replay func(ctx context.Context, bookingID int64) ([]byte, error)) ([]byte, error) {
+	const op = "storage.RunIdempotent"
+
+	var claimID int64
+	for {
+		var err error
+		claimID, err = s.claimIdempotencyKey(ctx, userID, key, fingerprint)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if claimID != 0 {
+			break
+		}
+
+		var (
+			heldID                      int64
+			storedFingerprint, response []byte
+			bookingID                   *int64
+			leaseExpired                bool
+		)
+		err = s.db.QueryRow(
+			ctx,
+			`SELECT claim_id, fingerprint, response, booking_id, lease_until < NOW()
+			FROM booking.idempotency_keys WHERE user_id = $1 AND key = $2`,
+			userID,
+			key,
+		).Scan(&heldID, &storedFingerprint, &response, &bookingID, &leaseExpired)
+		if errors.Is(err, pgx.ErrNoRows) {
+			// the first request failed and gave the key up
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to look up idempotency key: %w", op, err)
+		}
+		if !bytes.Equal(storedFingerprint, fingerprint) {
+			return nil, fmt.Errorf("%s: %w", op, ErrIdempotencyKeyReused)
+		}
+		if response != nil {
+			return response, nil
+		}
+		if leaseExpired && bookingID != nil {
+			// the request died after booking, its booking is the response
+			response, err = replay(ctx, *bookingID)
+			if err != nil {
+				return nil, fmt.Errorf("%s: failed to replay response: %w", op, err)
+			}
+			if err := s.storeIdempotentResponse(ctx, heldID, response); err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			return response, nil
+		}
+		if leaseExpired {
+			// the request died before booking; a booking it still makes is
+			// refused once the claim is gone
+			_, err = s.db.Exec(
+				ctx,
+				"DELETE FROM booking.idempotency_keys WHERE claim_id = $1 AND response IS NULL AND booking_id IS NULL AND lease_until < NOW()",
+				heldID,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("%s: failed to drop expired claim: %w", op, err)
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%s: %w", op, ctx.Err())
+		case <-time.After(idempotencyPollInterval):
+		}
+	}
+
+	stop := make(chan struct{})
+	go s.renewIdempotencyLease(ctx, claimID, stop)
+	response, err := run(context.WithValue(ctx, idempotencyClaimKey{}, claimID))
+	close(stop)
+	if err != nil {
+		// a fresh context, the request's own may be what failed it
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		defer cancel()
+		if _, releaseErr := s.db.Exec(releaseCtx, "DELETE FROM booking.idempotency_keys WHERE claim_id = $1 AND response IS NULL", claimID); releaseErr != nil {
+			return nil, fmt.Errorf("%s: failed to release idempotency key: %w: %w", op, releaseErr, err)
+		}
+		return nil, err
+	}
+
+	if err := s.storeIdempotentResponse(ctx, claimID, response); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return response, nil
+}
+
+// claimIdempotencyKey returns the id of the new claim on the key, 0 when
+// another request holds it.
+func (s *Storage) claimIdempotencyKey(ctx context.Context, userID int64, key string, fingerprint []byte) (int64, error) {
+	var claimID int64
+	err := s.db.QueryRow(
+		ctx,
+		`INSERT INTO booking.idempotency_keys (user_id, key, fingerprint, lease_until)
+		VALUES ($1, $2, $3, NOW() + make_interval(secs => $4))
+		ON CONFLICT DO NOTHING RETURNING claim_id`,
+		userID,
+		key,
+		fingerprint,
+		idempotencyLease.Seconds(),
+	).Scan(&claimID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+	return claimID, nil
+}
+
+// renewIdempotencyLease keeps the claim held until stop is closed. A lease
+// it fails to renew only lets a retry take the key over, and then the
+// booking of the request holding it is refused.
+func (s *Storage) renewIdempotencyLease(ctx context.Context, claimID int64, stop <-chan struct{}) {
+	ticker := time.NewTicker(idempotencyLease / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.db.Exec(
+				ctx,
+				"UPDATE booking.idempotency_keys SET lease_until = NOW() + make_interval(secs => $2) WHERE claim_id = $1 AND response IS NULL",
+				claimID,
+				idempotencyLease.Seconds(),
+			)
+		}
+	}
+}
+
+func (s *Storage) storeIdempotentResponse(ctx context.Context, claimID int64, response []byte) error {
+	_, err := s.db.Exec(ctx, "UPDATE booking.idempotency_keys SET response = $2 WHERE claim_id = $1", claimID, response)
+	if err != nil {
+		return fmt.Errorf("failed to store response: %w", err)
+	}
+	return nil
+}
+
+// linkIdempotencyClaim ties the booking made in tx to the claim of the
+// request it is made for, if the request runs under an idempotency key.
+// It fails with ErrIdempotencyClaimLost when a retry has taken the key over
+// or the claim already has a booking.
+func linkIdempotencyClaim(ctx context.Context, tx pgx.Tx, bookingID int64) error {
+	claimID, ok := ctx.Value(idempotencyClaimKey{}).(int64)
+	if !ok {
+		return nil
+	}
+
+	tag, err := tx.Exec(
+		ctx,
+		"UPDATE booking.idempotency_keys SET booking_id = $2 WHERE claim_id = $1 AND booking_id IS NULL",
+		claimID,
+		bookingID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to link booking to idempotency key: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrIdempotencyClaimLost
+	}
+	return nil
+}
+
+// PurgeIdempotencyKeys forgets the responses stored longer than retention
+// ago and returns how many were removed. A claim whose request is still
+// running is kept however old it is, or a retry could run it a second time.
+func (s *Storage) PurgeIdempotencyKeys(ctx context.Context, retention time.Duration) (int64, error) {
+	const op = "storage.PurgeIdempotencyKeys"
+
+	tag, err := s.db.Exec(
+		ctx,
+		`DELETE FROM booking.idempotency_keys
+		WHERE created_at < NOW() - make_interval(secs => $1)
+			AND (response IS NOT NULL OR booking_id IS NOT NULL OR lease_until < NOW())`,
+		retention.Seconds(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
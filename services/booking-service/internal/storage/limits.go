@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrBookingTooLarge = errors.New("too many tickets in one booking")
+var ErrTicketLimitReached = errors.New("ticket limit per customer reached")
+var ErrTooManyPendingBookings = errors.New("too many unpaid bookings")
+
+// checkPurchaseLimits refuses a booking of tickets seats and pool tickets
+// that would take the user past the purchase limits of the event. The
+// advisory lock serializes bookings of one user for one event until the
+// transaction ends, so two concurrent bookings cannot both slip under a
+// limit.
+func checkPurchaseLimits(ctx context.Context, tx pgx.Tx, userID, eventID int64, tickets int32) error {
+	var maxTickets, maxPending, maxPerBooking int32
+	err := tx.QueryRow(
+		ctx,
+		"SELECT max_tickets_per_user, max_pending_bookings, max_tickets_per_booking FROM event.purchase_limits WHERE event_id = $1",
+		eventID,
+	).Scan(&maxTickets, &maxPending, &maxPerBooking)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load purchase limits: %w", err)
+	}
+
+	if maxPerBooking > 0 && tickets > maxPerBooking {
+		return ErrBookingTooLarge
+	}
+	if maxTickets == 0 && maxPending == 0 {
+		return nil
+	}
+
+	_, err = tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtextextended('purchase:' || $1::text || ':' || $2::text, 0))", userID, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to lock purchases of user: %w", err)
+	}
+
+	// holds that have run out no longer count, even before the expiration worker gets to them
+	var held, pending int32
+	err = tx.QueryRow(
+		ctx,
+		`SELECT
+			COALESCE(SUM(
+				(SELECT COUNT(*) FROM booking.booking_seats bs WHERE bs.booking_id = b.id)
+				+ (SELECT COALESCE(SUM(pi.quantity), 0) FROM booking.booking_pool_items pi WHERE pi.booking_id = b.id)
+			), 0),
+			COUNT(*) FILTER (WHERE b.status IN ('PENDING', 'AWAITING_PAYMENT'))
+		FROM booking.bookings b
+		WHERE b.user_id = $1 AND b.event_id = $2
+			AND (b.status IN ('CONFIRMED', 'CHECKED_IN') OR (b.status IN ('PENDING', 'AWAITING_PAYMENT') AND b.expires_at > NOW()))`,
+		userID,
+		eventID,
+	).Scan(&held, &pending)
+	if err != nil {
+		return fmt.Errorf("failed to count tickets of user: %w", err)
+	}
+
+	if maxPending > 0 && pending >= maxPending {
+		return ErrTooManyPendingBookings
+	}
+	if maxTickets > 0 && held+tickets > maxTickets {
+		return ErrTicketLimitReached
+	}
+
+	return nil
+}
@@ -0,0 +1,258 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrOrderNotFound = errors.New("order not found")
+var ErrOrderCurrencyMismatch = errors.New("bookings of an order must be priced in one currency")
+
+// OrderItem is what an order books of one event.
+type OrderItem struct {
+	EventID    int64
+	SeatIDs    []int64
+	GA         GeneralAdmission
+	PromoCodes []string
+}
+
+// Order is a booking per event of tickets bought together, with a single
+// payment made against one of them, PaymentBookingID. Until the payment
+// comes in the bookings are held; they are confirmed or released together.
+type Order struct {
+	ID               int64
+	UserID           int64
+	Status           string
+	Total            Price
+	PaymentBookingID int64
+	// when the hold runs out, set only while the order is pending
+	ExpiresAt *time.Time
+	Bookings  []Booking
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CreateOrder books every item for the user in one transaction, or none of
+// them. The bookings are held as long as the shortest hold of their events
+// and the order is paid against the first one. It returns the order, the
+// booking to pay and the total.
+func (s *Storage) CreateOrder(ctx context.Context, userID int64, items []OrderItem) (int64, int64, Price, error) {
+	const op = "storage.CreateOrder"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, 0, Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var orderID int64
+	err = tx.QueryRow(ctx, "INSERT INTO booking.orders (user_id) VALUES ($1) RETURNING id", userID).Scan(&orderID)
+	if err != nil {
+		return 0, 0, Price{}, fmt.Errorf("%s: failed to create order: %w", op, err)
+	}
+
+	var total Price
+	bookingIDs := make([]int64, 0, len(items))
+	for i, item := range items {
+		if err := lockEventOnSale(ctx, tx, item.EventID, false); err != nil {
+			return 0, 0, Price{}, fmt.Errorf("%s: event %d: %w", op, item.EventID, err)
+		}
+
+		bookingID, price, err := bookTickets(ctx, tx, userID, item.EventID, item.SeatIDs, item.GA, item.PromoCodes, s.defaultHold)
+		if err != nil {
+			return 0, 0, Price{}, fmt.Errorf("%s: event %d: %w", op, item.EventID, err)
+		}
+		if i > 0 && price.Currency != total.Currency {
+			return 0, 0, Price{}, fmt.Errorf("%s: %w", op, ErrOrderCurrencyMismatch)
+		}
+
+		total.Amount += price.Amount
+		total.Currency = price.Currency
+		bookingIDs = append(bookingIDs, bookingID)
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`UPDATE booking.bookings SET order_id = $1,
+			expires_at = (SELECT MIN(expires_at) FROM booking.bookings WHERE id = ANY($2))
+		WHERE id = ANY($2)`,
+		orderID,
+		bookingIDs,
+	)
+	if err != nil {
+		return 0, 0, Price{}, fmt.Errorf("%s: failed to link bookings to order: %w", op, err)
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE booking.orders SET total_amount = $2, currency = $3, payment_booking_id = $4 WHERE id = $1",
+		orderID,
+		total.Amount,
+		total.Currency,
+		bookingIDs[0],
+	)
+	if err != nil {
+		return 0, 0, Price{}, fmt.Errorf("%s: failed to save order total: %w", op, err)
+	}
+
+	// the payment booking stands for the order a retry replays
+	if err := linkIdempotencyClaim(ctx, tx, bookingIDs[0]); err != nil {
+		return 0, 0, Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return orderID, bookingIDs[0], total, tx.Commit(ctx)
+}
+
+// GetOrder returns the order with its bookings if it belongs to the user.
+func (s *Storage) GetOrder(ctx context.Context, orderID, userID int64) (*Order, error) {
+	const op = "storage.GetOrder"
+
+	var o Order
+	err := s.db.QueryRow(
+		ctx,
+		`SELECT id, user_id, status, total_amount, COALESCE(currency, ''), COALESCE(payment_booking_id, 0), created_at, updated_at
+		FROM booking.orders WHERE id = $1 AND user_id = $2`,
+		orderID,
+		userID,
+	).Scan(&o.ID, &o.UserID, &o.Status, &o.Total.Amount, &o.Total.Currency, &o.PaymentBookingID, &o.CreatedAt, &o.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrOrderNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(ctx, bookingQuery+" WHERE b.order_id = $1 ORDER BY b.id", orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	o.Bookings, err = pgx.CollectRows(rows, scanBooking)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if o.Status == "PENDING" {
+		for _, b := range o.Bookings {
+			if b.ExpiresAt != nil && (o.ExpiresAt == nil || b.ExpiresAt.Before(*o.ExpiresAt)) {
+				o.ExpiresAt = b.ExpiresAt
+			}
+		}
+	}
+
+	return &o, nil
+}
+
+// lockOrderOf locks the order of the booking and returns its id, 0 when the
+// booking is not part of one. Whatever changes held bookings locks their
+// order first, so the bookings of an order change one transaction at a time.
+func lockOrderOf(ctx context.Context, tx pgx.Tx, bookingID int64) (int64, error) {
+	var orderID int64
+	err := tx.QueryRow(
+		ctx,
+		`SELECT o.id FROM booking.orders o JOIN booking.bookings b ON b.order_id = o.id
+		WHERE b.id = $1 FOR UPDATE OF o`,
+		bookingID,
+	).Scan(&orderID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("failed to lock order: %w", err)
+	}
+	return orderID, nil
+}
+
+type orderBooking struct {
+	id      int64
+	userID  int64
+	eventID int64
+	status  string
+}
+
+// lockOrderBookings locks the bookings of the order but exceptID, in the
+// order of id.
+func lockOrderBookings(ctx context.Context, tx pgx.Tx, orderID, exceptID int64) ([]orderBooking, error) {
+	rows, err := tx.Query(
+		ctx,
+		"SELECT id, user_id, event_id, status::text FROM booking.bookings WHERE order_id = $1 AND id <> $2 ORDER BY id FOR UPDATE",
+		orderID,
+		exceptID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock bookings of order: %w", err)
+	}
+
+	bookings, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (orderBooking, error) {
+		var b orderBooking
+		err := row.Scan(&b.id, &b.userID, &b.eventID, &b.status)
+		return b, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock bookings of order: %w", err)
+	}
+	return bookings, nil
+}
+
+// confirmOrder confirms the rest of the order once the payment of the order
+// has confirmed confirmedID. The bookings of a pending order are all held,
+// so one that is not fails the whole confirmation.
+func (s *Storage) confirmOrder(ctx context.Context, tx pgx.Tx, orderID, confirmedID int64) error {
+	if orderID == 0 {
+		return nil
+	}
+
+	tag, err := tx.Exec(ctx, "UPDATE booking.orders SET status = 'CONFIRMED', updated_at = NOW() WHERE id = $1 AND status = 'PENDING'", orderID)
+	if err != nil {
+		return fmt.Errorf("failed to confirm order: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrBookingCannotBeChanged
+	}
+
+	bookings, err := lockOrderBookings(ctx, tx, orderID, confirmedID)
+	if err != nil {
+		return err
+	}
+	for _, b := range bookings {
+		if err := s.confirmHeldBooking(ctx, tx, b.id, b.userID, b.eventID, b.status); err != nil {
+			return fmt.Errorf("booking %d of order: %w", b.id, err)
+		}
+	}
+	return nil
+}
+
+// releaseOrder ends the pending order of a booking that has just stopped
+// being held by trigger, and releases the bookings of the order still held
+// the same way: an order is bought whole or not at all.
+func (s *Storage) releaseOrder(ctx context.Context, tx pgx.Tx, orderID int64, trigger BookingTrigger, actor, routingKey string) error {
+	if orderID == 0 {
+		return nil
+	}
+
+	status := "CANCELLED"
+	if trigger == TriggerHoldExpired {
+		status = "EXPIRED"
+	}
+	tag, err := tx.Exec(ctx, "UPDATE booking.orders SET status = $2, updated_at = NOW() WHERE id = $1 AND status = 'PENDING'", orderID, status)
+	if err != nil {
+		return fmt.Errorf("failed to release order: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil
+	}
+
+	bookings, err := lockOrderBookings(ctx, tx, orderID, 0)
+	if err != nil {
+		return err
+	}
+	for _, b := range bookings {
+		if b.status != "PENDING" && b.status != "AWAITING_PAYMENT" {
+			continue
+		}
+		if err := s.releaseBooking(ctx, tx, b.id, trigger, actor, routingKey); err != nil {
+			return fmt.Errorf("booking %d of order: %w", b.id, err)
+		}
+	}
+	return nil
+}
@@ -14,16 +14,37 @@ import (
     authv1 "github.com/kay-kewl/ticket-booking-system/gen/go/auth"
     eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
 
-	"github.com/kay-kewl/ticket-booking-system/internal/metrics"
 )
 
 var ErrSeatNotAvailable = errors.New("seat is not available or does not exist")
 var ErrBookingCannotBeChanged = errors.New("booking is not in a state that can be changed")
+var ErrEventNotOnSale = errors.New("event is not on sale")
+var ErrTicketNotPriced = errors.New("ticket has no price")
+var ErrPoolNotFound = errors.New("inventory pool not found")
+var ErrNotEnoughTickets = errors.New("not enough tickets left in the pool")
+var ErrTicketReissued = errors.New("ticket has been reissued")
+
+// GeneralAdmission asks for quantity unnumbered tickets from an inventory
+// pool; the zero value books none.
+type GeneralAdmission struct {
+	PoolID   int64
+	Quantity int32
+}
+
+// Price is an amount in minor units of the ISO 4217 currency.
+type Price struct {
+	Amount   int64
+	Currency string
+}
 
 type Storage struct {
 	db          *pgxpool.Pool
     authClient  authv1.AuthClient
     eventClient eventv1.EventServiceClient
+    // hold time of bookings whose event does not set its own
+    defaultHold time.Duration
+    // how long a waitlist offer keeps its tickets
+    offerTime   time.Duration
 }
 
 type OutboxMessage struct {
@@ -32,83 +53,173 @@ type OutboxMessage struct {
 	Payload    []byte
 }
 
-func New(db *pgxpool.Pool, authClient authv1.AuthClient, eventClient eventv1.EventServiceClient) *Storage {
+func New(db *pgxpool.Pool, authClient authv1.AuthClient, eventClient eventv1.EventServiceClient, defaultHold, offerTime time.Duration) *Storage {
 	return &Storage{
         db:             db,
         authClient:     authClient,
         eventClient:    eventClient,
+        defaultHold:    defaultHold,
+        offerTime:      offerTime,
     }
 }
 
-func (s *Storage) CreateBooking(ctx context.Context, userID, eventID int64, seatIDs []int64) (int64, error) {
+func (s *Storage) CreateBooking(ctx context.Context, userID, eventID int64, seatIDs []int64, ga GeneralAdmission, promoCodes []string) (int64, Price, error) {
 	const op = "storage.CreateBooking"
 
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("%s: %w", op, err)
+		return 0, Price{}, fmt.Errorf("%s: %w", op, err)
 	}
 	defer tx.Rollback(ctx)
 
-	rows, err := tx.Query(
+	if err := lockEventOnSale(ctx, tx, eventID, false); err != nil {
+		return 0, Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	bookingID, total, err := bookTickets(ctx, tx, userID, eventID, seatIDs, ga, promoCodes, s.defaultHold)
+	if err != nil {
+		return 0, Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := linkIdempotencyClaim(ctx, tx, bookingID); err != nil {
+		return 0, Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return bookingID, total, tx.Commit(ctx)
+}
+
+// lockEventOnSale fails with ErrEventNotOnSale unless the event is on sale.
+// A sold out event counts only when soldOut is set, for tickets already set
+// aside for someone. FOR SHARE keeps the event from being cancelled or taken
+// off sale until the transaction ends, so a cancellation never misses it.
+func lockEventOnSale(ctx context.Context, tx pgx.Tx, eventID int64, soldOut bool) error {
+	var onSale bool
+	err := tx.QueryRow(
 		ctx,
-		"SELECT id FROM event.seats WHERE id = ANY($1) AND event_id = $2 AND status = 'AVAILABLE' ORDER BY id FOR UPDATE",
-		seatIDs,
+		`SELECT (status = 'ON_SALE' OR ($2 AND status = 'SOLD_OUT'))
+			AND (on_sale_at IS NULL OR on_sale_at <= NOW())
+			AND (off_sale_at IS NULL OR off_sale_at > NOW())
+		FROM event.events WHERE id = $1 FOR SHARE`,
 		eventID,
-	)
+		soldOut,
+	).Scan(&onSale)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to check event status: %w", err)
+	}
+	if !onSale {
+		return ErrEventNotOnSale
+	}
+	return nil
+}
+
+// bookTickets creates a pending booking of the available seats and pool
+// tickets in the transaction and holds them for the event's hold time, or
+// defaultHold when it sets none. The promo codes are redeemed in the same
+// transaction and their discount taken off the returned total.
+func bookTickets(ctx context.Context, tx pgx.Tx, userID, eventID int64, seatIDs []int64, ga GeneralAdmission, promoCodes []string, defaultHold time.Duration) (int64, Price, error) {
+	if err := checkPurchaseLimits(ctx, tx, userID, eventID, int32(len(seatIDs))+ga.Quantity); err != nil {
+		return 0, Price{}, err
+	}
+
+	lockedSeatIDs, seatPrices, lines, err := lockAvailableSeats(ctx, tx, eventID, seatIDs)
 	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			return 0, fmt.Errorf("%s: %w", op, ErrSeatNotAvailable)
-		}
-		return 0, fmt.Errorf("%s: failed to lock seats: %w", op, err)
+		return 0, Price{}, err
 	}
 
-	var lockedSeatIDs []int64
-	for rows.Next() {
-		var id int64
-		if err := rows.Scan(&id); err != nil {
-			rows.Close()
-			return 0, fmt.Errorf("%s: failed to scan locked seat id: %w", op, err)
+	prices := seatPrices
+	var poolPrice Price
+	if ga.Quantity > 0 {
+		var poolTierID int64
+		poolPrice, poolTierID, err = holdPoolTickets(ctx, tx, eventID, ga)
+		if err != nil {
+			return 0, Price{}, err
 		}
-		lockedSeatIDs = append(lockedSeatIDs, id)
+		prices = append(prices, Price{Amount: poolPrice.Amount * int64(ga.Quantity), Currency: poolPrice.Currency})
+		lines = append(lines, pricedLine{tierID: poolTierID, amount: poolPrice.Amount * int64(ga.Quantity)})
 	}
-	rows.Close()
 
-	if len(lockedSeatIDs) != len(seatIDs) {
-		return 0, ErrSeatNotAvailable
+	total, err := sumPrices(prices)
+	if err != nil {
+		return 0, Price{}, err
+	}
+
+	redemptions, err := redeemPromoCodes(ctx, tx, userID, eventID, promoCodes, lines, total.Currency)
+	if err != nil {
+		return 0, Price{}, err
+	}
+	var discount int64
+	for _, r := range redemptions {
+		discount += r.discount
 	}
+	total.Amount -= discount
 
-	var bookingID int64
+	var (
+		bookingID int64
+		expiresAt time.Time
+	)
 	err = tx.QueryRow(
 		ctx,
-		"INSERT INTO booking.bookings(user_id, event_id, status) VALUES($1, $2, 'PENDING') RETURNING id",
+		`INSERT INTO booking.bookings(user_id, event_id, status, total_amount, discount_amount, currency, expires_at)
+		VALUES($1, $2, 'PENDING', $3, $4, $5, NOW() + COALESCE(
+			(SELECT make_interval(mins => hold_minutes) FROM event.events WHERE id = $2),
+			make_interval(secs => $6)
+		))
+		RETURNING id, expires_at`,
 		userID,
 		eventID,
-	).Scan(&bookingID)
+		total.Amount,
+		discount,
+		total.Currency,
+		defaultHold.Seconds(),
+	).Scan(&bookingID, &expiresAt)
 	if err != nil {
-		return 0, fmt.Errorf("%s: failed to create booking: %w", op, err)
+		return 0, Price{}, fmt.Errorf("failed to create booking: %w", err)
+	}
+	if _, err := startBookingHistory(ctx, tx, bookingID, TriggerBooked, userActor(userID)); err != nil {
+		return 0, Price{}, err
 	}
 
-	for _, seatID := range lockedSeatIDs {
+	if err := saveRedemptions(ctx, tx, bookingID, userID, redemptions); err != nil {
+		return 0, Price{}, err
+	}
+
+	for i, seatID := range lockedSeatIDs {
 		_, err = tx.Exec(
 			ctx,
-			"INSERT INTO booking.booking_seats(booking_id, seat_id) VALUES($1, $2)",
+			"INSERT INTO booking.booking_seats(booking_id, seat_id, price_amount, price_currency) VALUES($1, $2, $3, $4)",
 			bookingID,
 			seatID,
+			seatPrices[i].Amount,
+			seatPrices[i].Currency,
 		)
 		if err != nil {
-			return 0, fmt.Errorf("%s: failed to link seat to booking: %w", op, err)
+			return 0, Price{}, fmt.Errorf("failed to link seat to booking: %w", err)
 		}
 	}
 
 	_, err = tx.Exec(ctx, "UPDATE event.seats SET status = 'RESERVED' WHERE id = ANY($1)", lockedSeatIDs)
 	if err != nil {
-		return 0, fmt.Errorf("%s: failed to update seat status: %w", op, err)
+		return 0, Price{}, fmt.Errorf("failed to update seat status: %w", err)
 	}
 
-	payload, err := json.Marshal(map[string]int64{"booking_id": bookingID})
+	if ga.Quantity > 0 {
+		_, err = tx.Exec(
+			ctx,
+			"INSERT INTO booking.booking_pool_items(booking_id, pool_id, quantity, price_amount, price_currency) VALUES($1, $2, $3, $4, $5)",
+			bookingID,
+			ga.PoolID,
+			ga.Quantity,
+			poolPrice.Amount,
+			poolPrice.Currency,
+		)
+		if err != nil {
+			return 0, Price{}, fmt.Errorf("failed to link pool tickets to booking: %w", err)
+		}
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{"booking_id": bookingID, "expires_at": expiresAt})
 	if err != nil {
-		return 0, fmt.Errorf("%s: failed to marshal outbox message: %w", op, err)
+		return 0, Price{}, fmt.Errorf("failed to marshal outbox message: %w", err)
 	}
 
 	_, err = tx.Exec(
@@ -119,10 +230,10 @@ func (s *Storage) CreateBooking(ctx context.Context, userID, eventID int64, seat
 		payload,
 	)
 	if err != nil {
-		return 0, fmt.Errorf("%s: failed to save outbox message: %w", op, err)
+		return 0, Price{}, fmt.Errorf("failed to save outbox message: %w", err)
 	}
 
-	return bookingID, tx.Commit(ctx)
+	return bookingID, total, nil
 }
 
 func (s *Storage) ConfirmBooking(ctx context.Context, bookingID int64) error {
@@ -134,12 +245,18 @@ func (s *Storage) ConfirmBooking(ctx context.Context, bookingID int64) error {
 	}
 	defer tx.Rollback(ctx)
 
+	orderID, err := lockOrderOf(ctx, tx, bookingID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
     var userID, eventID int64
+    var status string
     err = tx.QueryRow(
         ctx, 
-        "SELECT user_id, event_id FROM booking.bookings WHERE id = $1 FOR UPDATE",
+        "SELECT user_id, event_id, status::text FROM booking.bookings WHERE id = $1 FOR UPDATE",
         bookingID,
-    ).Scan(&userID, &eventID)
+    ).Scan(&userID, &eventID, &status)
     if err != nil {
         if errors.Is(err, pgx.ErrNoRows) {
             return ErrBookingCannotBeChanged
@@ -147,27 +264,59 @@ func (s *Storage) ConfirmBooking(ctx context.Context, bookingID int64) error {
         return fmt.Errorf("%s: failed to get booking details: %w", op, err)
     }
 
-	tag, err := tx.Exec(
+	if err := s.confirmHeldBooking(ctx, tx, bookingID, userID, eventID, status); err != nil {
+		if errors.Is(err, ErrBookingCannotBeChanged) {
+			return err
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// the payment of an order pays for all of its bookings
+	if err := s.confirmOrder(ctx, tx, orderID, bookingID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// confirmHeldBooking marks a held booking paid and sells its tickets.
+func (s *Storage) confirmHeldBooking(ctx context.Context, tx pgx.Tx, bookingID, userID, eventID int64, status string) error {
+	const op = "storage.internal.confirmHeldBooking"
+
+	if _, err := transitionBooking(ctx, tx, bookingID, status, TriggerPaymentConfirmed, actorPayment); err != nil {
+		if errors.Is(err, ErrBookingCannotBeChanged) {
+			return err
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err := tx.Exec(
 		ctx,
-		"UPDATE booking.bookings SET status = 'CONFIRMED' WHERE id = $1 AND status = 'PENDING'",
+		"UPDATE event.seats SET status = 'BOOKED' WHERE id IN (SELECT seat_id FROM booking.booking_seats WHERE booking_id = $1)",
 		bookingID,
 	)
 	if err != nil {
-		return fmt.Errorf("%s: failed to update booking status: %w", op, err)
-	}
-	if tag.RowsAffected() == 0 {
-		return ErrBookingCannotBeChanged
+		return fmt.Errorf("%s: failed to update seat status to BOOKED: %w", op, err)
 	}
 
-	metrics.BookingsTotal.WithLabelValues("CONFIRMED").Inc()
+	_, err = tx.Exec(
+		ctx,
+		`UPDATE event.inventory_pools p SET held = p.held - i.quantity, sold = p.sold + i.quantity
+		FROM booking.booking_pool_items i WHERE i.booking_id = $1 AND i.pool_id = p.id`,
+		bookingID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: failed to mark pool tickets as sold: %w", op, err)
+	}
 
 	_, err = tx.Exec(
 		ctx,
-		"UPDATE event.seats SET status = 'BOOKED' WHERE id IN (SELECT seat_id FROM booking.booking_seats WHERE booking_id = $1)",
+		"INSERT INTO booking.ownership_changes (booking_id, to_user_id) VALUES ($1, $2)",
 		bookingID,
+		userID,
 	)
 	if err != nil {
-		return fmt.Errorf("%s: failed to update seat status to BOOKED: %w", op, err)
+		return fmt.Errorf("%s: failed to record owner of tickets: %w", op, err)
 	}
 
     enrichCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
@@ -186,7 +335,10 @@ func (s *Storage) ConfirmBooking(ctx context.Context, bookingID int64) error {
     payloadMap := map[string]interface{}{
         "booking_id":   bookingID,
         "user_email":   userDetails.GetEmail(),
+        "event_id":     eventID,
         "event_title":  eventDetails.GetTitle(),
+        "event_starts_at": eventDetails.GetStartsAt(),
+        "event_poster_url": posterURL(eventDetails.GetPoster()),
         // TODO: add whatever's needed
     }
 
@@ -205,7 +357,7 @@ func (s *Storage) ConfirmBooking(ctx context.Context, bookingID int64) error {
 		return fmt.Errorf("%s: failed to save outbox message: %w", op, err)
 	}
 
-	return tx.Commit(ctx)
+	return nil
 }
 
 func (s *Storage) CancelBooking(ctx context.Context, bookingID int64) error {
@@ -217,14 +369,96 @@ func (s *Storage) CancelBooking(ctx context.Context, bookingID int64) error {
 	}
 	defer tx.Rollback(ctx)
 
-	err = s.updateBookingStatus(ctx, tx, bookingID, "CANCELLED", "booking.cancelled")
+	orderID, err := lockOrderOf(ctx, tx, bookingID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.releaseHeldBooking(ctx, tx, bookingID, orderID, TriggerPaymentFailed, actorPayment, "booking.cancelled")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// MarkAwaitingPayment notes that the payment of a pending booking has been
+// started. A payment confirmed in the meantime wins, the booking is then no
+// longer pending.
+func (s *Storage) MarkAwaitingPayment(ctx context.Context, bookingID int64) error {
+	const op = "storage.MarkAwaitingPayment"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	orderID, err := lockOrderOf(ctx, tx, bookingID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := transitionBooking(ctx, tx, bookingID, "PENDING", TriggerPaymentInitiated, actorSystem); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// the payment of an order is started for all of its bookings
+	if orderID != 0 {
+		bookings, err := lockOrderBookings(ctx, tx, orderID, bookingID)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		for _, b := range bookings {
+			if _, err := transitionBooking(ctx, tx, b.id, b.status, TriggerPaymentInitiated, actorSystem); err != nil {
+				return fmt.Errorf("%s: booking %d of order: %w", op, b.id, err)
+			}
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// CheckInBooking admits the holder of a paid booking of the event who shows
+// ticketVersion of its tickets; a booking is checked in once, and a ticket
+// voided by a transfer or seat change not at all.
+func (s *Storage) CheckInBooking(ctx context.Context, eventID, bookingID int64, ticketVersion int32, staffID int64) error {
+	const op = "storage.CheckInBooking"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var status string
+	var current int32
+	err = tx.QueryRow(
+		ctx,
+		"SELECT status::text, ticket_version FROM booking.bookings WHERE id = $1 AND event_id = $2 FOR UPDATE",
+		bookingID,
+		eventID,
+	).Scan(&status, &current)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, ErrBookingNotFound)
+		}
+		return fmt.Errorf("%s: failed to lock booking: %w", op, err)
+	}
+	if ticketVersion != current {
+		return fmt.Errorf("%s: version %d of %d: %w", op, ticketVersion, current, ErrTicketReissued)
+	}
+
+	if _, err := transitionBooking(ctx, tx, bookingID, status, TriggerCheckedIn, userActor(staffID)); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	return tx.Commit(ctx)
 }
 
+// ExpireBooking releases a held booking whose hold has run out. The
+// expiry is checked under the row lock, so a hold extended in the meantime
+// is left alone.
 func (s *Storage) ExpireBooking(ctx context.Context, bookingID int64) error {
 	const op = "storage.ExpireBooking"
 
@@ -234,7 +468,28 @@ func (s *Storage) ExpireBooking(ctx context.Context, bookingID int64) error {
 	}
 	defer tx.Rollback(ctx)
 
-	err = s.updateBookingStatus(ctx, tx, bookingID, "EXPIRED", "booking.expired")
+	orderID, err := lockOrderOf(ctx, tx, bookingID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var due bool
+	err = tx.QueryRow(
+		ctx,
+		"SELECT COALESCE(status IN ('PENDING', 'AWAITING_PAYMENT') AND expires_at <= NOW(), FALSE) FROM booking.bookings WHERE id = $1 FOR UPDATE",
+		bookingID,
+	).Scan(&due)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, ErrBookingCannotBeChanged)
+		}
+		return fmt.Errorf("%s: failed to lock booking: %w", op, err)
+	}
+	if !due {
+		return fmt.Errorf("%s: %w", op, ErrBookingCannotBeChanged)
+	}
+
+	err = s.releaseHeldBooking(ctx, tx, bookingID, orderID, TriggerHoldExpired, actorSystem, "booking.expired")
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -242,29 +497,44 @@ func (s *Storage) ExpireBooking(ctx context.Context, bookingID int64) error {
 	return tx.Commit(ctx)
 }
 
-func (s *Storage) updateBookingStatus(ctx context.Context, tx pgx.Tx, bookingID int64, newStatus, routingKey string) error {
-	const op = "storage.internal.updateBookingStatus"
+// releaseHeldBooking ends a booking that holds its tickets unpaid by
+// trigger and gives the tickets back, along with the rest of its order,
+// locked by the caller.
+func (s *Storage) releaseHeldBooking(ctx context.Context, tx pgx.Tx, bookingID, orderID int64, trigger BookingTrigger, actor, routingKey string) error {
+	if err := s.releaseBooking(ctx, tx, bookingID, trigger, actor, routingKey); err != nil {
+		return err
+	}
+	return s.releaseOrder(ctx, tx, orderID, trigger, actor, routingKey)
+}
 
-	tag, err := tx.Exec(
+// releaseBooking is releaseHeldBooking of the booking alone.
+func (s *Storage) releaseBooking(ctx context.Context, tx pgx.Tx, bookingID int64, trigger BookingTrigger, actor, routingKey string) error {
+	const op = "storage.internal.releaseBooking"
+
+	var (
+		eventID int64
+		status  string
+	)
+	err := tx.QueryRow(
 		ctx,
-		"UPDATE booking.bookings SET status = $1 WHERE id = $2 AND status = 'PENDING'",
-		newStatus,
+		"SELECT event_id, status::text FROM booking.bookings WHERE id = $1 AND status IN ('PENDING', 'AWAITING_PAYMENT') FOR UPDATE",
 		bookingID,
-	)
+	).Scan(&eventID, &status)
 	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "22P02" {
-			return fmt.Errorf("%s: invalid status value %s: %w", op, newStatus, err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrBookingCannotBeChanged
 		}
-		return fmt.Errorf("%s: failed to update booking status: %w", op, err)
+		return fmt.Errorf("%s: failed to lock booking: %w", op, err)
 	}
 
-	if tag.RowsAffected() == 0 {
-		return ErrBookingCannotBeChanged 
+	newStatus, err := transitionBooking(ctx, tx, bookingID, status, trigger, actor)
+	if err != nil {
+		if errors.Is(err, ErrBookingCannotBeChanged) {
+			return err
+		}
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	metrics.BookingsTotal.WithLabelValues(newStatus).Inc()
-
 	_, err = tx.Exec(
 		ctx,
 		"UPDATE event.seats SET status = 'AVAILABLE' WHERE id IN (SELECT seat_id FROM booking.booking_seats WHERE booking_id = $1)",
@@ -274,6 +544,19 @@ func (s *Storage) updateBookingStatus(ctx context.Context, tx pgx.Tx, bookingID
 		return fmt.Errorf("%s: failed to release seats: %w", op, err)
 	}
 
+	if err := releasePoolTickets(ctx, tx, bookingID, "PENDING"); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := releasePromoCodes(ctx, tx, bookingID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// released tickets go to the waitlist before anyone else can book them
+	if _, err := s.offerTickets(ctx, tx, eventID); err != nil {
+		return fmt.Errorf("%s: failed to offer released tickets: %w", op, err)
+	}
+
 	payload, err := json.Marshal(map[string]interface{}{"booking_id": bookingID, "reason": newStatus})
 	if err != nil {
 		return fmt.Errorf("%s: failed to marshal outbox payload: %w", op, err)
@@ -292,3 +575,136 @@ func (s *Storage) updateBookingStatus(ctx context.Context, tx pgx.Tx, bookingID
 
 	return nil
 }
+
+// lockAvailableSeats locks seatIDs of the event, which must all be available
+// and priced, and returns them in the order of id with their prices.
+func lockAvailableSeats(ctx context.Context, tx pgx.Tx, eventID int64, seatIDs []int64) ([]int64, []Price, []pricedLine, error) {
+	rows, err := tx.Query(
+		ctx,
+		`SELECT s.id, COALESCE(s.price_tier_id, 0), t.amount, t.currency FROM event.seats s
+		LEFT JOIN event.price_tiers t ON t.id = s.price_tier_id
+		WHERE s.id = ANY($1) AND s.event_id = $2 AND s.status = 'AVAILABLE'
+		ORDER BY s.id FOR UPDATE OF s`,
+		seatIDs,
+		eventID,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			return nil, nil, nil, ErrSeatNotAvailable
+		}
+		return nil, nil, nil, fmt.Errorf("failed to lock seats: %w", err)
+	}
+
+	var (
+		lockedSeatIDs []int64
+		seatPrices    []Price
+		lines         []pricedLine
+	)
+	for rows.Next() {
+		var (
+			id       int64
+			tierID   int64
+			amount   *int64
+			currency *string
+		)
+		if err := rows.Scan(&id, &tierID, &amount, &currency); err != nil {
+			rows.Close()
+			return nil, nil, nil, fmt.Errorf("failed to scan locked seat: %w", err)
+		}
+		if amount == nil || currency == nil {
+			rows.Close()
+			return nil, nil, nil, fmt.Errorf("seat %d: %w", id, ErrTicketNotPriced)
+		}
+		lockedSeatIDs = append(lockedSeatIDs, id)
+		seatPrices = append(seatPrices, Price{Amount: *amount, Currency: *currency})
+		lines = append(lines, pricedLine{tierID: tierID, amount: *amount})
+	}
+	rows.Close()
+
+	if len(lockedSeatIDs) != len(seatIDs) {
+		return nil, nil, nil, ErrSeatNotAvailable
+	}
+
+	return lockedSeatIDs, seatPrices, lines, nil
+}
+
+func sumPrices(prices []Price) (Price, error) {
+	var total Price
+	for _, price := range prices {
+		if total.Currency != "" && total.Currency != price.Currency {
+			return Price{}, fmt.Errorf("seats are priced in different currencies: %s and %s", total.Currency, price.Currency)
+		}
+		total.Currency = price.Currency
+		total.Amount += price.Amount
+	}
+	return total, nil
+}
+
+// holdPoolTickets takes ga.Quantity tickets of the pool into held and returns
+// the price of a single ticket and its price tier. The row lock on the pool
+// serializes concurrent bookings; chk_inventory_pools_not_oversold is the last
+// line of defence.
+func holdPoolTickets(ctx context.Context, tx pgx.Tx, eventID int64, ga GeneralAdmission) (Price, int64, error) {
+	var (
+		available int32
+		tierID    int64
+		amount    *int64
+		currency  *string
+	)
+	err := tx.QueryRow(
+		ctx,
+		`SELECT p.capacity - p.sold - p.held, COALESCE(p.price_tier_id, 0), t.amount, t.currency FROM event.inventory_pools p
+		LEFT JOIN event.price_tiers t ON t.id = p.price_tier_id
+		WHERE p.id = $1 AND p.event_id = $2
+		FOR UPDATE OF p`,
+		ga.PoolID,
+		eventID,
+	).Scan(&available, &tierID, &amount, &currency)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Price{}, 0, ErrPoolNotFound
+		}
+		return Price{}, 0, fmt.Errorf("failed to lock pool: %w", err)
+	}
+	if amount == nil || currency == nil {
+		return Price{}, 0, fmt.Errorf("pool %d: %w", ga.PoolID, ErrTicketNotPriced)
+	}
+	if available < ga.Quantity {
+		return Price{}, 0, ErrNotEnoughTickets
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE event.inventory_pools SET held = held + $1 WHERE id = $2", ga.Quantity, ga.PoolID)
+	if err != nil {
+		return Price{}, 0, fmt.Errorf("failed to hold pool tickets: %w", err)
+	}
+
+	return Price{Amount: *amount, Currency: *currency}, tierID, nil
+}
+
+// releasePoolTickets gives the pool tickets of a booking back to their pools.
+// fromStatus is the booking status they were counted under: held while
+// PENDING, sold once CONFIRMED.
+func releasePoolTickets(ctx context.Context, tx pgx.Tx, bookingID int64, fromStatus string) error {
+	query := `UPDATE event.inventory_pools p SET held = p.held - i.quantity
+		FROM booking.booking_pool_items i WHERE i.booking_id = $1 AND i.pool_id = p.id`
+	if fromStatus == "CONFIRMED" {
+		query = `UPDATE event.inventory_pools p SET sold = p.sold - i.quantity
+		FROM booking.booking_pool_items i WHERE i.booking_id = $1 AND i.pool_id = p.id`
+	}
+
+	if _, err := tx.Exec(ctx, query, bookingID); err != nil {
+		return fmt.Errorf("failed to release pool tickets: %w", err)
+	}
+	return nil
+}
+
+// posterURL picks the rendition printed on tickets, empty when the event has no poster.
+func posterURL(poster *eventv1.EventImage) string {
+	for _, rendition := range poster.GetRenditions() {
+		if rendition.GetName() == "medium" {
+			return rendition.GetUrl()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,303 @@
+package storage
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var ErrPromoCodeNotFound = errors.New("promo code not found")
+var ErrPromoCodeNotValid = errors.New("promo code is not valid now")
+var ErrPromoCodeNotApplicable = errors.New("promo code does not apply to these tickets")
+var ErrPromoCodeUsedUp = errors.New("promo code has been used up")
+var ErrPromoCodesNotStackable = errors.New("promo codes cannot be combined")
+
+const (
+	DiscountPercent = "PERCENT"
+	DiscountFixed   = "FIXED"
+)
+
+// PromoCode is a discount campaign. Caps of 0 mean no cap; EventID 0 and no
+// PriceTierIDs mean the code applies to any ticket.
+type PromoCode struct {
+	Code         string
+	DiscountType string
+	// PERCENT codes only
+	Percent int32
+	// FIXED codes only, in minor units
+	Amount                Price
+	ValidFrom             *time.Time
+	ValidUntil            *time.Time
+	MaxRedemptions        int32
+	MaxRedemptionsPerUser int32
+	Redemptions           int32
+	EventID               int64
+	PriceTierIDs          []int64
+	Stackable             bool
+	Active                bool
+}
+
+// pricedLine is a seat, or all the pool tickets, of a booking being priced.
+type pricedLine struct {
+	tierID int64
+	amount int64
+}
+
+// redemption is a promo code used by a booking and what it took off.
+type redemption struct {
+	codeID   int64
+	discount int64
+}
+
+const promoCodeColumns = `code, discount_type, percent, amount, COALESCE(currency, ''), valid_from, valid_until,
+	max_redemptions, max_redemptions_per_user, redemptions, COALESCE(event_id, 0), price_tier_ids, stackable, active`
+
+func (s *Storage) SetPromoCode(ctx context.Context, code PromoCode) (*PromoCode, error) {
+	const op = "storage.SetPromoCode"
+
+	rows, err := s.db.Query(
+		ctx,
+		`INSERT INTO booking.promo_codes (code, discount_type, percent, amount, currency, valid_from, valid_until,
+			max_redemptions, max_redemptions_per_user, event_id, price_tier_ids, stackable, active)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $9, NULLIF($10, 0), $11, $12, $13)
+		ON CONFLICT (code) DO UPDATE SET discount_type = EXCLUDED.discount_type, percent = EXCLUDED.percent,
+			amount = EXCLUDED.amount, currency = EXCLUDED.currency, valid_from = EXCLUDED.valid_from,
+			valid_until = EXCLUDED.valid_until, max_redemptions = EXCLUDED.max_redemptions,
+			max_redemptions_per_user = EXCLUDED.max_redemptions_per_user, event_id = EXCLUDED.event_id,
+			price_tier_ids = EXCLUDED.price_tier_ids, stackable = EXCLUDED.stackable, active = EXCLUDED.active
+		RETURNING `+promoCodeColumns,
+		code.Code,
+		code.DiscountType,
+		code.Percent,
+		code.Amount.Amount,
+		code.Amount.Currency,
+		code.ValidFrom,
+		code.ValidUntil,
+		code.MaxRedemptions,
+		code.MaxRedemptionsPerUser,
+		code.EventID,
+		code.PriceTierIDs,
+		code.Stackable,
+		code.Active,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	saved, err := pgx.CollectExactlyOneRow(rows, scanPromoCode)
+	if err != nil {
+		// lowering the cap below the redemptions made so far
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23514" {
+			return nil, fmt.Errorf("%s: %w", op, ErrPromoCodeUsedUp)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &saved, nil
+}
+
+func (s *Storage) GetPromoCode(ctx context.Context, code string) (*PromoCode, error) {
+	const op = "storage.GetPromoCode"
+
+	rows, err := s.db.Query(ctx, "SELECT "+promoCodeColumns+" FROM booking.promo_codes WHERE code = $1", code)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	promo, err := pgx.CollectExactlyOneRow(rows, scanPromoCode)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrPromoCodeNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &promo, nil
+}
+
+func scanPromoCode(row pgx.CollectableRow) (PromoCode, error) {
+	var p PromoCode
+	err := row.Scan(
+		&p.Code, &p.DiscountType, &p.Percent, &p.Amount.Amount, &p.Amount.Currency, &p.ValidFrom, &p.ValidUntil,
+		&p.MaxRedemptions, &p.MaxRedemptionsPerUser, &p.Redemptions, &p.EventID, &p.PriceTierIDs, &p.Stackable, &p.Active,
+	)
+	return p, err
+}
+
+// redeemPromoCodes checks that the codes may be used by the user for the
+// tickets of the event, counts them as redeemed and returns what each takes
+// off. The rows of the codes stay locked until the transaction ends, so
+// concurrent bookings queue up behind each other and never take a code past
+// its caps.
+func redeemPromoCodes(ctx context.Context, tx pgx.Tx, userID, eventID int64, codes []string, lines []pricedLine, currency string) ([]redemption, error) {
+	if len(codes) == 0 {
+		return nil, nil
+	}
+
+	// locked in the order of id, so bookings with the same codes cannot deadlock
+	rows, err := tx.Query(
+		ctx,
+		`SELECT id, `+promoCodeColumns+`,
+			active AND (valid_from IS NULL OR valid_from <= NOW()) AND (valid_until IS NULL OR valid_until > NOW()),
+			(SELECT COUNT(*) FROM booking.promo_redemptions r WHERE r.promo_code_id = p.id AND r.user_id = $2)
+		FROM booking.promo_codes p WHERE code = ANY($1) ORDER BY id FOR UPDATE`,
+		codes,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock promo codes: %w", err)
+	}
+
+	type lockedCode struct {
+		id int64
+		PromoCode
+		valid    bool
+		usedByMe int32
+	}
+	locked, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (lockedCode, error) {
+		var c lockedCode
+		p := &c.PromoCode
+		err := row.Scan(
+			&c.id, &p.Code, &p.DiscountType, &p.Percent, &p.Amount.Amount, &p.Amount.Currency, &p.ValidFrom, &p.ValidUntil,
+			&p.MaxRedemptions, &p.MaxRedemptionsPerUser, &p.Redemptions, &p.EventID, &p.PriceTierIDs, &p.Stackable, &p.Active,
+			&c.valid, &c.usedByMe,
+		)
+		return c, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock promo codes: %w", err)
+	}
+	if len(locked) != len(codes) {
+		return nil, ErrPromoCodeNotFound
+	}
+
+	promos := make([]PromoCode, 0, len(locked))
+	for _, c := range locked {
+		if !c.valid {
+			return nil, fmt.Errorf("%s: %w", c.Code, ErrPromoCodeNotValid)
+		}
+		if len(locked) > 1 && !c.Stackable {
+			return nil, fmt.Errorf("%s: %w", c.Code, ErrPromoCodesNotStackable)
+		}
+		if c.EventID != 0 && c.EventID != eventID {
+			return nil, fmt.Errorf("%s: %w", c.Code, ErrPromoCodeNotApplicable)
+		}
+		if (c.MaxRedemptions > 0 && c.Redemptions >= c.MaxRedemptions) || (c.MaxRedemptionsPerUser > 0 && c.usedByMe >= c.MaxRedemptionsPerUser) {
+			return nil, fmt.Errorf("%s: %w", c.Code, ErrPromoCodeUsedUp)
+		}
+		promos = append(promos, c.PromoCode)
+	}
+
+	discounts, err := applyDiscounts(lines, promos, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	redemptions := make([]redemption, 0, len(locked))
+	for i, c := range locked {
+		_, err := tx.Exec(ctx, "UPDATE booking.promo_codes SET redemptions = redemptions + 1 WHERE id = $1", c.id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to redeem promo code: %w", err)
+		}
+		redemptions = append(redemptions, redemption{codeID: c.id, discount: discounts[i]})
+	}
+
+	return redemptions, nil
+}
+
+// applyDiscounts works out what each code takes off the lines, all priced in
+// currency. Percentages go first, fixed amounts after them, each on what the
+// codes before it left of the tickets it applies to. A code that takes
+// nothing off does not apply. The discounts are returned in the order of the
+// codes.
+func applyDiscounts(lines []pricedLine, codes []PromoCode, currency string) ([]int64, error) {
+	remaining := make([]int64, len(lines))
+	for i, line := range lines {
+		remaining[i] = line.amount
+	}
+
+	order := make([]int, len(codes))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(discountRank(codes[a].DiscountType), discountRank(codes[b].DiscountType))
+	})
+
+	discounts := make([]int64, len(codes))
+	for _, i := range order {
+		code := codes[i]
+		if code.DiscountType == DiscountFixed && code.Amount.Currency != currency {
+			return nil, fmt.Errorf("%s: %w", code.Code, ErrPromoCodeNotApplicable)
+		}
+
+		left := code.Amount.Amount
+		for j, line := range lines {
+			if len(code.PriceTierIDs) > 0 && !slices.Contains(code.PriceTierIDs, line.tierID) {
+				continue
+			}
+			var off int64
+			switch code.DiscountType {
+			case DiscountPercent:
+				off = remaining[j] * int64(code.Percent) / 100
+			case DiscountFixed:
+				off = min(left, remaining[j])
+				left -= off
+			}
+			remaining[j] -= off
+			discounts[i] += off
+		}
+
+		if discounts[i] == 0 {
+			return nil, fmt.Errorf("%s: %w", code.Code, ErrPromoCodeNotApplicable)
+		}
+	}
+
+	return discounts, nil
+}
+
+func discountRank(discountType string) int {
+	if discountType == DiscountPercent {
+		return 0
+	}
+	return 1
+}
+
+func saveRedemptions(ctx context.Context, tx pgx.Tx, bookingID, userID int64, redemptions []redemption) error {
+	for _, r := range redemptions {
+		_, err := tx.Exec(
+			ctx,
+			"INSERT INTO booking.promo_redemptions (promo_code_id, booking_id, user_id, discount_amount) VALUES ($1, $2, $3, $4)",
+			r.codeID,
+			bookingID,
+			userID,
+			r.discount,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save promo code redemption: %w", err)
+		}
+	}
+	return nil
+}
+
+// releasePromoCodes gives the promo codes of a booking that was never paid
+// back to their caps.
+func releasePromoCodes(ctx context.Context, tx pgx.Tx, bookingID int64) error {
+	_, err := tx.Exec(
+		ctx,
+		`WITH released AS (DELETE FROM booking.promo_redemptions WHERE booking_id = $1 RETURNING promo_code_id)
+		UPDATE booking.promo_codes p SET redemptions = p.redemptions - 1 FROM released r WHERE p.id = r.promo_code_id`,
+		bookingID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to release promo codes: %w", err)
+	}
+	return nil
+}
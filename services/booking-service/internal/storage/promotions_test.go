@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyDiscounts(t *testing.T) {
+	lines := []pricedLine{
+		{tierID: 1, amount: 10000},
+		{tierID: 1, amount: 10000},
+		{tierID: 2, amount: 30000},
+	}
+
+	fixed := PromoCode{Code: "FIXED", DiscountType: DiscountFixed, Amount: Price{Amount: 25000, Currency: "RUB"}}
+	percent := PromoCode{Code: "PERCENT", DiscountType: DiscountPercent, Percent: 10}
+	discounts, err := applyDiscounts(lines, []PromoCode{fixed, percent}, "RUB")
+	require.NoError(t, err)
+	require.Equal(t, []int64{25000, 5000}, discounts, "percentages go first, in the order of the codes returned")
+
+	vip := PromoCode{Code: "VIP", DiscountType: DiscountPercent, Percent: 50, PriceTierIDs: []int64{2}}
+	discounts, err = applyDiscounts(lines, []PromoCode{vip}, "RUB")
+	require.NoError(t, err)
+	require.Equal(t, []int64{15000}, discounts, "only tickets of the listed tiers are discounted")
+
+	large := PromoCode{Code: "LARGE", DiscountType: DiscountFixed, Amount: Price{Amount: 100000, Currency: "RUB"}}
+	discounts, err = applyDiscounts(lines, []PromoCode{large}, "RUB")
+	require.NoError(t, err)
+	require.Equal(t, []int64{50000}, discounts, "a booking never costs less than nothing")
+
+	_, err = applyDiscounts(lines, []PromoCode{large, fixed}, "RUB")
+	require.ErrorIs(t, err, ErrPromoCodeNotApplicable, "a code with nothing left to discount does not apply")
+
+	_, err = applyDiscounts(lines, []PromoCode{fixed}, "EUR")
+	require.ErrorIs(t, err, ErrPromoCodeNotApplicable, "fixed amounts must be in the currency of the booking")
+
+	other := PromoCode{Code: "OTHER", DiscountType: DiscountPercent, Percent: 10, PriceTierIDs: []int64{3}}
+	_, err = applyDiscounts(lines, []PromoCode{other}, "RUB")
+	require.ErrorIs(t, err, ErrPromoCodeNotApplicable)
+}
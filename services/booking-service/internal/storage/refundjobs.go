@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// a claimed refund that is neither completed nor retried by then belongs to
+// a crashed worker and is sent again
+const refundJobLease = 2 * time.Minute
+
+// RefundJob is a refund owed to a customer out of the payment made against
+// PaymentBookingID. It is saved with the change that owes it and sent by the
+// refund worker under Key, which the payment service pays out once.
+type RefundJob struct {
+	ID               int64
+	Key              string
+	BookingID        int64
+	PaymentBookingID int64
+	// nil refunds the whole payment
+	Amount   *int64
+	Currency string
+	Attempts int32
+}
+
+// paymentBookingSQL selects the booking the tickets of booking $1 were paid
+// with: the first of the bookings its seats were transferred through, or
+// the one its order was paid against.
+const paymentBookingSQL = `WITH RECURSIVE chain (id, transferred_from_id) AS (
+		SELECT id, transferred_from_id FROM booking.bookings WHERE id = $1
+		UNION ALL
+		SELECT p.id, p.transferred_from_id FROM chain JOIN booking.bookings p ON p.id = chain.transferred_from_id
+	)
+	SELECT COALESCE(o.payment_booking_id, r.id) FROM chain c
+		JOIN booking.bookings r ON r.id = c.id
+		LEFT JOIN booking.orders o ON o.id = r.order_id
+		WHERE c.transferred_from_id IS NULL`
+
+func paymentBookingOf(ctx context.Context, tx pgx.Tx, bookingID int64) (int64, error) {
+	var paymentBookingID int64
+	if err := tx.QueryRow(ctx, paymentBookingSQL, bookingID).Scan(&paymentBookingID); err != nil {
+		return 0, fmt.Errorf("failed to find payment of booking: %w", err)
+	}
+	return paymentBookingID, nil
+}
+
+// queueRefund saves a refund of amount, or of the whole payment when amount
+// is nil, to be sent once the transaction commits. A refund already saved
+// under key is left as it is.
+func queueRefund(ctx context.Context, tx pgx.Tx, key string, bookingID, paymentBookingID int64, amount *Price, seatChangeID *int64) error {
+	// a booking that cost nothing was never paid for
+	if amount != nil && amount.Amount == 0 {
+		return nil
+	}
+
+	var value *int64
+	var currency *string
+	if amount != nil {
+		value, currency = &amount.Amount, &amount.Currency
+	}
+
+	_, err := tx.Exec(
+		ctx,
+		`INSERT INTO booking.refund_jobs (idempotency_key, booking_id, payment_booking_id, amount, currency, seat_change_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (idempotency_key) DO NOTHING`,
+		key,
+		bookingID,
+		paymentBookingID,
+		value,
+		currency,
+		seatChangeID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to queue refund: %w", err)
+	}
+	return nil
+}
+
+// ClaimRefundJobs leases up to limit refunds that are due to be sent,
+// counting the attempt. Several replicas can claim side by side.
+func (s *Storage) ClaimRefundJobs(ctx context.Context, limit int) ([]RefundJob, error) {
+	const op = "storage.ClaimRefundJobs"
+
+	rows, err := s.db.Query(
+		ctx,
+		`UPDATE booking.refund_jobs SET attempts = attempts + 1, next_attempt_at = NOW() + $2::interval
+		WHERE id IN (
+			SELECT id FROM booking.refund_jobs
+			WHERE status = 'PENDING' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, idempotency_key, booking_id, payment_booking_id, amount, COALESCE(currency, ''), attempts`,
+		limit,
+		refundJobLease.String(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	jobs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (RefundJob, error) {
+		var j RefundJob
+		err := row.Scan(&j.ID, &j.Key, &j.BookingID, &j.PaymentBookingID, &j.Amount, &j.Currency, &j.Attempts)
+		return j, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return jobs, nil
+}
+
+// CompleteRefundJob notes that the payment service has paid the refund out.
+func (s *Storage) CompleteRefundJob(ctx context.Context, jobID int64) error {
+	const op = "storage.CompleteRefundJob"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var seatChangeID *int64
+	err = tx.QueryRow(
+		ctx,
+		"UPDATE booking.refund_jobs SET status = 'COMPLETED', last_error = NULL, completed_at = NOW() WHERE id = $1 RETURNING seat_change_id",
+		jobID,
+	).Scan(&seatChangeID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if seatChangeID != nil {
+		_, err = tx.Exec(
+			ctx,
+			"UPDATE booking.seat_changes SET refund_status = 'SENT', updated_at = NOW() WHERE id = $1 AND refund_status = 'PENDING'",
+			*seatChangeID,
+		)
+		if err != nil {
+			return fmt.Errorf("%s: failed to mark refund of seat change as sent: %w", op, err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// RetryRefundJob records a failed attempt to send a refund and puts the next
+// one off by a minute per attempt so far, up to an hour.
+func (s *Storage) RetryRefundJob(ctx context.Context, jobID int64, cause error) error {
+	const op = "storage.RetryRefundJob"
+
+	_, err := s.db.Exec(
+		ctx,
+		`UPDATE booking.refund_jobs SET last_error = $2, next_attempt_at = NOW() + LEAST(attempts, 60) * INTERVAL '1 minute'
+		WHERE id = $1 AND status = 'PENDING'`,
+		jobID,
+		cause.Error(),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
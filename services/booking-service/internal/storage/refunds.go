@@ -0,0 +1,218 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+)
+
+// RefundPolicy is the refund policy of an event; the zero value, used for
+// events without one, refunds in full until the event starts.
+type RefundPolicy struct {
+	NonRefundable        bool
+	FullRefundHours      int32
+	PartialRefundHours   int32
+	PartialRefundPercent int32
+}
+
+// CancellationTerms is what deciding on the cancellation of a booking needs.
+type CancellationTerms struct {
+	BookingID int64
+	UserID    int64
+	EventID   int64
+	Status    string
+	Total     Price
+	// nil when the event has no start date
+	EventStartsAt *time.Time
+	Policy        RefundPolicy
+}
+
+func (s *Storage) CancellationTerms(ctx context.Context, bookingID int64) (*CancellationTerms, error) {
+	const op = "storage.CancellationTerms"
+
+	var t CancellationTerms
+	err := s.db.QueryRow(
+		ctx,
+		`SELECT b.id, b.user_id, b.event_id, b.status::text, COALESCE(b.total_amount, 0), COALESCE(b.currency, ''),
+			e.starts_at,
+			COALESCE(p.non_refundable, FALSE), COALESCE(p.full_refund_hours, 0),
+			COALESCE(p.partial_refund_hours, 0), COALESCE(p.partial_refund_percent, 0)
+		FROM booking.bookings b
+		JOIN event.events e ON e.id = b.event_id
+		LEFT JOIN event.refund_policies p ON p.event_id = b.event_id
+		WHERE b.id = $1`,
+		bookingID,
+	).Scan(
+		&t.BookingID, &t.UserID, &t.EventID, &t.Status, &t.Total.Amount, &t.Total.Currency,
+		&t.EventStartsAt,
+		&t.Policy.NonRefundable, &t.Policy.FullRefundHours, &t.Policy.PartialRefundHours, &t.Policy.PartialRefundPercent,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrBookingNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &t, nil
+}
+
+// CancelUserBooking cancels a booking on behalf of its customer, provided it
+// is still in fromStatus, gives its seats and tickets back and returns what is
+// refunded. The refund is decided by refund on the locked total of confirmed
+// bookings only, recorded on top of what seats given back earlier were
+// refunded and queued to be sent once the cancellation has been committed;
+// unpaid ones have nothing to refund.
+func (s *Storage) CancelUserBooking(ctx context.Context, bookingID int64, fromStatus string, refund RefundFunc) (Price, error) {
+	const op = "storage.CancelUserBooking"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return Price{}, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	orderID, err := lockOrderOf(ctx, tx, bookingID)
+	if err != nil {
+		return Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var userID, eventID int64
+	total := Price{}
+	err = tx.QueryRow(
+		ctx,
+		"SELECT user_id, event_id, COALESCE(total_amount, 0), COALESCE(currency, '') FROM booking.bookings WHERE id = $1 FOR UPDATE",
+		bookingID,
+	).Scan(&userID, &eventID, &total.Amount, &total.Currency)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Price{}, fmt.Errorf("%s: %w", op, ErrBookingCannotBeChanged)
+		}
+		return Price{}, fmt.Errorf("%s: failed to lock booking: %w", op, err)
+	}
+
+	if _, err := transitionBooking(ctx, tx, bookingID, fromStatus, TriggerCancelledByCustomer, userActor(userID)); err != nil {
+		return Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	refunded := Price{Currency: total.Currency}
+	if fromStatus == "CONFIRMED" {
+		refunded = refund(total)
+		_, err = tx.Exec(ctx, "UPDATE booking.bookings SET refund_amount = COALESCE(refund_amount, 0) + $2 WHERE id = $1", bookingID, refunded.Amount)
+		if err != nil {
+			return Price{}, fmt.Errorf("%s: failed to record refund: %w", op, err)
+		}
+		if refunded.Amount > 0 {
+			// transferred seats are refunded out of the payment they were bought with
+			paymentBookingID, err := paymentBookingOf(ctx, tx, bookingID)
+			if err != nil {
+				return Price{}, fmt.Errorf("%s: %w", op, err)
+			}
+			if err := queueRefund(ctx, tx, fmt.Sprintf("booking-%d-cancelled", bookingID), bookingID, paymentBookingID, &refunded, nil); err != nil {
+				return Price{}, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE event.seats SET status = 'AVAILABLE' WHERE id IN (SELECT seat_id FROM booking.booking_seats WHERE booking_id = $1)",
+		bookingID,
+	)
+	if err != nil {
+		return Price{}, fmt.Errorf("%s: failed to release seats: %w", op, err)
+	}
+
+	if err := releasePoolTickets(ctx, tx, bookingID, fromStatus); err != nil {
+		return Price{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// a paid booking keeps its promo codes redeemed; an unpaid one of an
+	// order takes the rest of the order with it
+	if fromStatus != "CONFIRMED" {
+		if err := releasePromoCodes(ctx, tx, bookingID); err != nil {
+			return Price{}, fmt.Errorf("%s: %w", op, err)
+		}
+		if err := s.releaseOrder(ctx, tx, orderID, TriggerCancelledByCustomer, userActor(userID), "booking.cancelled"); err != nil {
+			return Price{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if _, err := s.offerTickets(ctx, tx, eventID); err != nil {
+		return Price{}, fmt.Errorf("%s: failed to offer released tickets: %w", op, err)
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"booking_id":    bookingID,
+		"event_id":      eventID,
+		"reason":        "CANCELLED_BY_USER",
+		"refund_amount": refunded.Amount,
+		"currency":      refunded.Currency,
+	})
+	if err != nil {
+		return Price{}, fmt.Errorf("%s: failed to marshal outbox payload: %w", op, err)
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"INSERT INTO booking.outbox_messages (exchange, routing_key, payload) VALUES ($1, $2, $3::jsonb)",
+		"bookings_exchange",
+		"booking.cancelled",
+		payload,
+	)
+	if err != nil {
+		return Price{}, fmt.Errorf("%s: failed to save outbox message: %w", op, err)
+	}
+
+	return refunded, tx.Commit(ctx)
+}
+
+// RefundCancelledBookingPayment claims a payment that arrived after the
+// customer had cancelled the unpaid booking or its hold had expired, and
+// queues all of it to go back. A payment reported twice is refunded once:
+// a booking that has a refund already is not claimed again. When the
+// booking is what its order was paid against, the other bookings of the
+// order are noted as refunded what they were worth.
+func (s *Storage) RefundCancelledBookingPayment(ctx context.Context, bookingID int64) error {
+	const op = "storage.RefundCancelledBookingPayment"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(
+		ctx,
+		"UPDATE booking.bookings SET refund_amount = COALESCE(total_amount, 0) WHERE id = $1 AND status IN ('CANCELLED', 'EXPIRED') AND refund_amount IS NULL",
+		bookingID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrBookingCannotBeChanged)
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`UPDATE booking.bookings SET refund_amount = COALESCE(total_amount, 0)
+		WHERE order_id = (SELECT id FROM booking.orders WHERE payment_booking_id = $1)
+			AND id <> $1 AND status IN ('CANCELLED', 'EXPIRED') AND refund_amount IS NULL`,
+		bookingID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: failed to record refund of order: %w", op, err)
+	}
+
+	if err := queueRefund(ctx, tx, fmt.Sprintf("booking-%d-late-payment", bookingID), bookingID, bookingID, nil, nil); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,736 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	authv1 "github.com/kay-kewl/ticket-booking-system/gen/go/auth"
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+)
+
+var ErrSeatChangeNotFound = errors.New("booking has no seat change waiting for payment")
+var ErrSeatChangePending = errors.New("booking already has a seat change waiting for payment")
+var ErrNoTicketsLeft = errors.New("booking cannot give back all of its tickets")
+
+const (
+	SeatChangeCancellation = "CANCELLATION"
+	SeatChangeExchange     = "EXCHANGE"
+)
+
+// SeatChange is a change of the seats of a paid booking: seats given back
+// for a refund, or exchanged for other seats of the event. An exchange that
+// costs more waits in PENDING, with its new seats reserved, until AmountDue
+// is paid.
+type SeatChange struct {
+	ID              int64
+	BookingID       int64
+	Type            string
+	Status          string
+	ReleasedSeatIDs []int64
+	// what the released seats were paid, after their share of the discount
+	ReleasedAmount   int64
+	releasedDiscount int64
+	AddedSeatIDs     []int64
+	AddedSeatPrices  []int64
+	AmountDue        int64
+	// nil while a payment of AmountDue may still arrive
+	RefundAmount *int64
+	// PENDING while the refund is being sent, SENT once it has been paid
+	// out, empty when the change refunds nothing
+	RefundStatus string
+	Currency     string
+	// set only while PENDING
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+}
+
+// RefundFunc decides what of value is paid back for the seats a booking
+// gives back. The refund is saved with the change and sent once the change
+// has been committed.
+type RefundFunc func(value Price) Price
+
+const seatChangeColumns = `id, booking_id, change_type, status, released_seat_ids, released_amount, released_discount,
+	added_seat_ids, added_seat_prices, amount_due, refund_amount, COALESCE(refund_status, ''), currency, expires_at, created_at`
+
+// ticketDetails is what reissuing the tickets of a booking needs from the
+// other services. It is fetched before the transaction that reissues them,
+// so that no lock is held across a remote call.
+type ticketDetails struct {
+	userID int64
+	email  string
+	event  *eventv1.Event
+}
+
+// CancelSeats gives seatIDs of a paid booking of the user back, refunds
+// what refund decides and reissues the tickets of the seats kept.
+func (s *Storage) CancelSeats(ctx context.Context, bookingID, userID int64, seatIDs []int64, refund RefundFunc) (*SeatChange, error) {
+	const op = "storage.CancelSeats"
+
+	details, err := s.fetchTicketDetails(ctx, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	booking, err := lockChangeableBooking(ctx, tx, bookingID, userID, seatIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if booking.userID != details.userID {
+		return nil, fmt.Errorf("%s: %w", op, ErrBookingCannotBeChanged)
+	}
+	// giving back everything is cancelling the booking
+	if len(seatIDs) >= len(booking.seatIDs) && booking.poolTickets == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrNoTicketsLeft)
+	}
+
+	value, discount, err := seatsValue(ctx, tx, bookingID, booking, seatIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	refunded := refund(Price{Amount: value, Currency: booking.total.Currency})
+
+	change := SeatChange{
+		BookingID:        bookingID,
+		Type:             SeatChangeCancellation,
+		Status:           "COMPLETED",
+		ReleasedSeatIDs:  seatIDs,
+		ReleasedAmount:   value,
+		releasedDiscount: discount,
+		RefundAmount:     &refunded.Amount,
+		Currency:         booking.total.Currency,
+	}
+	if err := applySeatChange(ctx, tx, &change); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := queueSeatChangeRefund(ctx, tx, &change); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.finishSeatChange(ctx, tx, booking, details, &change); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &change, tx.Commit(ctx)
+}
+
+// ExchangeSeats swaps releaseIDs of a paid booking of the user for the
+// available seatIDs of its event. When the new seats cost no more than what
+// was paid for the old ones, the swap happens at once and refund decides
+// what of the difference is paid back. Otherwise the new seats are reserved
+// for the hold time and the exchange waits for the payment of AmountDue.
+func (s *Storage) ExchangeSeats(ctx context.Context, bookingID, userID int64, releaseIDs, seatIDs []int64, refund RefundFunc) (*SeatChange, error) {
+	const op = "storage.ExchangeSeats"
+
+	details, err := s.fetchTicketDetails(ctx, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	booking, err := lockChangeableBooking(ctx, tx, bookingID, userID, releaseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if booking.userID != details.userID {
+		return nil, fmt.Errorf("%s: %w", op, ErrBookingCannotBeChanged)
+	}
+	// the seats given back may be sold out again, so a sold out event counts
+	if err := lockEventOnSale(ctx, tx, booking.eventID, true); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	addedIDs, prices, _, err := lockAvailableSeats(ctx, tx, booking.eventID, seatIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	added, err := sumPrices(append(prices, Price{Currency: booking.total.Currency}))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrTicketNotPriced, err)
+	}
+
+	value, discount, err := seatsValue(ctx, tx, bookingID, booking, releaseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	change := SeatChange{
+		BookingID:        bookingID,
+		Type:             SeatChangeExchange,
+		ReleasedSeatIDs:  releaseIDs,
+		ReleasedAmount:   value,
+		releasedDiscount: discount,
+		AddedSeatIDs:     addedIDs,
+		Currency:         booking.total.Currency,
+	}
+	for _, price := range prices {
+		change.AddedSeatPrices = append(change.AddedSeatPrices, price.Amount)
+	}
+
+	if due := added.Amount - value; due > 0 {
+		change.Status = "PENDING"
+		change.AmountDue = due
+		err = tx.QueryRow(
+			ctx,
+			`INSERT INTO booking.seat_changes (booking_id, change_type, status, released_seat_ids, released_amount, released_discount,
+				added_seat_ids, added_seat_prices, amount_due, currency, expires_at)
+			VALUES ($1, $2, 'PENDING', $3, $4, $5, $6, $7, $8, $9, NOW() + COALESCE(
+				(SELECT make_interval(mins => hold_minutes) FROM event.events WHERE id = $10),
+				make_interval(secs => $11)
+			))
+			RETURNING id, expires_at, created_at`,
+			bookingID,
+			change.Type,
+			change.ReleasedSeatIDs,
+			change.ReleasedAmount,
+			change.releasedDiscount,
+			change.AddedSeatIDs,
+			change.AddedSeatPrices,
+			change.AmountDue,
+			change.Currency,
+			booking.eventID,
+			s.defaultHold.Seconds(),
+		).Scan(&change.ID, &change.ExpiresAt, &change.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, seatChangeSaveError(err))
+		}
+
+		_, err = tx.Exec(ctx, "UPDATE event.seats SET status = 'RESERVED' WHERE id = ANY($1)", addedIDs)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to reserve seats: %w", op, err)
+		}
+
+		return &change, tx.Commit(ctx)
+	}
+
+	refunded := Price{Currency: booking.total.Currency}
+	if back := value - added.Amount; back > 0 {
+		refunded = refund(Price{Amount: back, Currency: booking.total.Currency})
+	}
+	change.Status = "COMPLETED"
+	change.RefundAmount = &refunded.Amount
+	if err := applySeatChange(ctx, tx, &change); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := queueSeatChangeRefund(ctx, tx, &change); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.finishSeatChange(ctx, tx, booking, details, &change); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &change, tx.Commit(ctx)
+}
+
+// CompleteSeatExchange carries out the exchange of the booking that waited
+// for its payment. When the booking changed in the meantime, so that the
+// exchange no longer fits it, the exchange is cancelled instead and the
+// payment queued to go back.
+func (s *Storage) CompleteSeatExchange(ctx context.Context, bookingID int64) (*SeatChange, error) {
+	const op = "storage.CompleteSeatExchange"
+
+	var pending bool
+	err := s.db.QueryRow(
+		ctx,
+		"SELECT EXISTS (SELECT 1 FROM booking.seat_changes WHERE booking_id = $1 AND status = 'PENDING')",
+		bookingID,
+	).Scan(&pending)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to check seat changes: %w", op, err)
+	}
+	if !pending {
+		return nil, fmt.Errorf("%s: %w", op, ErrSeatChangeNotFound)
+	}
+
+	details, err := s.fetchTicketDetails(ctx, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	change, err := lockPendingSeatChange(ctx, tx, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	booking, err := lockBooking(ctx, tx, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// cancelled, refunded, transferred or changed otherwise while it waited
+	if booking.status != "CONFIRMED" || booking.userID != details.userID || !booking.holdsSeats(change.ReleasedSeatIDs) {
+		if err := s.dropSeatChange(ctx, tx, change, booking.eventID, "CANCELLED", nil); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if err := queueSeatExchangePaymentRefund(ctx, tx, change); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		return change, tx.Commit(ctx)
+	}
+
+	// the payment settles the difference, nothing goes back
+	var none int64
+	change.Status = "COMPLETED"
+	change.RefundAmount = &none
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE booking.seat_changes SET status = 'COMPLETED', refund_amount = 0, expires_at = NULL, updated_at = NOW() WHERE id = $1",
+		change.ID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to complete seat change: %w", op, err)
+	}
+	change.ExpiresAt = nil
+
+	if err := applySeatChange(ctx, tx, change); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.finishSeatChange(ctx, tx, booking, details, change); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return change, tx.Commit(ctx)
+}
+
+// CancelSeatExchange drops the exchange of the booking that waited for a
+// payment which failed, and gives its reserved seats back.
+func (s *Storage) CancelSeatExchange(ctx context.Context, bookingID int64) error {
+	const op = "storage.CancelSeatExchange"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	change, err := lockPendingSeatChange(ctx, tx, bookingID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var eventID int64
+	err = tx.QueryRow(ctx, "SELECT event_id FROM booking.bookings WHERE id = $1", bookingID).Scan(&eventID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to get booking details: %w", op, err)
+	}
+
+	// nothing was paid, so nothing is owed back
+	var none int64
+	if err := s.dropSeatChange(ctx, tx, change, eventID, "CANCELLED", &none); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ExpireSeatChanges drops up to limit exchanges whose payment did not come
+// in within the hold time and returns how many.
+func (s *Storage) ExpireSeatChanges(ctx context.Context, limit int) (int, error) {
+	const op = "storage.ExpireSeatChanges"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(
+		ctx,
+		`SELECT c.`+seatChangeColumns+`, b.event_id
+		FROM booking.seat_changes c JOIN booking.bookings b ON b.id = c.booking_id
+		WHERE c.status = 'PENDING' AND c.expires_at <= NOW()
+		ORDER BY c.expires_at LIMIT $1
+		FOR UPDATE OF c SKIP LOCKED`,
+		limit,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	type dueChange struct {
+		SeatChange
+		eventID int64
+	}
+	due, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (dueChange, error) {
+		var d dueChange
+		c := &d.SeatChange
+		err := row.Scan(
+			&c.ID, &c.BookingID, &c.Type, &c.Status, &c.ReleasedSeatIDs, &c.ReleasedAmount, &c.releasedDiscount,
+			&c.AddedSeatIDs, &c.AddedSeatPrices, &c.AmountDue, &c.RefundAmount, &c.RefundStatus, &c.Currency, &c.ExpiresAt, &c.CreatedAt,
+			&d.eventID,
+		)
+		return d, err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, d := range due {
+		// a payment may still arrive, refund_amount stays open for it
+		if err := s.dropSeatChange(ctx, tx, &d.SeatChange, d.eventID, "EXPIRED", nil); err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return len(due), tx.Commit(ctx)
+}
+
+// RefundDroppedSeatExchange claims the payment of the latest exchange of the
+// booking that was dropped while its payment could still arrive, and queues
+// it to go back. A payment reported twice is refunded once: the second call
+// finds nothing left to claim.
+func (s *Storage) RefundDroppedSeatExchange(ctx context.Context, bookingID int64) (*SeatChange, error) {
+	const op = "storage.RefundDroppedSeatExchange"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(
+		ctx,
+		`SELECT `+seatChangeColumns+` FROM booking.seat_changes
+		WHERE booking_id = $1 AND status IN ('CANCELLED', 'EXPIRED') AND amount_due > 0 AND refund_amount IS NULL
+		ORDER BY id DESC LIMIT 1
+		FOR UPDATE`,
+		bookingID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	change, err := pgx.CollectExactlyOneRow(rows, scanSeatChange)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrSeatChangeNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := queueSeatExchangePaymentRefund(ctx, tx, &change); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &change, tx.Commit(ctx)
+}
+
+// SeatChanges lists the seat changes of the booking, oldest first.
+func (s *Storage) SeatChanges(ctx context.Context, bookingID int64) ([]SeatChange, error) {
+	const op = "storage.SeatChanges"
+
+	rows, err := s.db.Query(ctx, "SELECT "+seatChangeColumns+" FROM booking.seat_changes WHERE booking_id = $1 ORDER BY id", bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	changes, err := pgx.CollectRows(rows, scanSeatChange)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return changes, nil
+}
+
+// lockChangeableBooking locks a paid booking of the user that holds seatIDs
+// and has no exchange waiting for payment.
+func lockChangeableBooking(ctx context.Context, tx pgx.Tx, bookingID, userID int64, seatIDs []int64) (*lockedBooking, error) {
+	booking, err := lockBooking(ctx, tx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.userID != userID {
+		return nil, ErrBookingNotFound
+	}
+	if booking.status != "CONFIRMED" {
+		return nil, ErrBookingCannotBeChanged
+	}
+	if !booking.holdsSeats(seatIDs) {
+		return nil, ErrSeatNotInBooking
+	}
+
+	var pending bool
+	err = tx.QueryRow(
+		ctx,
+		"SELECT EXISTS (SELECT 1 FROM booking.seat_changes WHERE booking_id = $1 AND status = 'PENDING')",
+		bookingID,
+	).Scan(&pending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check seat changes: %w", err)
+	}
+	if pending {
+		return nil, ErrSeatChangePending
+	}
+
+	return booking, nil
+}
+
+// applySeatChange moves the seats of a completed change in and out of its
+// booking and reprices the booking, saving the change when it is new.
+func applySeatChange(ctx context.Context, tx pgx.Tx, change *SeatChange) error {
+	if change.ID == 0 {
+		if *change.RefundAmount > 0 {
+			change.RefundStatus = "PENDING"
+		}
+		err := tx.QueryRow(
+			ctx,
+			`INSERT INTO booking.seat_changes (booking_id, change_type, status, released_seat_ids, released_amount, released_discount,
+				added_seat_ids, added_seat_prices, refund_amount, refund_status, currency)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11)
+			RETURNING id, created_at`,
+			change.BookingID,
+			change.Type,
+			change.Status,
+			change.ReleasedSeatIDs,
+			change.ReleasedAmount,
+			change.releasedDiscount,
+			nonNil(change.AddedSeatIDs),
+			nonNil(change.AddedSeatPrices),
+			change.RefundAmount,
+			change.RefundStatus,
+			change.Currency,
+		).Scan(&change.ID, &change.CreatedAt)
+		if err != nil {
+			return seatChangeSaveError(err)
+		}
+	}
+
+	_, err := tx.Exec(
+		ctx,
+		"DELETE FROM booking.booking_seats WHERE booking_id = $1 AND seat_id = ANY($2)",
+		change.BookingID,
+		change.ReleasedSeatIDs,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove seats from booking: %w", err)
+	}
+	_, err = tx.Exec(ctx, "UPDATE event.seats SET status = 'AVAILABLE' WHERE id = ANY($1)", change.ReleasedSeatIDs)
+	if err != nil {
+		return fmt.Errorf("failed to release seats: %w", err)
+	}
+
+	var added int64
+	for i, seatID := range change.AddedSeatIDs {
+		_, err = tx.Exec(
+			ctx,
+			"INSERT INTO booking.booking_seats(booking_id, seat_id, price_amount, price_currency) VALUES($1, $2, $3, $4)",
+			change.BookingID,
+			seatID,
+			change.AddedSeatPrices[i],
+			change.Currency,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to link seat to booking: %w", err)
+		}
+		added += change.AddedSeatPrices[i]
+	}
+	if len(change.AddedSeatIDs) > 0 {
+		_, err = tx.Exec(ctx, "UPDATE event.seats SET status = 'BOOKED' WHERE id = ANY($1)", change.AddedSeatIDs)
+		if err != nil {
+			return fmt.Errorf("failed to book seats: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`UPDATE booking.bookings SET total_amount = total_amount - $2 + $3, discount_amount = discount_amount - $4,
+			refund_amount = CASE WHEN $5::bigint > 0 THEN COALESCE(refund_amount, 0) + $5 ELSE refund_amount END,
+			ticket_version = ticket_version + 1
+		WHERE id = $1`,
+		change.BookingID,
+		change.ReleasedAmount,
+		added,
+		change.releasedDiscount,
+		*change.RefundAmount,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to reprice booking: %w", err)
+	}
+
+	return nil
+}
+
+// queueSeatChangeRefund saves the refund a completed change owes, out of
+// the payment the booking was bought with.
+func queueSeatChangeRefund(ctx context.Context, tx pgx.Tx, change *SeatChange) error {
+	if *change.RefundAmount == 0 {
+		return nil
+	}
+
+	paymentBookingID, err := paymentBookingOf(ctx, tx, change.BookingID)
+	if err != nil {
+		return err
+	}
+
+	refund := Price{Amount: *change.RefundAmount, Currency: change.Currency}
+	return queueRefund(ctx, tx, fmt.Sprintf("seat-change-%d", change.ID), change.BookingID, paymentBookingID, &refund, &change.ID)
+}
+
+// queueSeatExchangePaymentRefund claims the payment of a dropped exchange
+// for a refund and queues it. The difference was paid against the booking
+// itself, not the one it was bought with, and goes back there.
+func queueSeatExchangePaymentRefund(ctx context.Context, tx pgx.Tx, change *SeatChange) error {
+	tag, err := tx.Exec(
+		ctx,
+		"UPDATE booking.seat_changes SET refund_amount = amount_due, refund_status = 'PENDING', updated_at = NOW() WHERE id = $1 AND refund_amount IS NULL",
+		change.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to claim seat change payment: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSeatChangeNotFound
+	}
+	change.RefundAmount = &change.AmountDue
+	change.RefundStatus = "PENDING"
+
+	refund := Price{Amount: change.AmountDue, Currency: change.Currency}
+	return queueRefund(ctx, tx, fmt.Sprintf("seat-change-%d-payment", change.ID), change.BookingID, change.BookingID, &refund, &change.ID)
+}
+
+// finishSeatChange offers the released seats to the waitlist, reissues the
+// tickets of the booking, whose version applySeatChange moved on, and tells
+// its owner about the change.
+func (s *Storage) finishSeatChange(ctx context.Context, tx pgx.Tx, booking *lockedBooking, details *ticketDetails, change *SeatChange) error {
+	if _, err := s.offerTickets(ctx, tx, booking.eventID); err != nil {
+		return fmt.Errorf("failed to offer released tickets: %w", err)
+	}
+
+	if err := saveTicketReissue(ctx, tx, change.BookingID, details.email, details.event, booking.ticketVersion+1, booking.ticketVersion); err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"booking_id":    change.BookingID,
+		"user_id":       booking.userID,
+		"event_id":      booking.eventID,
+		"event_title":   details.event.GetTitle(),
+		"change_type":   change.Type,
+		"refund_amount": *change.RefundAmount,
+		"currency":      change.Currency,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox payload: %w", err)
+	}
+	return saveOutboxMessage(ctx, tx, "booking.seats_changed", payload)
+}
+
+// fetchTicketDetails looks up the owner's email and the event of a booking.
+func (s *Storage) fetchTicketDetails(ctx context.Context, bookingID int64) (*ticketDetails, error) {
+	var eventID int64
+	details := &ticketDetails{}
+	err := s.db.QueryRow(ctx, "SELECT user_id, event_id FROM booking.bookings WHERE id = $1", bookingID).Scan(&details.userID, &eventID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBookingNotFound
+		}
+		return nil, fmt.Errorf("failed to get booking details: %w", err)
+	}
+
+	enrichCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	user, err := s.authClient.GetUserDetails(enrichCtx, &authv1.GetUserDetailsRequest{UserId: details.userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user details: %w", err)
+	}
+	details.email = user.GetEmail()
+
+	details.event, err = s.eventClient.GetEvent(enrichCtx, &eventv1.GetEventRequest{EventId: eventID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event details: %w", err)
+	}
+
+	return details, nil
+}
+
+// dropSeatChange ends a pending exchange without carrying it out and gives
+// its reserved seats back.
+func (s *Storage) dropSeatChange(ctx context.Context, tx pgx.Tx, change *SeatChange, eventID int64, status string, refund *int64) error {
+	_, err := tx.Exec(
+		ctx,
+		"UPDATE booking.seat_changes SET status = $2, refund_amount = $3, updated_at = NOW() WHERE id = $1",
+		change.ID,
+		status,
+		refund,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to drop seat change: %w", err)
+	}
+	change.Status = status
+	change.RefundAmount = refund
+
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE event.seats SET status = 'AVAILABLE' WHERE id = ANY($1) AND status = 'RESERVED'",
+		change.AddedSeatIDs,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to release reserved seats: %w", err)
+	}
+
+	if _, err := s.offerTickets(ctx, tx, eventID); err != nil {
+		return fmt.Errorf("failed to offer released tickets: %w", err)
+	}
+	return nil
+}
+
+func lockPendingSeatChange(ctx context.Context, tx pgx.Tx, bookingID int64) (*SeatChange, error) {
+	rows, err := tx.Query(
+		ctx,
+		"SELECT "+seatChangeColumns+" FROM booking.seat_changes WHERE booking_id = $1 AND status = 'PENDING' FOR UPDATE",
+		bookingID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock seat change: %w", err)
+	}
+	change, err := pgx.CollectExactlyOneRow(rows, scanSeatChange)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSeatChangeNotFound
+		}
+		return nil, fmt.Errorf("failed to lock seat change: %w", err)
+	}
+	return &change, nil
+}
+
+func scanSeatChange(row pgx.CollectableRow) (SeatChange, error) {
+	var c SeatChange
+	err := row.Scan(
+		&c.ID, &c.BookingID, &c.Type, &c.Status, &c.ReleasedSeatIDs, &c.ReleasedAmount, &c.releasedDiscount,
+		&c.AddedSeatIDs, &c.AddedSeatPrices, &c.AmountDue, &c.RefundAmount, &c.RefundStatus, &c.Currency, &c.ExpiresAt, &c.CreatedAt,
+	)
+	return c, err
+}
+
+func seatChangeSaveError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrSeatChangePending
+	}
+	return fmt.Errorf("failed to save seat change: %w", err)
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CandidateSeat is an available, priced seat that best-available selection
+// may pick, together with what it needs to know about the seat's row.
+type CandidateSeat struct {
+	ID       int64
+	Label    string
+	Sector   string
+	Row      int32
+	Position int32
+	// quality of the sector, 0 to 100
+	SectorScore int32
+	// positions of the first and last seat of the row, booked ones included
+	RowFirst int32
+	RowLast  int32
+	Price    Price
+}
+
+// ListCandidateSeats returns the available seats of the event that have a
+// place in a row, optionally limited to a sector and to seats costing at
+// most maxPrice, ordered by sector, row and position.
+func (s *Storage) ListCandidateSeats(ctx context.Context, eventID int64, sector string, maxPrice int64) ([]CandidateSeat, error) {
+	const op = "storage.ListCandidateSeats"
+
+	rows, err := s.db.Query(
+		ctx,
+		`SELECT id, seat_number, sector, row_number, position, score, row_first, row_last, amount, currency
+		FROM (
+			SELECT s.id, s.seat_number, s.sector, s.row_number, s.position, s.status,
+				COALESCE(sc.score, 50) AS score,
+				MIN(s.position) OVER w AS row_first,
+				MAX(s.position) OVER w AS row_last,
+				t.amount, t.currency
+			FROM event.seats s
+			LEFT JOIN event.price_tiers t ON t.id = s.price_tier_id
+			LEFT JOIN event.sector_scores sc ON sc.event_id = s.event_id AND sc.sector = s.sector
+			WHERE s.event_id = $1 AND s.sector IS NOT NULL AND s.row_number IS NOT NULL AND s.position IS NOT NULL
+				AND ($2 = '' OR s.sector = $2)
+			WINDOW w AS (PARTITION BY s.sector, s.row_number)
+		) seat
+		WHERE status = 'AVAILABLE' AND amount IS NOT NULL AND ($3 = 0 OR amount <= $3)
+		ORDER BY sector, row_number, position`,
+		eventID,
+		sector,
+		maxPrice,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	seats, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (CandidateSeat, error) {
+		var c CandidateSeat
+		err := row.Scan(&c.ID, &c.Label, &c.Sector, &c.Row, &c.Position, &c.SectorScore, &c.RowFirst, &c.RowLast, &c.Price.Amount, &c.Price.Currency)
+		return c, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return seats, nil
+}
@@ -0,0 +1,185 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/kay-kewl/ticket-booking-system/internal/metrics"
+	"github.com/kay-kewl/ticket-booking-system/internal/requestid"
+)
+
+// BookingTrigger is what moves a booking from one status to the next. It is
+// recorded as the reason of the change.
+type BookingTrigger string
+
+const (
+	TriggerBooked              BookingTrigger = "BOOKED"
+	TriggerTransferAccepted    BookingTrigger = "TRANSFER_ACCEPTED"
+	TriggerPaymentInitiated    BookingTrigger = "PAYMENT_INITIATED"
+	TriggerPaymentConfirmed    BookingTrigger = "PAYMENT_CONFIRMED"
+	TriggerPaymentFailed       BookingTrigger = "PAYMENT_FAILED"
+	TriggerHoldExpired         BookingTrigger = "HOLD_EXPIRED"
+	TriggerCancelledByCustomer BookingTrigger = "CANCELLED_BY_CUSTOMER"
+	TriggerEventCancelled      BookingTrigger = "EVENT_CANCELLED"
+	// another booking of the order stopped being held
+	TriggerOrderReleased       BookingTrigger = "ORDER_RELEASED"
+	TriggerLatePaymentRefunded BookingTrigger = "LATE_PAYMENT_REFUNDED"
+	TriggerCheckedIn           BookingTrigger = "CHECKED_IN"
+)
+
+// bookingTransitions is the booking state machine: the status each trigger
+// moves a booking in a status to. "" is where new bookings come from;
+// CANCELLED, EXPIRED, REFUNDED and CHECKED_IN are final.
+var bookingTransitions = map[string]map[BookingTrigger]string{
+	"": {
+		TriggerBooked: "PENDING",
+		// seats split off a paid booking are paid already
+		TriggerTransferAccepted: "CONFIRMED",
+	},
+	"PENDING": {
+		TriggerPaymentInitiated:    "AWAITING_PAYMENT",
+		TriggerPaymentConfirmed:    "CONFIRMED",
+		TriggerPaymentFailed:       "CANCELLED",
+		TriggerHoldExpired:         "EXPIRED",
+		TriggerCancelledByCustomer: "CANCELLED",
+		TriggerEventCancelled:      "EVENT_CANCELLED",
+		TriggerOrderReleased:       "CANCELLED",
+	},
+	"AWAITING_PAYMENT": {
+		TriggerPaymentConfirmed:    "CONFIRMED",
+		TriggerPaymentFailed:       "CANCELLED",
+		TriggerHoldExpired:         "EXPIRED",
+		TriggerCancelledByCustomer: "CANCELLED",
+		TriggerEventCancelled:      "EVENT_CANCELLED",
+		TriggerOrderReleased:       "CANCELLED",
+	},
+	"CONFIRMED": {
+		TriggerCancelledByCustomer: "CANCELLED",
+		TriggerEventCancelled:      "REFUNDED",
+		TriggerCheckedIn:           "CHECKED_IN",
+	},
+	// a payment that arrives after the event was cancelled is sent back
+	"EVENT_CANCELLED": {
+		TriggerLatePaymentRefunded: "REFUNDED",
+	},
+}
+
+// who changed the status of a booking, besides its customer
+const (
+	actorSystem  = "system"
+	actorPayment = "payment"
+)
+
+func userActor(userID int64) string {
+	return "user:" + strconv.FormatInt(userID, 10)
+}
+
+// StatusChange is an entry of the status history of a booking.
+type StatusChange struct {
+	// nil for the status the booking was created in
+	FromStatus    *string
+	ToStatus      string
+	Actor         string
+	Reason        string
+	CorrelationID *string
+	ChangedAt     time.Time
+}
+
+// BookingStatusHistory lists the statuses the booking went through, oldest
+// first.
+func (s *Storage) BookingStatusHistory(ctx context.Context, bookingID int64) ([]StatusChange, error) {
+	const op = "storage.BookingStatusHistory"
+
+	rows, err := s.db.Query(
+		ctx,
+		`SELECT from_status::text, to_status::text, actor, reason, correlation_id, created_at
+		FROM booking.booking_status_history WHERE booking_id = $1 ORDER BY id`,
+		bookingID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	changes, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (StatusChange, error) {
+		var c StatusChange
+		err := row.Scan(&c.FromStatus, &c.ToStatus, &c.Actor, &c.Reason, &c.CorrelationID, &c.ChangedAt)
+		return c, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return changes, nil
+}
+
+// transitionBooking moves the booking from the status the caller has acted
+// upon on by trigger, as the state machine allows, and records the change.
+// It fails with ErrBookingCannotBeChanged when the machine does not allow
+// the change or the booking is no longer in from.
+func transitionBooking(ctx context.Context, tx pgx.Tx, bookingID int64, from string, trigger BookingTrigger, actor string) (string, error) {
+	to, ok := bookingTransitions[from][trigger]
+	if !ok || from == "" {
+		return "", ErrBookingCannotBeChanged
+	}
+
+	tag, err := tx.Exec(
+		ctx,
+		"UPDATE booking.bookings SET status = $2 WHERE id = $1 AND status::text = $3",
+		bookingID,
+		to,
+		from,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to update booking status: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return "", ErrBookingCannotBeChanged
+	}
+
+	metrics.BookingsTotal.WithLabelValues(to).Inc()
+
+	if err := recordBookingStatus(ctx, tx, bookingID, from, to, trigger, actor); err != nil {
+		return "", err
+	}
+	return to, nil
+}
+
+// startBookingHistory records the status a booking created by trigger
+// starts in and returns it.
+func startBookingHistory(ctx context.Context, tx pgx.Tx, bookingID int64, trigger BookingTrigger, actor string) (string, error) {
+	to, ok := bookingTransitions[""][trigger]
+	if !ok {
+		return "", fmt.Errorf("booking cannot be created by %s", trigger)
+	}
+	return to, recordBookingStatus(ctx, tx, bookingID, "", to, trigger, actor)
+}
+
+func recordBookingStatus(ctx context.Context, tx pgx.Tx, bookingID int64, from, to string, trigger BookingTrigger, actor string) error {
+	var fromStatus, correlationID *string
+	if from != "" {
+		fromStatus = &from
+	}
+	if id, ok := requestid.Get(ctx); ok && id != "" {
+		correlationID = &id
+	}
+
+	_, err := tx.Exec(
+		ctx,
+		`INSERT INTO booking.booking_status_history (booking_id, from_status, to_status, actor, reason, correlation_id)
+		VALUES ($1, $2::booking_status, $3::booking_status, $4, $5, $6)`,
+		bookingID,
+		fromStatus,
+		to,
+		actor,
+		string(trigger),
+		correlationID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record booking status: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookingTransitions(t *testing.T) {
+	for from, triggers := range bookingTransitions {
+		for trigger, to := range triggers {
+			require.NotEmpty(t, to, "%s by %s", from, trigger)
+			require.NotEqual(t, from, to, "%s by %s should change the status", from, trigger)
+		}
+	}
+
+	for _, final := range []string{"CANCELLED", "EXPIRED", "REFUNDED", "CHECKED_IN"} {
+		require.Empty(t, bookingTransitions[final], "%s is final", final)
+	}
+
+	require.Equal(t, "CHECKED_IN", bookingTransitions["CONFIRMED"][TriggerCheckedIn])
+	_, ok := bookingTransitions["AWAITING_PAYMENT"][TriggerCheckedIn]
+	require.False(t, ok, "only paid bookings can be checked in")
+	_, ok = bookingTransitions["CONFIRMED"][TriggerHoldExpired]
+	require.False(t, ok, "a paid booking is not held")
+}
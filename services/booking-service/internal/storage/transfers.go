@@ -0,0 +1,560 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	authv1 "github.com/kay-kewl/ticket-booking-system/gen/go/auth"
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+)
+
+var ErrTransferNotFound = errors.New("transfer not found")
+var ErrTransferClosed = errors.New("transfer is no longer pending")
+var ErrTransferExpired = errors.New("transfer has expired")
+var ErrTransferPending = errors.New("booking already has a pending transfer")
+var ErrTransferNotAllowed = errors.New("tickets of the event cannot be transferred")
+var ErrTransferLimitReached = errors.New("tickets have been transferred too often")
+var ErrTransferToSelf = errors.New("tickets cannot be transferred to their owner")
+var ErrSeatNotInBooking = errors.New("seat is not part of the booking")
+
+// how long the recipient has to accept a transfer
+const transferAcceptTime = 72 * time.Hour
+
+// Transfer hands the tickets of a paid booking, or some of its seats, over
+// to whoever signs in with RecipientEmail.
+type Transfer struct {
+	ID             int64
+	BookingID      int64
+	SenderID       int64
+	RecipientEmail string
+	// empty for all tickets of the booking
+	SeatIDs   []int64
+	Status    string
+	ExpiresAt time.Time
+	// the booking that holds the tickets once the transfer is accepted
+	RecipientBookingID *int64
+	CreatedAt          time.Time
+}
+
+// OwnershipChange is a change of hands of the tickets of a booking. The
+// purchase has no FromUserID.
+type OwnershipChange struct {
+	ID        int64
+	BookingID int64
+	// set when SeatIDs were split off another booking
+	FromBookingID *int64
+	SeatIDs       []int64
+	FromUserID    *int64
+	ToUserID      int64
+	TransferID    *int64
+	ChangedAt     time.Time
+}
+
+// lockedBooking is a paid booking locked for a change of its tickets.
+type lockedBooking struct {
+	userID        int64
+	eventID       int64
+	status        string
+	transferCount int32
+	ticketVersion int32
+	total         Price
+	discount      int64
+	seatIDs       []int64
+	poolTickets   int32
+}
+
+const transferColumns = `id, booking_id, sender_id, recipient_email, seat_ids, status, expires_at, recipient_booking_id, created_at`
+
+// StartTransfer offers the tickets of a paid booking of the sender, or just
+// seatIDs of it, to the owner of the email. It returns the token the
+// recipient accepts the transfer with.
+func (s *Storage) StartTransfer(ctx context.Context, bookingID, senderID int64, email string, seatIDs []int64) (*Transfer, string, error) {
+	const op = "storage.StartTransfer"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	booking, err := lockBooking(ctx, tx, bookingID)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+	if booking.userID != senderID {
+		return nil, "", fmt.Errorf("%s: %w", op, ErrBookingNotFound)
+	}
+	if booking.status != "CONFIRMED" {
+		return nil, "", fmt.Errorf("%s: %w", op, ErrBookingCannotBeChanged)
+	}
+	if err := checkTransferPolicy(ctx, tx, booking.eventID, booking.transferCount); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !booking.holdsSeats(seatIDs) {
+		return nil, "", fmt.Errorf("%s: %w", op, ErrSeatNotInBooking)
+	}
+	// naming every ticket of the booking is the same as naming none
+	if len(seatIDs) == len(booking.seatIDs) && booking.poolTickets == 0 {
+		seatIDs = nil
+	}
+
+	// a transfer nobody accepted in time makes way for a new one
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE booking.ticket_transfers SET status = 'EXPIRED', updated_at = NOW() WHERE booking_id = $1 AND status = 'PENDING' AND expires_at <= NOW()",
+		bookingID,
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: failed to expire earlier transfer: %w", op, err)
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := tx.Query(
+		ctx,
+		`INSERT INTO booking.ticket_transfers (booking_id, sender_id, recipient_email, seat_ids, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5, NOW() + make_interval(secs => $6))
+		RETURNING `+transferColumns,
+		bookingID,
+		senderID,
+		email,
+		nonNil(seatIDs),
+		tokenHash(token),
+		transferAcceptTime.Seconds(),
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: failed to save transfer: %w", op, err)
+	}
+	transfer, err := pgx.CollectExactlyOneRow(rows, scanTransfer)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, "", fmt.Errorf("%s: %w", op, ErrTransferPending)
+		}
+		return nil, "", fmt.Errorf("%s: failed to save transfer: %w", op, err)
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"transfer_id":     transfer.ID,
+		"booking_id":      bookingID,
+		"event_id":        booking.eventID,
+		"user_id":         senderID,
+		"recipient_email": email,
+		"seat_ids":        transfer.SeatIDs,
+		"expires_at":      transfer.ExpiresAt,
+		"accept_token":    token,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: failed to marshal outbox payload: %w", op, err)
+	}
+	// the accept token is not kept once the notification has been published
+	if err := saveOutboxMessage(ctx, tx, "transfer.started", payload, "accept_token"); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &transfer, token, tx.Commit(ctx)
+}
+
+// CancelTransfer takes back a transfer of the sender that was not accepted yet.
+func (s *Storage) CancelTransfer(ctx context.Context, transferID, senderID int64) (*Transfer, error) {
+	const op = "storage.CancelTransfer"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	transfer, err := lockTransfer(ctx, tx, "id = $1 AND sender_id = $2", transferID, senderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if transfer.Status != "PENDING" {
+		return nil, fmt.Errorf("%s: %w", op, ErrTransferClosed)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE booking.ticket_transfers SET status = 'CANCELLED', updated_at = NOW() WHERE id = $1", transferID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to cancel transfer: %w", op, err)
+	}
+	transfer.Status = "CANCELLED"
+
+	return transfer, tx.Commit(ctx)
+}
+
+// AcceptTransfer moves the tickets of the transfer with the token to the
+// recipient, who must be signed in with the email it was sent to. A whole
+// booking changes owner; seats are split off into a new booking of the
+// recipient, with their share of the price. Both owners get their tickets
+// reissued, which voids the ones issued before.
+func (s *Storage) AcceptTransfer(ctx context.Context, token string, recipientID int64) (*Transfer, error) {
+	const op = "storage.AcceptTransfer"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	transfer, err := lockTransfer(ctx, tx, "token_hash = $1", tokenHash(token))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if transfer.Status != "PENDING" {
+		return nil, fmt.Errorf("%s: %w", op, ErrTransferClosed)
+	}
+	if !transfer.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("%s: %w", op, ErrTransferExpired)
+	}
+	if transfer.SenderID == recipientID {
+		return nil, fmt.Errorf("%s: %w", op, ErrTransferToSelf)
+	}
+
+	enrichCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	recipient, err := s.authClient.GetUserDetails(enrichCtx, &authv1.GetUserDetailsRequest{UserId: recipientID})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get recipient details: %w", op, err)
+	}
+	// a token that leaked to someone else is no use to them
+	if !strings.EqualFold(recipient.GetEmail(), transfer.RecipientEmail) {
+		return nil, fmt.Errorf("%s: %w", op, ErrTransferNotFound)
+	}
+
+	booking, err := lockBooking(ctx, tx, transfer.BookingID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	// cancelled, refunded or handed over some other way in the meantime
+	if booking.status != "CONFIRMED" || booking.userID != transfer.SenderID || !booking.holdsSeats(transfer.SeatIDs) {
+		return nil, fmt.Errorf("%s: %w", op, ErrBookingCannotBeChanged)
+	}
+	if err := checkTransferPolicy(ctx, tx, booking.eventID, booking.transferCount); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	recipientBookingID := transfer.BookingID
+	var fromBookingID *int64
+	if len(transfer.SeatIDs) == 0 {
+		_, err = tx.Exec(
+			ctx,
+			"UPDATE booking.bookings SET user_id = $2, transfer_count = transfer_count + 1, ticket_version = ticket_version + 1 WHERE id = $1",
+			transfer.BookingID,
+			recipientID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to change owner of booking: %w", op, err)
+		}
+	} else {
+		recipientBookingID, err = splitBooking(ctx, tx, transfer.BookingID, booking, transfer.SeatIDs, recipientID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		fromBookingID = &transfer.BookingID
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO booking.ownership_changes (booking_id, from_booking_id, seat_ids, from_user_id, to_user_id, transfer_id)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		recipientBookingID,
+		fromBookingID,
+		nonNil(transfer.SeatIDs),
+		transfer.SenderID,
+		recipientID,
+		transfer.ID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to record change of owner: %w", op, err)
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE booking.ticket_transfers SET status = 'ACCEPTED', recipient_id = $2, recipient_booking_id = $3, updated_at = NOW() WHERE id = $1",
+		transfer.ID,
+		recipientID,
+		recipientBookingID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to accept transfer: %w", op, err)
+	}
+	transfer.Status = "ACCEPTED"
+	transfer.RecipientBookingID = &recipientBookingID
+
+	event, err := s.eventClient.GetEvent(enrichCtx, &eventv1.GetEventRequest{EventId: booking.eventID})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get event details: %w", op, err)
+	}
+
+	if recipientBookingID == transfer.BookingID {
+		err = saveTicketReissue(ctx, tx, recipientBookingID, recipient.GetEmail(), event, booking.ticketVersion+1, booking.ticketVersion)
+	} else {
+		err = saveTicketReissue(ctx, tx, recipientBookingID, recipient.GetEmail(), event, 1, 0)
+		if err == nil {
+			var sender *authv1.GetUserDetailsResponse
+			sender, err = s.authClient.GetUserDetails(enrichCtx, &authv1.GetUserDetailsRequest{UserId: transfer.SenderID})
+			if err != nil {
+				return nil, fmt.Errorf("%s: failed to get sender details: %w", op, err)
+			}
+			// what the sender kept needs a ticket without the seats they gave away
+			err = saveTicketReissue(ctx, tx, transfer.BookingID, sender.GetEmail(), event, booking.ticketVersion+1, booking.ticketVersion)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"transfer_id": transfer.ID,
+		"booking_id":  transfer.BookingID,
+		"event_id":    booking.eventID,
+		"event_title": event.GetTitle(),
+		"user_id":     transfer.SenderID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to marshal outbox payload: %w", op, err)
+	}
+	if err := saveOutboxMessage(ctx, tx, "transfer.accepted", payload); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return transfer, tx.Commit(ctx)
+}
+
+// splitBooking moves seatIDs of the booking into a new confirmed booking of
+// the recipient and returns its id. The seats take their price along, less
+// their share of the discount, so that both bookings together still add up
+// to what was paid.
+func splitBooking(ctx context.Context, tx pgx.Tx, bookingID int64, booking *lockedBooking, seatIDs []int64, recipientID int64) (int64, error) {
+	moved, movedDiscount, err := seatsValue(ctx, tx, bookingID, booking, seatIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	var newBookingID int64
+	err = tx.QueryRow(
+		ctx,
+		`INSERT INTO booking.bookings (user_id, event_id, status, total_amount, discount_amount, currency, transferred_from_id, transfer_count)
+		VALUES ($1, $2, 'CONFIRMED', $3, $4, $5, $6, $7)
+		RETURNING id`,
+		recipientID,
+		booking.eventID,
+		moved,
+		movedDiscount,
+		booking.total.Currency,
+		bookingID,
+		booking.transferCount+1,
+	).Scan(&newBookingID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create booking of recipient: %w", err)
+	}
+	if _, err := startBookingHistory(ctx, tx, newBookingID, TriggerTransferAccepted, userActor(recipientID)); err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE booking.booking_seats SET booking_id = $3 WHERE booking_id = $1 AND seat_id = ANY($2)",
+		bookingID,
+		seatIDs,
+		newBookingID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to move seats: %w", err)
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`UPDATE booking.bookings SET total_amount = total_amount - $2, discount_amount = discount_amount - $3,
+			ticket_version = ticket_version + 1
+		WHERE id = $1`,
+		bookingID,
+		moved,
+		movedDiscount,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reprice booking: %w", err)
+	}
+
+	return newBookingID, nil
+}
+
+// OwnershipHistory lists every change of hands of the tickets of the
+// booking, oldest first. The tickets of a booking split off another one
+// share its history up to the split.
+func (s *Storage) OwnershipHistory(ctx context.Context, bookingID int64) ([]OwnershipChange, error) {
+	const op = "storage.OwnershipHistory"
+
+	rows, err := s.db.Query(
+		ctx,
+		`WITH RECURSIVE chain (booking_id, before_id) AS (
+			SELECT $1::bigint, NULL::bigint
+			UNION ALL
+			SELECT c.from_booking_id, c.id FROM chain
+			JOIN booking.ownership_changes c ON c.booking_id = chain.booking_id AND c.from_booking_id IS NOT NULL
+		)
+		SELECT c.id, c.booking_id, c.from_booking_id, c.seat_ids, c.from_user_id, c.to_user_id, c.transfer_id, c.created_at
+		FROM booking.ownership_changes c
+		JOIN chain ON c.booking_id = chain.booking_id AND (chain.before_id IS NULL OR c.id <= chain.before_id)
+		ORDER BY c.id`,
+		bookingID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	changes, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (OwnershipChange, error) {
+		var c OwnershipChange
+		err := row.Scan(&c.ID, &c.BookingID, &c.FromBookingID, &c.SeatIDs, &c.FromUserID, &c.ToUserID, &c.TransferID, &c.ChangedAt)
+		return c, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return changes, nil
+}
+
+// checkTransferPolicy refuses a transfer of tickets that changed hands
+// transferCount times so far when the event does not allow it (anymore).
+func checkTransferPolicy(ctx context.Context, tx pgx.Tx, eventID int64, transferCount int32) error {
+	var (
+		open         bool
+		maxTransfers int32
+	)
+	err := tx.QueryRow(
+		ctx,
+		`SELECT COALESCE(p.transfers_allowed, TRUE) AND e.status <> 'CANCELLED'
+			AND (e.starts_at IS NULL OR e.starts_at - make_interval(hours => COALESCE(p.cutoff_hours, 0)) > NOW()),
+			COALESCE(p.max_transfers, 0)
+		FROM event.events e LEFT JOIN event.transfer_policies p ON p.event_id = e.id
+		WHERE e.id = $1`,
+		eventID,
+	).Scan(&open, &maxTransfers)
+	if err != nil {
+		return fmt.Errorf("failed to load transfer policy: %w", err)
+	}
+
+	if !open {
+		return ErrTransferNotAllowed
+	}
+	if maxTransfers > 0 && transferCount >= maxTransfers {
+		return ErrTransferLimitReached
+	}
+	return nil
+}
+
+// seatsValue returns what seatIDs of the booking were paid and the share of
+// the discount of the booking taken off them, which is spread over its
+// tickets by their price.
+func seatsValue(ctx context.Context, tx pgx.Tx, bookingID int64, booking *lockedBooking, seatIDs []int64) (int64, int64, error) {
+	var price int64
+	err := tx.QueryRow(
+		ctx,
+		"SELECT COALESCE(SUM(price_amount), 0) FROM booking.booking_seats WHERE booking_id = $1 AND seat_id = ANY($2)",
+		bookingID,
+		seatIDs,
+	).Scan(&price)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to price seats: %w", err)
+	}
+
+	var discount int64
+	if gross := booking.total.Amount + booking.discount; gross > 0 {
+		discount = booking.discount * price / gross
+	}
+	return price - discount, discount, nil
+}
+
+func lockBooking(ctx context.Context, tx pgx.Tx, bookingID int64) (*lockedBooking, error) {
+	var b lockedBooking
+	err := tx.QueryRow(
+		ctx,
+		`SELECT user_id, event_id, status::text, transfer_count, ticket_version,
+			COALESCE(total_amount, 0), COALESCE(currency, ''), discount_amount,
+			ARRAY(SELECT seat_id FROM booking.booking_seats WHERE booking_id = b.id),
+			(SELECT COALESCE(SUM(quantity), 0) FROM booking.booking_pool_items WHERE booking_id = b.id)
+		FROM booking.bookings b WHERE id = $1 FOR UPDATE`,
+		bookingID,
+	).Scan(&b.userID, &b.eventID, &b.status, &b.transferCount, &b.ticketVersion, &b.total.Amount, &b.total.Currency, &b.discount, &b.seatIDs, &b.poolTickets)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBookingNotFound
+		}
+		return nil, fmt.Errorf("failed to lock booking: %w", err)
+	}
+	return &b, nil
+}
+
+func (b *lockedBooking) holdsSeats(seatIDs []int64) bool {
+	held := make(map[int64]bool, len(b.seatIDs))
+	for _, id := range b.seatIDs {
+		held[id] = true
+	}
+	for _, id := range seatIDs {
+		if !held[id] {
+			return false
+		}
+	}
+	return true
+}
+
+func lockTransfer(ctx context.Context, tx pgx.Tx, where string, args ...any) (*Transfer, error) {
+	rows, err := tx.Query(ctx, "SELECT "+transferColumns+" FROM booking.ticket_transfers WHERE "+where+" FOR UPDATE", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock transfer: %w", err)
+	}
+	transfer, err := pgx.CollectExactlyOneRow(rows, scanTransfer)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTransferNotFound
+		}
+		return nil, fmt.Errorf("failed to lock transfer: %w", err)
+	}
+	return &transfer, nil
+}
+
+func scanTransfer(row pgx.CollectableRow) (Transfer, error) {
+	var t Transfer
+	err := row.Scan(&t.ID, &t.BookingID, &t.SenderID, &t.RecipientEmail, &t.SeatIDs, &t.Status, &t.ExpiresAt, &t.RecipientBookingID, &t.CreatedAt)
+	return t, err
+}
+
+// saveTicketReissue asks ticket-worker for version of the ticket of the
+// booking, which voids voidedVersion; 0 when there is nothing to void.
+func saveTicketReissue(ctx context.Context, tx pgx.Tx, bookingID int64, email string, event *eventv1.Event, version, voidedVersion int32) error {
+	payload, err := json.Marshal(map[string]any{
+		"booking_id":       bookingID,
+		"user_email":       email,
+		"event_id":         event.GetId(),
+		"event_title":      event.GetTitle(),
+		"event_starts_at":  event.GetStartsAt(),
+		"event_poster_url": posterURL(event.GetPoster()),
+		"ticket_version":   version,
+		"voided_version":   voidedVersion,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox payload: %w", err)
+	}
+	return saveOutboxMessage(ctx, tx, "ticket.reissued", payload)
+}
+
+// nonNil keeps an empty list from being saved as NULL.
+func nonNil(ids []int64) []int64 {
+	if ids == nil {
+		return []int64{}
+	}
+	return ids
+}
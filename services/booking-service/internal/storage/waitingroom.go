@@ -0,0 +1,255 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrWaitingRoomNotFound = errors.New("event has no waiting room")
+var ErrNotInWaitingRoom = errors.New("user is not in the waiting room")
+
+// WaitingRoom queues the customers of an event's on-sale and lets
+// AdmitPerMinute of them through each minute; an admission is good for
+// AdmissionMinutes.
+type WaitingRoom struct {
+	EventID          int64
+	AdmitPerMinute   int32
+	AdmissionMinutes int32
+}
+
+// QueueEntry is a user's place in a waiting room.
+type QueueEntry struct {
+	Room WaitingRoom
+	// users ahead in the queue plus one, 0 once admitted
+	Position   int64
+	AdmittedAt *time.Time
+}
+
+func (s *Storage) SetWaitingRoom(ctx context.Context, room WaitingRoom) error {
+	const op = "storage.SetWaitingRoom"
+
+	_, err := s.db.Exec(
+		ctx,
+		`INSERT INTO booking.waiting_rooms (event_id, admit_per_minute, admission_minutes) VALUES ($1, $2, $3)
+		ON CONFLICT (event_id) DO UPDATE SET admit_per_minute = EXCLUDED.admit_per_minute, admission_minutes = EXCLUDED.admission_minutes`,
+		room.EventID,
+		room.AdmitPerMinute,
+		room.AdmissionMinutes,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RemoveWaitingRoom opens the event to everyone and drops its queue.
+func (s *Storage) RemoveWaitingRoom(ctx context.Context, eventID int64) error {
+	const op = "storage.RemoveWaitingRoom"
+
+	if _, err := s.db.Exec(ctx, "DELETE FROM booking.waiting_rooms WHERE event_id = $1", eventID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetWaitingRoom(ctx context.Context, eventID int64) (*WaitingRoom, error) {
+	const op = "storage.GetWaitingRoom"
+
+	room := WaitingRoom{EventID: eventID}
+	err := s.db.QueryRow(
+		ctx,
+		"SELECT admit_per_minute, admission_minutes FROM booking.waiting_rooms WHERE event_id = $1",
+		eventID,
+	).Scan(&room.AdmitPerMinute, &room.AdmissionMinutes)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrWaitingRoomNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &room, nil
+}
+
+// JoinWaitingRoom puts the user at the back of the event's queue unless they
+// are already queued or hold an admission that is still good; an expired
+// admission sends them to the back again.
+func (s *Storage) JoinWaitingRoom(ctx context.Context, eventID, userID int64) (*QueueEntry, error) {
+	const op = "storage.JoinWaitingRoom"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	// FOR SHARE keeps the room from being removed under the new entry
+	var admissionMinutes int32
+	err = tx.QueryRow(ctx, "SELECT admission_minutes FROM booking.waiting_rooms WHERE event_id = $1 FOR SHARE", eventID).Scan(&admissionMinutes)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrWaitingRoomNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM booking.waiting_room_entries WHERE event_id = $1 AND user_id = $2 AND admitted_at <= NOW() - make_interval(mins => $3)",
+		eventID,
+		userID,
+		admissionMinutes,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to drop expired admission: %w", op, err)
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"INSERT INTO booking.waiting_room_entries (event_id, user_id) VALUES ($1, $2) ON CONFLICT (event_id, user_id) DO NOTHING",
+		eventID,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to join queue: %w", op, err)
+	}
+
+	entry, err := queueEntry(ctx, tx, eventID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entry, tx.Commit(ctx)
+}
+
+func (s *Storage) WaitingRoomEntry(ctx context.Context, eventID, userID int64) (*QueueEntry, error) {
+	const op = "storage.WaitingRoomEntry"
+
+	entry, err := queueEntry(ctx, s.db, eventID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entry, nil
+}
+
+func queueEntry(ctx context.Context, q interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}, eventID, userID int64) (*QueueEntry, error) {
+	entry := QueueEntry{Room: WaitingRoom{EventID: eventID}}
+	err := q.QueryRow(
+		ctx,
+		`SELECT r.admit_per_minute, r.admission_minutes, e.admitted_at,
+			CASE WHEN e.admitted_at IS NULL THEN (
+				SELECT COUNT(*) FROM booking.waiting_room_entries a
+				WHERE a.event_id = e.event_id AND a.admitted_at IS NULL AND a.id <= e.id
+			) ELSE 0 END
+		FROM booking.waiting_room_entries e
+		JOIN booking.waiting_rooms r ON r.event_id = e.event_id
+		WHERE e.event_id = $1 AND e.user_id = $2`,
+		eventID,
+		userID,
+	).Scan(&entry.Room.AdmitPerMinute, &entry.Room.AdmissionMinutes, &entry.AdmittedAt, &entry.Position)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotInWaitingRoom
+		}
+		return nil, fmt.Errorf("failed to find queue entry: %w", err)
+	}
+	return &entry, nil
+}
+
+// AdmitFromWaitingRooms lets the next users of every waiting room through,
+// as many as its rate allows since the last admission but at most a
+// minute's worth, and returns how many were admitted. Only the time the
+// admitted users used up is spent, so slow ticks do not lose the rest. A room being admitted
+// from by another replica is skipped.
+func (s *Storage) AdmitFromWaitingRooms(ctx context.Context) (int64, error) {
+	const op = "storage.AdmitFromWaitingRooms"
+
+	rows, err := s.db.Query(ctx, "SELECT event_id FROM booking.waiting_rooms")
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	eventIDs, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var admitted int64
+	for _, eventID := range eventIDs {
+		n, err := s.admitFromWaitingRoom(ctx, eventID)
+		if err != nil {
+			return admitted, fmt.Errorf("%s: event %d: %w", op, eventID, err)
+		}
+		admitted += n
+	}
+
+	return admitted, nil
+}
+
+func (s *Storage) admitFromWaitingRoom(ctx context.Context, eventID int64) (int64, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		allowance int32
+		since     time.Time
+	)
+	// time older than a minute no longer counts
+	err = tx.QueryRow(
+		ctx,
+		`SELECT FLOOR(EXTRACT(EPOCH FROM NOW() - since) * admit_per_minute / 60)::int, since
+		FROM (
+			SELECT admit_per_minute, GREATEST(last_admission_at, NOW() - INTERVAL '1 minute') AS since
+			FROM booking.waiting_rooms WHERE event_id = $1 FOR UPDATE SKIP LOCKED
+		) r`,
+		eventID,
+	).Scan(&allowance, &since)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock waiting room: %w", err)
+	}
+	if allowance < 1 {
+		return 0, nil
+	}
+
+	tag, err := tx.Exec(
+		ctx,
+		`UPDATE booking.waiting_room_entries SET admitted_at = NOW()
+		WHERE id IN (
+			SELECT id FROM booking.waiting_room_entries
+			WHERE event_id = $1 AND admitted_at IS NULL
+			ORDER BY id LIMIT $2
+		)`,
+		eventID,
+		allowance,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to admit users: %w", err)
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE booking.waiting_rooms SET last_admission_at = $2 + make_interval(secs => $3 * 60.0 / admit_per_minute) WHERE event_id = $1",
+		eventID,
+		since,
+		tag.RowsAffected(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update waiting room: %w", err)
+	}
+
+	return tag.RowsAffected(), tx.Commit(ctx)
+}
@@ -0,0 +1,575 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var ErrAlreadyWaitlisted = errors.New("user is already on the waitlist")
+var ErrTicketsAvailable = errors.New("tickets are still available")
+var ErrWaitlistEntryNotFound = errors.New("user is not on the waitlist")
+var ErrOfferNotFound = errors.New("waitlist offer not found")
+var ErrOfferExpired = errors.New("waitlist offer has expired")
+
+// how many waiting entries of an event are looked at each time tickets are
+// released; the rest are served by later releases or the waitlist worker
+const waitlistOfferBatch = 100
+
+// WaitlistEntry is a customer waiting for tickets of a sold out event.
+type WaitlistEntry struct {
+	ID      int64
+	EventID int64
+	UserID  int64
+	// 0 for seats
+	PoolID   int64
+	Quantity int32
+	Status   string
+	// place among the waiting entries, 0 once no longer waiting
+	Position int64
+	// the tickets set aside while the entry has an offer
+	OfferedSeatIDs []int64
+	OfferExpiresAt *time.Time
+	// the booking the offer was claimed with
+	BookingID *int64
+}
+
+// JoinWaitlist puts the user on the waitlist of the event for quantity seats,
+// or quantity tickets of the pool when poolID is set. Only an event that
+// cannot sell that many right now has a waitlist.
+func (s *Storage) JoinWaitlist(ctx context.Context, eventID, userID, poolID int64, quantity int32) (*WaitlistEntry, error) {
+	const op = "storage.JoinWaitlist"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockEventOnSale(ctx, tx, eventID, true); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var available int32
+	if poolID == 0 {
+		err = tx.QueryRow(
+			ctx,
+			"SELECT COUNT(*) FROM event.seats WHERE event_id = $1 AND status = 'AVAILABLE' AND price_tier_id IS NOT NULL",
+			eventID,
+		).Scan(&available)
+	} else {
+		err = tx.QueryRow(
+			ctx,
+			"SELECT capacity - sold - held FROM event.inventory_pools WHERE id = $1 AND event_id = $2",
+			poolID,
+			eventID,
+		).Scan(&available)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrPoolNotFound)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to count available tickets: %w", op, err)
+	}
+	if available >= quantity {
+		return nil, fmt.Errorf("%s: %w", op, ErrTicketsAvailable)
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"INSERT INTO booking.waitlist_entries (event_id, user_id, pool_id, quantity) VALUES ($1, $2, NULLIF($3, 0), $4)",
+		eventID,
+		userID,
+		poolID,
+		quantity,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, fmt.Errorf("%s: %w", op, ErrAlreadyWaitlisted)
+		}
+		return nil, fmt.Errorf("%s: failed to join waitlist: %w", op, err)
+	}
+
+	entry, err := waitlistEntry(ctx, tx, eventID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entry, tx.Commit(ctx)
+}
+
+// WaitlistEntry returns the latest waitlist entry of the user for the event.
+func (s *Storage) WaitlistEntry(ctx context.Context, eventID, userID int64) (*WaitlistEntry, error) {
+	const op = "storage.WaitlistEntry"
+
+	entry, err := waitlistEntry(ctx, s.db, eventID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entry, nil
+}
+
+func waitlistEntry(ctx context.Context, q interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}, eventID, userID int64) (*WaitlistEntry, error) {
+	var entry WaitlistEntry
+	err := q.QueryRow(
+		ctx,
+		`SELECT e.id, e.event_id, e.user_id, COALESCE(e.pool_id, 0), e.quantity, e.status,
+			CASE WHEN e.status = 'WAITING' THEN (
+				SELECT COUNT(*) FROM booking.waitlist_entries w
+				WHERE w.event_id = e.event_id AND w.status = 'WAITING' AND w.id <= e.id
+			) ELSE 0 END,
+			e.offered_seat_ids, e.offer_expires_at, e.booking_id
+		FROM booking.waitlist_entries e
+		WHERE e.event_id = $1 AND e.user_id = $2
+		ORDER BY e.id DESC LIMIT 1`,
+		eventID,
+		userID,
+	).Scan(
+		&entry.ID, &entry.EventID, &entry.UserID, &entry.PoolID, &entry.Quantity, &entry.Status,
+		&entry.Position, &entry.OfferedSeatIDs, &entry.OfferExpiresAt, &entry.BookingID,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrWaitlistEntryNotFound
+		}
+		return nil, fmt.Errorf("failed to find waitlist entry: %w", err)
+	}
+	return &entry, nil
+}
+
+// LeaveWaitlist takes the user off the waitlist of the event. Tickets offered
+// to them go to the next in line.
+func (s *Storage) LeaveWaitlist(ctx context.Context, eventID, userID int64) error {
+	const op = "storage.LeaveWaitlist"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	entry, err := lockWaitlistEntry(ctx, tx, "event_id = $1 AND user_id = $2 AND status IN ('WAITING', 'OFFERED')", eventID, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, ErrWaitlistEntryNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.closeWaitlistEntry(ctx, tx, entry, "LEFT"); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ClaimWaitlistOffer turns the offer with the claim token into a pending
+// booking of the offered tickets, provided the offer is the user's, is for
+// the event and has not expired. The event may still be sold out: the
+// tickets were set aside.
+func (s *Storage) ClaimWaitlistOffer(ctx context.Context, eventID, userID int64, token string) (int64, Price, []int64, error) {
+	const op = "storage.ClaimWaitlistOffer"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, Price{}, nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	entry, err := lockWaitlistEntry(ctx, tx, "offer_token_hash = $1 AND user_id = $2 AND event_id = $3 AND status = 'OFFERED'", tokenHash(token), userID, eventID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, Price{}, nil, fmt.Errorf("%s: %w", op, ErrOfferNotFound)
+		}
+		return 0, Price{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	// the expiry is checked here as well, the waitlist worker may not have got to it yet
+	if !entry.OfferExpiresAt.After(time.Now()) {
+		return 0, Price{}, nil, fmt.Errorf("%s: %w", op, ErrOfferExpired)
+	}
+
+	if err := lockEventOnSale(ctx, tx, entry.EventID, true); err != nil {
+		return 0, Price{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := releaseOffer(ctx, tx, entry); err != nil {
+		return 0, Price{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var ga GeneralAdmission
+	if entry.PoolID != 0 {
+		ga = GeneralAdmission{PoolID: entry.PoolID, Quantity: entry.Quantity}
+	}
+	bookingID, total, err := bookTickets(ctx, tx, userID, entry.EventID, entry.OfferedSeatIDs, ga, nil, s.defaultHold)
+	if err != nil {
+		return 0, Price{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"UPDATE booking.waitlist_entries SET status = 'CLAIMED', booking_id = $2, updated_at = NOW() WHERE id = $1",
+		entry.ID,
+		bookingID,
+	)
+	if err != nil {
+		return 0, Price{}, nil, fmt.Errorf("%s: failed to mark offer as claimed: %w", op, err)
+	}
+
+	return bookingID, total, entry.OfferedSeatIDs, tx.Commit(ctx)
+}
+
+// ExpireWaitlistOffers closes up to limit offers that were not claimed in
+// time and offers their tickets to the next in line. It returns how many
+// offers it closed.
+func (s *Storage) ExpireWaitlistOffers(ctx context.Context, limit int) (int, error) {
+	const op = "storage.ExpireWaitlistOffers"
+
+	rows, err := s.db.Query(
+		ctx,
+		"SELECT id FROM booking.waitlist_entries WHERE status = 'OFFERED' AND offer_expires_at <= NOW() ORDER BY offer_expires_at LIMIT $1",
+		limit,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	expired := 0
+	for _, id := range ids {
+		ok, err := s.expireWaitlistOffer(ctx, id)
+		if err != nil {
+			return expired, fmt.Errorf("%s: entry %d: %w", op, id, err)
+		}
+		if ok {
+			expired++
+		}
+	}
+
+	return expired, nil
+}
+
+func (s *Storage) expireWaitlistOffer(ctx context.Context, entryID int64) (bool, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// claimed or expired by someone else in the meantime
+	entry, err := lockWaitlistEntry(ctx, tx, "id = $1 AND status = 'OFFERED' AND offer_expires_at <= NOW()", entryID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.closeWaitlistEntry(ctx, tx, entry, "EXPIRED"); err != nil {
+		return false, err
+	}
+
+	payload, err := json.Marshal(map[string]any{"user_id": entry.UserID, "event_id": entry.EventID})
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal outbox payload: %w", err)
+	}
+	if err := saveOutboxMessage(ctx, tx, "waitlist.offer_expired", payload); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+// OfferWaitlistTickets offers the tickets available to the waitlists of all
+// events, e.g. after new seats were added, and returns how many offers it
+// made.
+func (s *Storage) OfferWaitlistTickets(ctx context.Context) (int, error) {
+	const op = "storage.OfferWaitlistTickets"
+
+	rows, err := s.db.Query(ctx, "SELECT DISTINCT event_id FROM booking.waitlist_entries WHERE status = 'WAITING'")
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	eventIDs, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	offered := 0
+	for _, eventID := range eventIDs {
+		n, err := s.offerEventTickets(ctx, eventID)
+		if err != nil {
+			return offered, fmt.Errorf("%s: event %d: %w", op, eventID, err)
+		}
+		offered += n
+	}
+
+	return offered, nil
+}
+
+func (s *Storage) offerEventTickets(ctx context.Context, eventID int64) (int, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	offered, err := s.offerTickets(ctx, tx, eventID)
+	if err != nil {
+		return 0, err
+	}
+
+	return offered, tx.Commit(ctx)
+}
+
+// offerTickets sets available tickets of the event aside for the waiting
+// entries in turn. An entry that asks for more than is left is passed over
+// for the ones behind it. Entries, seats and pools locked by another
+// transaction are skipped, so two releases never offer the same tickets and
+// never wait for each other.
+func (s *Storage) offerTickets(ctx context.Context, tx pgx.Tx, eventID int64) (int, error) {
+	var title string
+	err := tx.QueryRow(
+		ctx,
+		`SELECT title FROM event.events
+		WHERE id = $1 AND status IN ('ON_SALE', 'SOLD_OUT')
+			AND (on_sale_at IS NULL OR on_sale_at <= NOW())
+			AND (off_sale_at IS NULL OR off_sale_at > NOW())
+			AND EXISTS (SELECT 1 FROM booking.waitlist_entries WHERE event_id = $1 AND status = 'WAITING')`,
+		eventID,
+	).Scan(&title)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to check event: %w", err)
+	}
+
+	rows, err := tx.Query(
+		ctx,
+		`SELECT id, user_id, COALESCE(pool_id, 0), quantity FROM booking.waitlist_entries
+		WHERE event_id = $1 AND status = 'WAITING'
+		ORDER BY id LIMIT $2 FOR UPDATE SKIP LOCKED`,
+		eventID,
+		waitlistOfferBatch,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock waitlist: %w", err)
+	}
+	entries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (WaitlistEntry, error) {
+		entry := WaitlistEntry{EventID: eventID}
+		err := row.Scan(&entry.ID, &entry.UserID, &entry.PoolID, &entry.Quantity)
+		return entry, err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock waitlist: %w", err)
+	}
+
+	offered := 0
+	for _, entry := range entries {
+		ok, err := setTicketsAside(ctx, tx, &entry)
+		if err != nil {
+			return offered, err
+		}
+		if !ok {
+			continue
+		}
+
+		token, err := newToken()
+		if err != nil {
+			return offered, err
+		}
+
+		var expiresAt time.Time
+		err = tx.QueryRow(
+			ctx,
+			`UPDATE booking.waitlist_entries SET status = 'OFFERED', offer_token_hash = $2, offered_seat_ids = $3,
+				offer_expires_at = NOW() + make_interval(secs => $4), updated_at = NOW()
+			WHERE id = $1 RETURNING offer_expires_at`,
+			entry.ID,
+			tokenHash(token),
+			entry.OfferedSeatIDs,
+			s.offerTime.Seconds(),
+		).Scan(&expiresAt)
+		if err != nil {
+			return offered, fmt.Errorf("failed to save offer: %w", err)
+		}
+
+		// the claim token only ever leaves in the notification to the customer,
+		// and is not kept once that has been published
+		payload, err := json.Marshal(map[string]any{
+			"user_id":     entry.UserID,
+			"event_id":    eventID,
+			"event_title": title,
+			"quantity":    entry.Quantity,
+			"seat_ids":    entry.OfferedSeatIDs,
+			"expires_at":  expiresAt,
+			"claim_token": token,
+		})
+		if err != nil {
+			return offered, fmt.Errorf("failed to marshal outbox payload: %w", err)
+		}
+		if err := saveOutboxMessage(ctx, tx, "waitlist.offered", payload, "claim_token"); err != nil {
+			return offered, err
+		}
+		offered++
+	}
+
+	return offered, nil
+}
+
+// setTicketsAside takes the tickets the entry asks for out of sale, filling
+// in the seats it got. It reports false when there are not enough.
+func setTicketsAside(ctx context.Context, tx pgx.Tx, entry *WaitlistEntry) (bool, error) {
+	if entry.PoolID != 0 {
+		var available int32
+		err := tx.QueryRow(
+			ctx,
+			"SELECT capacity - sold - held FROM event.inventory_pools WHERE id = $1 FOR UPDATE SKIP LOCKED",
+			entry.PoolID,
+		).Scan(&available)
+		if errors.Is(err, pgx.ErrNoRows) || (err == nil && available < entry.Quantity) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to lock pool: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, "UPDATE event.inventory_pools SET held = held + $1 WHERE id = $2", entry.Quantity, entry.PoolID)
+		if err != nil {
+			return false, fmt.Errorf("failed to hold pool tickets: %w", err)
+		}
+		return true, nil
+	}
+
+	rows, err := tx.Query(
+		ctx,
+		`SELECT id FROM event.seats
+		WHERE event_id = $1 AND status = 'AVAILABLE' AND price_tier_id IS NOT NULL
+		ORDER BY id LIMIT $2 FOR UPDATE SKIP LOCKED`,
+		entry.EventID,
+		entry.Quantity,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to lock seats: %w", err)
+	}
+	seatIDs, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return false, fmt.Errorf("failed to lock seats: %w", err)
+	}
+	if int32(len(seatIDs)) < entry.Quantity {
+		return false, nil
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE event.seats SET status = 'RESERVED' WHERE id = ANY($1)", seatIDs)
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve seats: %w", err)
+	}
+	entry.OfferedSeatIDs = seatIDs
+	return true, nil
+}
+
+// closeWaitlistEntry moves a waiting or offered entry to status. Tickets it
+// was offered are released and offered to the next in line.
+func (s *Storage) closeWaitlistEntry(ctx context.Context, tx pgx.Tx, entry *WaitlistEntry, status string) error {
+	_, err := tx.Exec(
+		ctx,
+		"UPDATE booking.waitlist_entries SET status = $2, updated_at = NOW() WHERE id = $1",
+		entry.ID,
+		status,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to close waitlist entry: %w", err)
+	}
+
+	if entry.Status != "OFFERED" {
+		return nil
+	}
+	if err := releaseOffer(ctx, tx, entry); err != nil {
+		return err
+	}
+	if _, err := s.offerTickets(ctx, tx, entry.EventID); err != nil {
+		return fmt.Errorf("failed to offer released tickets: %w", err)
+	}
+	return nil
+}
+
+// releaseOffer puts the tickets set aside for an offer back on sale.
+func releaseOffer(ctx context.Context, tx pgx.Tx, entry *WaitlistEntry) error {
+	if entry.PoolID != 0 {
+		_, err := tx.Exec(ctx, "UPDATE event.inventory_pools SET held = held - $1 WHERE id = $2", entry.Quantity, entry.PoolID)
+		if err != nil {
+			return fmt.Errorf("failed to release offered pool tickets: %w", err)
+		}
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, "UPDATE event.seats SET status = 'AVAILABLE' WHERE id = ANY($1) AND status = 'RESERVED'", entry.OfferedSeatIDs)
+	if err != nil {
+		return fmt.Errorf("failed to release offered seats: %w", err)
+	}
+	return nil
+}
+
+func lockWaitlistEntry(ctx context.Context, tx pgx.Tx, where string, args ...any) (*WaitlistEntry, error) {
+	var entry WaitlistEntry
+	err := tx.QueryRow(
+		ctx,
+		`SELECT id, event_id, user_id, COALESCE(pool_id, 0), quantity, status, offered_seat_ids, offer_expires_at
+		FROM booking.waitlist_entries WHERE `+where+` FOR UPDATE`,
+		args...,
+	).Scan(&entry.ID, &entry.EventID, &entry.UserID, &entry.PoolID, &entry.Quantity, &entry.Status, &entry.OfferedSeatIDs, &entry.OfferExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to lock waitlist entry: %w", err)
+	}
+	return &entry, nil
+}
+
+// saveOutboxMessage queues a message for bookings_exchange. secretKeys are
+// keys of the payload that are dropped once it has been published.
+func saveOutboxMessage(ctx context.Context, tx pgx.Tx, routingKey string, payload []byte, secretKeys ...string) error {
+	if secretKeys == nil {
+		secretKeys = []string{}
+	}
+	_, err := tx.Exec(
+		ctx,
+		"INSERT INTO booking.outbox_messages (exchange, routing_key, payload, secret_keys) VALUES ($1, $2, $3::jsonb, $4)",
+		"bookings_exchange",
+		routingKey,
+		payload,
+		secretKeys,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save outbox message: %w", err)
+	}
+	return nil
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func tokenHash(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
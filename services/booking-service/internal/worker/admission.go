@@ -0,0 +1,48 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type WaitingRoomAdmitter interface {
+	AdmitWaitingUsers(ctx context.Context) (int64, error)
+}
+
+// AdmissionWorker lets queued users out of the waiting rooms at the rate of
+// each room. Several replicas can run it; each room is admitted from by one
+// at a time.
+type AdmissionWorker struct {
+	admitter WaitingRoomAdmitter
+	logger   *slog.Logger
+	ticker   *time.Ticker
+}
+
+func NewAdmissionWorker(admitter WaitingRoomAdmitter, logger *slog.Logger, interval time.Duration) *AdmissionWorker {
+	return &AdmissionWorker{
+		admitter: admitter,
+		logger:   logger,
+		ticker:   time.NewTicker(interval),
+	}
+}
+
+func (w *AdmissionWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting Admission Worker")
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Stopping Admission Worker")
+			w.ticker.Stop()
+			return
+		case <-w.ticker.C:
+			admitted, err := w.admitter.AdmitWaitingUsers(ctx)
+			if err != nil {
+				w.logger.Error("Failed to admit waiting users", "error", err)
+			}
+			if admitted > 0 {
+				w.logger.Info("Admitted users from waiting rooms", "users", admitted)
+			}
+		}
+	}
+}
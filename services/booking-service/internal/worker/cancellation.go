@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type CancellationProcessor interface {
+	ProcessEventCancellations(ctx context.Context, batchSize int) (int, error)
+}
+
+// CancellationWorker drives the bulk jobs started for cancelled events. Items
+// are claimed with a lease, so several replicas can run it side by side and a
+// job interrupted by a restart simply continues on the next tick.
+type CancellationWorker struct {
+	processor CancellationProcessor
+	logger    *slog.Logger
+	ticker    *time.Ticker
+	batchSize int
+}
+
+func NewCancellationWorker(processor CancellationProcessor, logger *slog.Logger, interval time.Duration, batchSize int) *CancellationWorker {
+	return &CancellationWorker{
+		processor: processor,
+		logger:    logger,
+		ticker:    time.NewTicker(interval),
+		batchSize: batchSize,
+	}
+}
+
+func (w *CancellationWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting Cancellation Worker")
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Stopping Cancellation Worker")
+			w.ticker.Stop()
+			return
+		case <-w.ticker.C:
+			// drain the backlog instead of waiting a full tick per batch
+			for {
+				processed, err := w.processor.ProcessEventCancellations(ctx, w.batchSize)
+				if err != nil {
+					w.logger.Error("Failed to process event cancellations", "error", err)
+					break
+				}
+				if processed > 0 {
+					w.logger.Info("Processed event cancellation items", "items", processed)
+				}
+				if processed < w.batchSize || ctx.Err() != nil {
+					break
+				}
+			}
+		}
+	}
+}
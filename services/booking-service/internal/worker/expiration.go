@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type ExpirationProcessor interface {
+	ExpireDueBookings(ctx context.Context, batchSize int) (int, error)
+}
+
+// ExpirationWorker releases unpaid bookings once their hold runs out. The
+// due bookings come from the expires_at index, so a booking expires within
+// one interval of its own hold time whatever that is.
+type ExpirationWorker struct {
+	processor ExpirationProcessor
+	logger    *slog.Logger
+	ticker    *time.Ticker
+	batchSize int
+}
+
+func NewExpirationWorker(processor ExpirationProcessor, logger *slog.Logger, interval time.Duration, batchSize int) *ExpirationWorker {
+	return &ExpirationWorker{
+		processor: processor,
+		logger:    logger,
+		ticker:    time.NewTicker(interval),
+		batchSize: batchSize,
+	}
+}
+
+func (w *ExpirationWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting Expiration Worker")
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Stopping Expiration Worker")
+			w.ticker.Stop()
+			return
+		case <-w.ticker.C:
+			for {
+				expired, err := w.processor.ExpireDueBookings(ctx, w.batchSize)
+				if err != nil {
+					w.logger.Error("Failed to expire bookings", "error", err)
+					break
+				}
+				if expired > 0 {
+					w.logger.Info("Expired bookings", "bookings", expired)
+				}
+				if expired < w.batchSize || ctx.Err() != nil {
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type IdempotencyPurger interface {
+	PurgeIdempotencyKeys(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+// IdempotencyPurgeWorker forgets stored idempotent responses once they are
+// older than the retention period; a retry after that is a new request.
+type IdempotencyPurgeWorker struct {
+	purger    IdempotencyPurger
+	logger    *slog.Logger
+	ticker    *time.Ticker
+	retention time.Duration
+}
+
+func NewIdempotencyPurgeWorker(purger IdempotencyPurger, logger *slog.Logger, interval, retention time.Duration) *IdempotencyPurgeWorker {
+	return &IdempotencyPurgeWorker{
+		purger:    purger,
+		logger:    logger,
+		ticker:    time.NewTicker(interval),
+		retention: retention,
+	}
+}
+
+func (w *IdempotencyPurgeWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting Idempotency Purge Worker")
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Stopping Idempotency Purge Worker")
+			w.ticker.Stop()
+			return
+		case <-w.ticker.C:
+			purged, err := w.purger.PurgeIdempotencyKeys(ctx, w.retention)
+			if err != nil {
+				w.logger.Error("Failed to purge idempotency keys", "error", err)
+				continue
+			}
+			if purged > 0 {
+				w.logger.Info("Purged idempotency keys", "keys", purged)
+			}
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type RefundProcessor interface {
+	ProcessRefunds(ctx context.Context, batchSize int) (int, error)
+}
+
+// RefundWorker sends the refunds saved by the booking transactions to the
+// payment service. Refunds are claimed with a lease, so several replicas can
+// run it, and one that fails is retried on a later tick.
+type RefundWorker struct {
+	processor RefundProcessor
+	logger    *slog.Logger
+	ticker    *time.Ticker
+	batchSize int
+}
+
+func NewRefundWorker(processor RefundProcessor, logger *slog.Logger, interval time.Duration, batchSize int) *RefundWorker {
+	return &RefundWorker{
+		processor: processor,
+		logger:    logger,
+		ticker:    time.NewTicker(interval),
+		batchSize: batchSize,
+	}
+}
+
+func (w *RefundWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting Refund Worker")
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Stopping Refund Worker")
+			w.ticker.Stop()
+			return
+		case <-w.ticker.C:
+			for {
+				processed, err := w.processor.ProcessRefunds(ctx, w.batchSize)
+				if err != nil {
+					w.logger.Error("Failed to process refunds", "error", err)
+					break
+				}
+				if processed > 0 {
+					w.logger.Info("Processed refunds", "refunds", processed)
+				}
+				if processed < w.batchSize || ctx.Err() != nil {
+					break
+				}
+			}
+		}
+	}
+}
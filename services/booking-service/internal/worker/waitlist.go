@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type WaitlistServer interface {
+	ServeWaitlists(ctx context.Context, batchSize int) (int, int, error)
+}
+
+// WaitlistWorker rolls offers nobody claimed in time over to the next in line
+// and offers tickets that became available outside of a booking, e.g. new
+// seats.
+type WaitlistWorker struct {
+	server    WaitlistServer
+	logger    *slog.Logger
+	ticker    *time.Ticker
+	batchSize int
+}
+
+func NewWaitlistWorker(server WaitlistServer, logger *slog.Logger, interval time.Duration, batchSize int) *WaitlistWorker {
+	return &WaitlistWorker{
+		server:    server,
+		logger:    logger,
+		ticker:    time.NewTicker(interval),
+		batchSize: batchSize,
+	}
+}
+
+func (w *WaitlistWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting Waitlist Worker")
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Stopping Waitlist Worker")
+			w.ticker.Stop()
+			return
+		case <-w.ticker.C:
+			expired, offered, err := w.server.ServeWaitlists(ctx, w.batchSize)
+			if err != nil {
+				w.logger.Error("Failed to serve waitlists", "error", err)
+			}
+			if expired > 0 || offered > 0 {
+				w.logger.Info("Served waitlists", "expired_offers", expired, "new_offers", offered)
+			}
+		}
+	}
+}
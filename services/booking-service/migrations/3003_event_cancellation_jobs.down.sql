@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS booking.idx_event_cancellation_items_pending;
+DROP TABLE IF EXISTS booking.event_cancellation_items;
+DROP TABLE IF EXISTS booking.event_cancellation_jobs;
+
+-- enum values can not be dropped; REFUNDED and EVENT_CANCELLED stay in booking_status
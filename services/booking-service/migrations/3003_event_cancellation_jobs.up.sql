@@ -0,0 +1,24 @@
+-- PENDING bookings of a cancelled event end up EVENT_CANCELLED, paid ones REFUNDED
+ALTER TYPE booking_status ADD VALUE IF NOT EXISTS 'REFUNDED';
+ALTER TYPE booking_status ADD VALUE IF NOT EXISTS 'EVENT_CANCELLED';
+
+CREATE TABLE IF NOT EXISTS booking.event_cancellation_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL UNIQUE REFERENCES event.events(id),
+    reason TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'RUNNING',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS booking.event_cancellation_items (
+    job_id BIGINT NOT NULL REFERENCES booking.event_cancellation_jobs(id) ON DELETE CASCADE,
+    booking_id BIGINT NOT NULL REFERENCES booking.bookings(id),
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (job_id, booking_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_cancellation_items_pending ON booking.event_cancellation_items (job_id) WHERE status = 'PENDING';
@@ -0,0 +1,5 @@
+ALTER TABLE booking.bookings DROP COLUMN IF EXISTS currency;
+ALTER TABLE booking.bookings DROP COLUMN IF EXISTS total_amount;
+
+ALTER TABLE booking.booking_seats DROP COLUMN IF EXISTS price_currency;
+ALTER TABLE booking.booking_seats DROP COLUMN IF EXISTS price_amount;
@@ -0,0 +1,6 @@
+-- prices are copied at booking time, later tier changes do not affect existing bookings
+ALTER TABLE booking.booking_seats ADD COLUMN IF NOT EXISTS price_amount BIGINT;
+ALTER TABLE booking.booking_seats ADD COLUMN IF NOT EXISTS price_currency CHAR(3);
+
+ALTER TABLE booking.bookings ADD COLUMN IF NOT EXISTS total_amount BIGINT;
+ALTER TABLE booking.bookings ADD COLUMN IF NOT EXISTS currency CHAR(3);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS booking.booking_pool_items (
+    booking_id BIGINT NOT NULL REFERENCES booking.bookings(id) ON DELETE CASCADE,
+    pool_id BIGINT NOT NULL REFERENCES event.inventory_pools(id),
+    quantity INT NOT NULL CHECK (quantity > 0),
+    price_amount BIGINT NOT NULL, -- за один билет
+    price_currency CHAR(3) NOT NULL,
+    PRIMARY KEY (booking_id, pool_id)
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS booking.idx_bookings_on_user_created_at;
+DROP TRIGGER IF EXISTS bookings_set_updated_at ON booking.bookings;
+DROP FUNCTION IF EXISTS booking.set_booking_updated_at();
+ALTER TABLE booking.bookings DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,18 @@
+-- updated_at shows when a booking last changed its status
+ALTER TABLE booking.bookings ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+UPDATE booking.bookings SET updated_at = created_at;
+
+CREATE OR REPLACE FUNCTION booking.set_booking_updated_at() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER bookings_set_updated_at
+    BEFORE UPDATE ON booking.bookings
+    FOR EACH ROW EXECUTE FUNCTION booking.set_booking_updated_at();
+
+-- a user's bookings are listed newest first
+CREATE INDEX IF NOT EXISTS idx_bookings_on_user_created_at ON booking.bookings (user_id, created_at DESC, id DESC);
@@ -0,0 +1,2 @@
+-- set when a confirmed booking is cancelled by the customer, 0 when the refund policy gave nothing back
+ALTER TABLE booking.bookings ADD COLUMN IF NOT EXISTS refund_amount BIGINT;
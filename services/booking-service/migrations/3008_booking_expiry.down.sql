@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS booking.idx_bookings_on_pending_expires_at;
+ALTER TABLE booking.bookings DROP COLUMN IF EXISTS expires_at;
@@ -0,0 +1,5 @@
+-- when an unpaid booking stops holding its tickets; bookings made before this
+-- migration keep the 15 minutes they were given
+ALTER TABLE booking.bookings ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+UPDATE booking.bookings SET expires_at = created_at + INTERVAL '15 minutes' WHERE status = 'PENDING' AND expires_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_bookings_on_pending_expires_at ON booking.bookings(expires_at) WHERE status = 'PENDING';
@@ -0,0 +1 @@
+ALTER TABLE booking.bookings DROP COLUMN IF EXISTS hold_extensions;
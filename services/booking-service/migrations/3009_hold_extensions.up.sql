@@ -0,0 +1,2 @@
+-- how many times the buyer has extended the hold of a pending booking
+ALTER TABLE booking.bookings ADD COLUMN IF NOT EXISTS hold_extensions INT NOT NULL DEFAULT 0;
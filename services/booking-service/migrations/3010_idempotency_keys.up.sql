@@ -0,0 +1,14 @@
+-- responses of requests sent with an Idempotency-Key, replayed when the
+-- same user retries with the same key; purged after the retention period
+CREATE TABLE IF NOT EXISTS booking.idempotency_keys (
+    user_id BIGINT NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    -- hash of the request, a different request under the same key is refused
+    fingerprint BYTEA NOT NULL,
+    -- NULL while the first request is still running
+    response BYTEA,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_on_created_at ON booking.idempotency_keys (created_at);
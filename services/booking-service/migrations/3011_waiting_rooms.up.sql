@@ -0,0 +1,21 @@
+-- events whose on-sale goes through a waiting room; admit_per_minute users
+-- are let through each minute and may book for admission_minutes
+CREATE TABLE IF NOT EXISTS booking.waiting_rooms (
+    event_id BIGINT PRIMARY KEY,
+    admit_per_minute INT NOT NULL CHECK (admit_per_minute > 0),
+    admission_minutes INT NOT NULL CHECK (admission_minutes BETWEEN 1 AND 1440),
+    last_admission_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- the queue of a waiting room in order of id; admitted_at is set when the
+-- user is let through
+CREATE TABLE IF NOT EXISTS booking.waiting_room_entries (
+    id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL REFERENCES booking.waiting_rooms(event_id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    admitted_at TIMESTAMPTZ,
+    UNIQUE (event_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_waiting_room_entries_on_waiting ON booking.waiting_room_entries (event_id, id) WHERE admitted_at IS NULL;
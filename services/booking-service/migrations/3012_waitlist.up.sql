@@ -0,0 +1,22 @@
+-- customers waiting for quantity tickets of a sold out event, seats unless
+-- pool_id is set; served in order of id. An OFFERED entry has the tickets
+-- set aside for it until offer_expires_at and can claim them with the token.
+CREATE TABLE IF NOT EXISTS booking.waitlist_entries (
+    id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    pool_id BIGINT,
+    quantity INT NOT NULL CHECK (quantity BETWEEN 1 AND 10),
+    status VARCHAR(20) NOT NULL DEFAULT 'WAITING' CHECK (status IN ('WAITING', 'OFFERED', 'CLAIMED', 'EXPIRED', 'LEFT')),
+    -- sha256 of the claim token, the token itself is only sent to the customer
+    offer_token_hash BYTEA UNIQUE,
+    offered_seat_ids BIGINT[] NOT NULL DEFAULT '{}',
+    offer_expires_at TIMESTAMPTZ,
+    booking_id BIGINT REFERENCES booking.bookings(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_waitlist_entries_on_event_id_user_id ON booking.waitlist_entries (event_id, user_id) WHERE status IN ('WAITING', 'OFFERED');
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_on_waiting ON booking.waitlist_entries (event_id, id) WHERE status = 'WAITING';
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_on_offer_expires_at ON booking.waitlist_entries (offer_expires_at) WHERE status = 'OFFERED';
@@ -0,0 +1,3 @@
+ALTER TABLE booking.bookings DROP COLUMN IF EXISTS discount_amount;
+DROP TABLE IF EXISTS booking.promo_redemptions;
+DROP TABLE IF EXISTS booking.promo_codes;
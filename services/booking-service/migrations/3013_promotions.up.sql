@@ -0,0 +1,39 @@
+-- discount campaigns; a PERCENT code takes percent off the eligible tickets,
+-- a FIXED one amount in currency. 0 caps mean no cap, an empty
+-- price_tier_ids and a NULL event_id mean any.
+CREATE TABLE IF NOT EXISTS booking.promo_codes (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    discount_type VARCHAR(10) NOT NULL CHECK (discount_type IN ('PERCENT', 'FIXED')),
+    percent INT NOT NULL DEFAULT 0 CHECK (percent BETWEEN 0 AND 100),
+    amount BIGINT NOT NULL DEFAULT 0 CHECK (amount >= 0),
+    currency CHAR(3),
+    valid_from TIMESTAMPTZ,
+    valid_until TIMESTAMPTZ,
+    max_redemptions INT NOT NULL DEFAULT 0 CHECK (max_redemptions >= 0),
+    max_redemptions_per_user INT NOT NULL DEFAULT 0 CHECK (max_redemptions_per_user >= 0),
+    redemptions INT NOT NULL DEFAULT 0 CHECK (redemptions >= 0),
+    event_id BIGINT,
+    price_tier_ids BIGINT[] NOT NULL DEFAULT '{}',
+    -- whether the code may be combined with other codes in one booking
+    stackable BOOLEAN NOT NULL DEFAULT FALSE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_promo_codes_within_cap CHECK (max_redemptions = 0 OR redemptions <= max_redemptions)
+);
+
+-- a redemption lives as long as its booking is pending or paid; a booking
+-- that is cancelled or expires unpaid gives it back
+CREATE TABLE IF NOT EXISTS booking.promo_redemptions (
+    promo_code_id BIGINT NOT NULL REFERENCES booking.promo_codes(id),
+    booking_id BIGINT NOT NULL REFERENCES booking.bookings(id),
+    user_id BIGINT NOT NULL,
+    discount_amount BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (promo_code_id, booking_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_promo_redemptions_on_promo_code_id_user_id ON booking.promo_redemptions (promo_code_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_promo_redemptions_on_booking_id ON booking.promo_redemptions (booking_id);
+
+ALTER TABLE booking.bookings ADD COLUMN IF NOT EXISTS discount_amount BIGINT NOT NULL DEFAULT 0;
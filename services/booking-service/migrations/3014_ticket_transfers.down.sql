@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS booking.ownership_changes;
+DROP TABLE IF EXISTS booking.ticket_transfers;
+DROP INDEX IF EXISTS booking.idx_bookings_on_transferred_from_id;
+ALTER TABLE booking.bookings DROP COLUMN IF EXISTS ticket_version;
+ALTER TABLE booking.bookings DROP COLUMN IF EXISTS transfer_count;
+ALTER TABLE booking.bookings DROP COLUMN IF EXISTS transferred_from_id;
@@ -0,0 +1,50 @@
+-- seats handed over from a booking are split off into a booking of the
+-- recipient; transferred_from_id points back at it. transfer_count is how
+-- often the tickets changed hands, ticket_version goes up with every ticket
+-- reissued for the booking and voids the earlier ones.
+ALTER TABLE booking.bookings ADD COLUMN IF NOT EXISTS transferred_from_id BIGINT REFERENCES booking.bookings(id);
+ALTER TABLE booking.bookings ADD COLUMN IF NOT EXISTS transfer_count INT NOT NULL DEFAULT 0;
+ALTER TABLE booking.bookings ADD COLUMN IF NOT EXISTS ticket_version INT NOT NULL DEFAULT 1;
+
+CREATE INDEX IF NOT EXISTS idx_bookings_on_transferred_from_id ON booking.bookings (transferred_from_id) WHERE transferred_from_id IS NOT NULL;
+
+-- a paid booking, or seat_ids of it, offered to whoever signs in with
+-- recipient_email and accepts with the token before expires_at
+CREATE TABLE IF NOT EXISTS booking.ticket_transfers (
+    id BIGSERIAL PRIMARY KEY,
+    booking_id BIGINT NOT NULL REFERENCES booking.bookings(id),
+    sender_id BIGINT NOT NULL,
+    recipient_email VARCHAR(255) NOT NULL,
+    -- empty for all tickets of the booking
+    seat_ids BIGINT[] NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'ACCEPTED', 'CANCELLED', 'EXPIRED')),
+    -- sha256 of the accept token, the token itself is only sent to the recipient
+    token_hash BYTEA NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    recipient_id BIGINT,
+    -- the booking that holds the tickets once accepted
+    recipient_booking_id BIGINT REFERENCES booking.bookings(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ticket_transfers_on_booking_id ON booking.ticket_transfers (booking_id) WHERE status = 'PENDING';
+
+-- every change of hands of the tickets of a booking, starting with the
+-- purchase; from_booking_id is set when seat_ids were split off it
+CREATE TABLE IF NOT EXISTS booking.ownership_changes (
+    id BIGSERIAL PRIMARY KEY,
+    booking_id BIGINT NOT NULL REFERENCES booking.bookings(id),
+    from_booking_id BIGINT REFERENCES booking.bookings(id),
+    seat_ids BIGINT[] NOT NULL DEFAULT '{}',
+    from_user_id BIGINT,
+    to_user_id BIGINT NOT NULL,
+    transfer_id BIGINT REFERENCES booking.ticket_transfers(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ownership_changes_on_booking_id ON booking.ownership_changes (booking_id, id);
+
+INSERT INTO booking.ownership_changes (booking_id, to_user_id, created_at)
+SELECT id, user_id, updated_at FROM booking.bookings
+WHERE status IN ('CONFIRMED', 'REFUNDED') AND NOT EXISTS (SELECT 1 FROM booking.ownership_changes c WHERE c.booking_id = bookings.id);
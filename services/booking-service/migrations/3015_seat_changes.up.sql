@@ -0,0 +1,28 @@
+-- changes of the seats of a paid booking, kept as its line-item history: seats
+-- given back with a refund, or exchanged for other seats of the event. An
+-- exchange that costs more stays PENDING, its new seats reserved, until the
+-- difference (amount_due) is paid.
+CREATE TABLE IF NOT EXISTS booking.seat_changes (
+    id BIGSERIAL PRIMARY KEY,
+    booking_id BIGINT NOT NULL REFERENCES booking.bookings(id),
+    change_type VARCHAR(20) NOT NULL CHECK (change_type IN ('CANCELLATION', 'EXCHANGE')),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('PENDING', 'COMPLETED', 'CANCELLED', 'EXPIRED')),
+    released_seat_ids BIGINT[] NOT NULL DEFAULT '{}',
+    -- what the released seats were paid, after their share of the discount
+    released_amount BIGINT NOT NULL DEFAULT 0,
+    released_discount BIGINT NOT NULL DEFAULT 0,
+    added_seat_ids BIGINT[] NOT NULL DEFAULT '{}',
+    -- price of each added seat, in the order of added_seat_ids
+    added_seat_prices BIGINT[] NOT NULL DEFAULT '{}',
+    amount_due BIGINT NOT NULL DEFAULT 0,
+    -- NULL while a payment of amount_due may still arrive
+    refund_amount BIGINT,
+    currency CHAR(3) NOT NULL,
+    expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_seat_changes_on_booking_id ON booking.seat_changes (booking_id, id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_seat_changes_pending_on_booking_id ON booking.seat_changes (booking_id) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_seat_changes_pending_on_expires_at ON booking.seat_changes (expires_at) WHERE status = 'PENDING';
@@ -0,0 +1 @@
+-- enum values can not be dropped; AWAITING_PAYMENT and CHECKED_IN stay in booking_status
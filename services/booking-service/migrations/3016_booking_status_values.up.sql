@@ -0,0 +1,4 @@
+-- in a migration of their own: new enum values can not be used in the
+-- transaction that adds them
+ALTER TYPE booking_status ADD VALUE IF NOT EXISTS 'AWAITING_PAYMENT';
+ALTER TYPE booking_status ADD VALUE IF NOT EXISTS 'CHECKED_IN';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS booking.idx_bookings_on_held_expires_at;
+CREATE INDEX IF NOT EXISTS idx_bookings_on_pending_expires_at ON booking.bookings(expires_at) WHERE status = 'PENDING';
+
+DROP INDEX IF EXISTS booking.idx_booking_status_history_on_booking_id;
+DROP TABLE IF EXISTS booking.booking_status_history;
@@ -0,0 +1,24 @@
+-- every status a booking went through: from_status is NULL for the status it
+-- was created in, correlation_id the request that caused the change, if any
+CREATE TABLE IF NOT EXISTS booking.booking_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    booking_id BIGINT NOT NULL REFERENCES booking.bookings(id),
+    from_status booking_status,
+    to_status booking_status NOT NULL,
+    -- user:<id>, system or payment
+    actor VARCHAR(50) NOT NULL,
+    reason VARCHAR(50) NOT NULL,
+    correlation_id VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_booking_status_history_on_booking_id ON booking.booking_status_history (booking_id, id);
+
+-- bookings from before the history start with their current status
+INSERT INTO booking.booking_status_history (booking_id, to_status, actor, reason, created_at)
+SELECT id, status, 'system', 'BACKFILLED', updated_at FROM booking.bookings
+WHERE NOT EXISTS (SELECT 1 FROM booking.booking_status_history h WHERE h.booking_id = bookings.id);
+
+-- a hold runs out whether or not the payment was started
+DROP INDEX IF EXISTS booking.idx_bookings_on_pending_expires_at;
+CREATE INDEX IF NOT EXISTS idx_bookings_on_held_expires_at ON booking.bookings (expires_at) WHERE status IN ('PENDING', 'AWAITING_PAYMENT');
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS booking.idx_bookings_on_order_id;
+ALTER TABLE booking.bookings DROP COLUMN IF EXISTS order_id;
+DROP TABLE IF EXISTS booking.orders;
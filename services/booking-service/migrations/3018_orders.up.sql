@@ -0,0 +1,17 @@
+-- an order books tickets of several events at once: a booking per event,
+-- paid with a single payment made against payment_booking_id, one of them.
+-- Until it is paid the bookings are held, confirmed and released together.
+CREATE TABLE IF NOT EXISTS booking.orders (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'CONFIRMED', 'CANCELLED', 'EXPIRED')),
+    total_amount BIGINT NOT NULL DEFAULT 0,
+    currency CHAR(3),
+    payment_booking_id BIGINT REFERENCES booking.bookings(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE booking.bookings ADD COLUMN IF NOT EXISTS order_id BIGINT REFERENCES booking.orders(id);
+
+CREATE INDEX IF NOT EXISTS idx_bookings_on_order_id ON booking.bookings (order_id) WHERE order_id IS NOT NULL;
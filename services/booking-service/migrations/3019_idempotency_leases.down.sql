@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS booking.idx_idempotency_keys_on_claim_id;
+
+ALTER TABLE booking.idempotency_keys
+    DROP COLUMN IF EXISTS booking_id,
+    DROP COLUMN IF EXISTS lease_until,
+    DROP COLUMN IF EXISTS claim_id;
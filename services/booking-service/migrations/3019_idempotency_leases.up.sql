@@ -0,0 +1,10 @@
+-- a claim on a key is held by its request for as long as the request keeps
+-- renewing lease_until, and is tied to the booking the request made in the
+-- booking's own transaction, so a request that dies after booking is
+-- replayed from booking_id instead of being run again
+ALTER TABLE booking.idempotency_keys
+    ADD COLUMN IF NOT EXISTS claim_id BIGSERIAL,
+    ADD COLUMN IF NOT EXISTS lease_until TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS booking_id BIGINT REFERENCES booking.bookings(id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_idempotency_keys_on_claim_id ON booking.idempotency_keys (claim_id);
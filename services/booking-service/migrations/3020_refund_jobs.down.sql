@@ -0,0 +1,3 @@
+ALTER TABLE booking.seat_changes DROP COLUMN IF EXISTS refund_status;
+
+DROP TABLE IF EXISTS booking.refund_jobs;
@@ -0,0 +1,28 @@
+-- refunds owed to customers, saved in the transaction that decides them and
+-- sent to the payment service afterwards by the refund worker. Every attempt
+-- carries idempotency_key, so the payment service pays a refund out once
+-- however often it is sent.
+CREATE TABLE IF NOT EXISTS booking.refund_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    idempotency_key VARCHAR(100) NOT NULL UNIQUE,
+    booking_id BIGINT NOT NULL REFERENCES booking.bookings(id),
+    -- the booking the refunded payment was made against
+    payment_booking_id BIGINT NOT NULL REFERENCES booking.bookings(id),
+    -- NULL refunds the whole payment
+    amount BIGINT,
+    currency CHAR(3),
+    seat_change_id BIGINT REFERENCES booking.seat_changes(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'COMPLETED')),
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_refund_jobs_pending_on_next_attempt_at ON booking.refund_jobs (next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_refund_jobs_on_booking_id ON booking.refund_jobs (booking_id);
+
+-- PENDING while the refund a change owes is being sent, SENT once it has
+-- been paid out; NULL for changes that refund nothing
+ALTER TABLE booking.seat_changes ADD COLUMN IF NOT EXISTS refund_status VARCHAR(20) CHECK (refund_status IN ('PENDING', 'SENT'));
@@ -0,0 +1 @@
+ALTER TABLE booking.outbox_messages DROP COLUMN IF EXISTS secret_keys;
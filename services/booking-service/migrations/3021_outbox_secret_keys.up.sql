@@ -0,0 +1,6 @@
+-- keys of the payload that carry a bearer token for the recipient only; the
+-- outbox worker drops them once the message has been published
+ALTER TABLE booking.outbox_messages ADD COLUMN IF NOT EXISTS secret_keys TEXT[] NOT NULL DEFAULT '{}';
+
+UPDATE booking.outbox_messages SET secret_keys = '{claim_token}' WHERE routing_key = 'waitlist.offered';
+UPDATE booking.outbox_messages SET payload = payload - secret_keys WHERE processed_at IS NOT NULL AND secret_keys <> '{}';
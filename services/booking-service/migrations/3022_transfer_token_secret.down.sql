@@ -0,0 +1 @@
+UPDATE booking.outbox_messages SET secret_keys = '{}' WHERE routing_key = 'transfer.started';
@@ -0,0 +1,2 @@
+UPDATE booking.outbox_messages SET secret_keys = '{accept_token}' WHERE routing_key = 'transfer.started';
+UPDATE booking.outbox_messages SET payload = payload - 'accept_token' WHERE routing_key = 'transfer.started' AND processed_at IS NOT NULL;
@@ -7,7 +7,9 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
@@ -16,14 +18,20 @@ import (
 
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/kay-kewl/ticket-booking-system/internal/blobstore"
 	"github.com/kay-kewl/ticket-booking-system/internal/config"
 	"github.com/kay-kewl/ticket-booking-system/internal/database"
 	"github.com/kay-kewl/ticket-booking-system/internal/grpc/interceptors"
 	"github.com/kay-kewl/ticket-booking-system/internal/logging"
+	"github.com/kay-kewl/ticket-booking-system/internal/outbox"
+	"github.com/kay-kewl/ticket-booking-system/internal/rabbitmq"
 	"github.com/kay-kewl/ticket-booking-system/internal/telemetry"
 	grpcserver "github.com/kay-kewl/ticket-booking-system/services/event-service/internal/grpc"
 	"github.com/kay-kewl/ticket-booking-system/services/event-service/internal/service"
 	"github.com/kay-kewl/ticket-booking-system/services/event-service/internal/storage"
+	"github.com/kay-kewl/ticket-booking-system/services/event-service/internal/worker"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
@@ -56,6 +64,25 @@ func main() {
 		os.Exit(1)
 	}
 
+	rabbitmqManager := rabbitmq.NewConnectionManager(cfg.RabbitMQURL, logger)
+	defer rabbitmqManager.Close()
+
+	logger.Info("Waiting for RabbitMQ connection...")
+	rabbitmqManager.WaitUntilReady()
+	logger.Info("RabbitMQ connection is ready")
+
+	setupCh, err := rabbitmqManager.GetChannel()
+	if err != nil {
+		logger.Error("Failed to get channel for topology setup", "error", err)
+		os.Exit(1)
+	}
+	if err := setupRabbitMQTopology(setupCh); err != nil {
+		logger.Error("Failed to setup RabbitMQ topology", "error", err)
+		os.Exit(1)
+	}
+	setupCh.Close()
+	logger.Info("RabbitMQ topology setup successfully")
+
 	dbPool, err := database.NewConnection(context.Background(), cfg.PostgresURL, logger)
 	if err != nil {
 		logger.Error("Failed to connect to database", "error", err)
@@ -64,8 +91,10 @@ func main() {
 
 	defer dbPool.Close()
 
-	eventStorage := storage.New(dbPool)
-	eventService := service.New(eventStorage)
+	media := blobstore.NewFileSystem(cfg.MediaStoragePath, cfg.MediaBaseURL)
+
+	eventStorage := storage.New(dbPool, media.URL)
+	eventService := service.New(eventStorage, eventStorage, eventStorage, eventStorage, eventStorage, eventStorage, media, eventStorage, eventStorage, strings.ToLower(cfg.DefaultLocale))
 
 	l, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.EventGRPCPort))
 	if err != nil {
@@ -73,6 +102,19 @@ func main() {
 		os.Exit(1)
 	}
 
+	workerCtx, workerCancel := context.WithCancel(context.Background())
+	defer workerCancel()
+
+	outboxWorker := outbox.NewWorker(dbPool, "event.outbox_messages", rabbitmqManager, logger, 10*time.Second)
+	go outboxWorker.Start(workerCtx)
+
+	// also how late a status may follow the sales window and the stock
+	lifecycleWorker := worker.NewLifecycleWorker(eventStorage, logger, 30*time.Second)
+	go lifecycleWorker.Start(workerCtx)
+
+	cacheWorker := worker.NewCacheInvalidationWorker(rabbitmqManager, eventService, logger)
+	go cacheWorker.Start(workerCtx)
+
 	logger.Info("Event Service ready. gRPC server listening", "address", l.Addr().String())
 
 	healthSrv := health.NewServer()
@@ -82,6 +124,8 @@ func main() {
     // should be replaced with secure credentials
 	grpcSrv := grpc.NewServer(
         grpc.StatsHandler(statsHandler),
+		// image uploads are larger than the 4 MB default
+		grpc.MaxRecvMsgSize(service.MaxImageSize+1<<20),
 		grpc.ChainUnaryInterceptor(
 			interceptors.ServerRequestIDInterceptor(),
 		),
@@ -111,3 +155,12 @@ func main() {
 	grpcSrv.GracefulStop()
 	logger.Info("gRPC server stopped")
 }
+
+func setupRabbitMQTopology(ch *amqp.Channel) error {
+	err := ch.ExchangeDeclare("events_exchange", "topic", true, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to declare exchange: %w", err)
+	}
+
+	return nil
+}
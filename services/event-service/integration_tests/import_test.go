@@ -0,0 +1,148 @@
+package integration_tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	eventstorage "github.com/kay-kewl/ticket-booking-system/services/event-service/internal/storage"
+)
+
+func TestEventImport_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	pgContainer, err := postgres.RunContainer(
+		ctx,
+		testcontainers.WithImage("postgres:16-alpine"),
+		postgres.WithDatabase("test-db"),
+		postgres.WithUsername("user"),
+		postgres.WithPassword("password"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second),
+		),
+	)
+	require.NoError(t, err, "Failed to start postgres container")
+
+	defer func() {
+		require.NoError(t, pgContainer.Terminate(context.Background()), "Failed to terminate postgres container")
+	}()
+
+	// the migrations create their tables in the schema of the search path,
+	// as they do when the service is deployed
+	connStr, err := pgContainer.ConnectionString(ctx, "sslmode=disable", "search_path=event")
+	require.NoError(t, err, "Failed to get connection string")
+
+	pool, err := pgxpool.New(ctx, connStr)
+	require.NoError(t, err, "Failed to create db pool")
+	defer pool.Close()
+
+	applyMigrations(t, pool)
+
+	storage := eventstorage.New(pool, func(key string) string { return key })
+
+	t.Run("Re-Import - Booked Seats Are Left As They Are", func(t *testing.T) {
+		importFile := func(sector string, row int32, tier string, amount int64) []eventstorage.ImportEvent {
+			return []eventstorage.ImportEvent{{
+				Line:        2,
+				ExternalRef: "venue-1",
+				Title:       "Shrek",
+				PriceTiers:  []eventstorage.ImportPriceTier{{Line: 2, Name: tier, Amount: amount, Currency: "RUB"}},
+				Seats: []eventstorage.ImportSeat{
+					{Line: 2, Label: "A1", Sector: sector, Row: row, PriceTier: tier},
+					{Line: 3, Label: "A2", Sector: sector, Row: row, PriceTier: tier},
+				},
+			}}
+		}
+
+		result, err := storage.ImportEvents(ctx, importFile("Parter", 1, "Standard", 150000), 0, false)
+		require.NoError(t, err)
+		require.True(t, result.Applied)
+		require.Equal(t, 2, result.SeatsCreated)
+
+		_, err = pool.Exec(ctx, "UPDATE event.events SET status = 'ON_SALE' WHERE external_ref = 'venue-1'")
+		require.NoError(t, err)
+		_, err = pool.Exec(ctx, "UPDATE event.seats SET status = 'BOOKED' WHERE seat_number = 'A1'")
+		require.NoError(t, err)
+
+		corrected := importFile("Balcony", 2, "VIP", 300000)
+		result, err = storage.ImportEvents(ctx, corrected, 0, true)
+		require.NoError(t, err)
+		require.False(t, result.Applied, "A dry run should not be applied")
+		require.Empty(t, result.Errors, "An event on sale can be re-imported")
+		require.Equal(t, 1, result.SeatsUpdated)
+		require.Equal(t, 1, result.SeatsSkipped, "The dry run should report the booked seat")
+		require.Len(t, result.Warnings, 1)
+		require.Equal(t, 2, result.Warnings[0].Line)
+
+		result, err = storage.ImportEvents(ctx, corrected, 0, false)
+		require.NoError(t, err)
+		require.True(t, result.Applied)
+		require.Equal(t, 1, result.SeatsSkipped)
+
+		var (
+			sector, tier, status string
+			row                  int32
+		)
+		err = pool.QueryRow(
+			ctx,
+			`SELECT s.sector, s.row_number, t.name, s.status FROM event.seats s JOIN event.price_tiers t ON t.id = s.price_tier_id
+			WHERE s.seat_number = 'A1'`,
+		).Scan(&sector, &row, &tier, &status)
+		require.NoError(t, err)
+		require.Equal(t, "Parter", sector, "A booked seat should keep its sector")
+		require.Equal(t, int32(1), row, "A booked seat should keep its row")
+		require.Equal(t, "Standard", tier, "A booked seat should keep its price tier")
+		require.Equal(t, "BOOKED", status)
+
+		err = pool.QueryRow(
+			ctx,
+			`SELECT s.sector, s.row_number, t.name, s.status FROM event.seats s JOIN event.price_tiers t ON t.id = s.price_tier_id
+			WHERE s.seat_number = 'A2'`,
+		).Scan(&sector, &row, &tier, &status)
+		require.NoError(t, err)
+		require.Equal(t, "Balcony", sector, "An available seat should be updated")
+		require.Equal(t, int32(2), row)
+		require.Equal(t, "VIP", tier)
+		require.Equal(t, "AVAILABLE", status)
+	})
+}
+
+// applyMigrations runs the service's own migrations after the tables of
+// other services they refer to.
+func applyMigrations(t *testing.T, pool *pgxpool.Pool) {
+	migrations := []string{
+		`CREATE SCHEMA IF NOT EXISTS auth;`,
+		`CREATE SCHEMA IF NOT EXISTS event;`,
+		`CREATE TABLE IF NOT EXISTS auth.users (id BIGSERIAL PRIMARY KEY);`,
+	}
+
+	files, err := filepath.Glob("../migrations/*.up.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, files, "Migrations should be found")
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		migrations = append(migrations, string(content))
+	}
+
+	for _, migration := range migrations {
+		_, err := pool.Exec(context.Background(), migration)
+		require.NoError(t, err, "Failed to apply migration: "+strings.SplitN(migration, "\n", 2)[0])
+	}
+}
@@ -2,13 +2,18 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+	"github.com/kay-kewl/ticket-booking-system/internal/pagination"
+	"github.com/kay-kewl/ticket-booking-system/services/event-service/internal/service"
+	"github.com/kay-kewl/ticket-booking-system/services/event-service/internal/storage"
 )
 
 const (
@@ -17,8 +22,39 @@ const (
 )
 
 type Events interface {
-	ListEvents(ctx context.Context, pageNumber, pageSize int32) ([]*eventv1.Event, int64, error)
-    GetEvent(ctx context.Context, eventID int64) (*eventv1.Event, error)
+	ListEvents(ctx context.Context, filter storage.EventFilter, pageNumber, pageSize int32, locale string) ([]*eventv1.Event, int64, error)
+	ListEventsAfter(ctx context.Context, filter storage.EventFilter, after *pagination.Cursor, pageSize int32, locale string) ([]*eventv1.Event, *pagination.Cursor, int64, error)
+    GetEvent(ctx context.Context, eventID int64, locale string) (*eventv1.Event, error)
+	CatalogLastModified(ctx context.Context, filter storage.EventFilter) (time.Time, error)
+	UpdateEventStatus(ctx context.Context, eventID int64, status string) (*eventv1.Event, error)
+	CancelEvent(ctx context.Context, eventID int64, reason string) (*eventv1.Event, error)
+	ScheduleSales(ctx context.Context, eventID int64, onSaleAt, offSaleAt *time.Time) (*eventv1.Event, error)
+	SetHoldPolicy(ctx context.Context, eventID int64, holdMinutes int32, maxExtensions *int32) (*eventv1.Event, error)
+	SetPriceTier(ctx context.Context, tier *eventv1.PriceTier, sectors []string, seatIDs []int64) (*eventv1.PriceTier, error)
+	ListPriceTiers(ctx context.Context, eventID int64) ([]*eventv1.PriceTier, error)
+	SetRefundPolicy(ctx context.Context, policy *eventv1.RefundPolicy) (*eventv1.RefundPolicy, error)
+	GetRefundPolicy(ctx context.Context, eventID int64) (*eventv1.RefundPolicy, error)
+	SetPurchaseLimits(ctx context.Context, limits *eventv1.PurchaseLimits) (*eventv1.PurchaseLimits, error)
+	GetPurchaseLimits(ctx context.Context, eventID int64) (*eventv1.PurchaseLimits, error)
+	SetTransferPolicy(ctx context.Context, policy *eventv1.TransferPolicy) (*eventv1.TransferPolicy, error)
+	GetTransferPolicy(ctx context.Context, eventID int64) (*eventv1.TransferPolicy, error)
+	SetInventoryPool(ctx context.Context, pool *eventv1.InventoryPool) (*eventv1.InventoryPool, error)
+	ListInventoryPools(ctx context.Context, eventID int64) ([]*eventv1.InventoryPool, error)
+	SetSectorScore(ctx context.Context, score *eventv1.SectorScore) (*eventv1.SectorScore, error)
+	ListSectorScores(ctx context.Context, eventID int64) ([]*eventv1.SectorScore, error)
+	UpsertCategory(ctx context.Context, category *eventv1.Category) (*eventv1.Category, error)
+	ListCategories(ctx context.Context) ([]*eventv1.Category, error)
+	SetEventCategories(ctx context.Context, eventID int64, slugs []string) (*eventv1.Event, error)
+	SetEventTags(ctx context.Context, eventID int64, tags []string) (*eventv1.Event, error)
+	UpsertCollection(ctx context.Context, collection *eventv1.Collection, eventIDs []int64) (*eventv1.Collection, error)
+	GetCollection(ctx context.Context, slug string) (*eventv1.Collection, error)
+	UploadEventImage(ctx context.Context, eventID int64, kind string, content []byte) (*eventv1.EventImage, error)
+	DeleteEventImage(ctx context.Context, eventID, imageID int64) (*eventv1.Event, error)
+	ImportEvents(ctx context.Context, format string, content []byte, organizerID int64, dryRun bool) (*storage.ImportResult, error)
+	EventsCalendar(ctx context.Context, filter storage.EventFilter) ([]byte, error)
+	SetEventTranslation(ctx context.Context, eventID int64, locale, title, description string) (*eventv1.Event, error)
+	DeleteEventTranslation(ctx context.Context, eventID int64, locale string) (*eventv1.Event, error)
+	TranslationStatus(ctx context.Context, organizerID int64, locales []string) (*eventv1.TranslationStatusReport, error)
 }
 
 type serverAPI struct {
@@ -33,11 +69,6 @@ func Register(gRPCServer *grpc.Server, events Events, log *slog.Logger) {
 
 func (s *serverAPI) ListEvents(ctx context.Context, req *eventv1.ListEventsRequest) (*eventv1.ListEventsResponse, error) {
 	s.log.InfoContext(ctx, "ListEvents request received in event-service")
-	pageNumber := req.GetPageNumber()
-	if pageNumber < 1 {
-		pageNumber = 1
-	}
-
 	pageSize := req.GetPageSize()
 	if pageSize < 1 {
 		pageSize = defaultPageSize
@@ -46,12 +77,52 @@ func (s *serverAPI) ListEvents(ctx context.Context, req *eventv1.ListEventsReque
 		pageSize = maxPageSize
 	}
 
-	events, totalCount, err := s.events.ListEvents(ctx, pageNumber, pageSize)
+	filter := storage.EventFilter{Category: req.GetCategory(), Tag: req.GetTag()}
+
+	// page_number without a token keeps the old OFFSET behaviour for existing clients
+	if req.GetPageToken() == "" && req.GetPageNumber() > 0 {
+		events, totalCount, err := s.events.ListEvents(ctx, filter, req.GetPageNumber(), pageSize, req.GetLocale())
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to list events")
+		}
+
+		return &eventv1.ListEventsResponse{Events: events, TotalCount: &totalCount, LastModified: s.lastModified(ctx, filter)}, nil
+	}
+
+	var after *pagination.Cursor
+	if req.GetPageToken() != "" {
+		cursor, err := pagination.DecodeCursor(req.GetPageToken())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+		after = &cursor
+	}
+
+	events, next, totalCount, err := s.events.ListEventsAfter(ctx, filter, after, pageSize, req.GetLocale())
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to list events")
 	}
 
-	return &eventv1.ListEventsResponse{Events: events, TotalCount: totalCount}, nil
+	resp := &eventv1.ListEventsResponse{Events: events, TotalCount: &totalCount, LastModified: s.lastModified(ctx, filter)}
+	if next != nil {
+		resp.NextPageToken = pagination.EncodeCursor(*next)
+	}
+
+	return resp, nil
+}
+
+// lastModified only feeds HTTP caching, so a failure leaves it out instead of
+// failing the listing.
+func (s *serverAPI) lastModified(ctx context.Context, filter storage.EventFilter) string {
+	lastModified, err := s.events.CatalogLastModified(ctx, filter)
+	if err != nil {
+		s.log.WarnContext(ctx, "Failed to get catalog last modification time", "error", err)
+		return ""
+	}
+	if lastModified.IsZero() {
+		return ""
+	}
+	return lastModified.UTC().Format(time.RFC3339)
 }
 
 func (s *serverAPI) GetEvent(ctx context.Context, req *eventv1.GetEventRequest) (*eventv1.Event, error) {
@@ -61,12 +132,636 @@ func (s *serverAPI) GetEvent(ctx context.Context, req *eventv1.GetEventRequest)
         return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
     }
 
-    event, err := s.events.GetEvent(ctx, req.GetEventId())
+    event, err := s.events.GetEvent(ctx, req.GetEventId(), req.GetLocale())
     if err != nil {
-        // TODO: not found -> codes.NotFound
+        if errors.Is(err, service.ErrEventNotFound) {
+            return nil, status.Error(codes.NotFound, "event not found")
+        }
         s.log.ErrorContext(ctx, "Failed to get event", "error", err)
         return nil, status.Error(codes.Internal, "failed to get event")
     }
 
     return event, nil
 }
+
+func (s *serverAPI) UpdateEventStatus(ctx context.Context, req *eventv1.UpdateEventStatusRequest) (*eventv1.Event, error) {
+	s.log.InfoContext(ctx, "UpdateEventStatus request received", "event_id", req.GetEventId(), "status", req.GetStatus())
+
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	event, err := s.events.UpdateEventStatus(ctx, req.GetEventId(), req.GetStatus())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrEventNotFound):
+			return nil, status.Error(codes.NotFound, "event not found")
+		case errors.Is(err, service.ErrInvalidTransition):
+			return nil, status.Error(codes.FailedPrecondition, "event status transition is not allowed")
+		}
+		s.log.ErrorContext(ctx, "Failed to update event status", "error", err)
+		return nil, status.Error(codes.Internal, "failed to update event status")
+	}
+
+	return event, nil
+}
+
+func (s *serverAPI) CancelEvent(ctx context.Context, req *eventv1.CancelEventRequest) (*eventv1.Event, error) {
+	s.log.InfoContext(ctx, "CancelEvent request received", "event_id", req.GetEventId())
+
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	event, err := s.events.CancelEvent(ctx, req.GetEventId(), req.GetReason())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrEventNotFound):
+			return nil, status.Error(codes.NotFound, "event not found")
+		case errors.Is(err, service.ErrInvalidTransition):
+			return nil, status.Error(codes.FailedPrecondition, "event is already cancelled")
+		}
+		s.log.ErrorContext(ctx, "Failed to cancel event", "error", err)
+		return nil, status.Error(codes.Internal, "failed to cancel event")
+	}
+
+	return event, nil
+}
+
+func (s *serverAPI) ScheduleSales(ctx context.Context, req *eventv1.ScheduleSalesRequest) (*eventv1.Event, error) {
+	s.log.InfoContext(ctx, "ScheduleSales request received", "event_id", req.GetEventId())
+
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	onSaleAt, err := parseOptionalTime(req.GetOnSaleAt())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "on_sale_at must be an RFC 3339 timestamp")
+	}
+	offSaleAt, err := parseOptionalTime(req.GetOffSaleAt())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "off_sale_at must be an RFC 3339 timestamp")
+	}
+
+	event, err := s.events.ScheduleSales(ctx, req.GetEventId(), onSaleAt, offSaleAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrEventNotFound):
+			return nil, status.Error(codes.NotFound, "event not found")
+		case errors.Is(err, service.ErrInvalidSalesWindow):
+			return nil, status.Error(codes.InvalidArgument, "off_sale_at must be after on_sale_at")
+		}
+		s.log.ErrorContext(ctx, "Failed to schedule sales", "error", err)
+		return nil, status.Error(codes.Internal, "failed to schedule sales")
+	}
+
+	return event, nil
+}
+
+func (s *serverAPI) SetHoldPolicy(ctx context.Context, req *eventv1.SetHoldPolicyRequest) (*eventv1.Event, error) {
+	s.log.InfoContext(ctx, "SetHoldPolicy request received", "event_id", req.GetEventId())
+
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	event, err := s.events.SetHoldPolicy(ctx, req.GetEventId(), req.GetHoldMinutes(), req.MaxHoldExtensions)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrEventNotFound):
+			return nil, status.Error(codes.NotFound, "event not found")
+		case errors.Is(err, service.ErrInvalidHoldTime):
+			return nil, status.Error(codes.InvalidArgument, "hold_minutes must be between 0 and 1440")
+		case errors.Is(err, service.ErrInvalidHoldExtensions):
+			return nil, status.Error(codes.InvalidArgument, "max_hold_extensions must be between 0 and 10")
+		}
+		s.log.ErrorContext(ctx, "Failed to set hold policy", "error", err)
+		return nil, status.Error(codes.Internal, "failed to set hold policy")
+	}
+
+	return event, nil
+}
+
+func (s *serverAPI) SetPriceTier(ctx context.Context, req *eventv1.SetPriceTierRequest) (*eventv1.PriceTier, error) {
+	s.log.InfoContext(ctx, "SetPriceTier request received", "event_id", req.GetEventId(), "name", req.GetName())
+
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	tier, err := s.events.SetPriceTier(ctx, &eventv1.PriceTier{
+		EventId:  req.GetEventId(),
+		Name:     req.GetName(),
+		Amount:   req.GetAmount(),
+		Currency: req.GetCurrency(),
+	}, req.GetSectors(), req.GetSeatIds())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPriceTier):
+			return nil, status.Error(codes.InvalidArgument, "price tier needs a name, a non-negative amount and an ISO 4217 currency")
+		case errors.Is(err, service.ErrEventNotFound):
+			return nil, status.Error(codes.NotFound, "event not found")
+		case errors.Is(err, service.ErrSeatNotFound):
+			return nil, status.Error(codes.InvalidArgument, "some seats do not belong to the event")
+		case errors.Is(err, service.ErrCurrencyMismatch):
+			return nil, status.Error(codes.FailedPrecondition, "all price tiers of an event must use the same currency")
+		}
+		s.log.ErrorContext(ctx, "Failed to set price tier", "error", err)
+		return nil, status.Error(codes.Internal, "failed to set price tier")
+	}
+
+	return tier, nil
+}
+
+func (s *serverAPI) ListPriceTiers(ctx context.Context, req *eventv1.ListPriceTiersRequest) (*eventv1.ListPriceTiersResponse, error) {
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	tiers, err := s.events.ListPriceTiers(ctx, req.GetEventId())
+	if err != nil {
+		s.log.ErrorContext(ctx, "Failed to list price tiers", "error", err)
+		return nil, status.Error(codes.Internal, "failed to list price tiers")
+	}
+
+	return &eventv1.ListPriceTiersResponse{Tiers: tiers}, nil
+}
+
+func (s *serverAPI) SetRefundPolicy(ctx context.Context, req *eventv1.RefundPolicy) (*eventv1.RefundPolicy, error) {
+	s.log.InfoContext(ctx, "SetRefundPolicy request received", "event_id", req.GetEventId())
+
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	policy, err := s.events.SetRefundPolicy(ctx, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidRefundPolicy):
+			return nil, status.Error(codes.InvalidArgument, "refund policy needs non-negative hours, a partial refund cutoff not before the full refund one and a percent between 0 and 100")
+		case errors.Is(err, service.ErrEventNotFound):
+			return nil, status.Error(codes.NotFound, "event not found")
+		}
+		s.log.ErrorContext(ctx, "Failed to set refund policy", "error", err)
+		return nil, status.Error(codes.Internal, "failed to set refund policy")
+	}
+
+	return policy, nil
+}
+
+func (s *serverAPI) GetRefundPolicy(ctx context.Context, req *eventv1.GetRefundPolicyRequest) (*eventv1.RefundPolicy, error) {
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	policy, err := s.events.GetRefundPolicy(ctx, req.GetEventId())
+	if err != nil {
+		if errors.Is(err, service.ErrEventNotFound) {
+			return nil, status.Error(codes.NotFound, "event not found")
+		}
+		s.log.ErrorContext(ctx, "Failed to get refund policy", "error", err)
+		return nil, status.Error(codes.Internal, "failed to get refund policy")
+	}
+
+	return policy, nil
+}
+
+func (s *serverAPI) SetPurchaseLimits(ctx context.Context, req *eventv1.PurchaseLimits) (*eventv1.PurchaseLimits, error) {
+	s.log.InfoContext(ctx, "SetPurchaseLimits request received", "event_id", req.GetEventId())
+
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	limits, err := s.events.SetPurchaseLimits(ctx, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPurchaseLimits):
+			return nil, status.Error(codes.InvalidArgument, "purchase limits must not be negative")
+		case errors.Is(err, service.ErrEventNotFound):
+			return nil, status.Error(codes.NotFound, "event not found")
+		}
+		s.log.ErrorContext(ctx, "Failed to set purchase limits", "error", err)
+		return nil, status.Error(codes.Internal, "failed to set purchase limits")
+	}
+
+	return limits, nil
+}
+
+func (s *serverAPI) GetPurchaseLimits(ctx context.Context, req *eventv1.GetPurchaseLimitsRequest) (*eventv1.PurchaseLimits, error) {
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	limits, err := s.events.GetPurchaseLimits(ctx, req.GetEventId())
+	if err != nil {
+		if errors.Is(err, service.ErrEventNotFound) {
+			return nil, status.Error(codes.NotFound, "event not found")
+		}
+		s.log.ErrorContext(ctx, "Failed to get purchase limits", "error", err)
+		return nil, status.Error(codes.Internal, "failed to get purchase limits")
+	}
+
+	return limits, nil
+}
+
+func (s *serverAPI) SetTransferPolicy(ctx context.Context, req *eventv1.TransferPolicy) (*eventv1.TransferPolicy, error) {
+	s.log.InfoContext(ctx, "SetTransferPolicy request received", "event_id", req.GetEventId())
+
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	policy, err := s.events.SetTransferPolicy(ctx, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidTransferPolicy):
+			return nil, status.Error(codes.InvalidArgument, "cutoff_hours and max_transfers must not be negative")
+		case errors.Is(err, service.ErrEventNotFound):
+			return nil, status.Error(codes.NotFound, "event not found")
+		}
+		s.log.ErrorContext(ctx, "Failed to set transfer policy", "error", err)
+		return nil, status.Error(codes.Internal, "failed to set transfer policy")
+	}
+
+	return policy, nil
+}
+
+func (s *serverAPI) GetTransferPolicy(ctx context.Context, req *eventv1.GetTransferPolicyRequest) (*eventv1.TransferPolicy, error) {
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	policy, err := s.events.GetTransferPolicy(ctx, req.GetEventId())
+	if err != nil {
+		if errors.Is(err, service.ErrEventNotFound) {
+			return nil, status.Error(codes.NotFound, "event not found")
+		}
+		s.log.ErrorContext(ctx, "Failed to get transfer policy", "error", err)
+		return nil, status.Error(codes.Internal, "failed to get transfer policy")
+	}
+
+	return policy, nil
+}
+
+func (s *serverAPI) SetInventoryPool(ctx context.Context, req *eventv1.SetInventoryPoolRequest) (*eventv1.InventoryPool, error) {
+	s.log.InfoContext(ctx, "SetInventoryPool request received", "event_id", req.GetEventId(), "name", req.GetName())
+
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	pool, err := s.events.SetInventoryPool(ctx, &eventv1.InventoryPool{
+		EventId:     req.GetEventId(),
+		Name:        req.GetName(),
+		Capacity:    req.GetCapacity(),
+		PriceTierId: req.GetPriceTierId(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPool):
+			return nil, status.Error(codes.InvalidArgument, "pool needs a name and a non-negative capacity")
+		case errors.Is(err, service.ErrEventNotFound):
+			return nil, status.Error(codes.NotFound, "event not found")
+		case errors.Is(err, service.ErrPriceTierNotFound):
+			return nil, status.Error(codes.InvalidArgument, "price tier does not belong to the event")
+		case errors.Is(err, service.ErrCapacityBelowAllocated):
+			return nil, status.Error(codes.FailedPrecondition, "capacity is below the tickets already sold or held")
+		}
+		s.log.ErrorContext(ctx, "Failed to set inventory pool", "error", err)
+		return nil, status.Error(codes.Internal, "failed to set inventory pool")
+	}
+
+	return pool, nil
+}
+
+func (s *serverAPI) ListInventoryPools(ctx context.Context, req *eventv1.ListInventoryPoolsRequest) (*eventv1.ListInventoryPoolsResponse, error) {
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	pools, err := s.events.ListInventoryPools(ctx, req.GetEventId())
+	if err != nil {
+		s.log.ErrorContext(ctx, "Failed to list inventory pools", "error", err)
+		return nil, status.Error(codes.Internal, "failed to list inventory pools")
+	}
+
+	return &eventv1.ListInventoryPoolsResponse{Pools: pools}, nil
+}
+
+func (s *serverAPI) SetSectorScore(ctx context.Context, req *eventv1.SetSectorScoreRequest) (*eventv1.SectorScore, error) {
+	s.log.InfoContext(ctx, "SetSectorScore request received", "event_id", req.GetEventId(), "sector", req.GetSector(), "score", req.GetScore())
+
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	score, err := s.events.SetSectorScore(ctx, &eventv1.SectorScore{
+		EventId: req.GetEventId(),
+		Sector:  req.GetSector(),
+		Score:   req.GetScore(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidSectorScore):
+			return nil, status.Error(codes.InvalidArgument, "sector is required and score must be between 0 and 100")
+		case errors.Is(err, service.ErrEventNotFound):
+			return nil, status.Error(codes.NotFound, "event not found")
+		}
+		s.log.ErrorContext(ctx, "Failed to set sector score", "error", err)
+		return nil, status.Error(codes.Internal, "failed to set sector score")
+	}
+
+	return score, nil
+}
+
+func (s *serverAPI) ListSectorScores(ctx context.Context, req *eventv1.ListSectorScoresRequest) (*eventv1.ListSectorScoresResponse, error) {
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	scores, err := s.events.ListSectorScores(ctx, req.GetEventId())
+	if err != nil {
+		s.log.ErrorContext(ctx, "Failed to list sector scores", "error", err)
+		return nil, status.Error(codes.Internal, "failed to list sector scores")
+	}
+
+	return &eventv1.ListSectorScoresResponse{Scores: scores}, nil
+}
+
+func (s *serverAPI) UpsertCategory(ctx context.Context, req *eventv1.UpsertCategoryRequest) (*eventv1.Category, error) {
+	s.log.InfoContext(ctx, "UpsertCategory request received", "slug", req.GetSlug())
+
+	category, err := s.events.UpsertCategory(ctx, &eventv1.Category{
+		Slug:       req.GetSlug(),
+		Name:       req.GetName(),
+		ParentSlug: req.GetParentSlug(),
+		Position:   req.GetPosition(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidCategory):
+			return nil, status.Error(codes.InvalidArgument, "category needs a lowercase slug and a name")
+		case errors.Is(err, service.ErrCategoryNotFound):
+			return nil, status.Error(codes.InvalidArgument, "parent category not found")
+		case errors.Is(err, service.ErrCategoryCycle):
+			return nil, status.Error(codes.FailedPrecondition, "category can not be nested under its own subcategory")
+		}
+		s.log.ErrorContext(ctx, "Failed to upsert category", "error", err)
+		return nil, status.Error(codes.Internal, "failed to upsert category")
+	}
+
+	return category, nil
+}
+
+func (s *serverAPI) ListCategories(ctx context.Context, req *eventv1.ListCategoriesRequest) (*eventv1.ListCategoriesResponse, error) {
+	categories, err := s.events.ListCategories(ctx)
+	if err != nil {
+		s.log.ErrorContext(ctx, "Failed to list categories", "error", err)
+		return nil, status.Error(codes.Internal, "failed to list categories")
+	}
+
+	return &eventv1.ListCategoriesResponse{Categories: categories}, nil
+}
+
+func (s *serverAPI) SetEventCategories(ctx context.Context, req *eventv1.SetEventCategoriesRequest) (*eventv1.Event, error) {
+	s.log.InfoContext(ctx, "SetEventCategories request received", "event_id", req.GetEventId())
+
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	event, err := s.events.SetEventCategories(ctx, req.GetEventId(), req.GetCategories())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrEventNotFound):
+			return nil, status.Error(codes.NotFound, "event not found")
+		case errors.Is(err, service.ErrCategoryNotFound):
+			return nil, status.Error(codes.InvalidArgument, "unknown category")
+		}
+		s.log.ErrorContext(ctx, "Failed to set event categories", "error", err)
+		return nil, status.Error(codes.Internal, "failed to set event categories")
+	}
+
+	return event, nil
+}
+
+func (s *serverAPI) SetEventTags(ctx context.Context, req *eventv1.SetEventTagsRequest) (*eventv1.Event, error) {
+	s.log.InfoContext(ctx, "SetEventTags request received", "event_id", req.GetEventId())
+
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	event, err := s.events.SetEventTags(ctx, req.GetEventId(), req.GetTags())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidTags):
+			return nil, status.Error(codes.InvalidArgument, "tags must be non-empty, at most 50 characters and at most 20 per event")
+		case errors.Is(err, service.ErrEventNotFound):
+			return nil, status.Error(codes.NotFound, "event not found")
+		}
+		s.log.ErrorContext(ctx, "Failed to set event tags", "error", err)
+		return nil, status.Error(codes.Internal, "failed to set event tags")
+	}
+
+	return event, nil
+}
+
+func (s *serverAPI) UpsertCollection(ctx context.Context, req *eventv1.UpsertCollectionRequest) (*eventv1.Collection, error) {
+	s.log.InfoContext(ctx, "UpsertCollection request received", "slug", req.GetSlug())
+
+	collection, err := s.events.UpsertCollection(ctx, &eventv1.Collection{
+		Slug:        req.GetSlug(),
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+	}, req.GetEventIds())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidCollection):
+			return nil, status.Error(codes.InvalidArgument, "collection needs a lowercase slug, a title and distinct events")
+		case errors.Is(err, service.ErrEventNotFound):
+			return nil, status.Error(codes.InvalidArgument, "some events do not exist")
+		}
+		s.log.ErrorContext(ctx, "Failed to upsert collection", "error", err)
+		return nil, status.Error(codes.Internal, "failed to upsert collection")
+	}
+
+	return collection, nil
+}
+
+func (s *serverAPI) GetCollection(ctx context.Context, req *eventv1.GetCollectionRequest) (*eventv1.Collection, error) {
+	if req.GetSlug() == "" {
+		return nil, status.Error(codes.InvalidArgument, "slug is required")
+	}
+
+	collection, err := s.events.GetCollection(ctx, req.GetSlug())
+	if err != nil {
+		if errors.Is(err, service.ErrCollectionNotFound) {
+			return nil, status.Error(codes.NotFound, "collection not found")
+		}
+		s.log.ErrorContext(ctx, "Failed to get collection", "error", err)
+		return nil, status.Error(codes.Internal, "failed to get collection")
+	}
+
+	return collection, nil
+}
+
+func (s *serverAPI) UploadEventImage(ctx context.Context, req *eventv1.UploadEventImageRequest) (*eventv1.EventImage, error) {
+	s.log.InfoContext(ctx, "UploadEventImage request received", "event_id", req.GetEventId(), "kind", req.GetKind(), "size", len(req.GetContent()))
+
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	image, err := s.events.UploadEventImage(ctx, req.GetEventId(), req.GetKind(), req.GetContent())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidImage):
+			return nil, status.Error(codes.InvalidArgument, "image must be a JPEG, PNG or GIF of at most 10 MB, from 200 to 10000 pixels per side, uploaded as POSTER or GALLERY")
+		case errors.Is(err, service.ErrEventNotFound):
+			return nil, status.Error(codes.NotFound, "event not found")
+		case errors.Is(err, service.ErrGalleryFull):
+			return nil, status.Error(codes.FailedPrecondition, "the gallery already has the maximum number of images")
+		}
+		s.log.ErrorContext(ctx, "Failed to upload event image", "error", err)
+		return nil, status.Error(codes.Internal, "failed to upload event image")
+	}
+
+	return image, nil
+}
+
+func (s *serverAPI) DeleteEventImage(ctx context.Context, req *eventv1.DeleteEventImageRequest) (*eventv1.Event, error) {
+	s.log.InfoContext(ctx, "DeleteEventImage request received", "event_id", req.GetEventId(), "image_id", req.GetImageId())
+
+	if req.GetEventId() <= 0 || req.GetImageId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id and image_id must be positive")
+	}
+
+	event, err := s.events.DeleteEventImage(ctx, req.GetEventId(), req.GetImageId())
+	if err != nil {
+		if errors.Is(err, service.ErrImageNotFound) || errors.Is(err, service.ErrEventNotFound) {
+			return nil, status.Error(codes.NotFound, "image not found")
+		}
+		s.log.ErrorContext(ctx, "Failed to delete event image", "error", err)
+		return nil, status.Error(codes.Internal, "failed to delete event image")
+	}
+
+	return event, nil
+}
+
+func (s *serverAPI) ImportEvents(ctx context.Context, req *eventv1.ImportEventsRequest) (*eventv1.ImportEventsResponse, error) {
+	s.log.InfoContext(ctx, "ImportEvents request received", "format", req.GetFormat(), "size", len(req.GetContent()), "dry_run", req.GetDryRun())
+
+	result, err := s.events.ImportEvents(ctx, req.GetFormat(), req.GetContent(), req.GetOrganizerId(), req.GetDryRun())
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidImport) {
+			return nil, status.Error(codes.InvalidArgument, "import must be a csv or json file of at most 5 MB")
+		}
+		s.log.ErrorContext(ctx, "Failed to import events", "error", err)
+		return nil, status.Error(codes.Internal, "failed to import events")
+	}
+
+	resp := &eventv1.ImportEventsResponse{
+		Applied:       result.Applied,
+		EventsCreated: int32(result.EventsCreated),
+		EventsUpdated: int32(result.EventsUpdated),
+		SeatsCreated:  int32(result.SeatsCreated),
+		SeatsUpdated:  int32(result.SeatsUpdated),
+		SeatsSkipped:  int32(result.SeatsSkipped),
+	}
+	for _, lineErr := range result.Errors {
+		resp.Errors = append(resp.Errors, &eventv1.ImportError{Line: int32(lineErr.Line), Message: lineErr.Message})
+	}
+	for _, warning := range result.Warnings {
+		resp.Warnings = append(resp.Warnings, &eventv1.ImportError{Line: int32(warning.Line), Message: warning.Message})
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) GetEventsCalendar(ctx context.Context, req *eventv1.GetEventsCalendarRequest) (*eventv1.CalendarFeed, error) {
+	content, err := s.events.EventsCalendar(ctx, storage.EventFilter{
+		Category:    req.GetCategory(),
+		OrganizerID: req.GetOrganizerId(),
+	})
+	if err != nil {
+		s.log.ErrorContext(ctx, "Failed to render events calendar", "error", err)
+		return nil, status.Error(codes.Internal, "failed to render events calendar")
+	}
+
+	return &eventv1.CalendarFeed{Content: string(content)}, nil
+}
+
+func (s *serverAPI) SetEventTranslation(ctx context.Context, req *eventv1.SetEventTranslationRequest) (*eventv1.Event, error) {
+	s.log.InfoContext(ctx, "SetEventTranslation request received", "event_id", req.GetEventId(), "locale", req.GetLocale())
+
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	event, err := s.events.SetEventTranslation(ctx, req.GetEventId(), req.GetLocale(), req.GetTitle(), req.GetDescription())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidLocale):
+			return nil, status.Error(codes.InvalidArgument, "locale must be a language tag other than the default locale")
+		case errors.Is(err, service.ErrInvalidTranslation):
+			return nil, status.Error(codes.InvalidArgument, service.ErrInvalidTranslation.Error())
+		case errors.Is(err, service.ErrEventNotFound):
+			return nil, status.Error(codes.NotFound, "event not found")
+		}
+		s.log.ErrorContext(ctx, "Failed to set event translation", "error", err)
+		return nil, status.Error(codes.Internal, "failed to set event translation")
+	}
+
+	return event, nil
+}
+
+func (s *serverAPI) DeleteEventTranslation(ctx context.Context, req *eventv1.DeleteEventTranslationRequest) (*eventv1.Event, error) {
+	s.log.InfoContext(ctx, "DeleteEventTranslation request received", "event_id", req.GetEventId(), "locale", req.GetLocale())
+
+	if req.GetEventId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be positive")
+	}
+
+	event, err := s.events.DeleteEventTranslation(ctx, req.GetEventId(), req.GetLocale())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidLocale):
+			return nil, status.Error(codes.InvalidArgument, "invalid locale")
+		case errors.Is(err, service.ErrTranslationNotFound), errors.Is(err, service.ErrEventNotFound):
+			return nil, status.Error(codes.NotFound, "translation not found")
+		}
+		s.log.ErrorContext(ctx, "Failed to delete event translation", "error", err)
+		return nil, status.Error(codes.Internal, "failed to delete event translation")
+	}
+
+	return event, nil
+}
+
+func (s *serverAPI) GetTranslationStatus(ctx context.Context, req *eventv1.GetTranslationStatusRequest) (*eventv1.TranslationStatusReport, error) {
+	s.log.InfoContext(ctx, "GetTranslationStatus request received", "organizer_id", req.GetOrganizerId())
+
+	report, err := s.events.TranslationStatus(ctx, req.GetOrganizerId(), req.GetLocales())
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidLocale) {
+			return nil, status.Error(codes.InvalidArgument, "invalid locale")
+		}
+		s.log.ErrorContext(ctx, "Failed to build translation status report", "error", err)
+		return nil, status.Error(codes.Internal, "failed to build translation status report")
+	}
+
+	return report, nil
+}
+
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
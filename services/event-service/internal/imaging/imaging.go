@@ -0,0 +1,131 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+
+	// decoders for image.Decode
+	_ "image/gif"
+	_ "image/png"
+)
+
+const (
+	MinDimension = 200
+	MaxDimension = 10000
+	// guards against decompression bombs: a small file that decodes into gigabytes
+	maxPixels   = 40_000_000
+	jpegQuality = 85
+)
+
+var ErrUnsupportedFormat = errors.New("image must be a JPEG, PNG or GIF")
+var ErrInvalidDimensions = errors.New("image is too small or too large")
+
+// Sizes are the standard renditions, each fitted into a square box of the given side.
+var Sizes = []struct {
+	Name string
+	Box  int
+}{
+	{"thumbnail", 320},
+	{"medium", 800},
+	{"large", 1600},
+}
+
+type Rendition struct {
+	Name   string
+	Width  int
+	Height int
+	// JPEG-encoded
+	Data []byte
+}
+
+// Renditions validates the uploaded image and renders it in every standard
+// size. Images are never upscaled, transparency is flattened onto white.
+func Renditions(content []byte) ([]Rendition, error) {
+	const op = "imaging.Renditions"
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil || (format != "jpeg" && format != "png" && format != "gif") {
+		return nil, fmt.Errorf("%s: %w", op, ErrUnsupportedFormat)
+	}
+	if config.Width < MinDimension || config.Height < MinDimension ||
+		config.Width > MaxDimension || config.Height > MaxDimension ||
+		config.Width*config.Height > maxPixels {
+		return nil, fmt.Errorf("%s: %dx%d: %w", op, config.Width, config.Height, ErrInvalidDimensions)
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, ErrUnsupportedFormat)
+	}
+
+	src := image.NewRGBA(image.Rect(0, 0, decoded.Bounds().Dx(), decoded.Bounds().Dy()))
+	draw.Draw(src, src.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(src, src.Bounds(), decoded, decoded.Bounds().Min, draw.Over)
+
+	renditions := make([]Rendition, 0, len(Sizes))
+	for _, size := range Sizes {
+		width, height := fit(src.Bounds().Dx(), src.Bounds().Dy(), size.Box)
+
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, downscale(src, width, height), &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, fmt.Errorf("%s: failed to encode %s: %w", op, size.Name, err)
+		}
+
+		renditions = append(renditions, Rendition{Name: size.Name, Width: width, Height: height, Data: buf.Bytes()})
+	}
+
+	return renditions, nil
+}
+
+// fit scales width and height down to fit into box, keeping the aspect ratio.
+func fit(width, height, box int) (int, int) {
+	if width <= box && height <= box {
+		return width, height
+	}
+	if width >= height {
+		return box, max(1, height*box/width)
+	}
+	return max(1, width*box/height), box
+}
+
+// downscale resizes by averaging the source pixels that fall into each
+// destination pixel, which avoids the aliasing of nearest-neighbour sampling.
+func downscale(src *image.RGBA, width, height int) *image.RGBA {
+	srcWidth, srcHeight := src.Bounds().Dx(), src.Bounds().Dy()
+	if width == srcWidth && height == srcHeight {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*srcHeight/height, max((y+1)*srcHeight/height, y*srcHeight/height+1)
+		for x := 0; x < width; x++ {
+			x0, x1 := x*srcWidth/width, max((x+1)*srcWidth/width, x*srcWidth/width+1)
+
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride+x0*4 : sy*src.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					r += int(row[i])
+					g += int(row[i+1])
+					b += int(row[i+2])
+					a += int(row[i+3])
+					n++
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
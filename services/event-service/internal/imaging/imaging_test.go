@@ -0,0 +1,81 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	img.Set(0, 0, color.Transparent)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestRenditionsFitIntoBoxes(t *testing.T) {
+	renditions, err := Renditions(encodePNG(t, 2000, 1000))
+	if err != nil {
+		t.Fatalf("Renditions() error = %v", err)
+	}
+
+	want := map[string][2]int{"thumbnail": {320, 160}, "medium": {800, 400}, "large": {1600, 800}}
+	if len(renditions) != len(want) {
+		t.Fatalf("got %d renditions, want %d", len(renditions), len(want))
+	}
+	for _, r := range renditions {
+		if size := want[r.Name]; r.Width != size[0] || r.Height != size[1] {
+			t.Errorf("%s is %dx%d, want %dx%d", r.Name, r.Width, r.Height, size[0], size[1])
+		}
+
+		decoded, err := jpeg.Decode(bytes.NewReader(r.Data))
+		if err != nil {
+			t.Fatalf("%s is not a JPEG: %v", r.Name, err)
+		}
+		if b := decoded.Bounds(); b.Dx() != r.Width || b.Dy() != r.Height {
+			t.Errorf("%s encoded as %v", r.Name, b)
+		}
+	}
+}
+
+func TestRenditionsNeverUpscale(t *testing.T) {
+	renditions, err := Renditions(encodePNG(t, 300, 600))
+	if err != nil {
+		t.Fatalf("Renditions() error = %v", err)
+	}
+
+	for _, r := range renditions {
+		switch r.Name {
+		case "thumbnail":
+			if r.Width != 160 || r.Height != 320 {
+				t.Errorf("thumbnail is %dx%d, want 160x320", r.Width, r.Height)
+			}
+		default:
+			if r.Width != 300 || r.Height != 600 {
+				t.Errorf("%s is %dx%d, want the original 300x600", r.Name, r.Width, r.Height)
+			}
+		}
+	}
+}
+
+func TestRenditionsRejectInvalidImages(t *testing.T) {
+	if _, err := Renditions([]byte("%PDF-1.4")); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("non-image error = %v, want ErrUnsupportedFormat", err)
+	}
+	if _, err := Renditions(encodePNG(t, 100, 400)); !errors.Is(err, ErrInvalidDimensions) {
+		t.Errorf("small image error = %v, want ErrInvalidDimensions", err)
+	}
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+	"github.com/kay-kewl/ticket-booking-system/internal/cache"
+	"github.com/kay-kewl/ticket-booking-system/internal/pagination"
+	"github.com/kay-kewl/ticket-booking-system/services/event-service/internal/storage"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	catalogCacheSize = 1000
+	// bounds staleness of changes this instance is not notified about, such
+	// as edits made directly in the database
+	catalogCacheTTL = time.Minute
+)
+
+// eventPage is a cached result of one of the listing queries.
+type eventPage struct {
+	events []*eventv1.Event
+	total  int64
+	next   *pagination.Cursor
+}
+
+// catalogCache is a read-through cache of single events and listing pages.
+// A change of any event drops all pages, since it can move the event into
+// or out of any filtered listing.
+type catalogCache struct {
+	events *cache.LRU[int64, *eventv1.Event]
+	pages  *cache.LRU[string, eventPage]
+	// last modification time per filter
+	modified *cache.LRU[string, time.Time]
+	// translations per event, empty for events without any
+	translations *cache.LRU[int64, map[string]storage.Translation]
+	// bumped on every invalidation, so that a query that started before it
+	// does not put its stale result into the cache
+	generation atomic.Uint64
+}
+
+func newCatalogCache() *catalogCache {
+	return &catalogCache{
+		events: cache.NewLRU[int64, *eventv1.Event](catalogCacheSize, catalogCacheTTL),
+		pages:  cache.NewLRU[string, eventPage](catalogCacheSize, catalogCacheTTL),
+		modified: cache.NewLRU[string, time.Time](catalogCacheSize, catalogCacheTTL),
+		translations: cache.NewLRU[int64, map[string]storage.Translation](catalogCacheSize, catalogCacheTTL),
+	}
+}
+
+func (c *catalogCache) getEvent(eventID int64) (*eventv1.Event, bool) {
+	event, ok := c.events.Get(eventID)
+	if !ok {
+		return nil, false
+	}
+	return proto.Clone(event).(*eventv1.Event), true
+}
+
+func (c *catalogCache) addEvent(generation uint64, event *eventv1.Event) {
+	if c.generation.Load() != generation {
+		return
+	}
+	c.events.Add(event.Id, proto.Clone(event).(*eventv1.Event))
+}
+
+func (c *catalogCache) getPage(key string) (eventPage, bool) {
+	page, ok := c.pages.Get(key)
+	if !ok {
+		return eventPage{}, false
+	}
+	page.events = cloneEvents(page.events)
+	return page, true
+}
+
+func (c *catalogCache) addPage(generation uint64, key string, page eventPage) {
+	if c.generation.Load() != generation {
+		return
+	}
+	page.events = cloneEvents(page.events)
+	c.pages.Add(key, page)
+}
+
+func (c *catalogCache) addLastModified(generation uint64, key string, lastModified time.Time) {
+	if c.generation.Load() != generation {
+		return
+	}
+	c.modified.Add(key, lastModified)
+}
+
+func (c *catalogCache) addTranslations(generation uint64, eventID int64, translations map[string]storage.Translation) {
+	if c.generation.Load() != generation {
+		return
+	}
+	c.translations.Add(eventID, translations)
+}
+
+func (c *catalogCache) invalidateEvent(eventID int64) {
+	c.generation.Add(1)
+	c.events.Remove(eventID)
+	c.translations.Remove(eventID)
+	c.pages.Purge()
+	c.modified.Purge()
+}
+
+func (c *catalogCache) purge() {
+	c.generation.Add(1)
+	c.events.Purge()
+	c.pages.Purge()
+	c.modified.Purge()
+	c.translations.Purge()
+}
+
+// InvalidateEvent drops the cached copies of an event changed elsewhere,
+// e.g. by the lifecycle worker or another instance of the service.
+func (e *Events) InvalidateEvent(eventID int64) {
+	e.catalog.invalidateEvent(eventID)
+}
+
+// InvalidateCatalog drops everything cached, for when change notifications
+// may have been missed.
+func (e *Events) InvalidateCatalog() {
+	e.catalog.purge()
+}
+
+func filterKey(filter storage.EventFilter) string {
+	return fmt.Sprintf("%s|%s|%d", filter.Category, filter.Tag, filter.OrganizerID)
+}
+
+func offsetPageKey(filter storage.EventFilter, pageNumber, pageSize int32) string {
+	return fmt.Sprintf("offset|%s|%d|%d", filterKey(filter), pageNumber, pageSize)
+}
+
+func cursorPageKey(filter storage.EventFilter, after *pagination.Cursor, pageSize int32) string {
+	cursor := "first"
+	if after != nil {
+		cursor = pagination.EncodeCursor(*after)
+	}
+	return fmt.Sprintf("cursor|%s|%s|%d", filterKey(filter), cursor, pageSize)
+}
+
+func cloneEvents(events []*eventv1.Event) []*eventv1.Event {
+	cloned := make([]*eventv1.Event, len(events))
+	for i, event := range events {
+		cloned[i] = proto.Clone(event).(*eventv1.Event)
+	}
+	return cloned
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"testing"
+
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogCacheReturnsCopies(t *testing.T) {
+	c := newCatalogCache()
+	c.addEvent(c.generation.Load(), &eventv1.Event{Id: 1, Title: "Shrek"})
+
+	event, ok := c.getEvent(1)
+	require.True(t, ok)
+	event.Title = "changed by a caller"
+
+	event, ok = c.getEvent(1)
+	require.True(t, ok)
+	require.Equal(t, "Shrek", event.Title, "callers must not be able to change the cached event")
+}
+
+func TestCatalogCacheInvalidation(t *testing.T) {
+	c := newCatalogCache()
+	generation := c.generation.Load()
+	c.addEvent(generation, &eventv1.Event{Id: 1})
+	c.addEvent(generation, &eventv1.Event{Id: 2})
+	c.addPage(generation, "page", eventPage{events: []*eventv1.Event{{Id: 1}, {Id: 2}}, total: 2})
+
+	c.invalidateEvent(1)
+
+	_, ok := c.getEvent(1)
+	require.False(t, ok)
+	_, ok = c.getEvent(2)
+	require.True(t, ok, "other events stay cached")
+	_, ok = c.getPage("page")
+	require.False(t, ok, "pages are dropped on any change")
+
+	// a query that started before the invalidation must not cache its result
+	c.addEvent(generation, &eventv1.Event{Id: 1, Status: "ON_SALE"})
+	_, ok = c.getEvent(1)
+	require.False(t, ok)
+}
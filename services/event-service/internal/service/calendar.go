@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+	"github.com/kay-kewl/ticket-booking-system/internal/ical"
+	"github.com/kay-kewl/ticket-booking-system/services/event-service/internal/storage"
+)
+
+const maxCalendarEvents = 1000
+
+// EventsCalendar renders the upcoming events matching the filter as an
+// iCalendar feed.
+func (e *Events) EventsCalendar(ctx context.Context, filter storage.EventFilter) ([]byte, error) {
+	const op = "service.EventsCalendar"
+
+	events, err := e.eventProvider.ListUpcomingEvents(ctx, normalizeFilter(filter), maxCalendarEvents)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	name := "Events"
+	if filter.Category != "" {
+		name += ": " + filter.Category
+	}
+
+	cal := ical.Calendar{Name: name}
+	for _, event := range events {
+		start, err := time.Parse(time.RFC3339, event.GetStartsAt())
+		if err != nil {
+			return nil, fmt.Errorf("%s: event %d: %w", op, event.GetId(), err)
+		}
+		cal.Events = append(cal.Events, ical.Event{
+			UID:         ical.EventUID(event.GetId()),
+			Start:       start,
+			Duration:    ical.DefaultEventDuration,
+			Summary:     event.GetTitle(),
+			Description: event.GetDescription(),
+			Status:      calendarStatus(event),
+		})
+	}
+
+	return ical.Render(cal, time.Now()), nil
+}
+
+func calendarStatus(event *eventv1.Event) string {
+	if event.GetStatus() == StatusCancelled {
+		return "CANCELLED"
+	}
+	return "CONFIRMED"
+}
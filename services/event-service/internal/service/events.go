@@ -2,27 +2,232 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+	"github.com/kay-kewl/ticket-booking-system/internal/blobstore"
+	"github.com/kay-kewl/ticket-booking-system/internal/pagination"
+	"github.com/kay-kewl/ticket-booking-system/services/event-service/internal/storage"
+)
+
+var ErrEventNotFound = errors.New("event not found")
+var ErrInvalidTransition = errors.New("event status transition is not allowed")
+var ErrInvalidSalesWindow = errors.New("off-sale time must be after on-sale time")
+var ErrInvalidHoldTime = errors.New("hold time must be between 1 minute and a day")
+var ErrInvalidHoldExtensions = errors.New("hold extensions must be between 0 and 10")
+
+const (
+	maxHoldMinutes    = 24 * 60
+	maxHoldExtensions = 10
 )
 
 type EventProvider interface {
-	ListEvents(ctx context.Context, pageNumber, pageSize int32) ([]*eventv1.Event, int64, error)
+	ListEvents(ctx context.Context, filter storage.EventFilter, pageNumber, pageSize int32) ([]*eventv1.Event, int64, error)
+	ListEventsAfter(ctx context.Context, filter storage.EventFilter, after *pagination.Cursor, pageSize int32) ([]*eventv1.Event, *pagination.Cursor, int64, error)
     GetEvent(ctx context.Context, eventID int64) (*eventv1.Event, error)
+	CatalogLastModified(ctx context.Context, filter storage.EventFilter) (time.Time, error)
+	ListUpcomingEvents(ctx context.Context, filter storage.EventFilter, limit int32) ([]*eventv1.Event, error)
+}
+
+type EventLifecycle interface {
+	SetEventStatus(ctx context.Context, eventID int64, status string, allowedFrom []string, reason string) (*eventv1.Event, error)
+	ScheduleSales(ctx context.Context, eventID int64, onSaleAt, offSaleAt *time.Time) (*eventv1.Event, error)
+	SetHoldPolicy(ctx context.Context, eventID int64, holdMinutes int32, maxExtensions *int32) (*eventv1.Event, error)
 }
 
 type Events struct {
-	eventProvider EventProvider
+	eventProvider  EventProvider
+	eventLifecycle EventLifecycle
+	priceTiers     PriceTierStorage
+	inventory      InventoryStorage
+	taxonomy       TaxonomyStorage
+	images         ImageStorage
+	blobs          blobstore.Store
+	imports        ImportStorage
+	translations   TranslationStorage
+	// locale of the title and description stored with the events
+	defaultLocale  string
+	catalog        *catalogCache
 }
 
-func New(eventProvider EventProvider) *Events {
-	return &Events{eventProvider: eventProvider}
+func New(eventProvider EventProvider, eventLifecycle EventLifecycle, priceTiers PriceTierStorage, inventory InventoryStorage, taxonomy TaxonomyStorage, images ImageStorage, blobs blobstore.Store, imports ImportStorage, translations TranslationStorage, defaultLocale string) *Events {
+	return &Events{
+		eventProvider:  eventProvider,
+		eventLifecycle: eventLifecycle,
+		priceTiers:     priceTiers,
+		inventory:      inventory,
+		taxonomy:       taxonomy,
+		images:         images,
+		blobs:          blobs,
+		imports:        imports,
+		translations:   translations,
+		defaultLocale:  defaultLocale,
+		catalog:        newCatalogCache(),
+	}
 }
 
-func (e *Events) ListEvents(ctx context.Context, pageNumber, pageSize int32) ([]*eventv1.Event, int64, error) {
-	return e.eventProvider.ListEvents(ctx, pageNumber, pageSize)
+func (e *Events) ListEvents(ctx context.Context, filter storage.EventFilter, pageNumber, pageSize int32, locale string) ([]*eventv1.Event, int64, error) {
+	filter = normalizeFilter(filter)
+	key := offsetPageKey(filter, pageNumber, pageSize)
+	page, ok := e.catalog.getPage(key)
+	if !ok {
+		generation := e.catalog.generation.Load()
+		events, total, err := e.eventProvider.ListEvents(ctx, filter, pageNumber, pageSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		page = eventPage{events: events, total: total}
+		e.catalog.addPage(generation, key, page)
+	}
+
+	if err := e.localize(ctx, page.events, locale); err != nil {
+		return nil, 0, err
+	}
+
+	return page.events, page.total, nil
+}
+
+func (e *Events) ListEventsAfter(ctx context.Context, filter storage.EventFilter, after *pagination.Cursor, pageSize int32, locale string) ([]*eventv1.Event, *pagination.Cursor, int64, error) {
+	filter = normalizeFilter(filter)
+	key := cursorPageKey(filter, after, pageSize)
+	page, ok := e.catalog.getPage(key)
+	if !ok {
+		generation := e.catalog.generation.Load()
+		events, next, total, err := e.eventProvider.ListEventsAfter(ctx, filter, after, pageSize)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		page = eventPage{events: events, next: next, total: total}
+		e.catalog.addPage(generation, key, page)
+	}
+
+	if err := e.localize(ctx, page.events, locale); err != nil {
+		return nil, nil, 0, err
+	}
+
+	return page.events, page.next, page.total, nil
+}
+
+func (e *Events) CatalogLastModified(ctx context.Context, filter storage.EventFilter) (time.Time, error) {
+	const op = "service.CatalogLastModified"
+
+	filter = normalizeFilter(filter)
+	key := filterKey(filter)
+	if lastModified, ok := e.catalog.modified.Get(key); ok {
+		return lastModified, nil
+	}
+
+	generation := e.catalog.generation.Load()
+	lastModified, err := e.eventProvider.CatalogLastModified(ctx, filter)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+	e.catalog.addLastModified(generation, key, lastModified)
+
+	return lastModified, nil
+}
+
+func (e *Events) GetEvent(ctx context.Context, eventID int64, locale string) (*eventv1.Event, error) {
+	const op = "service.GetEvent"
+
+	event, ok := e.catalog.getEvent(eventID)
+	if !ok {
+		generation := e.catalog.generation.Load()
+		var err error
+		event, err = e.eventProvider.GetEvent(ctx, eventID)
+		if err != nil {
+			if errors.Is(err, storage.ErrEventNotFound) {
+				return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+			}
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		e.catalog.addEvent(generation, event)
+	}
+
+	if err := e.localize(ctx, []*eventv1.Event{event}, locale); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return event, nil
 }
 
-func (e *Events) GetEvent(ctx context.Context, eventID int64) (*eventv1.Event, error) {
-    return e.eventProvider.GetEvent(ctx, eventID)
+func (e *Events) UpdateEventStatus(ctx context.Context, eventID int64, status string) (*eventv1.Event, error) {
+	return e.changeStatus(ctx, eventID, status, "")
+}
+
+// CancelEvent is the only way to pass a reason along with the cancellation;
+// booking-service reacts to the published event.cancelled by refunding all
+// bookings of the event.
+func (e *Events) CancelEvent(ctx context.Context, eventID int64, reason string) (*eventv1.Event, error) {
+	return e.changeStatus(ctx, eventID, StatusCancelled, reason)
+}
+
+func (e *Events) changeStatus(ctx context.Context, eventID int64, status, reason string) (*eventv1.Event, error) {
+	const op = "service.changeStatus"
+
+	allowedFrom := sourcesOf(status)
+	if len(allowedFrom) == 0 {
+		return nil, fmt.Errorf("%s: unknown target status %q: %w", op, status, ErrInvalidTransition)
+	}
+
+	event, err := e.eventLifecycle.SetEventStatus(ctx, eventID, status, allowedFrom, reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrEventNotFound):
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		case errors.Is(err, storage.ErrInvalidTransition):
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidTransition)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	e.catalog.invalidateEvent(eventID)
+
+	return event, nil
+}
+
+func (e *Events) ScheduleSales(ctx context.Context, eventID int64, onSaleAt, offSaleAt *time.Time) (*eventv1.Event, error) {
+	const op = "service.ScheduleSales"
+
+	if onSaleAt != nil && offSaleAt != nil && !offSaleAt.After(*onSaleAt) {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidSalesWindow)
+	}
+
+	event, err := e.eventLifecycle.ScheduleSales(ctx, eventID, onSaleAt, offSaleAt)
+	if err != nil {
+		if errors.Is(err, storage.ErrEventNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	e.catalog.invalidateEvent(eventID)
+
+	return event, nil
+}
+
+func (e *Events) SetHoldPolicy(ctx context.Context, eventID int64, holdMinutes int32, maxExtensions *int32) (*eventv1.Event, error) {
+	const op = "service.SetHoldPolicy"
+
+	// zero restores the default
+	if holdMinutes < 0 || holdMinutes > maxHoldMinutes {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidHoldTime)
+	}
+	if maxExtensions != nil && (*maxExtensions < 0 || *maxExtensions > maxHoldExtensions) {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidHoldExtensions)
+	}
+
+	event, err := e.eventLifecycle.SetHoldPolicy(ctx, eventID, holdMinutes, maxExtensions)
+	if err != nil {
+		if errors.Is(err, storage.ErrEventNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	e.catalog.invalidateEvent(eventID)
+
+	return event, nil
 }
@@ -0,0 +1,416 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/kay-kewl/ticket-booking-system/services/event-service/internal/storage"
+)
+
+const (
+	MaxImportSize = 5 << 20
+
+	maxImportErrors = 100
+	maxSeatRange    = 1000
+)
+
+var ErrInvalidImport = errors.New("import must be a csv or json file of at most 5 MB")
+
+type ImportStorage interface {
+	ImportEvents(ctx context.Context, events []storage.ImportEvent, organizerID int64, dryRun bool) (*storage.ImportResult, error)
+}
+
+// importRecord is one line of a CSV file or one object of a JSON array: a
+// row of seats of an event together with the event and price tier it uses.
+// Event fields only need to be filled in on the first record of the event.
+type importRecord struct {
+	line int
+
+	EventRef    string `json:"event_ref"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	StartsAt    string `json:"starts_at"`
+	Sector      string `json:"sector"`
+	Row         int32  `json:"row"`
+	// labels separated by commas or spaces, or ranges such as "A1-A20"
+	Seats     string `json:"seats"`
+	PriceTier string `json:"price_tier"`
+	// the tier is created or updated when amount is set
+	Amount   *int64 `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+var importColumns = []string{"event_ref", "title", "description", "starts_at", "sector", "row", "seats", "price_tier", "amount", "currency"}
+
+// ImportEvents validates the whole file first; only a file without errors
+// reaches the database, where it is applied in one transaction.
+func (e *Events) ImportEvents(ctx context.Context, format string, content []byte, organizerID int64, dryRun bool) (*storage.ImportResult, error) {
+	const op = "service.ImportEvents"
+
+	if len(content) > MaxImportSize {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidImport)
+	}
+
+	var (
+		records []importRecord
+		errs    []storage.ImportLineError
+	)
+	switch strings.ToLower(format) {
+	case "csv":
+		records, errs = parseImportCSV(content)
+	case "json":
+		records, errs = parseImportJSON(content)
+	default:
+		return nil, fmt.Errorf("%s: format %q: %w", op, format, ErrInvalidImport)
+	}
+
+	events, validationErrs := groupImportRecords(records)
+	errs = append(errs, validationErrs...)
+	if len(errs) > 0 {
+		return &storage.ImportResult{Errors: limitImportErrors(errs)}, nil
+	}
+
+	result, err := e.imports.ImportEvents(ctx, events, organizerID, dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	result.Errors = limitImportErrors(result.Errors)
+	result.Warnings = limitImportErrors(result.Warnings)
+	if result.Applied {
+		e.catalog.purge()
+	}
+
+	return result, nil
+}
+
+func parseImportCSV(content []byte) ([]importRecord, []storage.ImportLineError) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, []storage.ImportLineError{{Line: 1, Message: "the file must start with a header line"}}
+	}
+
+	index := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !slices.Contains(importColumns, column) {
+			return nil, []storage.ImportLineError{{Line: 1, Message: fmt.Sprintf("unknown column %q", column)}}
+		}
+		index[column] = i
+	}
+	for _, column := range []string{"event_ref", "seats", "price_tier"} {
+		if _, ok := index[column]; !ok {
+			return nil, []storage.ImportLineError{{Line: 1, Message: fmt.Sprintf("column %q is required", column)}}
+		}
+	}
+
+	var (
+		records []importRecord
+		errs    []storage.ImportLineError
+	)
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				errs = append(errs, storage.ImportLineError{Line: parseErr.StartLine, Message: parseErr.Err.Error()})
+				if errors.Is(err, csv.ErrFieldCount) {
+					continue
+				}
+			}
+			// quoting errors leave the reader at an unknown position
+			break
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := func(column string) string {
+			if i, ok := index[column]; ok {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+
+		record := importRecord{
+			line:        line,
+			EventRef:    field("event_ref"),
+			Title:       field("title"),
+			Description: field("description"),
+			StartsAt:    field("starts_at"),
+			Sector:      field("sector"),
+			Seats:       field("seats"),
+			PriceTier:   field("price_tier"),
+			Currency:    field("currency"),
+		}
+		if row := field("row"); row != "" {
+			number, err := strconv.ParseInt(row, 10, 32)
+			if err != nil {
+				errs = append(errs, storage.ImportLineError{Line: line, Message: fmt.Sprintf("row %q is not a number", row)})
+				continue
+			}
+			record.Row = int32(number)
+		}
+		if amount := field("amount"); amount != "" {
+			value, err := strconv.ParseInt(amount, 10, 64)
+			if err != nil {
+				errs = append(errs, storage.ImportLineError{Line: line, Message: fmt.Sprintf("amount %q is not a whole number of minor units", amount)})
+				continue
+			}
+			record.Amount = &value
+		}
+
+		records = append(records, record)
+	}
+
+	return records, errs
+}
+
+func parseImportJSON(content []byte) ([]importRecord, []storage.ImportLineError) {
+	lineAt := func(offset int64) int {
+		// skip to the start of the value, past the separator before it
+		for offset < int64(len(content)) && (content[offset] == ',' || unicode.IsSpace(rune(content[offset]))) {
+			offset++
+		}
+		return bytes.Count(content[:offset], []byte("\n")) + 1
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, []storage.ImportLineError{{Line: 1, Message: "the file must be a JSON array of records"}}
+	}
+
+	var (
+		records []importRecord
+		errs    []storage.ImportLineError
+	)
+	for decoder.More() {
+		line := lineAt(decoder.InputOffset())
+
+		var record importRecord
+		if err := decoder.Decode(&record); err != nil {
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+				errs = append(errs, storage.ImportLineError{Line: line, Message: "malformed JSON"})
+				break
+			}
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				errs = append(errs, storage.ImportLineError{Line: line, Message: fmt.Sprintf("%s must be a %s", typeErr.Field, typeErr.Type)})
+				continue
+			}
+			errs = append(errs, storage.ImportLineError{Line: line, Message: strings.TrimPrefix(err.Error(), "json: ")})
+			continue
+		}
+
+		record.line = line
+		records = append(records, record)
+	}
+
+	return records, errs
+}
+
+// groupImportRecords validates the records and collects them into events,
+// keeping the order in which the events first appear in the file.
+func groupImportRecords(records []importRecord) ([]storage.ImportEvent, []storage.ImportLineError) {
+	type eventState struct {
+		event      *storage.ImportEvent
+		startsAt   string
+		fieldLines map[string]int
+		tierLines  map[string]int
+		seatLines  map[string]int
+	}
+
+	var (
+		events []*eventState
+		byRef  = make(map[string]*eventState)
+		errs   []storage.ImportLineError
+	)
+	fail := func(line int, format string, args ...any) {
+		errs = append(errs, storage.ImportLineError{Line: line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, record := range records {
+		if record.EventRef == "" || len(record.EventRef) > 100 {
+			fail(record.line, "event_ref is required and must be at most 100 characters")
+			continue
+		}
+
+		state, ok := byRef[record.EventRef]
+		if !ok {
+			state = &eventState{
+				event:      &storage.ImportEvent{Line: record.line, ExternalRef: record.EventRef},
+				fieldLines: make(map[string]int),
+				tierLines:  make(map[string]int),
+				seatLines:  make(map[string]int),
+			}
+			byRef[record.EventRef] = state
+			events = append(events, state)
+		}
+		event := state.event
+
+		// event fields may be repeated on every line, but then they have to agree
+		setField := func(name, value string, target *string) bool {
+			if value == "" {
+				return true
+			}
+			if *target != "" && *target != value {
+				fail(record.line, "%s differs from line %d", name, state.fieldLines[name])
+				return false
+			}
+			if *target == "" {
+				*target = value
+				state.fieldLines[name] = record.line
+			}
+			return true
+		}
+		setField("title", record.Title, &event.Title)
+		setField("description", record.Description, &event.Description)
+
+		if record.StartsAt != "" {
+			t, err := time.Parse(time.RFC3339, record.StartsAt)
+			if err != nil {
+				fail(record.line, "starts_at %q must be an RFC 3339 timestamp", record.StartsAt)
+			} else if setField("starts_at", t.UTC().Format(time.RFC3339), &state.startsAt) && event.StartsAt == nil {
+				event.StartsAt = &t
+			}
+		}
+
+		if len([]rune(record.Title)) > 255 {
+			fail(record.line, "title must be at most 255 characters")
+		}
+		if len([]rune(record.Sector)) > 50 {
+			fail(record.line, "sector must be at most 50 characters")
+		}
+		if record.Row < 1 {
+			fail(record.line, "row must be a positive number")
+		}
+
+		if record.PriceTier == "" || len([]rune(record.PriceTier)) > 100 {
+			fail(record.line, "price_tier is required and must be at most 100 characters")
+		} else if record.Amount != nil {
+			switch {
+			case *record.Amount < 0:
+				fail(record.line, "amount must not be negative")
+			case !currencyCode.MatchString(record.Currency):
+				fail(record.line, "currency must be an ISO 4217 code such as RUB")
+			default:
+				tier := storage.ImportPriceTier{Line: record.line, Name: record.PriceTier, Amount: *record.Amount, Currency: record.Currency}
+				if line, ok := state.tierLines[tier.Name]; ok {
+					i := slices.IndexFunc(event.PriceTiers, func(t storage.ImportPriceTier) bool { return t.Name == tier.Name })
+					if event.PriceTiers[i].Amount != tier.Amount || event.PriceTiers[i].Currency != tier.Currency {
+						fail(record.line, "price tier %q is defined differently on line %d", tier.Name, line)
+					}
+				} else {
+					state.tierLines[tier.Name] = record.line
+					event.PriceTiers = append(event.PriceTiers, tier)
+				}
+			}
+		} else if record.Currency != "" {
+			fail(record.line, "currency is given without an amount")
+		}
+
+		labels, err := parseSeatLabels(record.Seats)
+		if err != nil {
+			fail(record.line, "%v", err)
+			continue
+		}
+		for _, label := range labels {
+			if line, ok := state.seatLines[label]; ok {
+				fail(record.line, "seat %q is already listed on line %d", label, line)
+				continue
+			}
+			state.seatLines[label] = record.line
+			event.Seats = append(event.Seats, storage.ImportSeat{
+				Line:      record.line,
+				Label:     label,
+				Sector:    record.Sector,
+				Row:       record.Row,
+				PriceTier: record.PriceTier,
+			})
+		}
+	}
+
+	result := make([]storage.ImportEvent, 0, len(events))
+	for _, state := range events {
+		if state.event.Title == "" {
+			fail(state.event.Line, "event %q has no title", state.event.ExternalRef)
+		}
+		result = append(result, *state.event)
+	}
+
+	return result, errs
+}
+
+// parseSeatLabels expands a seat list such as "A1-A5, A7" into labels.
+func parseSeatLabels(spec string) ([]string, error) {
+	items := strings.FieldsFunc(spec, func(r rune) bool { return r == ',' || r == ';' || unicode.IsSpace(r) })
+	if len(items) == 0 {
+		return nil, errors.New("seats are required")
+	}
+
+	var labels []string
+	for _, item := range items {
+		from, to, isRange := strings.Cut(item, "-")
+		fromPrefix, fromNumber := splitSeatLabel(from)
+		toPrefix, toNumber := splitSeatLabel(to)
+
+		if !isRange || fromNumber == "" || toNumber == "" {
+			if len(item) > 10 {
+				return nil, fmt.Errorf("seat %q is longer than 10 characters", item)
+			}
+			labels = append(labels, item)
+			continue
+		}
+
+		start, _ := strconv.Atoi(fromNumber)
+		end, _ := strconv.Atoi(toNumber)
+		if fromPrefix != toPrefix || start > end || end-start >= maxSeatRange {
+			return nil, fmt.Errorf("seat range %q must go upwards within one prefix, at most %d seats", item, maxSeatRange)
+		}
+		for number := start; number <= end; number++ {
+			label := fromPrefix + strconv.Itoa(number)
+			if len(label) > 10 {
+				return nil, fmt.Errorf("seat %q is longer than 10 characters", label)
+			}
+			labels = append(labels, label)
+		}
+	}
+
+	return labels, nil
+}
+
+// splitSeatLabel splits "A12" into "A" and "12".
+func splitSeatLabel(label string) (string, string) {
+	i := len(label)
+	for i > 0 && label[i-1] >= '0' && label[i-1] <= '9' {
+		i--
+	}
+	if len(label)-i > 6 {
+		return label, ""
+	}
+	return label[:i], label[i:]
+}
+
+func limitImportErrors(errs []storage.ImportLineError) []storage.ImportLineError {
+	slices.SortStableFunc(errs, func(a, b storage.ImportLineError) int { return a.Line - b.Line })
+	if len(errs) > maxImportErrors {
+		errs = errs[:maxImportErrors]
+	}
+	return errs
+}
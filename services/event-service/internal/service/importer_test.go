@@ -0,0 +1,88 @@
+package service
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseImportCSV(t *testing.T) {
+	content := []byte(`event_ref,title,starts_at,sector,row,seats,price_tier,amount,currency
+shrek-0120,Shrek,2027-01-20T19:00:00Z,Parter,1,A1-A3,VIP,300000,RUB
+shrek-0120,,,Parter,2,"B1, B2",Standard,150000,RUB
+shrek-0120,,,Balcony,1,C1,Standard,,
+`)
+
+	records, errs := parseImportCSV(content)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	events, errs := groupImportRecords(records)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+
+	event := events[0]
+	if event.Title != "Shrek" || event.StartsAt == nil || len(event.PriceTiers) != 2 {
+		t.Errorf("unexpected event: %+v", event)
+	}
+
+	var labels []string
+	for _, seat := range event.Seats {
+		labels = append(labels, seat.Label)
+	}
+	if want := []string{"A1", "A2", "A3", "B1", "B2", "C1"}; !slices.Equal(labels, want) {
+		t.Errorf("seats = %v, want %v", labels, want)
+	}
+	if event.Seats[5].Line != 4 || event.Seats[5].Sector != "Balcony" {
+		t.Errorf("last seat = %+v, want line 4 in Balcony", event.Seats[5])
+	}
+}
+
+func TestImportReportsLineErrors(t *testing.T) {
+	content := []byte(`[
+  {"event_ref": "gig", "title": "Gig", "row": 1, "seats": "1-5", "price_tier": "GA", "amount": 1000, "currency": "RUB"},
+  {"event_ref": "gig", "title": "Another gig", "row": 1, "seats": "5-6", "price_tier": "GA", "amount": 2000, "currency": "RUB"},
+  {"event_ref": "gig", "row": "two", "seats": "7", "price_tier": "GA"},
+  {"event_ref": "gig", "row": 3, "seats": "9-8", "price_tier": "GA", "colour": "red"}
+]`)
+
+	records, errs := parseImportJSON(content)
+	_, validationErrs := groupImportRecords(records)
+	errs = limitImportErrors(append(errs, validationErrs...))
+
+	lines := make([]int, 0, len(errs))
+	for _, err := range errs {
+		lines = append(lines, err.Line)
+	}
+	// line 3: title, price tier and seat 5 conflict with line 2; line 4: bad row; line 5: unknown field
+	if want := []int{3, 3, 3, 4, 5}; !slices.Equal(lines, want) {
+		t.Errorf("error lines = %v, want %v (%v)", lines, want, errs)
+	}
+}
+
+func TestParseSeatLabels(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    []string
+		wantErr bool
+	}{
+		{spec: "A1-A3", want: []string{"A1", "A2", "A3"}},
+		{spec: "1;2 7", want: []string{"1", "2", "7"}},
+		{spec: "VIP-BOX", want: []string{"VIP-BOX"}},
+		{spec: "A3-A1", wantErr: true},
+		{spec: "A1-B3", wantErr: true},
+		{spec: "1-5000", wantErr: true},
+		{spec: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseSeatLabels(tt.spec)
+		if (err != nil) != tt.wantErr || !slices.Equal(got, tt.want) {
+			t.Errorf("parseSeatLabels(%q) = %v, %v", tt.spec, got, err)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+	"github.com/kay-kewl/ticket-booking-system/services/event-service/internal/storage"
+)
+
+var ErrInvalidPool = errors.New("invalid inventory pool")
+var ErrPriceTierNotFound = errors.New("price tier not found")
+var ErrCapacityBelowAllocated = errors.New("capacity is below the tickets already sold or held")
+var ErrInvalidSectorScore = errors.New("sector score must be between 0 and 100")
+
+type InventoryStorage interface {
+	SetInventoryPool(ctx context.Context, pool *eventv1.InventoryPool) (*eventv1.InventoryPool, error)
+	ListInventoryPools(ctx context.Context, eventID int64) ([]*eventv1.InventoryPool, error)
+	SetSectorScore(ctx context.Context, score *eventv1.SectorScore) (*eventv1.SectorScore, error)
+	ListSectorScores(ctx context.Context, eventID int64) ([]*eventv1.SectorScore, error)
+}
+
+func (e *Events) SetInventoryPool(ctx context.Context, pool *eventv1.InventoryPool) (*eventv1.InventoryPool, error) {
+	const op = "service.SetInventoryPool"
+
+	pool.Name = strings.TrimSpace(pool.Name)
+	if pool.Name == "" || pool.Capacity < 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidPool)
+	}
+
+	saved, err := e.inventory.SetInventoryPool(ctx, pool)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrEventNotFound):
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		case errors.Is(err, storage.ErrPriceTierNotFound):
+			return nil, fmt.Errorf("%s: %w", op, ErrPriceTierNotFound)
+		case errors.Is(err, storage.ErrCapacityBelowAllocated):
+			return nil, fmt.Errorf("%s: %w", op, ErrCapacityBelowAllocated)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saved, nil
+}
+
+func (e *Events) ListInventoryPools(ctx context.Context, eventID int64) ([]*eventv1.InventoryPool, error) {
+	const op = "service.ListInventoryPools"
+
+	pools, err := e.inventory.ListInventoryPools(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pools, nil
+}
+
+// SetSectorScore rates the seats of a sector for best-available selection.
+func (e *Events) SetSectorScore(ctx context.Context, score *eventv1.SectorScore) (*eventv1.SectorScore, error) {
+	const op = "service.SetSectorScore"
+
+	score.Sector = strings.TrimSpace(score.Sector)
+	if score.Sector == "" || len(score.Sector) > 50 || score.Score < 0 || score.Score > 100 {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidSectorScore)
+	}
+
+	saved, err := e.inventory.SetSectorScore(ctx, score)
+	if err != nil {
+		if errors.Is(err, storage.ErrEventNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saved, nil
+}
+
+func (e *Events) ListSectorScores(ctx context.Context, eventID int64) ([]*eventv1.SectorScore, error) {
+	const op = "service.ListSectorScores"
+
+	scores, err := e.inventory.ListSectorScores(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return scores, nil
+}
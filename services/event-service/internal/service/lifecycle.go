@@ -0,0 +1,36 @@
+package service
+
+import "slices"
+
+const (
+	StatusDraft     = "DRAFT"
+	StatusPublished = "PUBLISHED"
+	StatusOnSale    = "ON_SALE"
+	StatusSoldOut   = "SOLD_OUT"
+	StatusCancelled = "CANCELLED"
+)
+
+// transitions lists every status change an event may go through. SOLD_OUT is
+// normally reached and left automatically by the lifecycle scheduler.
+var transitions = map[string][]string{
+	StatusDraft:     {StatusPublished, StatusCancelled},
+	StatusPublished: {StatusDraft, StatusOnSale, StatusCancelled},
+	StatusOnSale:    {StatusPublished, StatusSoldOut, StatusCancelled},
+	StatusSoldOut:   {StatusOnSale, StatusCancelled},
+	StatusCancelled: {},
+}
+
+func CanTransition(from, to string) bool {
+	return slices.Contains(transitions[from], to)
+}
+
+// sourcesOf returns the statuses from which the event may move to status.
+func sourcesOf(status string) []string {
+	var from []string
+	for s := range transitions {
+		if CanTransition(s, status) {
+			from = append(from, s)
+		}
+	}
+	return from
+}
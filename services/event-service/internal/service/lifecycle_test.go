@@ -0,0 +1,24 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransitions(t *testing.T) {
+	require.True(t, CanTransition(StatusDraft, StatusPublished))
+	require.True(t, CanTransition(StatusPublished, StatusOnSale))
+	require.True(t, CanTransition(StatusOnSale, StatusSoldOut))
+	require.True(t, CanTransition(StatusSoldOut, StatusOnSale))
+
+	require.False(t, CanTransition(StatusDraft, StatusOnSale), "drafts must be published first")
+	require.False(t, CanTransition(StatusCancelled, StatusOnSale), "cancelled is terminal")
+	require.False(t, CanTransition(StatusOnSale, StatusOnSale))
+}
+
+func TestSourcesOf(t *testing.T) {
+	require.ElementsMatch(t, []string{StatusDraft, StatusPublished, StatusOnSale, StatusSoldOut}, sourcesOf(StatusCancelled))
+	require.ElementsMatch(t, []string{StatusPublished, StatusSoldOut}, sourcesOf(StatusOnSale))
+	require.Empty(t, sourcesOf("BOGUS"))
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+	"github.com/kay-kewl/ticket-booking-system/services/event-service/internal/imaging"
+	"github.com/kay-kewl/ticket-booking-system/services/event-service/internal/storage"
+)
+
+// MaxImageSize is the largest accepted upload, before resizing.
+const MaxImageSize = 10 << 20
+
+var ErrInvalidImage = errors.New("invalid image")
+var ErrImageNotFound = errors.New("image not found")
+var ErrGalleryFull = errors.New("gallery is full")
+
+type ImageStorage interface {
+	AddEventImage(ctx context.Context, eventID int64, kind string, renditions []storage.StoredRendition) (*eventv1.EventImage, []string, error)
+	DeleteEventImage(ctx context.Context, eventID, imageID int64) (*eventv1.Event, []string, error)
+}
+
+// UploadEventImage validates the image, stores its renditions in the blob
+// store and attaches them to the event as the poster or a gallery image.
+func (e *Events) UploadEventImage(ctx context.Context, eventID int64, kind string, content []byte) (*eventv1.EventImage, error) {
+	const op = "service.UploadEventImage"
+
+	if kind != storage.ImageKindPoster && kind != storage.ImageKindGallery {
+		return nil, fmt.Errorf("%s: unknown kind %q: %w", op, kind, ErrInvalidImage)
+	}
+	if len(content) > MaxImageSize {
+		return nil, fmt.Errorf("%s: %d bytes: %w", op, len(content), ErrInvalidImage)
+	}
+
+	renditions, err := imaging.Renditions(content)
+	if err != nil {
+		if errors.Is(err, imaging.ErrUnsupportedFormat) || errors.Is(err, imaging.ErrInvalidDimensions) {
+			return nil, fmt.Errorf("%s: %w: %w", op, ErrInvalidImage, err)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// every upload gets its own prefix, so a URL always points at the same bytes
+	prefix := fmt.Sprintf("events/%d/%s", eventID, uuid.NewString())
+	stored := make([]storage.StoredRendition, 0, len(renditions))
+	for _, rendition := range renditions {
+		key := fmt.Sprintf("%s/%s.jpg", prefix, rendition.Name)
+		if err := e.blobs.Put(ctx, key, bytes.NewReader(rendition.Data)); err != nil {
+			e.removeBlobs(ctx, storedKeys(stored))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		stored = append(stored, storage.StoredRendition{
+			Name:   rendition.Name,
+			Key:    key,
+			Width:  int32(rendition.Width),
+			Height: int32(rendition.Height),
+		})
+	}
+
+	image, replaced, err := e.images.AddEventImage(ctx, eventID, kind, stored)
+	if err != nil {
+		e.removeBlobs(ctx, storedKeys(stored))
+		switch {
+		case errors.Is(err, storage.ErrEventNotFound):
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		case errors.Is(err, storage.ErrGalleryFull):
+			return nil, fmt.Errorf("%s: %w", op, ErrGalleryFull)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	e.catalog.invalidateEvent(eventID)
+	e.removeBlobs(ctx, replaced)
+
+	return image, nil
+}
+
+func (e *Events) DeleteEventImage(ctx context.Context, eventID, imageID int64) (*eventv1.Event, error) {
+	const op = "service.DeleteEventImage"
+
+	event, keys, err := e.images.DeleteEventImage(ctx, eventID, imageID)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrImageNotFound):
+			return nil, fmt.Errorf("%s: %w", op, ErrImageNotFound)
+		case errors.Is(err, storage.ErrEventNotFound):
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	e.removeBlobs(ctx, keys)
+
+	e.catalog.invalidateEvent(eventID)
+
+	return event, nil
+}
+
+// removeBlobs is best effort: a blob left behind is only wasted space, while
+// the database row that referenced it is already gone or was never written.
+func (e *Events) removeBlobs(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		_ = e.blobs.Delete(ctx, key)
+	}
+}
+
+func storedKeys(renditions []storage.StoredRendition) []string {
+	keys := make([]string, 0, len(renditions))
+	for _, rendition := range renditions {
+		keys = append(keys, rendition.Key)
+	}
+	return keys
+}
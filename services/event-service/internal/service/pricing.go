@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+	"github.com/kay-kewl/ticket-booking-system/services/event-service/internal/storage"
+)
+
+var ErrInvalidPriceTier = errors.New("invalid price tier")
+var ErrSeatNotFound = errors.New("seat not found")
+var ErrCurrencyMismatch = errors.New("all price tiers of an event must use the same currency")
+
+var ErrInvalidRefundPolicy = errors.New("invalid refund policy")
+var ErrInvalidPurchaseLimits = errors.New("purchase limits must not be negative")
+var ErrInvalidTransferPolicy = errors.New("transfer cutoff and limit must not be negative")
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+type PriceTierStorage interface {
+	SetPriceTier(ctx context.Context, tier *eventv1.PriceTier, sectors []string, seatIDs []int64) (*eventv1.PriceTier, error)
+	ListPriceTiers(ctx context.Context, eventID int64) ([]*eventv1.PriceTier, error)
+	SetRefundPolicy(ctx context.Context, policy *eventv1.RefundPolicy) (*eventv1.RefundPolicy, error)
+	GetRefundPolicy(ctx context.Context, eventID int64) (*eventv1.RefundPolicy, error)
+	SetPurchaseLimits(ctx context.Context, limits *eventv1.PurchaseLimits) (*eventv1.PurchaseLimits, error)
+	GetPurchaseLimits(ctx context.Context, eventID int64) (*eventv1.PurchaseLimits, error)
+	SetTransferPolicy(ctx context.Context, policy *eventv1.TransferPolicy) (*eventv1.TransferPolicy, error)
+	GetTransferPolicy(ctx context.Context, eventID int64) (*eventv1.TransferPolicy, error)
+}
+
+func (e *Events) SetPriceTier(ctx context.Context, tier *eventv1.PriceTier, sectors []string, seatIDs []int64) (*eventv1.PriceTier, error) {
+	const op = "service.SetPriceTier"
+
+	tier.Name = strings.TrimSpace(tier.Name)
+	tier.Currency = strings.ToUpper(tier.Currency)
+	switch {
+	case tier.Name == "":
+		return nil, fmt.Errorf("%s: name is required: %w", op, ErrInvalidPriceTier)
+	case tier.Amount < 0:
+		return nil, fmt.Errorf("%s: amount must not be negative: %w", op, ErrInvalidPriceTier)
+	case !currencyCode.MatchString(tier.Currency):
+		return nil, fmt.Errorf("%s: currency must be an ISO 4217 code: %w", op, ErrInvalidPriceTier)
+	}
+
+	saved, err := e.priceTiers.SetPriceTier(ctx, tier, sectors, seatIDs)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrEventNotFound):
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		case errors.Is(err, storage.ErrSeatNotFound):
+			return nil, fmt.Errorf("%s: %w", op, ErrSeatNotFound)
+		case errors.Is(err, storage.ErrCurrencyMismatch):
+			return nil, fmt.Errorf("%s: %w", op, ErrCurrencyMismatch)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saved, nil
+}
+
+func (e *Events) ListPriceTiers(ctx context.Context, eventID int64) ([]*eventv1.PriceTier, error) {
+	const op = "service.ListPriceTiers"
+
+	tiers, err := e.priceTiers.ListPriceTiers(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tiers, nil
+}
+
+func (e *Events) SetRefundPolicy(ctx context.Context, policy *eventv1.RefundPolicy) (*eventv1.RefundPolicy, error) {
+	const op = "service.SetRefundPolicy"
+
+	switch {
+	case policy.FullRefundHours < 0 || policy.PartialRefundHours < 0:
+		return nil, fmt.Errorf("%s: hours must not be negative: %w", op, ErrInvalidRefundPolicy)
+	case policy.PartialRefundHours > policy.FullRefundHours:
+		return nil, fmt.Errorf("%s: the partial refund cutoff must not come before the full refund one: %w", op, ErrInvalidRefundPolicy)
+	case policy.PartialRefundPercent < 0 || policy.PartialRefundPercent > 100:
+		return nil, fmt.Errorf("%s: percent must be between 0 and 100: %w", op, ErrInvalidRefundPolicy)
+	}
+
+	saved, err := e.priceTiers.SetRefundPolicy(ctx, policy)
+	if err != nil {
+		if errors.Is(err, storage.ErrEventNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saved, nil
+}
+
+func (e *Events) GetRefundPolicy(ctx context.Context, eventID int64) (*eventv1.RefundPolicy, error) {
+	const op = "service.GetRefundPolicy"
+
+	policy, err := e.priceTiers.GetRefundPolicy(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, storage.ErrEventNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return policy, nil
+}
+
+func (e *Events) SetPurchaseLimits(ctx context.Context, limits *eventv1.PurchaseLimits) (*eventv1.PurchaseLimits, error) {
+	const op = "service.SetPurchaseLimits"
+
+	if limits.MaxTicketsPerUser < 0 || limits.MaxPendingBookings < 0 || limits.MaxTicketsPerBooking < 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidPurchaseLimits)
+	}
+
+	saved, err := e.priceTiers.SetPurchaseLimits(ctx, limits)
+	if err != nil {
+		if errors.Is(err, storage.ErrEventNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saved, nil
+}
+
+func (e *Events) GetPurchaseLimits(ctx context.Context, eventID int64) (*eventv1.PurchaseLimits, error) {
+	const op = "service.GetPurchaseLimits"
+
+	limits, err := e.priceTiers.GetPurchaseLimits(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, storage.ErrEventNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return limits, nil
+}
+
+func (e *Events) SetTransferPolicy(ctx context.Context, policy *eventv1.TransferPolicy) (*eventv1.TransferPolicy, error) {
+	const op = "service.SetTransferPolicy"
+
+	if policy.CutoffHours < 0 || policy.MaxTransfers < 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidTransferPolicy)
+	}
+
+	saved, err := e.priceTiers.SetTransferPolicy(ctx, policy)
+	if err != nil {
+		if errors.Is(err, storage.ErrEventNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saved, nil
+}
+
+func (e *Events) GetTransferPolicy(ctx context.Context, eventID int64) (*eventv1.TransferPolicy, error) {
+	const op = "service.GetTransferPolicy"
+
+	policy, err := e.priceTiers.GetTransferPolicy(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, storage.ErrEventNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+	"github.com/kay-kewl/ticket-booking-system/services/event-service/internal/storage"
+)
+
+const (
+	maxTagLength    = 50
+	maxTagsPerEvent = 20
+)
+
+var ErrInvalidCategory = errors.New("category needs a slug and a name")
+var ErrInvalidTags = errors.New("invalid tags")
+var ErrInvalidCollection = errors.New("collection needs a slug, a title and distinct events")
+var ErrCategoryNotFound = errors.New("category not found")
+var ErrCategoryCycle = errors.New("category can not be nested under itself")
+var ErrCollectionNotFound = errors.New("collection not found")
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+type TaxonomyStorage interface {
+	UpsertCategory(ctx context.Context, category *eventv1.Category) (*eventv1.Category, error)
+	ListCategories(ctx context.Context) ([]*eventv1.Category, error)
+	SetEventCategories(ctx context.Context, eventID int64, slugs []string) (*eventv1.Event, error)
+	SetEventTags(ctx context.Context, eventID int64, tags []string) (*eventv1.Event, error)
+	UpsertCollection(ctx context.Context, collection *eventv1.Collection, eventIDs []int64) error
+	GetCollection(ctx context.Context, slug string) (*eventv1.Collection, error)
+}
+
+func (e *Events) UpsertCategory(ctx context.Context, category *eventv1.Category) (*eventv1.Category, error) {
+	const op = "service.UpsertCategory"
+
+	category.Name = strings.TrimSpace(category.Name)
+	if !slugPattern.MatchString(category.Slug) || category.Name == "" || category.ParentSlug == category.Slug {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidCategory)
+	}
+
+	saved, err := e.taxonomy.UpsertCategory(ctx, category)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrCategoryNotFound):
+			return nil, fmt.Errorf("%s: %w", op, ErrCategoryNotFound)
+		case errors.Is(err, storage.ErrCategoryCycle):
+			return nil, fmt.Errorf("%s: %w", op, ErrCategoryCycle)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	// moving a category changes which events its filter matches
+	e.catalog.purge()
+
+	return saved, nil
+}
+
+func (e *Events) ListCategories(ctx context.Context) ([]*eventv1.Category, error) {
+	const op = "service.ListCategories"
+
+	categories, err := e.taxonomy.ListCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return buildCategoryTree(categories), nil
+}
+
+func (e *Events) SetEventCategories(ctx context.Context, eventID int64, slugs []string) (*eventv1.Event, error) {
+	const op = "service.SetEventCategories"
+
+	slices.Sort(slugs)
+	slugs = slices.Compact(slugs)
+
+	event, err := e.taxonomy.SetEventCategories(ctx, eventID, slugs)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrEventNotFound):
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		case errors.Is(err, storage.ErrCategoryNotFound):
+			return nil, fmt.Errorf("%s: %w", op, ErrCategoryNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	e.catalog.invalidateEvent(eventID)
+
+	return event, nil
+}
+
+func (e *Events) SetEventTags(ctx context.Context, eventID int64, tags []string) (*eventv1.Event, error) {
+	const op = "service.SetEventTags"
+
+	tags, err := normalizeTags(tags)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	event, err := e.taxonomy.SetEventTags(ctx, eventID, tags)
+	if err != nil {
+		if errors.Is(err, storage.ErrEventNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	e.catalog.invalidateEvent(eventID)
+
+	return event, nil
+}
+
+func (e *Events) UpsertCollection(ctx context.Context, collection *eventv1.Collection, eventIDs []int64) (*eventv1.Collection, error) {
+	const op = "service.UpsertCollection"
+
+	collection.Title = strings.TrimSpace(collection.Title)
+	if !slugPattern.MatchString(collection.Slug) || collection.Title == "" || len(slices.Compact(slices.Sorted(slices.Values(eventIDs)))) != len(eventIDs) {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidCollection)
+	}
+
+	if err := e.taxonomy.UpsertCollection(ctx, collection, eventIDs); err != nil {
+		if errors.Is(err, storage.ErrEventNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return e.GetCollection(ctx, collection.Slug)
+}
+
+func (e *Events) GetCollection(ctx context.Context, slug string) (*eventv1.Collection, error) {
+	const op = "service.GetCollection"
+
+	collection, err := e.taxonomy.GetCollection(ctx, slug)
+	if err != nil {
+		if errors.Is(err, storage.ErrCollectionNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrCollectionNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return collection, nil
+}
+
+// normalizeTags lowercases, trims and deduplicates tags so that "Jazz" and
+// "jazz " end up as one tag.
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || len([]rune(tag)) > maxTagLength {
+			return nil, fmt.Errorf("tag %q: %w", tag, ErrInvalidTags)
+		}
+		normalized = append(normalized, tag)
+	}
+
+	slices.Sort(normalized)
+	normalized = slices.Compact(normalized)
+	if len(normalized) > maxTagsPerEvent {
+		return nil, fmt.Errorf("more than %d tags: %w", maxTagsPerEvent, ErrInvalidTags)
+	}
+
+	return normalized, nil
+}
+
+func normalizeFilter(filter storage.EventFilter) storage.EventFilter {
+	filter.Tag = strings.ToLower(strings.TrimSpace(filter.Tag))
+	return filter
+}
+
+// buildCategoryTree nests the flat, position-ordered list under the parents.
+func buildCategoryTree(categories []*eventv1.Category) []*eventv1.Category {
+	bySlug := make(map[string]*eventv1.Category, len(categories))
+	for _, category := range categories {
+		bySlug[category.Slug] = category
+	}
+
+	var roots []*eventv1.Category
+	for _, category := range categories {
+		parent, ok := bySlug[category.ParentSlug]
+		if !ok {
+			roots = append(roots, category)
+			continue
+		}
+		parent.Children = append(parent.Children, category)
+	}
+
+	return roots
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := normalizeTags([]string{"Jazz", " jazz ", "Open Air"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"jazz", "open air"}; !slices.Equal(tags, want) {
+		t.Errorf("normalizeTags() = %v, want %v", tags, want)
+	}
+
+	for _, invalid := range [][]string{{"  "}, {strings.Repeat("x", maxTagLength+1)}} {
+		if _, err := normalizeTags(invalid); !errors.Is(err, ErrInvalidTags) {
+			t.Errorf("normalizeTags(%q) error = %v, want ErrInvalidTags", invalid, err)
+		}
+	}
+}
+
+func TestBuildCategoryTree(t *testing.T) {
+	roots := buildCategoryTree([]*eventv1.Category{
+		{Slug: "concerts"},
+		{Slug: "rock", ParentSlug: "concerts"},
+		{Slug: "theatre"},
+		{Slug: "jazz", ParentSlug: "concerts"},
+	})
+
+	if len(roots) != 2 || roots[0].Slug != "concerts" || roots[1].Slug != "theatre" {
+		t.Fatalf("unexpected roots: %v", roots)
+	}
+	children := roots[0].GetChildren()
+	if len(children) != 2 || children[0].Slug != "rock" || children[1].Slug != "jazz" {
+		t.Errorf("children should keep the list order, got %v", children)
+	}
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+	"github.com/kay-kewl/ticket-booking-system/services/event-service/internal/storage"
+)
+
+var ErrInvalidLocale = errors.New("invalid locale")
+var ErrInvalidTranslation = errors.New("translation must have a title of at most 255 characters")
+var ErrTranslationNotFound = errors.New("translation not found")
+
+const (
+	TranslationComplete = "COMPLETE"
+	TranslationPartial  = "PARTIAL"
+	TranslationMissing  = "MISSING"
+)
+
+const maxTranslationReportEvents = 1000
+
+// BCP 47 tags, kept in lower case since they are case-insensitive
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+type TranslationStorage interface {
+	SetEventTranslation(ctx context.Context, eventID int64, translation storage.Translation) error
+	DeleteEventTranslation(ctx context.Context, eventID int64, locale string) error
+	ListEventTranslations(ctx context.Context, eventIDs []int64) (map[int64]map[string]storage.Translation, error)
+	ListTranslationStatus(ctx context.Context, organizerID int64, limit int32) ([]storage.EventTranslations, error)
+}
+
+// SetEventTranslation stores the text of the event in a locale other than
+// the default one and returns the event in that locale.
+func (e *Events) SetEventTranslation(ctx context.Context, eventID int64, locale, title, description string) (*eventv1.Event, error) {
+	const op = "service.SetEventTranslation"
+
+	locale, ok := normalizeLocale(locale)
+	if !ok || locale == e.defaultLocale {
+		return nil, fmt.Errorf("%s: %q: %w", op, locale, ErrInvalidLocale)
+	}
+	title = strings.TrimSpace(title)
+	if title == "" || len([]rune(title)) > 255 {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidTranslation)
+	}
+
+	err := e.translations.SetEventTranslation(ctx, eventID, storage.Translation{
+		Locale:      locale,
+		Title:       title,
+		Description: strings.TrimSpace(description),
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrEventNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	e.catalog.invalidateEvent(eventID)
+
+	return e.GetEvent(ctx, eventID, locale)
+}
+
+func (e *Events) DeleteEventTranslation(ctx context.Context, eventID int64, locale string) (*eventv1.Event, error) {
+	const op = "service.DeleteEventTranslation"
+
+	locale, ok := normalizeLocale(locale)
+	if !ok {
+		return nil, fmt.Errorf("%s: %q: %w", op, locale, ErrInvalidLocale)
+	}
+
+	if err := e.translations.DeleteEventTranslation(ctx, eventID, locale); err != nil {
+		if errors.Is(err, storage.ErrTranslationNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrTranslationNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	e.catalog.invalidateEvent(eventID)
+
+	return e.GetEvent(ctx, eventID, "")
+}
+
+// TranslationStatus reports, for every event of the organizer, which of the
+// locales it is translated to. Without explicit locales it reports on every
+// locale that any of the events has.
+func (e *Events) TranslationStatus(ctx context.Context, organizerID int64, locales []string) (*eventv1.TranslationStatusReport, error) {
+	const op = "service.TranslationStatus"
+
+	normalized := make([]string, 0, len(locales))
+	for _, locale := range locales {
+		locale, ok := normalizeLocale(locale)
+		if !ok {
+			return nil, fmt.Errorf("%s: %q: %w", op, locale, ErrInvalidLocale)
+		}
+		normalized = append(normalized, locale)
+	}
+
+	events, err := e.translations.ListTranslationStatus(ctx, organizerID, maxTranslationReportEvents)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return buildTranslationReport(events, normalized, e.defaultLocale), nil
+}
+
+func buildTranslationReport(events []storage.EventTranslations, locales []string, defaultLocale string) *eventv1.TranslationStatusReport {
+	if len(locales) == 0 {
+		for _, event := range events {
+			for _, t := range event.Translations {
+				locales = append(locales, t.Locale)
+			}
+		}
+	}
+	locales = slices.DeleteFunc(slices.Compact(slices.Sorted(slices.Values(locales))), func(locale string) bool {
+		return locale == defaultLocale
+	})
+
+	report := &eventv1.TranslationStatusReport{DefaultLocale: defaultLocale, Locales: locales}
+	for _, event := range events {
+		translated := make(map[string]storage.Translation, len(event.Translations))
+		for _, t := range event.Translations {
+			translated[t.Locale] = t
+		}
+
+		status := &eventv1.EventTranslationStatus{EventId: event.EventID, Title: event.Title}
+		for _, locale := range locales {
+			t, ok := translated[locale]
+			switch {
+			case !ok:
+				report.Missing++
+				status.Locales = append(status.Locales, &eventv1.LocaleTranslationStatus{Locale: locale, Status: TranslationMissing})
+			case t.Description == "" && event.Description != "":
+				report.Partial++
+				status.Locales = append(status.Locales, &eventv1.LocaleTranslationStatus{Locale: locale, Status: TranslationPartial})
+			default:
+				report.Complete++
+				status.Locales = append(status.Locales, &eventv1.LocaleTranslationStatus{Locale: locale, Status: TranslationComplete})
+			}
+		}
+		report.Events = append(report.Events, status)
+	}
+
+	return report
+}
+
+// localize replaces the text of the events with their best translation for
+// the preferred locales. The events must be copies, not cached values.
+func (e *Events) localize(ctx context.Context, events []*eventv1.Event, locales string) error {
+	const op = "service.localize"
+
+	for _, event := range events {
+		event.Locale = e.defaultLocale
+	}
+
+	preferences := parseLocales(locales)
+	if len(events) == 0 || len(preferences) == 0 || preferences[0] == e.defaultLocale {
+		return nil
+	}
+
+	translations, err := e.eventTranslations(ctx, events)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, event := range events {
+		t, ok := pickTranslation(preferences, e.defaultLocale, translations[event.Id])
+		if !ok {
+			continue
+		}
+		event.Title = t.Title
+		if t.Description != "" {
+			event.Description = t.Description
+		}
+		event.Locale = t.Locale
+	}
+
+	return nil
+}
+
+// eventTranslations reads the translations through the catalog cache, which
+// also remembers the events that have none.
+func (e *Events) eventTranslations(ctx context.Context, events []*eventv1.Event) (map[int64]map[string]storage.Translation, error) {
+	translations := make(map[int64]map[string]storage.Translation, len(events))
+	var missing []int64
+	for _, event := range events {
+		if cached, ok := e.catalog.translations.Get(event.Id); ok {
+			translations[event.Id] = cached
+			continue
+		}
+		missing = append(missing, event.Id)
+	}
+	if len(missing) == 0 {
+		return translations, nil
+	}
+
+	generation := e.catalog.generation.Load()
+	loaded, err := e.translations.ListEventTranslations(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, eventID := range missing {
+		translations[eventID] = loaded[eventID]
+		e.catalog.addTranslations(generation, eventID, loaded[eventID])
+	}
+
+	return translations, nil
+}
+
+func normalizeLocale(locale string) (string, bool) {
+	locale = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	return locale, localePattern.MatchString(locale)
+}
+
+// parseLocales reads a comma-separated preference list. It also accepts an
+// Accept-Language value as is, ignoring quality values, so the order has to
+// be by preference already.
+func parseLocales(value string) []string {
+	var locales []string
+	for _, part := range strings.Split(value, ",") {
+		locale, _, _ := strings.Cut(part, ";")
+		locale, ok := normalizeLocale(locale)
+		if !ok || slices.Contains(locales, locale) {
+			continue
+		}
+		locales = append(locales, locale)
+	}
+	return locales
+}
+
+// pickTranslation goes through the preferences in order and takes the first
+// one available, either exactly or, failing that, in the same language ("de"
+// or "de-ch" for "de-at"). The default locale is always available, as the
+// text of the event itself, in which case ok is false.
+func pickTranslation(preferences []string, defaultLocale string, available map[string]storage.Translation) (storage.Translation, bool) {
+	for _, preference := range preferences {
+		if preference == defaultLocale {
+			return storage.Translation{}, false
+		}
+		if t, ok := available[preference]; ok {
+			return t, true
+		}
+
+		language := languageOf(preference)
+		if t, ok := available[language]; ok {
+			return t, true
+		}
+		var sameLanguage []string
+		for locale := range available {
+			if languageOf(locale) == language {
+				sameLanguage = append(sameLanguage, locale)
+			}
+		}
+		if len(sameLanguage) > 0 {
+			// map order is random, keep the choice stable
+			return available[slices.Min(sameLanguage)], true
+		}
+		if languageOf(defaultLocale) == language {
+			return storage.Translation{}, false
+		}
+	}
+
+	return storage.Translation{}, false
+}
+
+func languageOf(locale string) string {
+	language, _, _ := strings.Cut(locale, "-")
+	return language
+}
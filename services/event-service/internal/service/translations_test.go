@@ -0,0 +1,69 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kay-kewl/ticket-booking-system/services/event-service/internal/storage"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLocales(t *testing.T) {
+	require.Equal(t, []string{"de-at", "de", "en"}, parseLocales("de-AT, de;q=0.9, en;q=0.5"))
+	require.Equal(t, []string{"pt-br", "en"}, parseLocales("pt_BR,en,EN,*,"))
+	require.Empty(t, parseLocales(""))
+}
+
+func TestPickTranslation(t *testing.T) {
+	available := map[string]storage.Translation{
+		"en":    {Locale: "en", Title: "Shrek"},
+		"de-ch": {Locale: "de-ch", Title: "Shrek (CH)"},
+		"de-de": {Locale: "de-de", Title: "Shrek (DE)"},
+	}
+
+	tests := []struct {
+		name        string
+		preferences []string
+		want        string
+	}{
+		{name: "exact match", preferences: []string{"en"}, want: "en"},
+		{name: "region falls back to the language", preferences: []string{"en-gb"}, want: "en"},
+		{name: "another region of the language", preferences: []string{"de-at"}, want: "de-ch"},
+		{name: "first available preference wins", preferences: []string{"fr", "de-de", "en"}, want: "de-de"},
+		{name: "default locale beats later preferences", preferences: []string{"ru", "en"}, want: ""},
+		{name: "default language beats later preferences", preferences: []string{"ru-ua", "en"}, want: ""},
+		{name: "nothing matches", preferences: []string{"fr"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translation, ok := pickTranslation(tt.preferences, "ru", available)
+			require.Equal(t, tt.want != "", ok)
+			require.Equal(t, tt.want, translation.Locale)
+		})
+	}
+}
+
+func TestBuildTranslationReport(t *testing.T) {
+	events := []storage.EventTranslations{
+		{EventID: 1, Title: "Шрек", Description: "Огр", Translations: []storage.Translation{
+			{Locale: "en", Title: "Shrek", Description: "An ogre"},
+			{Locale: "de", Title: "Shrek"},
+		}},
+		{EventID: 2, Title: "Агил", Translations: []storage.Translation{
+			{Locale: "de", Title: "Agil"},
+		}},
+	}
+
+	report := buildTranslationReport(events, nil, "ru")
+	require.Equal(t, []string{"de", "en"}, report.Locales, "locales default to the ones in use")
+	require.Equal(t, int32(2), report.Complete)
+	require.Equal(t, int32(1), report.Partial, "a missing description only counts when the original has one")
+	require.Equal(t, int32(1), report.Missing)
+	require.Equal(t, TranslationPartial, report.Events[0].Locales[0].Status)
+	require.Equal(t, TranslationMissing, report.Events[1].Locales[1].Status)
+
+	report = buildTranslationReport(events, []string{"fr", "ru"}, "ru")
+	require.Equal(t, []string{"fr"}, report.Locales, "the default locale is never reported on")
+	require.Equal(t, int32(2), report.Missing)
+}
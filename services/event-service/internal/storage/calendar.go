@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+)
+
+// ListUpcomingEvents returns the events with a start date that have not
+// passed yet, soonest first. Cancelled events stay in the list so that
+// calendars which already show them learn about the cancellation.
+func (s *Storage) ListUpcomingEvents(ctx context.Context, filter EventFilter, limit int32) ([]*eventv1.Event, error) {
+	const op = "storage.ListUpcomingEvents"
+
+	where, args := filter.where([]any{limit})
+	rows, err := s.db.Query(
+		ctx,
+		"SELECT "+eventColumns+" FROM event.events WHERE "+where+" AND starts_at > NOW() - INTERVAL '1 day' ORDER BY starts_at, id LIMIT $1",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*eventv1.Event, error) {
+		return s.scanEvent(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return events, nil
+}
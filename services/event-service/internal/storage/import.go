@@ -0,0 +1,271 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ImportEvent is one event of an import file with everything that belongs to
+// it; Line fields point back into the file for error reporting.
+type ImportEvent struct {
+	Line        int
+	ExternalRef string
+	Title       string
+	Description string
+	StartsAt    *time.Time
+	PriceTiers  []ImportPriceTier
+	Seats       []ImportSeat
+}
+
+type ImportPriceTier struct {
+	Line     int
+	Name     string
+	Amount   int64
+	Currency string
+}
+
+type ImportSeat struct {
+	Line      int
+	Label     string
+	Sector    string
+	Row       int32
+	PriceTier string
+}
+
+type ImportLineError struct {
+	Line    int
+	Message string
+}
+
+type ImportResult struct {
+	EventsCreated int
+	EventsUpdated int
+	SeatsCreated  int
+	SeatsUpdated  int
+	// seats that are booked or held and were left as they are
+	SeatsSkipped int
+	Errors       []ImportLineError
+	// lines that were applied only in part; they do not stop the import
+	Warnings []ImportLineError
+	// false for dry runs and when any line failed
+	Applied bool
+}
+
+// ImportEvents upserts the events, their price tiers and seats by their
+// natural keys in a single transaction. Nothing is committed when dryRun is
+// set or when any line turns out to conflict with the data already stored;
+// the counters then describe what the import would have done.
+func (s *Storage) ImportEvents(ctx context.Context, events []ImportEvent, organizerID int64, dryRun bool) (*ImportResult, error) {
+	const op = "storage.ImportEvents"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	result := &ImportResult{}
+	for _, event := range events {
+		if err := importEvent(ctx, tx, event, organizerID, result); err != nil {
+			return nil, fmt.Errorf("%s: event %q: %w", op, event.ExternalRef, err)
+		}
+	}
+
+	if dryRun || len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	result.Applied = true
+	return result, nil
+}
+
+func importEvent(ctx context.Context, tx pgx.Tx, event ImportEvent, organizerID int64, result *ImportResult) error {
+	var owner *int64
+	err := tx.QueryRow(ctx, "SELECT organizer_id FROM event.events WHERE external_ref = $1 FOR UPDATE", event.ExternalRef).Scan(&owner)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to look up event: %w", err)
+	}
+	if err == nil && organizerID != 0 && (owner == nil || *owner != organizerID) {
+		result.Errors = append(result.Errors, ImportLineError{event.Line, fmt.Sprintf("event %q belongs to another organizer", event.ExternalRef)})
+		return nil
+	}
+
+	var (
+		eventID  int64
+		inserted bool
+	)
+	err = tx.QueryRow(
+		ctx,
+		`INSERT INTO event.events (external_ref, title, description, starts_at, organizer_id) VALUES ($1, $2, $3, $4, NULLIF($5, 0))
+		ON CONFLICT (external_ref) DO UPDATE SET title = EXCLUDED.title, description = EXCLUDED.description, starts_at = EXCLUDED.starts_at
+		RETURNING id, xmax = 0`,
+		event.ExternalRef,
+		event.Title,
+		event.Description,
+		event.StartsAt,
+		organizerID,
+	).Scan(&eventID, &inserted)
+	if err != nil {
+		return fmt.Errorf("failed to save event: %w", err)
+	}
+	if inserted {
+		result.EventsCreated++
+	} else {
+		result.EventsUpdated++
+	}
+	if err := saveEventUpdate(ctx, tx, eventID, "import"); err != nil {
+		return err
+	}
+
+	for _, tier := range event.PriceTiers {
+		_, err := tx.Exec(
+			ctx,
+			`INSERT INTO event.price_tiers (event_id, name, amount, currency) VALUES ($1, $2, $3, $4)
+			ON CONFLICT (event_id, name) DO UPDATE SET amount = EXCLUDED.amount, currency = EXCLUDED.currency`,
+			eventID,
+			tier.Name,
+			tier.Amount,
+			tier.Currency,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save price tier: %w", err)
+		}
+	}
+
+	if len(event.PriceTiers) > 0 {
+		var currencies int
+		err := tx.QueryRow(ctx, "SELECT COUNT(DISTINCT currency) FROM event.price_tiers WHERE event_id = $1", eventID).Scan(&currencies)
+		if err != nil {
+			return fmt.Errorf("failed to check currencies: %w", err)
+		}
+		if currencies > 1 {
+			result.Errors = append(result.Errors, ImportLineError{event.PriceTiers[0].Line, "all price tiers of an event must use the same currency"})
+		}
+	}
+
+	rows, err := tx.Query(ctx, "SELECT name, id FROM event.price_tiers WHERE event_id = $1", eventID)
+	if err != nil {
+		return fmt.Errorf("failed to load price tiers: %w", err)
+	}
+	tierIDs := make(map[string]int64)
+	var (
+		name string
+		id   int64
+	)
+	_, err = pgx.ForEachRow(rows, []any{&name, &id}, func() error {
+		tierIDs[name] = id
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load price tiers: %w", err)
+	}
+
+	taken, err := lockTakenSeats(ctx, tx, eventID, event.Seats)
+	if err != nil {
+		return err
+	}
+
+	var (
+		labels  []string
+		numbers []int32
+		sectors []string
+		tiers   []int64
+	)
+	for _, seat := range event.Seats {
+		tierID, ok := tierIDs[seat.PriceTier]
+		if !ok {
+			result.Errors = append(result.Errors, ImportLineError{seat.Line, fmt.Sprintf("unknown price tier %q", seat.PriceTier)})
+			continue
+		}
+		if taken[seat.Label] {
+			// its ticket was sold with the row, sector and price it has now
+			result.SeatsSkipped++
+			result.Warnings = append(result.Warnings, ImportLineError{seat.Line, fmt.Sprintf("seat %q is booked or held and was left as it is", seat.Label)})
+			continue
+		}
+		labels = append(labels, seat.Label)
+		numbers = append(numbers, seat.Row)
+		sectors = append(sectors, seat.Sector)
+		tiers = append(tiers, tierID)
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+
+	// seats are matched by their label; statuses are left alone so that
+	// re-importing never frees or takes a booked seat. The number in the label
+	// is the position of the seat in its row.
+	rows, err = tx.Query(
+		ctx,
+		`INSERT INTO event.seats (event_id, seat_number, row_number, sector, price_tier_id, position)
+		SELECT $1, seat.*, NULLIF(substring(seat.label from '[0-9]+$'), '')::int
+		FROM unnest($2::text[], $3::int[], $4::text[], $5::bigint[]) AS seat(label, row_number, sector, price_tier_id)
+		ON CONFLICT (event_id, seat_number) DO UPDATE
+			SET row_number = EXCLUDED.row_number, sector = EXCLUDED.sector, price_tier_id = EXCLUDED.price_tier_id,
+				position = EXCLUDED.position
+		RETURNING xmax = 0`,
+		eventID,
+		labels,
+		numbers,
+		sectors,
+		tiers,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save seats: %w", err)
+	}
+	_, err = pgx.ForEachRow(rows, []any{&inserted}, func() error {
+		if inserted {
+			result.SeatsCreated++
+		} else {
+			result.SeatsUpdated++
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save seats: %w", err)
+	}
+
+	return nil
+}
+
+// lockTakenSeats locks the stored seats of the event that the import lists,
+// so that none of them is booked before the import commits, and returns the
+// labels of those already booked or held.
+func lockTakenSeats(ctx context.Context, tx pgx.Tx, eventID int64, seats []ImportSeat) (map[string]bool, error) {
+	labels := make([]string, 0, len(seats))
+	for _, seat := range seats {
+		labels = append(labels, seat.Label)
+	}
+
+	rows, err := tx.Query(
+		ctx,
+		"SELECT seat_number, status FROM event.seats WHERE event_id = $1 AND seat_number = ANY($2) FOR UPDATE",
+		eventID,
+		labels,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock seats: %w", err)
+	}
+
+	taken := make(map[string]bool)
+	var label, status string
+	_, err = pgx.ForEachRow(rows, []any{&label, &status}, func() error {
+		if status != "AVAILABLE" {
+			taken[label] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock seats: %w", err)
+	}
+
+	return taken, nil
+}
@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+)
+
+var ErrPriceTierNotFound = errors.New("price tier not found")
+var ErrCapacityBelowAllocated = errors.New("capacity is below the tickets already sold or held")
+
+const poolColumns = "id, event_id, name, capacity, sold, held, capacity - sold - held, COALESCE(price_tier_id, 0)"
+
+// SetInventoryPool upserts the general-admission pool by name.
+func (s *Storage) SetInventoryPool(ctx context.Context, pool *eventv1.InventoryPool) (*eventv1.InventoryPool, error) {
+	const op = "storage.SetInventoryPool"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var priceTierID *int64
+	if pool.PriceTierId != 0 {
+		var exists bool
+		err := tx.QueryRow(
+			ctx,
+			"SELECT EXISTS (SELECT 1 FROM event.price_tiers WHERE id = $1 AND event_id = $2)",
+			pool.PriceTierId,
+			pool.EventId,
+		).Scan(&exists)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to check price tier: %w", op, err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("%s: %w", op, ErrPriceTierNotFound)
+		}
+		priceTierID = &pool.PriceTierId
+	}
+
+	saved, err := scanPool(tx.QueryRow(
+		ctx,
+		`INSERT INTO event.inventory_pools (event_id, name, capacity, price_tier_id) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (event_id, name) DO UPDATE SET capacity = EXCLUDED.capacity, price_tier_id = EXCLUDED.price_tier_id
+		RETURNING `+poolColumns,
+		pool.EventId,
+		pool.Name,
+		pool.Capacity,
+		priceTierID,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23503":
+				return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+			case "23514":
+				return nil, fmt.Errorf("%s: %w", op, ErrCapacityBelowAllocated)
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := saveEventUpdate(ctx, tx, saved.EventId, "inventory_pools"); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saved, tx.Commit(ctx)
+}
+
+func (s *Storage) ListInventoryPools(ctx context.Context, eventID int64) ([]*eventv1.InventoryPool, error) {
+	const op = "storage.ListInventoryPools"
+
+	rows, err := s.db.Query(ctx, "SELECT "+poolColumns+" FROM event.inventory_pools WHERE event_id = $1 ORDER BY id", eventID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pools, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*eventv1.InventoryPool, error) {
+		return scanPool(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pools, nil
+}
+
+func (s *Storage) SetSectorScore(ctx context.Context, score *eventv1.SectorScore) (*eventv1.SectorScore, error) {
+	const op = "storage.SetSectorScore"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(
+		ctx,
+		`INSERT INTO event.sector_scores (event_id, sector, score)
+		SELECT id, $2, $3 FROM event.events WHERE id = $1
+		ON CONFLICT (event_id, sector) DO UPDATE SET score = EXCLUDED.score`,
+		score.EventId,
+		score.Sector,
+		score.Score,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+	}
+
+	if err := saveEventUpdate(ctx, tx, score.EventId, "sector_scores"); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return score, tx.Commit(ctx)
+}
+
+func (s *Storage) ListSectorScores(ctx context.Context, eventID int64) ([]*eventv1.SectorScore, error) {
+	const op = "storage.ListSectorScores"
+
+	rows, err := s.db.Query(ctx, "SELECT event_id, sector, score FROM event.sector_scores WHERE event_id = $1 ORDER BY sector", eventID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	scores, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*eventv1.SectorScore, error) {
+		var score eventv1.SectorScore
+		err := row.Scan(&score.EventId, &score.Sector, &score.Score)
+		return &score, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return scores, nil
+}
+
+func scanPool(row pgx.Row) (*eventv1.InventoryPool, error) {
+	var pool eventv1.InventoryPool
+	err := row.Scan(&pool.Id, &pool.EventId, &pool.Name, &pool.Capacity, &pool.Sold, &pool.Held, &pool.Available, &pool.PriceTierId)
+	if err != nil {
+		return nil, err
+	}
+	return &pool, nil
+}
@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+)
+
+const (
+	ImageKindPoster  = "POSTER"
+	ImageKindGallery = "GALLERY"
+
+	maxGalleryImages = 20
+)
+
+var ErrImageNotFound = errors.New("image not found")
+var ErrGalleryFull = errors.New("gallery is full")
+
+// StoredRendition is a rendition as kept in event_images.renditions.
+type StoredRendition struct {
+	Name   string `json:"name"`
+	Key    string `json:"key"`
+	Width  int32  `json:"width"`
+	Height int32  `json:"height"`
+}
+
+type storedImage struct {
+	ID         int64             `json:"id"`
+	Kind       string            `json:"kind"`
+	Position   int32             `json:"position"`
+	Renditions []StoredRendition `json:"renditions"`
+}
+
+// AddEventImage saves an uploaded image. A poster replaces the previous one;
+// the blob keys of the replaced poster are returned so they can be removed.
+func (s *Storage) AddEventImage(ctx context.Context, eventID int64, kind string, renditions []StoredRendition) (*eventv1.EventImage, []string, error) {
+	const op = "storage.AddEventImage"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockEvent(ctx, tx, eventID); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	image := storedImage{Kind: kind, Renditions: renditions}
+	var replaced []string
+
+	if kind == ImageKindPoster {
+		rows, err := tx.Query(ctx, "DELETE FROM event.event_images WHERE event_id = $1 AND kind = $2 RETURNING renditions", eventID, ImageKindPoster)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: failed to remove previous poster: %w", op, err)
+		}
+		replaced, err = collectKeys(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: failed to remove previous poster: %w", op, err)
+		}
+	} else {
+		var count int
+		err := tx.QueryRow(
+			ctx,
+			"SELECT COUNT(*), COALESCE(MAX(position), 0) + 1 FROM event.event_images WHERE event_id = $1 AND kind = $2",
+			eventID,
+			ImageKindGallery,
+		).Scan(&count, &image.Position)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: failed to inspect gallery: %w", op, err)
+		}
+		if count >= maxGalleryImages {
+			return nil, nil, fmt.Errorf("%s: %w", op, ErrGalleryFull)
+		}
+	}
+
+	err = tx.QueryRow(
+		ctx,
+		"INSERT INTO event.event_images (event_id, kind, position, renditions) VALUES ($1, $2, $3, $4) RETURNING id",
+		eventID,
+		kind,
+		image.Position,
+		renditions,
+	).Scan(&image.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to save image: %w", op, err)
+	}
+
+	if err := saveEventUpdate(ctx, tx, eventID, "images"); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return s.eventImage(image), replaced, nil
+}
+
+// DeleteEventImage removes the image and returns the updated event together
+// with the blob keys that are no longer referenced.
+func (s *Storage) DeleteEventImage(ctx context.Context, eventID, imageID int64) (*eventv1.Event, []string, error) {
+	const op = "storage.DeleteEventImage"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, "DELETE FROM event.event_images WHERE id = $1 AND event_id = $2 RETURNING renditions", imageID, eventID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	keys, err := collectKeys(rows)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(keys) == 0 {
+		return nil, nil, fmt.Errorf("%s: %w", op, ErrImageNotFound)
+	}
+
+	if err := saveEventUpdate(ctx, tx, eventID, "images"); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	event, err := s.scanEvent(tx.QueryRow(ctx, "SELECT "+eventColumns+" FROM event.events WHERE id = $1", eventID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return event, keys, nil
+}
+
+func collectKeys(rows pgx.Rows) ([]string, error) {
+	renditions, err := pgx.CollectRows(rows, pgx.RowTo[[]StoredRendition])
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, image := range renditions {
+		for _, rendition := range image {
+			keys = append(keys, rendition.Key)
+		}
+	}
+	return keys, nil
+}
+
+func (s *Storage) eventImage(image storedImage) *eventv1.EventImage {
+	result := &eventv1.EventImage{Id: image.ID, Kind: image.Kind, Position: image.Position}
+	for _, rendition := range image.Renditions {
+		result.Renditions = append(result.Renditions, &eventv1.ImageRendition{
+			Name:   rendition.Name,
+			Url:    s.mediaURL(rendition.Key),
+			Width:  rendition.Width,
+			Height: rendition.Height,
+		})
+	}
+	return result
+}
@@ -2,32 +2,90 @@ package storage
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+	"github.com/kay-kewl/ticket-booking-system/internal/pagination"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+var ErrEventNotFound = errors.New("event not found")
+var ErrInvalidTransition = errors.New("event status transition is not allowed")
+
+const eventColumns = `id, title, description, status, on_sale_at, off_sale_at, organizer_id, starts_at, COALESCE(external_ref, ''), updated_at, COALESCE(hold_minutes, 0), max_hold_extensions,
+	ARRAY(SELECT c.slug FROM event.event_categories ec JOIN event.categories c ON c.id = ec.category_id WHERE ec.event_id = events.id ORDER BY c.slug),
+	ARRAY(SELECT t.tag FROM event.event_tags t WHERE t.event_id = events.id ORDER BY t.tag),
+	(SELECT COALESCE(json_agg(json_build_object('id', i.id, 'kind', i.kind, 'position', i.position, 'renditions', i.renditions) ORDER BY i.position, i.id), '[]')
+		FROM event.event_images i WHERE i.event_id = events.id)`
+
+// EventFilter narrows the public listing; empty fields do not filter.
+type EventFilter struct {
+	Category    string
+	Tag         string
+	OrganizerID int64
+}
+
+// where returns the conditions for the filter, numbering its placeholders
+// after the given args, and the args extended with the filter values.
+func (f EventFilter) where(args []any) (string, []any) {
+	clause := "status <> 'DRAFT'"
+	if f.Category != "" {
+		args = append(args, f.Category)
+		clause += fmt.Sprintf(` AND id IN (SELECT ec.event_id FROM event.event_categories ec WHERE ec.category_id IN (
+			WITH RECURSIVE sub AS (
+				SELECT id FROM event.categories WHERE slug = $%d
+				UNION ALL
+				SELECT c.id FROM event.categories c JOIN sub ON c.parent_id = sub.id
+			) SELECT id FROM sub))`, len(args))
+	}
+	if f.Tag != "" {
+		args = append(args, f.Tag)
+		clause += fmt.Sprintf(" AND id IN (SELECT event_id FROM event.event_tags WHERE tag = $%d)", len(args))
+	}
+	if f.OrganizerID != 0 {
+		args = append(args, f.OrganizerID)
+		clause += fmt.Sprintf(" AND organizer_id = $%d", len(args))
+	}
+	return clause, args
+}
+
 type Storage struct {
-	db *pgxpool.Pool
+	db       *pgxpool.Pool
+	mediaURL func(key string) string
 }
 
-func New(db *pgxpool.Pool) *Storage {
-	return &Storage{db: db}
+// mediaURL turns blob keys of event images into public URLs; only keys are
+// persisted so that the media host can change without rewriting rows.
+func New(db *pgxpool.Pool, mediaURL func(key string) string) *Storage {
+	return &Storage{db: db, mediaURL: mediaURL}
 }
 
-func (s *Storage) ListEvents(ctx context.Context, pageNumber, pageSize int32) ([]*eventv1.Event, int64, error) {
+// drafts are only visible to organizers, never in the public catalog
+func (s *Storage) ListEvents(ctx context.Context, filter EventFilter, pageNumber, pageSize int32) ([]*eventv1.Event, int64, error) {
 	const op = "storage.ListEvents"
 
+	where, args := filter.where(nil)
+
 	var totalCount int64
-	if err := s.db.QueryRow(ctx, "SELECT COUNT(*) FROM event.events").Scan(&totalCount); err != nil {
+	if err := s.db.QueryRow(ctx, "SELECT COUNT(*) FROM event.events WHERE "+where, args...).Scan(&totalCount); err != nil {
         return nil, 0, fmt.Errorf("%s: failed to count events: %w", op, err)
 	}
 
 	offset := (pageNumber - 1) * pageSize
+	args = append(args, pageSize, offset)
 
-	rows, err := s.db.Query(ctx, "SELECT id, title, description FROM event.events ORDER BY created_at DESC LIMIT $1 OFFSET $2", pageSize, offset)
+	query := fmt.Sprintf(
+		"SELECT %s FROM event.events WHERE %s ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d",
+		eventColumns, where, len(args)-1, len(args),
+	)
+	rows, err := s.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("%s: %w", op, err)
 	}
@@ -35,26 +93,384 @@ func (s *Storage) ListEvents(ctx context.Context, pageNumber, pageSize int32) ([
 
 	var events []*eventv1.Event
 	for rows.Next() {
-		var event eventv1.Event
-		if err := rows.Scan(&event.Id, &event.Title, &event.Description); err != nil {
+		event, err := s.scanEvent(rows)
+		if err != nil {
 			return nil, 0, fmt.Errorf("%s: %w", op, err)
 		}
-		events = append(events, &event)
+		events = append(events, event)
 	}
 
 	return events, totalCount, nil
 }
 
+// ListEventsAfter returns the page that follows the given cursor (or the first
+// page when it is nil), the cursor of the next page, nil on the last one, and
+// an estimate of the number of events matching the filter.
+func (s *Storage) ListEventsAfter(ctx context.Context, filter EventFilter, after *pagination.Cursor, pageSize int32) ([]*eventv1.Event, *pagination.Cursor, int64, error) {
+	const op = "storage.ListEventsAfter"
+
+	where, args := filter.where(nil)
+
+	// an exact count scans every matching event, the planner's estimate only
+	// reads the table statistics
+	var plan []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		}
+	}
+	if err := s.db.QueryRow(ctx, "EXPLAIN (FORMAT JSON) SELECT 1 FROM event.events WHERE "+where, args...).Scan(&plan); err != nil {
+		return nil, nil, 0, fmt.Errorf("%s: failed to estimate events: %w", op, err)
+	}
+	var totalCount int64
+	if len(plan) > 0 {
+		totalCount = int64(plan[0].Plan.Rows)
+	}
+
+	where, args = filter.where([]any{pageSize + 1})
+	query := "SELECT " + eventColumns + ", created_at FROM event.events WHERE " + where
+	if after != nil {
+		args = append(args, after.CreatedAt, after.ID)
+		query += fmt.Sprintf(" AND (created_at, id) < ($%d, $%d)", len(args)-1, len(args))
+	}
+	query += " ORDER BY created_at DESC, id DESC LIMIT $1"
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var (
+		events []*eventv1.Event
+		last   pagination.Cursor
+	)
+	for rows.Next() {
+		var createdAt time.Time
+		event, err := s.scanEvent(rows, &createdAt)
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("%s: %w", op, err)
+		}
+		if int32(len(events)) == pageSize {
+			// the extra row only tells us that another page exists
+			return events, &last, totalCount, nil
+		}
+		events = append(events, event)
+		last = pagination.Cursor{CreatedAt: createdAt, ID: event.Id}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return events, nil, totalCount, nil
+}
+
+// CatalogLastModified returns the latest change of any event matching the
+// filter. Events are never deleted and new ones start with the current time,
+// so it also moves when a page of the listing shifts.
+func (s *Storage) CatalogLastModified(ctx context.Context, filter EventFilter) (time.Time, error) {
+	const op = "storage.CatalogLastModified"
+
+	where, args := filter.where(nil)
+
+	var lastModified *time.Time
+	if err := s.db.QueryRow(ctx, "SELECT MAX(updated_at) FROM event.events WHERE "+where, args...).Scan(&lastModified); err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if lastModified == nil {
+		return time.Time{}, nil
+	}
+
+	return *lastModified, nil
+}
+
 func (s *Storage) GetEvent(ctx context.Context, eventID int64) (*eventv1.Event, error) {
     const op = "storage.GetEvent"
 
-    var event eventv1.Event
-    query := "SELECT id, title, description FROM event.events WHERE id = $1"
-    err := s.db.QueryRow(ctx, query, eventID).Scan(&event.Id, &event.Title, &event.Description)
+    query := "SELECT " + eventColumns + " FROM event.events WHERE id = $1"
+    event, err := s.scanEvent(s.db.QueryRow(ctx, query, eventID))
     if err != nil {
-        // TODO: pgx.ErrNoRows -> "not found"
+        if errors.Is(err, pgx.ErrNoRows) {
+            return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+        }
         return nil, fmt.Errorf("%s: %w", op, err)
     }
 
-    return &event, nil
+    return event, nil
+}
+
+// SetEventStatus moves the event to the given status if its current status is
+// one of allowedFrom and publishes the change through the outbox.
+func (s *Storage) SetEventStatus(ctx context.Context, eventID int64, status string, allowedFrom []string, reason string) (*eventv1.Event, error) {
+	const op = "storage.SetEventStatus"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var current string
+	err = tx.QueryRow(ctx, "SELECT status FROM event.events WHERE id = $1 FOR UPDATE", eventID).Scan(&current)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to lock event: %w", op, err)
+	}
+
+	if !slices.Contains(allowedFrom, current) {
+		return nil, fmt.Errorf("%s: %s -> %s: %w", op, current, status, ErrInvalidTransition)
+	}
+
+	event, err := s.scanEvent(tx.QueryRow(
+		ctx,
+		"UPDATE event.events SET status = $1 WHERE id = $2 RETURNING "+eventColumns,
+		status,
+		eventID,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to update event status: %w", op, err)
+	}
+
+	if err := saveStatusChange(ctx, tx, eventID, current, status, reason); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return event, tx.Commit(ctx)
+}
+
+// ScheduleSales sets the sales window of the event and publishes it.
+func (s *Storage) ScheduleSales(ctx context.Context, eventID int64, onSaleAt, offSaleAt *time.Time) (*eventv1.Event, error) {
+	const op = "storage.ScheduleSales"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	event, err := s.scanEvent(tx.QueryRow(
+		ctx,
+		"UPDATE event.events SET on_sale_at = $1, off_sale_at = $2 WHERE id = $3 RETURNING "+eventColumns,
+		onSaleAt,
+		offSaleAt,
+		eventID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = saveOutboxMessage(ctx, tx, "event.sales_scheduled", map[string]any{
+		"event_id":     eventID,
+		"on_sale_at":   onSaleAt,
+		"off_sale_at":  offSaleAt,
+		"scheduled_at": time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return event, tx.Commit(ctx)
+}
+
+// SetHoldPolicy sets how the event's bookings hold their tickets; a zero hold
+// time or nil extensions fall back to booking-service's defaults.
+func (s *Storage) SetHoldPolicy(ctx context.Context, eventID int64, holdMinutes int32, maxHoldExtensions *int32) (*eventv1.Event, error) {
+	const op = "storage.SetHoldPolicy"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	event, err := s.scanEvent(tx.QueryRow(
+		ctx,
+		"UPDATE event.events SET hold_minutes = NULLIF($2::int, 0), max_hold_extensions = $3 WHERE id = $1 RETURNING "+eventColumns,
+		eventID,
+		holdMinutes,
+		maxHoldExtensions,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := saveEventUpdate(ctx, tx, eventID, "hold_policy"); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return event, tx.Commit(ctx)
+}
+
+// ApplyScheduledTransitions opens and closes sales by the sales window and
+// keeps SOLD_OUT in sync with the remaining seats and general-admission
+// tickets. An event taken off sale goes back to PUBLISHED.
+func (s *Storage) ApplyScheduledTransitions(ctx context.Context) (int, error) {
+	const op = "storage.ApplyScheduledTransitions"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	transitions := []struct {
+		from, to string
+		query    string
+	}{
+		{
+			from:  "PUBLISHED",
+			to:    "ON_SALE",
+			query: "UPDATE event.events SET status = 'ON_SALE' WHERE status = 'PUBLISHED' AND on_sale_at <= NOW() AND (off_sale_at IS NULL OR off_sale_at > NOW()) RETURNING id",
+		},
+		{
+			from:  "ON_SALE",
+			to:    "PUBLISHED",
+			query: "UPDATE event.events SET status = 'PUBLISHED' WHERE status = 'ON_SALE' AND off_sale_at <= NOW() RETURNING id",
+		},
+		{
+			from:  "SOLD_OUT",
+			to:    "PUBLISHED",
+			query: "UPDATE event.events SET status = 'PUBLISHED' WHERE status = 'SOLD_OUT' AND off_sale_at <= NOW() RETURNING id",
+		},
+		{
+			from: "ON_SALE",
+			to:   "SOLD_OUT",
+			query: `UPDATE event.events e SET status = 'SOLD_OUT'
+				WHERE e.status = 'ON_SALE'
+				AND (EXISTS (SELECT 1 FROM event.seats s WHERE s.event_id = e.id)
+					OR EXISTS (SELECT 1 FROM event.inventory_pools p WHERE p.event_id = e.id))
+				AND NOT EXISTS (SELECT 1 FROM event.seats s WHERE s.event_id = e.id AND s.status = 'AVAILABLE')
+				AND NOT EXISTS (SELECT 1 FROM event.inventory_pools p WHERE p.event_id = e.id AND p.sold + p.held < p.capacity)
+				RETURNING e.id`,
+		},
+		{
+			from: "SOLD_OUT",
+			to:   "ON_SALE",
+			query: `UPDATE event.events e SET status = 'ON_SALE'
+				WHERE e.status = 'SOLD_OUT'
+				AND (EXISTS (SELECT 1 FROM event.seats s WHERE s.event_id = e.id AND s.status = 'AVAILABLE')
+					OR EXISTS (SELECT 1 FROM event.inventory_pools p WHERE p.event_id = e.id AND p.sold + p.held < p.capacity))
+				RETURNING e.id`,
+		},
+	}
+
+	changed := 0
+	for _, t := range transitions {
+		ids, err := queryIDs(ctx, tx, t.query)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %s -> %s: %w", op, t.from, t.to, err)
+		}
+		for _, id := range ids {
+			if err := saveStatusChange(ctx, tx, id, t.from, t.to, ""); err != nil {
+				return 0, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+		changed += len(ids)
+	}
+
+	return changed, tx.Commit(ctx)
+}
+
+func queryIDs(ctx context.Context, tx pgx.Tx, query string, args ...any) ([]int64, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[int64])
+}
+
+func saveStatusChange(ctx context.Context, tx pgx.Tx, eventID int64, from, to, reason string) error {
+	return saveOutboxMessage(ctx, tx, "event."+strings.ToLower(to), map[string]any{
+		"event_id":    eventID,
+		"from_status": from,
+		"to_status":   to,
+		"reason":      reason,
+		"changed_at":  time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// saveEventUpdate announces a change of the event other than its status, so
+// that every instance drops what it has cached of it.
+func saveEventUpdate(ctx context.Context, tx pgx.Tx, eventID int64, change string) error {
+	return saveOutboxMessage(ctx, tx, "event.updated", map[string]any{
+		"event_id":   eventID,
+		"change":     change,
+		"updated_at": time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// saveOutboxMessage queues a message for events_exchange in the transaction
+// that made the change it announces.
+func saveOutboxMessage(ctx context.Context, tx pgx.Tx, routingKey string, payload map[string]any) error {
+	const op = "storage.internal.saveOutboxMessage"
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("%s: failed to marshal outbox payload: %w", op, err)
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		"INSERT INTO event.outbox_messages (exchange, routing_key, payload) VALUES ($1, $2, $3::jsonb)",
+		"events_exchange",
+		routingKey,
+		data,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: failed to save outbox message: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) scanEvent(row pgx.Row, extra ...any) (*eventv1.Event, error) {
+	var (
+		event               eventv1.Event
+		description         *string
+		onSaleAt, offSaleAt *time.Time
+		organizerID         *int64
+		startsAt            *time.Time
+		updatedAt           time.Time
+		images              []storedImage
+	)
+
+	dest := append([]any{&event.Id, &event.Title, &description, &event.Status, &onSaleAt, &offSaleAt, &organizerID, &startsAt, &event.ExternalRef, &updatedAt, &event.HoldMinutes, &event.MaxHoldExtensions, &event.Categories, &event.Tags, &images}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	for _, image := range images {
+		if image.Kind == ImageKindPoster {
+			event.Poster = s.eventImage(image)
+			continue
+		}
+		event.Gallery = append(event.Gallery, s.eventImage(image))
+	}
+
+	if description != nil {
+		event.Description = *description
+	}
+	event.OnSaleAt = formatTime(onSaleAt)
+	event.OffSaleAt = formatTime(offSaleAt)
+	event.StartsAt = formatTime(startsAt)
+	event.UpdatedAt = formatTime(&updatedAt)
+	if organizerID != nil {
+		event.OrganizerId = *organizerID
+	}
+
+	return &event, nil
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
 }
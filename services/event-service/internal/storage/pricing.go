@@ -0,0 +1,307 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+)
+
+var ErrSeatNotFound = errors.New("seat not found")
+var ErrCurrencyMismatch = errors.New("all price tiers of an event must use the same currency")
+
+// SetPriceTier upserts the tier by name and assigns it to the given sectors
+// and seats of the event.
+func (s *Storage) SetPriceTier(ctx context.Context, tier *eventv1.PriceTier, sectors []string, seatIDs []int64) (*eventv1.PriceTier, error) {
+	const op = "storage.SetPriceTier"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	// the lock serializes concurrent tier changes of one event for the currency check
+	err = tx.QueryRow(ctx, "SELECT id FROM event.events WHERE id = $1 FOR UPDATE", tier.EventId).Scan(new(int64))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to lock event: %w", op, err)
+	}
+
+	var otherCurrency bool
+	err = tx.QueryRow(
+		ctx,
+		"SELECT EXISTS (SELECT 1 FROM event.price_tiers WHERE event_id = $1 AND name <> $2 AND currency <> $3)",
+		tier.EventId,
+		tier.Name,
+		tier.Currency,
+	).Scan(&otherCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to check currency: %w", op, err)
+	}
+	if otherCurrency {
+		return nil, fmt.Errorf("%s: %w", op, ErrCurrencyMismatch)
+	}
+
+	var saved eventv1.PriceTier
+	err = tx.QueryRow(
+		ctx,
+		`INSERT INTO event.price_tiers (event_id, name, amount, currency) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (event_id, name) DO UPDATE SET amount = EXCLUDED.amount, currency = EXCLUDED.currency
+		RETURNING id, event_id, name, amount, currency`,
+		tier.EventId,
+		tier.Name,
+		tier.Amount,
+		tier.Currency,
+	).Scan(&saved.Id, &saved.EventId, &saved.Name, &saved.Amount, &saved.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to save price tier: %w", op, err)
+	}
+
+	if len(sectors) > 0 {
+		_, err = tx.Exec(
+			ctx,
+			"UPDATE event.seats SET price_tier_id = $1 WHERE event_id = $2 AND sector = ANY($3)",
+			saved.Id,
+			saved.EventId,
+			sectors,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to assign sectors: %w", op, err)
+		}
+	}
+
+	if len(seatIDs) > 0 {
+		tag, err := tx.Exec(
+			ctx,
+			"UPDATE event.seats SET price_tier_id = $1 WHERE event_id = $2 AND id = ANY($3)",
+			saved.Id,
+			saved.EventId,
+			seatIDs,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to assign seats: %w", op, err)
+		}
+		if tag.RowsAffected() != int64(len(seatIDs)) {
+			return nil, fmt.Errorf("%s: %w", op, ErrSeatNotFound)
+		}
+	}
+
+	if err := saveEventUpdate(ctx, tx, saved.EventId, "price_tiers"); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &saved, tx.Commit(ctx)
+}
+
+func (s *Storage) ListPriceTiers(ctx context.Context, eventID int64) ([]*eventv1.PriceTier, error) {
+	const op = "storage.ListPriceTiers"
+
+	rows, err := s.db.Query(
+		ctx,
+		"SELECT id, event_id, name, amount, currency FROM event.price_tiers WHERE event_id = $1 ORDER BY amount DESC, id",
+		eventID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tiers, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*eventv1.PriceTier, error) {
+		var tier eventv1.PriceTier
+		err := row.Scan(&tier.Id, &tier.EventId, &tier.Name, &tier.Amount, &tier.Currency)
+		return &tier, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tiers, nil
+}
+
+func (s *Storage) SetRefundPolicy(ctx context.Context, policy *eventv1.RefundPolicy) (*eventv1.RefundPolicy, error) {
+	const op = "storage.SetRefundPolicy"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var saved eventv1.RefundPolicy
+	err = tx.QueryRow(
+		ctx,
+		`INSERT INTO event.refund_policies (event_id, non_refundable, full_refund_hours, partial_refund_hours, partial_refund_percent)
+		SELECT id, $2, $3, $4, $5 FROM event.events WHERE id = $1
+		ON CONFLICT (event_id) DO UPDATE SET non_refundable = EXCLUDED.non_refundable,
+			full_refund_hours = EXCLUDED.full_refund_hours,
+			partial_refund_hours = EXCLUDED.partial_refund_hours,
+			partial_refund_percent = EXCLUDED.partial_refund_percent
+		RETURNING event_id, non_refundable, full_refund_hours, partial_refund_hours, partial_refund_percent`,
+		policy.EventId,
+		policy.NonRefundable,
+		policy.FullRefundHours,
+		policy.PartialRefundHours,
+		policy.PartialRefundPercent,
+	).Scan(&saved.EventId, &saved.NonRefundable, &saved.FullRefundHours, &saved.PartialRefundHours, &saved.PartialRefundPercent)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := saveEventUpdate(ctx, tx, saved.EventId, "refund_policy"); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &saved, tx.Commit(ctx)
+}
+
+// GetRefundPolicy returns the refund policy of the event, the default one when
+// it has none.
+func (s *Storage) GetRefundPolicy(ctx context.Context, eventID int64) (*eventv1.RefundPolicy, error) {
+	const op = "storage.GetRefundPolicy"
+
+	policy := eventv1.RefundPolicy{EventId: eventID}
+	err := s.db.QueryRow(
+		ctx,
+		`SELECT COALESCE(p.non_refundable, FALSE), COALESCE(p.full_refund_hours, 0),
+			COALESCE(p.partial_refund_hours, 0), COALESCE(p.partial_refund_percent, 0)
+		FROM event.events e LEFT JOIN event.refund_policies p ON p.event_id = e.id
+		WHERE e.id = $1`,
+		eventID,
+	).Scan(&policy.NonRefundable, &policy.FullRefundHours, &policy.PartialRefundHours, &policy.PartialRefundPercent)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &policy, nil
+}
+
+func (s *Storage) SetPurchaseLimits(ctx context.Context, limits *eventv1.PurchaseLimits) (*eventv1.PurchaseLimits, error) {
+	const op = "storage.SetPurchaseLimits"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var saved eventv1.PurchaseLimits
+	err = tx.QueryRow(
+		ctx,
+		`INSERT INTO event.purchase_limits (event_id, max_tickets_per_user, max_pending_bookings, max_tickets_per_booking)
+		SELECT id, $2, $3, $4 FROM event.events WHERE id = $1
+		ON CONFLICT (event_id) DO UPDATE SET max_tickets_per_user = EXCLUDED.max_tickets_per_user,
+			max_pending_bookings = EXCLUDED.max_pending_bookings,
+			max_tickets_per_booking = EXCLUDED.max_tickets_per_booking
+		RETURNING event_id, max_tickets_per_user, max_pending_bookings, max_tickets_per_booking`,
+		limits.EventId,
+		limits.MaxTicketsPerUser,
+		limits.MaxPendingBookings,
+		limits.MaxTicketsPerBooking,
+	).Scan(&saved.EventId, &saved.MaxTicketsPerUser, &saved.MaxPendingBookings, &saved.MaxTicketsPerBooking)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := saveEventUpdate(ctx, tx, saved.EventId, "purchase_limits"); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &saved, tx.Commit(ctx)
+}
+
+// GetPurchaseLimits returns the purchase limits of the event, all zero when it
+// has none.
+func (s *Storage) GetPurchaseLimits(ctx context.Context, eventID int64) (*eventv1.PurchaseLimits, error) {
+	const op = "storage.GetPurchaseLimits"
+
+	limits := eventv1.PurchaseLimits{EventId: eventID}
+	err := s.db.QueryRow(
+		ctx,
+		`SELECT COALESCE(l.max_tickets_per_user, 0), COALESCE(l.max_pending_bookings, 0), COALESCE(l.max_tickets_per_booking, 0)
+		FROM event.events e LEFT JOIN event.purchase_limits l ON l.event_id = e.id
+		WHERE e.id = $1`,
+		eventID,
+	).Scan(&limits.MaxTicketsPerUser, &limits.MaxPendingBookings, &limits.MaxTicketsPerBooking)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &limits, nil
+}
+
+func (s *Storage) SetTransferPolicy(ctx context.Context, policy *eventv1.TransferPolicy) (*eventv1.TransferPolicy, error) {
+	const op = "storage.SetTransferPolicy"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var saved eventv1.TransferPolicy
+	err = tx.QueryRow(
+		ctx,
+		`INSERT INTO event.transfer_policies (event_id, transfers_allowed, cutoff_hours, max_transfers)
+		SELECT id, $2, $3, $4 FROM event.events WHERE id = $1
+		ON CONFLICT (event_id) DO UPDATE SET transfers_allowed = EXCLUDED.transfers_allowed,
+			cutoff_hours = EXCLUDED.cutoff_hours,
+			max_transfers = EXCLUDED.max_transfers
+		RETURNING event_id, transfers_allowed, cutoff_hours, max_transfers`,
+		policy.EventId,
+		policy.TransfersAllowed,
+		policy.CutoffHours,
+		policy.MaxTransfers,
+	).Scan(&saved.EventId, &saved.TransfersAllowed, &saved.CutoffHours, &saved.MaxTransfers)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := saveEventUpdate(ctx, tx, saved.EventId, "transfer_policy"); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &saved, tx.Commit(ctx)
+}
+
+// GetTransferPolicy returns the transfer policy of the event; one without a
+// policy allows transfers.
+func (s *Storage) GetTransferPolicy(ctx context.Context, eventID int64) (*eventv1.TransferPolicy, error) {
+	const op = "storage.GetTransferPolicy"
+
+	policy := eventv1.TransferPolicy{EventId: eventID}
+	err := s.db.QueryRow(
+		ctx,
+		`SELECT COALESCE(p.transfers_allowed, TRUE), COALESCE(p.cutoff_hours, 0), COALESCE(p.max_transfers, 0)
+		FROM event.events e LEFT JOIN event.transfer_policies p ON p.event_id = e.id
+		WHERE e.id = $1`,
+		eventID,
+	).Scan(&policy.TransfersAllowed, &policy.CutoffHours, &policy.MaxTransfers)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &policy, nil
+}
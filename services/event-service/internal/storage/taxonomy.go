@@ -0,0 +1,276 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	eventv1 "github.com/kay-kewl/ticket-booking-system/gen/go/event"
+)
+
+var ErrCategoryNotFound = errors.New("category not found")
+var ErrCategoryCycle = errors.New("category can not be nested under itself")
+var ErrCollectionNotFound = errors.New("collection not found")
+
+func (s *Storage) UpsertCategory(ctx context.Context, category *eventv1.Category) (*eventv1.Category, error) {
+	const op = "storage.UpsertCategory"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var parentID *int64
+	if category.ParentSlug != "" {
+		var id int64
+		err := tx.QueryRow(ctx, "SELECT id FROM event.categories WHERE slug = $1", category.ParentSlug).Scan(&id)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, fmt.Errorf("%s: parent %q: %w", op, category.ParentSlug, ErrCategoryNotFound)
+			}
+			return nil, fmt.Errorf("%s: failed to get parent category: %w", op, err)
+		}
+
+		// moving a category under one of its own descendants would detach the subtree
+		var cycle bool
+		err = tx.QueryRow(
+			ctx,
+			`WITH RECURSIVE ancestors AS (
+				SELECT id, parent_id FROM event.categories WHERE id = $1
+				UNION ALL
+				SELECT c.id, c.parent_id FROM event.categories c JOIN ancestors a ON c.id = a.parent_id
+			)
+			SELECT EXISTS (SELECT 1 FROM ancestors a JOIN event.categories c ON c.id = a.id WHERE c.slug = $2)`,
+			id,
+			category.Slug,
+		).Scan(&cycle)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to check category hierarchy: %w", op, err)
+		}
+		if cycle {
+			return nil, fmt.Errorf("%s: %w", op, ErrCategoryCycle)
+		}
+		parentID = &id
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO event.categories (slug, name, parent_id, position) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (slug) DO UPDATE SET name = EXCLUDED.name, parent_id = EXCLUDED.parent_id, position = EXCLUDED.position`,
+		category.Slug,
+		category.Name,
+		parentID,
+		category.Position,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to save category: %w", op, err)
+	}
+
+	return category, tx.Commit(ctx)
+}
+
+// ListCategories returns all categories as a flat list ordered by position.
+func (s *Storage) ListCategories(ctx context.Context) ([]*eventv1.Category, error) {
+	const op = "storage.ListCategories"
+
+	rows, err := s.db.Query(
+		ctx,
+		`SELECT c.slug, c.name, COALESCE(p.slug, ''), c.position
+		FROM event.categories c LEFT JOIN event.categories p ON p.id = c.parent_id
+		ORDER BY c.position, c.name`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	categories, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*eventv1.Category, error) {
+		var category eventv1.Category
+		err := row.Scan(&category.Slug, &category.Name, &category.ParentSlug, &category.Position)
+		return &category, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return categories, nil
+}
+
+func (s *Storage) SetEventCategories(ctx context.Context, eventID int64, slugs []string) (*eventv1.Event, error) {
+	const op = "storage.SetEventCategories"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockEvent(ctx, tx, eventID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM event.event_categories WHERE event_id = $1", eventID); err != nil {
+		return nil, fmt.Errorf("%s: failed to clear categories: %w", op, err)
+	}
+
+	tag, err := tx.Exec(
+		ctx,
+		"INSERT INTO event.event_categories (event_id, category_id) SELECT $1, id FROM event.categories WHERE slug = ANY($2)",
+		eventID,
+		slugs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to save categories: %w", op, err)
+	}
+	if tag.RowsAffected() != int64(len(slugs)) {
+		return nil, fmt.Errorf("%s: %w", op, ErrCategoryNotFound)
+	}
+
+	if err := saveEventUpdate(ctx, tx, eventID, "categories"); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	event, err := s.scanEvent(tx.QueryRow(ctx, "SELECT "+eventColumns+" FROM event.events WHERE id = $1", eventID))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return event, tx.Commit(ctx)
+}
+
+func (s *Storage) SetEventTags(ctx context.Context, eventID int64, tags []string) (*eventv1.Event, error) {
+	const op = "storage.SetEventTags"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockEvent(ctx, tx, eventID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM event.event_tags WHERE event_id = $1", eventID); err != nil {
+		return nil, fmt.Errorf("%s: failed to clear tags: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, "INSERT INTO event.event_tags (event_id, tag) SELECT $1, unnest($2::text[])", eventID, tags)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to save tags: %w", op, err)
+	}
+
+	if err := saveEventUpdate(ctx, tx, eventID, "tags"); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	event, err := s.scanEvent(tx.QueryRow(ctx, "SELECT "+eventColumns+" FROM event.events WHERE id = $1", eventID))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return event, tx.Commit(ctx)
+}
+
+// UpsertCollection saves the collection and replaces its events, which keep
+// the order of eventIDs.
+func (s *Storage) UpsertCollection(ctx context.Context, collection *eventv1.Collection, eventIDs []int64) error {
+	const op = "storage.UpsertCollection"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var collectionID int64
+	err = tx.QueryRow(
+		ctx,
+		`INSERT INTO event.collections (slug, title, description) VALUES ($1, $2, $3)
+		ON CONFLICT (slug) DO UPDATE SET title = EXCLUDED.title, description = EXCLUDED.description
+		RETURNING id`,
+		collection.Slug,
+		collection.Title,
+		collection.Description,
+	).Scan(&collectionID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to save collection: %w", op, err)
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM event.collection_events WHERE collection_id = $1", collectionID); err != nil {
+		return fmt.Errorf("%s: failed to clear collection events: %w", op, err)
+	}
+
+	tag, err := tx.Exec(
+		ctx,
+		`INSERT INTO event.collection_events (collection_id, event_id, position)
+		SELECT $1, e.id, o.position FROM unnest($2::bigint[]) WITH ORDINALITY AS o(event_id, position)
+		JOIN event.events e ON e.id = o.event_id`,
+		collectionID,
+		eventIDs,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: failed to save collection events: %w", op, err)
+	}
+	if tag.RowsAffected() != int64(len(eventIDs)) {
+		return fmt.Errorf("%s: %w", op, ErrEventNotFound)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetCollection returns the collection with its events in editorial order;
+// drafts are left out like everywhere else in the public catalog.
+func (s *Storage) GetCollection(ctx context.Context, slug string) (*eventv1.Collection, error) {
+	const op = "storage.GetCollection"
+
+	var (
+		collection   eventv1.Collection
+		collectionID int64
+	)
+	err := s.db.QueryRow(
+		ctx,
+		"SELECT id, slug, title, description FROM event.collections WHERE slug = $1",
+		slug,
+	).Scan(&collectionID, &collection.Slug, &collection.Title, &collection.Description)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrCollectionNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(
+		ctx,
+		`SELECT `+eventColumns+` FROM event.events
+		JOIN event.collection_events ce ON ce.event_id = events.id
+		WHERE ce.collection_id = $1 AND status <> 'DRAFT'
+		ORDER BY ce.position`,
+		collectionID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query collection events: %w", op, err)
+	}
+
+	collection.Events, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (*eventv1.Event, error) {
+		return s.scanEvent(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to scan collection events: %w", op, err)
+	}
+
+	return &collection, nil
+}
+
+func lockEvent(ctx context.Context, tx pgx.Tx, eventID int64) error {
+	err := tx.QueryRow(ctx, "SELECT id FROM event.events WHERE id = $1 FOR UPDATE", eventID).Scan(new(int64))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrEventNotFound
+		}
+		return fmt.Errorf("failed to lock event: %w", err)
+	}
+	return nil
+}
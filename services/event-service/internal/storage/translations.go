@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrTranslationNotFound = errors.New("translation not found")
+
+// Translation is the text of an event in a locale other than the default one.
+type Translation struct {
+	Locale      string
+	Title       string
+	Description string
+}
+
+// EventTranslations is an event with the text it has in the default locale
+// and all its translations, as used by the translation status report.
+type EventTranslations struct {
+	EventID      int64
+	Title        string
+	Description  string
+	Translations []Translation
+}
+
+func (s *Storage) SetEventTranslation(ctx context.Context, eventID int64, translation Translation) error {
+	const op = "storage.SetEventTranslation"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(
+		ctx,
+		`INSERT INTO event.event_translations (event_id, locale, title, description)
+		SELECT id, $2, $3, $4 FROM event.events WHERE id = $1
+		ON CONFLICT (event_id, locale) DO UPDATE
+			SET title = EXCLUDED.title, description = EXCLUDED.description, updated_at = NOW()`,
+		eventID,
+		translation.Locale,
+		translation.Title,
+		translation.Description,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrEventNotFound)
+	}
+
+	if err := saveEventUpdate(ctx, tx, eventID, "translations"); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (s *Storage) DeleteEventTranslation(ctx context.Context, eventID int64, locale string) error {
+	const op = "storage.DeleteEventTranslation"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, "DELETE FROM event.event_translations WHERE event_id = $1 AND locale = $2", eventID, locale)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrTranslationNotFound)
+	}
+
+	if err := saveEventUpdate(ctx, tx, eventID, "translations"); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ListEventTranslations returns the translations of the events by event id
+// and locale; events without translations are left out.
+func (s *Storage) ListEventTranslations(ctx context.Context, eventIDs []int64) (map[int64]map[string]Translation, error) {
+	const op = "storage.ListEventTranslations"
+
+	rows, err := s.db.Query(
+		ctx,
+		"SELECT event_id, locale, title, description FROM event.event_translations WHERE event_id = ANY($1)",
+		eventIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	translations := make(map[int64]map[string]Translation)
+	for rows.Next() {
+		var (
+			eventID int64
+			t       Translation
+		)
+		if err := rows.Scan(&eventID, &t.Locale, &t.Title, &t.Description); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if translations[eventID] == nil {
+			translations[eventID] = make(map[string]Translation)
+		}
+		translations[eventID][t.Locale] = t
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return translations, nil
+}
+
+// ListTranslationStatus returns the events of an organizer, or of everyone
+// when organizerID is 0, drafts included, together with their translations.
+func (s *Storage) ListTranslationStatus(ctx context.Context, organizerID int64, limit int32) ([]EventTranslations, error) {
+	const op = "storage.ListTranslationStatus"
+
+	rows, err := s.db.Query(
+		ctx,
+		`SELECT e.id, e.title, COALESCE(e.description, ''),
+			(SELECT COALESCE(json_agg(json_build_object('locale', t.locale, 'title', t.title, 'description', t.description) ORDER BY t.locale), '[]')
+				FROM event.event_translations t WHERE t.event_id = e.id)
+		FROM event.events e
+		WHERE $1 = 0 OR e.organizer_id = $1
+		ORDER BY e.id
+		LIMIT $2`,
+		organizerID,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (EventTranslations, error) {
+		var (
+			e            EventTranslations
+			translations []struct {
+				Locale      string `json:"locale"`
+				Title       string `json:"title"`
+				Description string `json:"description"`
+			}
+		)
+		if err := row.Scan(&e.EventID, &e.Title, &e.Description, &translations); err != nil {
+			return e, err
+		}
+		for _, t := range translations {
+			e.Translations = append(e.Translations, Translation{Locale: t.Locale, Title: t.Title, Description: t.Description})
+		}
+		return e, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,101 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/kay-kewl/ticket-booking-system/internal/outbox"
+)
+
+type CatalogInvalidator interface {
+	InvalidateEvent(eventID int64)
+	InvalidateCatalog()
+}
+
+// CacheInvalidationWorker keeps the catalog cache of this instance in sync
+// with event changes announced on events_exchange, including the ones made
+// by the lifecycle worker and by other instances.
+type CacheInvalidationWorker struct {
+	provider    outbox.ChannelProvider
+	invalidator CatalogInvalidator
+	logger      *slog.Logger
+}
+
+func NewCacheInvalidationWorker(provider outbox.ChannelProvider, invalidator CatalogInvalidator, logger *slog.Logger) *CacheInvalidationWorker {
+	return &CacheInvalidationWorker{
+		provider:    provider,
+		invalidator: invalidator,
+		logger:      logger,
+	}
+}
+
+func (w *CacheInvalidationWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting Cache Invalidation Worker")
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Stopping Cache Invalidation Worker")
+			return
+		default:
+		}
+
+		if err := w.consume(ctx); err != nil {
+			w.logger.Error("Cache invalidation consumer failed, retrying...", "error", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(10 * time.Second):
+			}
+		}
+	}
+}
+
+// consume listens on a queue of its own: every instance has to see every
+// notification, so the queue is exclusive and goes away with the connection.
+func (w *CacheInvalidationWorker) consume(ctx context.Context) error {
+	ch, err := w.provider.GetChannel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	queue, err := ch.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		return err
+	}
+	if err := ch.QueueBind(queue.Name, "event.*", "events_exchange", false, nil); err != nil {
+		return err
+	}
+
+	msgs, err := ch.Consume(queue.Name, "", true, true, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	// notifications sent while there was no queue are lost
+	w.invalidator.InvalidateCatalog()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case d, ok := <-msgs:
+			if !ok {
+				w.logger.Warn("Cache invalidation channel closed. Reconnecting...")
+				return nil
+			}
+
+			var msgBody struct {
+				EventID int64 `json:"event_id"`
+			}
+			if err := json.Unmarshal(d.Body, &msgBody); err != nil || msgBody.EventID == 0 {
+				w.logger.Warn("Invalid event notification, dropping the whole cache", "routing_key", d.RoutingKey, "body", string(d.Body))
+				w.invalidator.InvalidateCatalog()
+				continue
+			}
+
+			w.invalidator.InvalidateEvent(msgBody.EventID)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type TransitionApplier interface {
+	ApplyScheduledTransitions(ctx context.Context) (int, error)
+}
+
+// LifecycleWorker periodically applies the time- and inventory-driven status
+// changes: the sales window opening and closing, and sold-out detection.
+type LifecycleWorker struct {
+	applier TransitionApplier
+	logger  *slog.Logger
+	ticker  *time.Ticker
+}
+
+func NewLifecycleWorker(applier TransitionApplier, logger *slog.Logger, interval time.Duration) *LifecycleWorker {
+	return &LifecycleWorker{
+		applier: applier,
+		logger:  logger,
+		ticker:  time.NewTicker(interval),
+	}
+}
+
+func (w *LifecycleWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting Lifecycle Worker")
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Stopping Lifecycle Worker")
+			w.ticker.Stop()
+			return
+		case <-w.ticker.C:
+			changed, err := w.applier.ApplyScheduledTransitions(ctx)
+			if err != nil {
+				w.logger.Error("Failed to apply scheduled status transitions", "error", err)
+				continue
+			}
+			if changed > 0 {
+				w.logger.Info("Applied scheduled status transitions", "events", changed)
+			}
+		}
+	}
+}
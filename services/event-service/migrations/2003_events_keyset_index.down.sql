@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_events_on_created_at_and_id;
+
+CREATE INDEX IF NOT EXISTS idx_events_on_created_at ON events (created_at DESC);
\ No newline at end of file
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_event_outbox_unprocessed;
+DROP TABLE IF EXISTS outbox_messages;
+
+DROP INDEX IF EXISTS idx_events_on_status;
+
+ALTER TABLE events
+    DROP CONSTRAINT IF EXISTS chk_events_sales_window,
+    DROP COLUMN IF EXISTS off_sale_at,
+    DROP COLUMN IF EXISTS on_sale_at,
+    DROP COLUMN IF EXISTS status;
+
+DROP TYPE IF EXISTS event_status;
@@ -0,0 +1,23 @@
+CREATE TYPE event_status AS ENUM ('DRAFT', 'PUBLISHED', 'ON_SALE', 'SOLD_OUT', 'CANCELLED');
+
+-- events created before lifecycle states existed were already on sale
+ALTER TABLE events
+    ADD COLUMN status event_status NOT NULL DEFAULT 'ON_SALE',
+    ADD COLUMN on_sale_at TIMESTAMPTZ,
+    ADD COLUMN off_sale_at TIMESTAMPTZ,
+    ADD CONSTRAINT chk_events_sales_window CHECK (off_sale_at IS NULL OR on_sale_at IS NULL OR off_sale_at > on_sale_at);
+
+ALTER TABLE events ALTER COLUMN status SET DEFAULT 'DRAFT';
+
+CREATE INDEX IF NOT EXISTS idx_events_on_status ON events (status);
+
+CREATE TABLE IF NOT EXISTS outbox_messages (
+    id BIGSERIAL PRIMARY KEY,
+    exchange TEXT NOT NULL,
+    routing_key TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_outbox_unprocessed ON outbox_messages (processed_at) WHERE processed_at IS NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_events_on_organizer;
+
+ALTER TABLE events DROP COLUMN IF EXISTS organizer_id;
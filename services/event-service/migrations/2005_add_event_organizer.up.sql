@@ -0,0 +1,3 @@
+ALTER TABLE events ADD COLUMN organizer_id BIGINT REFERENCES auth.users(id);
+
+CREATE INDEX IF NOT EXISTS idx_events_on_organizer ON events (organizer_id);
@@ -0,0 +1,3 @@
+ALTER TABLE seats DROP COLUMN IF EXISTS price_tier_id;
+
+DROP TABLE IF EXISTS price_tiers;
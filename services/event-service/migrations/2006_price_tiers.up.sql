@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS price_tiers (
+    id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount >= 0), -- в минимальных единицах валюты
+    currency CHAR(3) NOT NULL,
+    UNIQUE (event_id, name)
+);
+
+ALTER TABLE seats ADD COLUMN IF NOT EXISTS price_tier_id BIGINT REFERENCES price_tiers(id) ON DELETE SET NULL;
+
+-- existing events keep the price that used to be hard-coded in booking-service
+INSERT INTO price_tiers (event_id, name, amount, currency)
+SELECT id, 'Standard', 150000, 'RUB' FROM events
+ON CONFLICT (event_id, name) DO NOTHING;
+
+UPDATE seats s SET price_tier_id = t.id
+FROM price_tiers t
+WHERE t.event_id = s.event_id AND t.name = 'Standard' AND s.price_tier_id IS NULL;
@@ -0,0 +1,13 @@
+-- general admission: a pool sells up to capacity tickets without numbered seats;
+-- held tickets belong to unpaid bookings, sold ones to confirmed bookings
+CREATE TABLE IF NOT EXISTS inventory_pools (
+    id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    capacity INT NOT NULL CHECK (capacity >= 0),
+    sold INT NOT NULL DEFAULT 0 CHECK (sold >= 0),
+    held INT NOT NULL DEFAULT 0 CHECK (held >= 0),
+    price_tier_id BIGINT REFERENCES price_tiers(id) ON DELETE SET NULL,
+    UNIQUE (event_id, name),
+    CONSTRAINT chk_inventory_pools_not_oversold CHECK (sold + held <= capacity)
+);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS collection_events;
+DROP TABLE IF EXISTS collections;
+DROP TABLE IF EXISTS event_tags;
+DROP TABLE IF EXISTS event_categories;
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,12 @@
+-- renditions: [{"name": "thumbnail", "key": "events/1/<uuid>/thumbnail.jpg", "width": 320, "height": 180}, ...]
+CREATE TABLE IF NOT EXISTS event_images (
+    id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('POSTER', 'GALLERY')),
+    position INT NOT NULL DEFAULT 0,
+    renditions JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_images_on_event ON event_images (event_id, position);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_event_images_poster ON event_images (event_id) WHERE kind = 'POSTER';
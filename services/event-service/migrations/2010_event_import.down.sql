@@ -0,0 +1,3 @@
+ALTER TABLE events
+    DROP COLUMN IF EXISTS starts_at,
+    DROP COLUMN IF EXISTS external_ref;
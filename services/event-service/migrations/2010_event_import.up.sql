@@ -0,0 +1,4 @@
+-- external_ref is the organizer's own key of an event, so that re-importing a file updates instead of duplicating
+ALTER TABLE events
+    ADD COLUMN external_ref VARCHAR(100) UNIQUE,
+    ADD COLUMN starts_at TIMESTAMPTZ;
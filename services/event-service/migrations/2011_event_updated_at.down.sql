@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS event_images_touch_event ON event_images;
+DROP TRIGGER IF EXISTS event_tags_touch_event ON event_tags;
+DROP TRIGGER IF EXISTS event_categories_touch_event ON event_categories;
+DROP FUNCTION IF EXISTS touch_parent_event();
+DROP TRIGGER IF EXISTS events_set_updated_at ON events;
+DROP FUNCTION IF EXISTS set_event_updated_at();
+ALTER TABLE events DROP COLUMN IF EXISTS updated_at;
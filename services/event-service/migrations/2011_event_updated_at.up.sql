@@ -0,0 +1,36 @@
+-- updated_at backs HTTP Last-Modified of the catalog; changes to categories, tags and images touch the event as well
+ALTER TABLE events ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+CREATE OR REPLACE FUNCTION set_event_updated_at() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER events_set_updated_at
+    BEFORE UPDATE ON events
+    FOR EACH ROW EXECUTE FUNCTION set_event_updated_at();
+
+CREATE OR REPLACE FUNCTION touch_parent_event() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        UPDATE events SET updated_at = NOW() WHERE id = OLD.event_id;
+    ELSE
+        UPDATE events SET updated_at = NOW() WHERE id = NEW.event_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER event_categories_touch_event
+    AFTER INSERT OR UPDATE OR DELETE ON event_categories
+    FOR EACH ROW EXECUTE FUNCTION touch_parent_event();
+
+CREATE TRIGGER event_tags_touch_event
+    AFTER INSERT OR UPDATE OR DELETE ON event_tags
+    FOR EACH ROW EXECUTE FUNCTION touch_parent_event();
+
+CREATE TRIGGER event_images_touch_event
+    AFTER INSERT OR UPDATE OR DELETE ON event_images
+    FOR EACH ROW EXECUTE FUNCTION touch_parent_event();
@@ -0,0 +1,13 @@
+-- title and description of events are in the default locale, other locales live here
+CREATE TABLE IF NOT EXISTS event_translations (
+    event_id BIGINT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    locale VARCHAR(35) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (event_id, locale)
+);
+
+CREATE TRIGGER event_translations_touch_event
+    AFTER INSERT OR UPDATE OR DELETE ON event_translations
+    FOR EACH ROW EXECUTE FUNCTION touch_parent_event();
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS sector_scores;
+DROP INDEX IF EXISTS idx_seats_on_event_sector_row;
+ALTER TABLE seats DROP COLUMN IF EXISTS position;
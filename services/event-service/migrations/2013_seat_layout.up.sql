@@ -0,0 +1,15 @@
+-- position is the place of a seat in its row: neighbours have consecutive positions,
+-- which is what best-available selection uses to find seats together
+ALTER TABLE seats ADD COLUMN IF NOT EXISTS position INT;
+
+UPDATE seats SET position = NULLIF(substring(seat_number from '[0-9]+$'), '')::int WHERE position IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_seats_on_event_sector_row ON seats (event_id, sector, row_number, position);
+
+-- how good the seats of a sector are, 0 to 100; sectors without a score count as 50
+CREATE TABLE IF NOT EXISTS sector_scores (
+    event_id BIGINT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    sector VARCHAR(50) NOT NULL,
+    score INT NOT NULL CHECK (score BETWEEN 0 AND 100),
+    PRIMARY KEY (event_id, sector)
+);
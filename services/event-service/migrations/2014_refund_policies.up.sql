@@ -0,0 +1,11 @@
+-- a booking cancelled at least full_refund_hours before the start is refunded in full,
+-- at least partial_refund_hours before it by partial_refund_percent, later not at all;
+-- events without a policy refund in full until they start
+CREATE TABLE IF NOT EXISTS refund_policies (
+    event_id BIGINT PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    non_refundable BOOLEAN NOT NULL DEFAULT FALSE,
+    full_refund_hours INT NOT NULL DEFAULT 0 CHECK (full_refund_hours >= 0),
+    partial_refund_hours INT NOT NULL DEFAULT 0 CHECK (partial_refund_hours >= 0),
+    partial_refund_percent INT NOT NULL DEFAULT 0 CHECK (partial_refund_percent BETWEEN 0 AND 100),
+    CHECK (partial_refund_hours <= full_refund_hours)
+);
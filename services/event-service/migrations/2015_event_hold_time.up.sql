@@ -0,0 +1,3 @@
+-- how long a booking of the event holds its tickets before it expires unpaid;
+-- NULL leaves it to booking-service's default
+ALTER TABLE events ADD COLUMN IF NOT EXISTS hold_minutes INT CHECK (hold_minutes BETWEEN 1 AND 1440);
@@ -0,0 +1,3 @@
+-- how many times a buyer may extend the hold of a booking of the event;
+-- NULL leaves it to booking-service's default
+ALTER TABLE events ADD COLUMN IF NOT EXISTS max_hold_extensions INT CHECK (max_hold_extensions BETWEEN 0 AND 10);
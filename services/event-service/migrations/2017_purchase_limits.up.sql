@@ -0,0 +1,8 @@
+-- limits on what one customer may buy of an event; 0 means no limit
+CREATE TABLE IF NOT EXISTS purchase_limits (
+    event_id BIGINT PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    -- seats and general-admission tickets of paid and held bookings together
+    max_tickets_per_user INT NOT NULL DEFAULT 0 CHECK (max_tickets_per_user >= 0),
+    max_pending_bookings INT NOT NULL DEFAULT 0 CHECK (max_pending_bookings >= 0),
+    max_tickets_per_booking INT NOT NULL DEFAULT 0 CHECK (max_tickets_per_booking >= 0)
+);
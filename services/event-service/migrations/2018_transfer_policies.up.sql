@@ -0,0 +1,10 @@
+-- whether customers may hand tickets of an event over to someone else; events
+-- without a policy allow transfers until they start
+CREATE TABLE IF NOT EXISTS transfer_policies (
+    event_id BIGINT PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    transfers_allowed BOOLEAN NOT NULL DEFAULT TRUE,
+    -- transfers close this many hours before the start
+    cutoff_hours INT NOT NULL DEFAULT 0 CHECK (cutoff_hours >= 0),
+    -- how often a ticket may change hands, 0 means no limit
+    max_transfers INT NOT NULL DEFAULT 0 CHECK (max_transfers >= 0)
+);
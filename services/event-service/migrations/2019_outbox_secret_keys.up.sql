@@ -0,0 +1,3 @@
+-- the outbox worker is shared with booking-service and drops these keys of
+-- the payload once a message has been published
+ALTER TABLE outbox_messages ADD COLUMN IF NOT EXISTS secret_keys TEXT[] NOT NULL DEFAULT '{}';
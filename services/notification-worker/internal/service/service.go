@@ -5,7 +5,9 @@ import (
     "encoding/json"
     "fmt"
     "log/slog"
+    "time"
 
+    "github.com/kay-kewl/ticket-booking-system/internal/ical"
     "github.com/kay-kewl/ticket-booking-system/internal/rabbitmq"
     amqp "github.com/rabbitmq/amqp091-go"
 )
@@ -67,9 +69,29 @@ func (s *NotificationService) StartConsumer(ctx context.Context, rabbitManager *
     }
 }
 
+// Attachment is a file sent along with a notification.
+type Attachment struct {
+    Filename    string
+    ContentType string
+    Content     []byte
+}
+
 func (s *NotificationService) processMessage(msg amqp.Delivery) error {
     var message struct {
-        BookingID int64 `json:"booking_id"`
+        BookingID       int64   `json:"booking_id"`
+        EventTitle      string  `json:"event_title"`
+        EventStartsAt   string  `json:"event_starts_at"`
+        RefundAmount    int64   `json:"refund_amount"`
+        Currency        string  `json:"currency"`
+        UserID          int64   `json:"user_id"`
+        EventID         int64   `json:"event_id"`
+        Quantity        int32   `json:"quantity"`
+        ExpiresAt       string  `json:"expires_at"`
+        ClaimToken      string  `json:"claim_token"`
+        TransferID      int64   `json:"transfer_id"`
+        RecipientEmail  string  `json:"recipient_email"`
+        AcceptToken     string  `json:"accept_token"`
+        ChangeType      string  `json:"change_type"`
     }
 
     if err := json.Unmarshal(msg.Body, &message); err != nil {
@@ -77,21 +99,95 @@ func (s *NotificationService) processMessage(msg amqp.Delivery) error {
     }
 
     var notificationType string
+    var attachments []Attachment
     switch msg.RoutingKey {
     case "booking.confirmed":
         notificationType = "Booking Confirmed"
+        if message.EventStartsAt != "" {
+            attachment, err := bookingCalendarAttachment(message.BookingID, message.EventTitle, message.EventStartsAt)
+            if err != nil {
+                // the confirmation matters more than the calendar entry
+                s.logger.Warn("Failed to build calendar attachment", "booking_id", message.BookingID, "error", err)
+            } else {
+                attachments = append(attachments, attachment)
+            }
+        }
     case "booking.cancelled":
         notificationType = "Booking Cancelled"
+        if message.RefundAmount > 0 {
+            // amounts are in minor units
+            notificationType = fmt.Sprintf("Booking Cancelled, %d.%02d %s Refunded", message.RefundAmount/100, message.RefundAmount%100, message.Currency)
+        }
     case "booking.expired":
         notificationType = "Booking Expired"
+    case "booking.refunded":
+        notificationType = "Event Cancelled, Booking Refunded"
+    case "booking.event_cancelled":
+        notificationType = "Event Cancelled"
+    case "waitlist.offered":
+        notificationType = fmt.Sprintf("%d Tickets For %s Are Yours Until %s", message.Quantity, message.EventTitle, message.ExpiresAt)
+        // the claim token lets whoever reads it book the offer, so it goes into the email and nowhere else
+        s.logger.Info("Simulating sending notification", "type", notificationType, "user_id", message.UserID, "event_id", message.EventID)
+        return nil
+    case "waitlist.offer_expired":
+        notificationType = "Waitlist Offer Expired"
+        s.logger.Info("Simulating sending notification", "type", notificationType, "user_id", message.UserID, "event_id", message.EventID)
+        return nil
+    case "transfer.started":
+        notificationType = fmt.Sprintf("Tickets Sent To You, Accept Them Until %s", message.ExpiresAt)
+        // the recipient may not have an account yet, so the email goes to the address the sender gave;
+        // the accept token hands the tickets to whoever reads it and goes into that email only
+        s.logger.Info("Simulating sending notification", "type", notificationType, "email", message.RecipientEmail, "transfer_id", message.TransferID)
+        return nil
+    case "transfer.accepted":
+        notificationType = fmt.Sprintf("Your Tickets For %s Were Accepted", message.EventTitle)
+        s.logger.Info("Simulating sending notification", "type", notificationType, "user_id", message.UserID, "transfer_id", message.TransferID)
+        return nil
+    case "booking.seats_changed":
+        notificationType = fmt.Sprintf("Seats For %s Exchanged, Tickets Reissued", message.EventTitle)
+        if message.ChangeType == "CANCELLATION" {
+            notificationType = fmt.Sprintf("Seats For %s Cancelled, Tickets Reissued", message.EventTitle)
+        }
+        if message.RefundAmount > 0 {
+            notificationType += fmt.Sprintf(", %d.%02d %s Refunded", message.RefundAmount/100, message.RefundAmount%100, message.Currency)
+        }
     default:
         notificationType = "Unknown Event"
     }
 
+    for _, attachment := range attachments {
+        s.logger.Info("Attaching file to notification", "booking_id", message.BookingID, "filename", attachment.Filename, "content_type", attachment.ContentType, "size", len(attachment.Content))
+    }
     s.logger.Info("Simulating sending notification", "type", notificationType, "booking_id", message.BookingID)
     return nil
 }
 
+// bookingCalendarAttachment builds the .ics file of a confirmed booking, with
+// the UID of the booking in the personal calendar feed.
+func bookingCalendarAttachment(bookingID int64, title, startsAt string) (Attachment, error) {
+    start, err := time.Parse(time.RFC3339, startsAt)
+    if err != nil {
+        return Attachment{}, fmt.Errorf("invalid event start %q: %w", startsAt, err)
+    }
+
+    content := ical.Render(ical.Calendar{
+        Events: []ical.Event{{
+            UID:         ical.BookingUID(bookingID),
+            Start:       start,
+            Duration:    ical.DefaultEventDuration,
+            Summary:     title,
+            Description: fmt.Sprintf("Booking #%d", bookingID),
+            Status:      "CONFIRMED",
+        }},
+    }, time.Now())
+
+    return Attachment{
+        Filename:    fmt.Sprintf("booking-%d.ics", bookingID),
+        ContentType: ical.ContentType,
+        Content:     content,
+    }, nil
+}
+
 func (s *NotificationService) setupTopology(ch *amqp.Channel) error {
     _, err := ch.QueueDeclare("notification_dlq", true, false, false, false, nil)
     if err != nil {
@@ -112,6 +208,13 @@ func (s *NotificationService) setupTopology(ch *amqp.Channel) error {
         "booking.confirmed",
         "booking.cancelled",
         "booking.expired",
+        "booking.refunded",
+        "booking.event_cancelled",
+        "waitlist.offered",
+        "waitlist.offer_expired",
+        "transfer.started",
+        "transfer.accepted",
+        "booking.seats_changed",
     }
 
     for _, eventKey := range eventsToBind {
@@ -31,6 +31,7 @@ func main() {
 
     mux := http.NewServeMux()
     mux.HandleFunc("POST /v1/payments", paymentService.CreatePaymentHandler)
+    mux.HandleFunc("POST /v1/payments/refunds", paymentService.CreateRefundHandler)
 
     port := "8081"
     logger.Info("Starting payment-service", "port", port)
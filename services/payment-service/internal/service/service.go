@@ -11,6 +11,7 @@ import (
     "log/slog"
     "math/rand/v2"
     "net/http"
+    "sync"
     "time"
 )
 
@@ -18,6 +19,10 @@ type PaymentService struct {
     logger              *slog.Logger
     webhookTargetURL    string
     webhookSecret       []byte
+
+    mu                  sync.Mutex
+    // idempotency keys of the refunds paid out so far
+    refunded            map[string]bool
 }
 
 func New(logger *slog.Logger, targetURL, secret string) *PaymentService {
@@ -25,12 +30,15 @@ func New(logger *slog.Logger, targetURL, secret string) *PaymentService {
         logger:             logger,
         webhookTargetURL:   targetURL,
         webhookSecret:      []byte(secret),
+        refunded:           make(map[string]bool),
     }
 }
 
+// Amount is in minor units of Currency, e.g. 150000 RUB is 1500.00
 type CreatePaymentRequest struct {
     BookingID   int64   `json:"booking_id"`
-    Amount      float64 `json:"amount"`
+    Amount      int64   `json:"amount"`
+    Currency    string  `json:"currency"`
 }
 
 type CreatePaymentResponse struct {
@@ -45,7 +53,7 @@ func (s *PaymentService) CreatePaymentHandler(w http.ResponseWriter, r *http.Req
         return
     }
 
-    s.logger.Info("Payment creation request received", "booking_id", req.BookingID)
+    s.logger.Info("Payment creation request received", "booking_id", req.BookingID, "amount", req.Amount, "currency", req.Currency)
 
     go s.simulatePaymentAndSendWebhook(req.BookingID)
 
@@ -57,6 +65,49 @@ func (s *PaymentService) CreatePaymentHandler(w http.ResponseWriter, r *http.Req
     })
 }
 
+// without an amount the whole payment is refunded; a refund sent again
+// under the same idempotency key is not paid out twice
+type CreateRefundRequest struct {
+    BookingID       int64   `json:"booking_id"`
+    Amount          int64   `json:"amount,omitempty"`
+    Currency        string  `json:"currency,omitempty"`
+    IdempotencyKey  string  `json:"idempotency_key,omitempty"`
+}
+
+type CreateRefundResponse struct {
+    BookingID   int64   `json:"booking_id"`
+    Status      string  `json:"status"`
+}
+
+// refunds are settled synchronously, the caller only needs to know whether the money went back
+func (s *PaymentService) CreateRefundHandler(w http.ResponseWriter, r *http.Request) {
+    var req CreateRefundRequest
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        http.Error(w, "Invalid request body", http.StatusBadRequest)
+        return
+    }
+
+    s.mu.Lock()
+    repeated := req.IdempotencyKey != "" && s.refunded[req.IdempotencyKey]
+    if req.IdempotencyKey != "" {
+        s.refunded[req.IdempotencyKey] = true
+    }
+    s.mu.Unlock()
+
+    if repeated {
+        s.logger.Info("Refund already paid out", "booking_id", req.BookingID, "idempotency_key", req.IdempotencyKey)
+    } else {
+        s.logger.Info("Refund request received", "booking_id", req.BookingID, "amount", req.Amount, "currency", req.Currency, "idempotency_key", req.IdempotencyKey)
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(http.StatusOK)
+    json.NewEncoder(w).Encode(CreateRefundResponse{
+        BookingID:  req.BookingID,
+        Status:     "REFUNDED",
+    })
+}
+
 func (s *PaymentService) simulatePaymentAndSendWebhook(bookingID int64) {
     time.Sleep(3 * time.Second)
 
@@ -7,6 +7,7 @@ import (
     "sync"
     "syscall"
 
+    "github.com/kay-kewl/ticket-booking-system/internal/blobstore"
     "github.com/kay-kewl/ticket-booking-system/internal/config"
     "github.com/kay-kewl/ticket-booking-system/internal/logging"
     "github.com/kay-kewl/ticket-booking-system/internal/rabbitmq"
@@ -23,6 +24,11 @@ func main() {
         os.Exit(1)
     }
 
+    if cfg.TicketSecret == "" {
+        logger.Error("TICKET_SECRET environmental variable is not set")
+        os.Exit(1)
+    }
+
     outputPath := os.Getenv("TICKET_OUTPUT_PATH")
     if outputPath == "" {
         outputPath = "/tickets"
@@ -31,7 +37,10 @@ func main() {
     rabbitManager := rabbitmq.NewConnectionManager(cfg.RabbitMQURL, logger)
     defer rabbitManager.Close()
 
-    ticketService := service.New(outputPath, logger)
+    // the poster renditions are read from the same store event-service writes to
+    media := blobstore.NewFileSystem(cfg.MediaStoragePath, cfg.MediaBaseURL)
+
+    ticketService := service.New(outputPath, cfg.TicketSecret, media, logger)
 
     ctx, cancel := context.WithCancel(context.Background())
     var wg sync.WaitGroup
@@ -3,23 +3,29 @@ package service
 import (
     "context"
     "encoding/json"
+    "errors"
     "fmt"
     "log/slog"
     "os"
     "time"
 
     "github.com/jung-kurt/gofpdf"
+    "github.com/kay-kewl/ticket-booking-system/internal/blobstore"
     "github.com/kay-kewl/ticket-booking-system/internal/rabbitmq"
+    "github.com/kay-kewl/ticket-booking-system/internal/ticketcode"
     amqp "github.com/rabbitmq/amqp091-go"
 )
 
 type TicketService struct {
     outputPath  string
+    // signs the check-in code printed on every ticket
+    secret      []byte
+    media       blobstore.Store
     logger      *slog.Logger
 }
 
-func New(outputPath string, logger *slog.Logger) *TicketService {
-    return &TicketService{outputPath, logger}
+func New(outputPath string, secret string, media blobstore.Store, logger *slog.Logger) *TicketService {
+    return &TicketService{outputPath, []byte(secret), media, logger}
 }
 
 func (s *TicketService) StartConsumer(ctx context.Context, rabbitManager *rabbitmq.ConnectionManager) {
@@ -92,6 +98,10 @@ func (s *TicketService) processMessage(msg amqp.Delivery) error {
         BookingID   int64   `json:"booking_id"`
         UserEmail   string  `json:"user_email"`
         EventTitle  string  `json:"event_title"`
+        PosterURL   string  `json:"event_poster_url"`
+        // set when the tickets changed hands; 0 means the first ticket
+        TicketVersion   int32   `json:"ticket_version"`
+        VoidedVersion   int32   `json:"voided_version"`
     }
 
     if err := json.Unmarshal(msg.Body, &message); err != nil {
@@ -106,26 +116,85 @@ func (s *TicketService) processMessage(msg amqp.Delivery) error {
     pdf.AddPage()
     pdf.SetFont("Arial", "B", 16)
     pdf.Cell(40, 10, "Your Ticket")
+
+    if message.PosterURL != "" {
+        // a ticket without the poster is still a valid ticket
+        if err := s.addPoster(pdf, message.PosterURL); err != nil {
+            s.logger.Warn("Failed to embed event poster", "booking_id", message.BookingID, "url", message.PosterURL, "error", err)
+            pdf.ClearError()
+        }
+    }
     pdf.Ln(20)
 
     pdf.SetFont("Arial", "", 12)
     pdf.Cell(40, 10, fmt.Sprintf("Booking ID: %d", message.BookingID))
     pdf.Ln(10)
+    if message.TicketVersion > 1 {
+        pdf.Cell(40, 10, fmt.Sprintf("Ticket version: %d (earlier versions are void)", message.TicketVersion))
+        pdf.Ln(10)
+    }
     pdf.Cell(40, 10, fmt.Sprintf("Issued: %s", time.Now().Format("2006-01-02 15:04:05")))
     pdf.Ln(10)
     pdf.Cell(40, 10, fmt.Sprintf("Event: %s", message.EventTitle))
     pdf.Ln(10)
     pdf.Cell(40, 10, fmt.Sprintf("Email: %s", message.UserEmail))
+    pdf.Ln(10)
+
+    // scanned at the door; a code of a reissued ticket is turned away
+    code := ticketcode.Sign(s.secret, ticketcode.Code{BookingID: message.BookingID, Version: max(message.TicketVersion, 1)})
+    pdf.SetFont("Courier", "", 8)
+    pdf.MultiCell(0, 5, fmt.Sprintf("Check-in code: %s", code), "", "", false)
 
-    filename := fmt.Sprintf("%s/ticket_%d.pdf", s.outputPath, message.BookingID)
+    filename := s.ticketFile(message.BookingID, message.TicketVersion)
     if err := pdf.OutputFileAndClose(filename); err != nil {
         return fmt.Errorf("failed to save PDF file: %w", err)
     }
 
+    if message.VoidedVersion > 0 {
+        // the voided ticket must not be handed out again
+        voided := s.ticketFile(message.BookingID, message.VoidedVersion)
+        if err := os.Remove(voided); err != nil && !errors.Is(err, os.ErrNotExist) {
+            return fmt.Errorf("failed to remove voided ticket: %w", err)
+        }
+        s.logger.Info("Voided ticket removed", "booking_id", message.BookingID, "version", message.VoidedVersion)
+    }
+
     s.logger.Info("Ticket generated successfully", "booking_id", message.BookingID, "file", filename)
     return nil
 }
 
+// ticketFile is where version of the ticket of the booking is kept. The first
+// version keeps the name tickets always had.
+func (s *TicketService) ticketFile(bookingID int64, version int32) string {
+    if version <= 1 {
+        return fmt.Sprintf("%s/ticket_%d.pdf", s.outputPath, bookingID)
+    }
+    return fmt.Sprintf("%s/ticket_%d_v%d.pdf", s.outputPath, bookingID, version)
+}
+
+// addPoster places the poster in the top right corner of the page.
+func (s *TicketService) addPoster(pdf *gofpdf.Fpdf, url string) error {
+    key, ok := s.media.Key(url)
+    if !ok {
+        return fmt.Errorf("%s is not in the media store", url)
+    }
+
+    r, err := s.media.Open(context.Background(), key)
+    if err != nil {
+        return err
+    }
+    defer r.Close()
+
+    options := gofpdf.ImageOptions{ImageType: "JPG", ReadDpi: false}
+    pdf.RegisterImageOptionsReader(key, options, r)
+    if err := pdf.Error(); err != nil {
+        return err
+    }
+
+    pdf.ImageOptions(key, 140, 10, 60, 0, false, options, 0, "")
+    return pdf.Error()
+}
+
 func (s *TicketService) setupTopology(ch *amqp.Channel) error {
     _, err := ch.QueueDeclare("ticket_dlq", true, false, false, false, nil)
     if err != nil {
@@ -142,6 +211,10 @@ func (s *TicketService) setupTopology(ch *amqp.Channel) error {
         return err
     }
     
-    err = ch.QueueBind("ticket_queue", "booking.confirmed", "bookings_exchange", false, nil)
-    return err
+    for _, routingKey := range []string{"booking.confirmed", "ticket.reissued"} {
+        if err := ch.QueueBind("ticket_queue", routingKey, "bookings_exchange", false, nil); err != nil {
+            return err
+        }
+    }
+    return nil
 }